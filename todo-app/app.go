@@ -3,23 +3,56 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"fmt"
 	"time"
 	"todo-app/app/config"
+	"todo-app/app/diagnostics"
+	"todo-app/app/events"
 	"todo-app/app/models"
+	"todo-app/app/realtime"
+	"todo-app/app/reminders"
 	"todo-app/app/usecases"
+	"todo-app/internal/i18n"
+	"todo-app/internal/tracing"
 	"todo-app/internal/utils"
+	"todo-app/internal/validation"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
+// TaskChangesEvent имя события Wails с пачкой изменений задач (models.TaskChangeBatch)
+const TaskChangesEvent = "tasks:changed"
+
+// TaskRemindersEvent имя события Wails с задачами, срок которых подходит ([]*models.Task)
+const TaskRemindersEvent = "tasks:reminder"
+
+// SettingsChangedEvent имя события Wails после изменения настроек (events.SettingsUpdated)
+const SettingsChangedEvent = "settings:changed"
+
 // App struct
 type App struct {
-	ctx              context.Context
-	db               *sql.DB
-	config           *config.Config
-	logger           *utils.Logger
-	TaskUseCase      usecases.TaskUseCase
-	AnalyticsUseCase usecases.AnalyticsUseCase
-	ExportUseCase    usecases.ExportUseCase
+	ctx               context.Context
+	db                *sql.DB
+	config            *config.Config
+	logger            *utils.Logger
+	TaskUseCase       usecases.TaskUseCase
+	AnalyticsUseCase  usecases.AnalyticsUseCase
+	ExportUseCase     usecases.ExportUseCase
+	WebhookUseCase    usecases.WebhookUseCase
+	SettingsUseCase   usecases.SettingsUseCase
+	TimeUseCase       usecases.TimeTrackingUseCase
+	CommentUseCase    usecases.CommentUseCase
+	AttachmentUseCase usecases.AttachmentUseCase
+	Events            *events.Bus
+	Realtime          *realtime.Hub
+	Reminders         *reminders.Scheduler
+	Diagnostics       *diagnostics.Diagnostics
+	Locales           *i18n.Resolver
+
+	unsubscribeRealtime  func()
+	unsubscribeReminders func()
+	unsubscribeSettings  func()
 }
 
 // NewApp creates a new App application struct (for backward compatibility)
@@ -31,6 +64,32 @@ func NewApp() *App {
 // so we can call the runtime methods (for backward compatibility)
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+
+	// Пересылаем изменения задач во фронтенд
+	if a.Realtime != nil {
+		a.unsubscribeRealtime = a.Realtime.Subscribe(func(batch models.TaskChangeBatch) {
+			runtime.EventsEmit(ctx, TaskChangesEvent, batch)
+		})
+	}
+
+	// Напоминания показываются фронтендом; до подписки они не расходуются
+	if a.Reminders != nil {
+		a.unsubscribeReminders = a.Reminders.Subscribe(func(tasks []*models.Task) {
+			runtime.EventsEmit(ctx, TaskRemindersEvent, tasks)
+		})
+		a.Reminders.Notify()
+	}
+
+	// Изменения настроек (в том числе из других окон и клиентов) сбрасывают
+	// кэш языка и пересылаются во фронтенд
+	if a.Events != nil {
+		a.unsubscribeSettings = events.On(a.Events, func(_ context.Context, event events.SettingsUpdated) error {
+			a.Locales.Invalidate()
+			runtime.EventsEmit(ctx, SettingsChangedEvent, event)
+			return nil
+		})
+	}
+
 	if a.logger != nil {
 		a.logger.Info("Application started successfully")
 	}
@@ -43,18 +102,82 @@ func (a *App) Startup(ctx context.Context) {
 
 // Shutdown is called when the app is shutting down
 func (a *App) Shutdown(ctx context.Context) {
+	if a.unsubscribeRealtime != nil {
+		a.unsubscribeRealtime()
+	}
+
+	if a.unsubscribeReminders != nil {
+		a.unsubscribeReminders()
+	}
+
+	if a.unsubscribeSettings != nil {
+		a.unsubscribeSettings()
+	}
+
 	if a.logger != nil {
 		a.logger.Info("Application shutting down")
 	}
 }
 
+// operation возвращает контекст вызова binding с именем операции и новым operation_id
+// и начинает корневой span "App.<name>" (nil при отключенной трассировке).
+// Записи логов и span'ы App, use case, сервиса и репозитория с этим контекстом
+// связываются по operation_id и trace_id. В контекст добавляется язык пользователя
+// из настроек. Вызывающий завершает span через defer span.End()
+func (a *App) operation(name string) (context.Context, *tracing.Span) {
+	ctx := a.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx = utils.WithOperation(ctx, name)
+	if a.logger != nil {
+		ctx = utils.WithLogger(ctx, a.logger)
+	}
+
+	ctx = i18n.WithLocale(ctx, a.Locales.Locale(ctx))
+	ctx, span := tracing.Start(ctx, "App."+name)
+
+	utils.DebugContext(ctx, "Operation started")
+	return ctx, span
+}
+
+// respond формирует ответ binding в формате utils.StandardResponse. Ошибка с полной
+// цепочкой записывается в span и лог, а фронтенду передаются ее тип, код, ошибки
+// полей и сообщение на языке вызова
+func (a *App) respond(ctx context.Context, span *tracing.Span, data interface{}, err error) interface{} {
+	if err == nil {
+		return utils.WailsResponse(data, nil)
+	}
+
+	span.RecordError(err)
+
+	appErr := utils.AsAppError(err)
+	fields := map[string]interface{}{
+		"error":      err.Error(),
+		"error_type": string(appErr.Type),
+	}
+	if utils.GetErrorCode(appErr) >= 500 {
+		utils.ErrorContext(ctx, "Operation failed", fields)
+	} else {
+		utils.WarnContext(ctx, "Operation rejected", fields)
+	}
+
+	return utils.WailsResponse(nil, utils.LocalizeError(appErr, i18n.LocaleFromContext(ctx)))
+}
+
+// errNotInitialized ошибка вызова binding до инициализации зависимости
+func errNotInitialized(dependency string) error {
+	return utils.NewInternalError(dependency + " not initialized")
+}
+
 // Greet returns a greeting for the given name (example Wails method)
 func (a *App) Greet(name string) string {
 	return fmt.Sprintf("Hello %s, It's show time!", name)
 }
 
 // GetAppInfo возвращает информацию о приложении
-func (a *App) GetAppInfo() map[string]interface{} {
+func (a *App) GetAppInfo() interface{} {
 	info := map[string]interface{}{
 		"name":    "Todo App",
 		"version": "1.0.0",
@@ -67,11 +190,14 @@ func (a *App) GetAppInfo() map[string]interface{} {
 		info["debug"] = a.config.App.Debug
 	}
 
-	return info
+	return utils.WailsResponse(info, nil)
 }
 
-// HealthCheck проверяет состояние приложения (Wails method)
-func (a *App) HealthCheck() map[string]interface{} {
+// HealthCheck проверяет состояние приложения (Wails method).
+// status: ok, degraded (БД отвечает медленно, пул исчерпан или недавно были
+// временные ошибки) или error (БД недоступна). Недоступность БД - это данные
+// проверки, а не ошибка вызова
+func (a *App) HealthCheck() interface{} {
 	result := map[string]interface{}{
 		"status":    "ok",
 		"timestamp": fmt.Sprintf("%d", time.Now().Unix()),
@@ -79,47 +205,120 @@ func (a *App) HealthCheck() map[string]interface{} {
 
 	// Проверяем базу данных
 	if a.db != nil {
-		if err := a.db.Ping(); err != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		health := utils.CheckDBHealth(ctx, a.db, utils.DefaultDBHealthThresholds)
+		result["database_latency_ms"] = health.Latency.Milliseconds()
+		result["database_open_connections"] = health.Stats.OpenConnections
+		result["database_in_use"] = health.Stats.InUse
+
+		switch health.Status {
+		case utils.DBStatusUnavailable:
 			result["database"] = "error"
-			result["database_error"] = err.Error()
+			result["database_error"] = health.Error
 			result["status"] = "error"
-		} else {
+		case utils.DBStatusDegraded:
+			result["database"] = "degraded"
+			result["degraded_reasons"] = health.Reasons
+			result["status"] = "degraded"
+		default:
 			result["database"] = "ok"
 		}
 	}
 
-	return result
+	return utils.WailsResponse(result, nil)
 }
 
-// === Task Management Methods (Wails bindings) ===
-
-// CreateTask создает новую задачу
-func (a *App) CreateTask(title, description string, priorityStr string, deadline string) (*models.Task, error) {
-	if a.TaskUseCase == nil {
-		return nil, fmt.Errorf("task use case not initialized")
+// GetDiagnostics возвращает подробный отчет о состоянии приложения
+// с рекомендациями по исправлению проблем (Wails method)
+func (a *App) GetDiagnostics() interface{} {
+	if a.Diagnostics == nil {
+		return utils.WailsResponse(nil, errNotInitialized("diagnostics"))
 	}
 
-	// Конвертируем строку в Priority
-	var priority models.Priority
+	ctx, span := a.operation("GetDiagnostics")
+	defer span.End()
+
+	return a.respond(ctx, span, a.Diagnostics.Report(ctx), nil)
+}
+
+// === Task Management Methods (Wails bindings) ===
+//
+// Все bindings возвращают utils.StandardResponse: {success, data} или
+// {success: false, error, code, type, fields}. type - стабильный код ошибки
+// (VALIDATION_ERROR, NOT_FOUND, CONFLICT, DATABASE_ERROR, ...)
+
+// parsePriority преобразует приоритет из формы; неизвестное значение - средний приоритет
+func parsePriority(priorityStr string) models.Priority {
 	switch priorityStr {
 	case "low":
-		priority = models.PriorityLow
-	case "medium":
-		priority = models.PriorityMedium
+		return models.PriorityLow
 	case "high":
-		priority = models.PriorityHigh
+		return models.PriorityHigh
 	default:
-		priority = models.PriorityMedium
+		return models.PriorityMedium
+	}
+}
+
+// parseDeadline разбирает дедлайн формы (пустая строка - без срока):
+//   - YYYY-MM-DD - срок на весь день
+//   - YYYY-MM-DDTHH:MM - срок со временем в часовом поясе location
+//   - RFC3339 - срок со временем и явным смещением
+func parseDeadline(deadline string, location *time.Location) (*time.Time, bool, error) {
+	if deadline == "" {
+		return nil, false, nil
+	}
+
+	if day, err := time.Parse("2006-01-02", deadline); err == nil {
+		return &day, true, nil
+	}
+
+	if local, err := time.ParseInLocation("2006-01-02T15:04", deadline, location); err == nil {
+		return &local, false, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, deadline)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid deadline format, expected YYYY-MM-DD, YYYY-MM-DDTHH:MM or RFC3339: %w",
+			validation.InvalidValue("due_date", deadline))
+	}
+	return &parsed, false, nil
+}
+
+// userLocation возвращает часовой пояс пользователя из настроек;
+// без настроек используется локальный пояс
+func (a *App) userLocation(ctx context.Context) *time.Location {
+	if a.SettingsUseCase == nil {
+		return time.Local
 	}
 
+	settings, err := a.SettingsUseCase.GetSettings(ctx)
+	if err != nil {
+		return time.Local
+	}
+	return settings.Location()
+}
+
+// CreateTask создает новую задачу
+func (a *App) CreateTask(title, description string, priorityStr string, deadline string) interface{} {
+	if a.TaskUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
+	}
+
+	ctx, span := a.operation("CreateTask")
+	defer span.End()
+
 	// Парсим дедлайн если он предоставлен
-	var dueDate *time.Time
-	if deadline != "" {
-		parsedDate, err := time.Parse("2006-01-02", deadline)
-		if err != nil {
-			return nil, fmt.Errorf("invalid deadline format, expected YYYY-MM-DD: %w", err)
-		}
-		dueDate = &parsedDate
+	dueDate, allDay, err := parseDeadline(deadline, a.userLocation(ctx))
+	if err != nil {
+		return a.respond(ctx, span, nil, err)
+	}
+
+	// Пустой приоритет заменяется приоритетом по умолчанию из настроек
+	var priority models.Priority
+	if priorityStr != "" {
+		priority = parsePriority(priorityStr)
 	}
 
 	req := models.CreateTaskRequest{
@@ -127,21 +326,65 @@ func (a *App) CreateTask(title, description string, priorityStr string, deadline
 		Description: description,
 		Priority:    priority,
 		DueDate:     dueDate,
+		DueAllDay:   allDay,
+	}
+
+	utils.InfoContext(ctx, "Creating task via frontend", map[string]interface{}{
+		"title":    title,
+		"priority": priorityStr,
+		"deadline": deadline,
+	})
+
+	task, err := a.TaskUseCase.CreateTask(ctx, req)
+	return a.respond(ctx, span, task, err)
+}
+
+// ParseQuickAdd разбирает строку быстрого добавления для предпросмотра:
+// заголовок, срок, приоритет, проект, метки и повторение
+func (a *App) ParseQuickAdd(input string) interface{} {
+	if a.TaskUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
 	}
 
-	// Log task creation in the terminal
-	fmt.Printf("Task created via frontend: Title='%s', Description='%s', Priority='%s', Deadline='%s'\n", title, description, priorityStr, deadline)
+	ctx, span := a.operation("ParseQuickAdd")
+	defer span.End()
 
-	return a.TaskUseCase.CreateTask(a.ctx, req)
+	result, err := a.TaskUseCase.ParseQuickAdd(ctx, input)
+	return a.respond(ctx, span, result, err)
+}
+
+// QuickAdd создает задачу из строки быстрого добавления
+func (a *App) QuickAdd(input string) interface{} {
+	if a.TaskUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
+	}
+
+	ctx, span := a.operation("QuickAdd")
+	defer span.End()
+
+	utils.InfoContext(ctx, "Quick adding task via frontend", map[string]interface{}{
+		"input": input,
+	})
+
+	task, err := a.TaskUseCase.QuickAdd(ctx, input)
+	return a.respond(ctx, span, task, err)
 }
 
 // GetAllTasks возвращает все задачи
-func (a *App) GetAllTasks() ([]*models.Task, error) {
+func (a *App) GetAllTasks() interface{} {
 	if a.TaskUseCase == nil {
-		return nil, fmt.Errorf("task use case not initialized")
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
 	}
 
-	// Исключаем архивные задачи по умолчанию
+	ctx, span := a.operation("GetAllTasks")
+	defer span.End()
+
+	tasks, err := a.activeTasks(ctx)
+	return a.respond(ctx, span, tasks, err)
+}
+
+// activeTasks возвращает неархивные задачи, новые первыми
+func (a *App) activeTasks(ctx context.Context) ([]*models.Task, error) {
 	filter := models.TaskFilter{
 		Archived: false,
 	}
@@ -150,23 +393,65 @@ func (a *App) GetAllTasks() ([]*models.Task, error) {
 		Order: models.SortOrderDesc,
 	}
 
-	return a.TaskUseCase.GetTasks(a.ctx, filter, sort)
+	return a.TaskUseCase.GetTasks(ctx, filter, sort)
 }
 
-// GetTasksByStatus возвращает задачи по статусу
-func (a *App) GetTasksByStatus(status string) ([]*models.Task, error) {
+// GetTaskSync возвращает полный список задач и номер последней пачки изменений.
+// Фронтенд вызывает его при старте, после переподключения и при пропуске номера пачки
+func (a *App) GetTaskSync() interface{} {
 	if a.TaskUseCase == nil {
-		return nil, fmt.Errorf("task use case not initialized")
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
 	}
 
-	var taskStatus models.TaskStatus
-	switch status {
-	case "active":
-		taskStatus = models.TaskStatusActive
-	case "completed":
-		taskStatus = models.TaskStatusCompleted
-	default:
-		taskStatus = models.TaskStatusActive
+	ctx, span := a.operation("GetTaskSync")
+	defer span.End()
+
+	// Номер берется до чтения задач: пачки с большим номером применяются поверх снимка
+	var seq uint64
+	if a.Realtime != nil {
+		seq = a.Realtime.Seq()
+	}
+
+	tasks, err := a.activeTasks(ctx)
+	if err != nil {
+		return a.respond(ctx, span, nil, err)
+	}
+
+	return a.respond(ctx, span, &models.TaskSyncSnapshot{
+		Seq:   seq,
+		Tasks: tasks,
+	}, nil)
+}
+
+// GetTasksPage возвращает страницу задач по курсору (пустой курсор - первая страница)
+func (a *App) GetTasksPage(filter models.TaskFilter, sort models.TaskSort, cursor string, limit int) interface{} {
+	if a.TaskUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
+	}
+
+	if sort.Field == "" {
+		sort = models.GetDefaultSort()
+	}
+	if filter.DateType == "" {
+		filter.DateType = models.DateFilterAll
+	}
+
+	ctx, span := a.operation("GetTasksPage")
+	defer span.End()
+
+	page, err := a.TaskUseCase.GetTasksWithCursor(ctx, filter, sort, cursor, limit)
+	return a.respond(ctx, span, page, err)
+}
+
+// GetTasksByStatus возвращает задачи по статусу или группе статусов (active, completed)
+func (a *App) GetTasksByStatus(status string) interface{} {
+	if a.TaskUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
+	}
+
+	taskStatus := models.TaskStatus(status)
+	if taskStatus == "" || !models.IsValidStatus(status) {
+		taskStatus = models.StatusFilterActive
 	}
 
 	filter := models.TaskFilter{
@@ -178,73 +463,129 @@ func (a *App) GetTasksByStatus(status string) ([]*models.Task, error) {
 		Order: models.SortOrderDesc,
 	}
 
-	return a.TaskUseCase.GetTasks(a.ctx, filter, sort)
+	ctx, span := a.operation("GetTasksByStatus")
+	defer span.End()
+
+	tasks, err := a.TaskUseCase.GetTasks(ctx, filter, sort)
+	return a.respond(ctx, span, tasks, err)
 }
 
 // UpdateTask обновляет задачу
-func (a *App) UpdateTask(id int, title, description, priorityStr string, deadline string) (*models.Task, error) {
+func (a *App) UpdateTask(id int, title, description, priorityStr string, deadline string) interface{} {
 	if a.TaskUseCase == nil {
-		return nil, fmt.Errorf("task use case not initialized")
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
 	}
 
-	var priority models.Priority
-	switch priorityStr {
-	case "low":
-		priority = models.PriorityLow
-	case "medium":
-		priority = models.PriorityMedium
-	case "high":
-		priority = models.PriorityHigh
-	default:
-		priority = models.PriorityMedium
-	}
+	ctx, span := a.operation("UpdateTask")
+	defer span.End()
 
 	// Парсим дедлайн если он предоставлен
-	var dueDate *time.Time
-	if deadline != "" {
-		parsedDate, err := time.Parse("2006-01-02", deadline)
-		if err != nil {
-			return nil, fmt.Errorf("invalid deadline format, expected YYYY-MM-DD: %w", err)
-		}
-		dueDate = &parsedDate
+	dueDate, allDay, err := parseDeadline(deadline, a.userLocation(ctx))
+	if err != nil {
+		return a.respond(ctx, span, nil, err)
 	}
 
 	req := models.UpdateTaskRequest{
 		ID:          id,
 		Title:       title,
 		Description: description,
-		Priority:    priority,
+		Priority:    parsePriority(priorityStr),
 		DueDate:     dueDate,
+		DueAllDay:   allDay,
 	}
 
-	return a.TaskUseCase.UpdateTask(a.ctx, req)
+	task, err := a.TaskUseCase.UpdateTask(ctx, req)
+	return a.respond(ctx, span, task, err)
 }
 
 // DeleteTask удаляет задачу
-func (a *App) DeleteTask(id int) error {
+func (a *App) DeleteTask(id int) interface{} {
 	if a.TaskUseCase == nil {
-		return fmt.Errorf("task use case not initialized")
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
 	}
 
-	return a.TaskUseCase.DeleteTask(a.ctx, id)
+	ctx, span := a.operation("DeleteTask")
+	defer span.End()
+
+	return a.respond(ctx, span, nil, a.TaskUseCase.DeleteTask(ctx, id))
 }
 
 // ToggleTaskStatus переключает статус задачи
-func (a *App) ToggleTaskStatus(id int) (*models.Task, error) {
+func (a *App) ToggleTaskStatus(id int) interface{} {
+	if a.TaskUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
+	}
+
+	ctx, span := a.operation("ToggleTaskStatus")
+	defer span.End()
+
+	task, err := a.TaskUseCase.ToggleTaskStatus(ctx, id)
+	return a.respond(ctx, span, task, err)
+}
+
+// ChangeTaskStatus переводит задачу в статус по правилам workflow
+func (a *App) ChangeTaskStatus(id int, status string) interface{} {
+	if a.TaskUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
+	}
+
+	ctx, span := a.operation("ChangeTaskStatus")
+	defer span.End()
+
+	task, err := a.TaskUseCase.ChangeTaskStatus(ctx, id, models.TaskStatus(status))
+	return a.respond(ctx, span, task, err)
+}
+
+// GetWorkflow возвращает статусы задач и разрешенные переходы
+func (a *App) GetWorkflow() interface{} {
+	if a.TaskUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
+	}
+
+	ctx, span := a.operation("GetWorkflow")
+	defer span.End()
+
+	return a.respond(ctx, span, a.TaskUseCase.GetWorkflow(ctx), nil)
+}
+
+// MoveTask ставит задачу между соседями beforeID и afterID (0 - нет соседа);
+// column - статус колонки доски, пустой - общий список
+func (a *App) MoveTask(id, beforeID, afterID int, column string) interface{} {
+	if a.TaskUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
+	}
+
+	ctx, span := a.operation("MoveTask")
+	defer span.End()
+
+	task, err := a.TaskUseCase.MoveTask(ctx, id, beforeID, afterID, models.TaskStatus(column))
+	return a.respond(ctx, span, task, err)
+}
+
+// GetBoard возвращает задачи, сгруппированные по колонкам статусов
+func (a *App) GetBoard(filter models.TaskFilter) interface{} {
 	if a.TaskUseCase == nil {
-		return nil, fmt.Errorf("task use case not initialized")
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
 	}
 
-	return a.TaskUseCase.ToggleTaskStatus(a.ctx, id)
+	ctx, span := a.operation("GetBoard")
+	defer span.End()
+
+	board, err := a.TaskUseCase.GetBoard(ctx, filter)
+	return a.respond(ctx, span, board, err)
 }
 
 // GetTaskByID получает задачу по ID
-func (a *App) GetTaskByID(id int) (*models.Task, error) {
+func (a *App) GetTaskByID(id int) interface{} {
 	if a.TaskUseCase == nil {
-		return nil, fmt.Errorf("task use case not initialized")
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
 	}
 
-	return a.TaskUseCase.GetTaskByID(a.ctx, id)
+	ctx, span := a.operation("GetTaskByID")
+	defer span.End()
+
+	task, err := a.TaskUseCase.GetTaskByID(ctx, id)
+	return a.respond(ctx, span, task, err)
 }
 
 // === Analytics Methods ===
@@ -252,45 +593,59 @@ func (a *App) GetTaskByID(id int) (*models.Task, error) {
 // GetTasksStats возвращает статистику по задачам
 func (a *App) GetTasksStats() interface{} {
 	if a.AnalyticsUseCase == nil {
-		return utils.ErrorResponse(fmt.Errorf("analytics use case not initialized"))
+		return utils.WailsResponse(nil, errNotInitialized("analytics use case"))
 	}
 
-	stats, err := a.AnalyticsUseCase.GetTasksStats(a.ctx)
-	return utils.WailsResponse(stats, err)
+	ctx, span := a.operation("GetTasksStats")
+	defer span.End()
+
+	stats, err := a.AnalyticsUseCase.GetTasksStats(ctx)
+	return a.respond(ctx, span, stats, err)
 }
 
 // GetDashboardStats возвращает статистику для дашборда
 func (a *App) GetDashboardStats() interface{} {
 	if a.AnalyticsUseCase == nil {
-		return utils.ErrorResponse(fmt.Errorf("analytics use case not initialized"))
+		return utils.WailsResponse(nil, errNotInitialized("analytics use case"))
+	}
+
+	ctx, span := a.operation("GetDashboardStats")
+	defer span.End()
+
+	stats, err := a.AnalyticsUseCase.GetDashboardStats(ctx)
+	return a.respond(ctx, span, stats, err)
+}
+
+// GetEstimateReport сравнивает оценки задач фильтра с затраченным временем
+func (a *App) GetEstimateReport(filter models.TaskFilter) interface{} {
+	if a.AnalyticsUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("analytics use case"))
 	}
 
-	stats, err := a.AnalyticsUseCase.GetDashboardStats(a.ctx)
-	return utils.WailsResponse(stats, err)
+	ctx, span := a.operation("GetEstimateReport")
+	defer span.End()
+
+	report, err := a.AnalyticsUseCase.GetEstimateReport(ctx, filter)
+	return a.respond(ctx, span, report, err)
 }
 
 // === Priority-based Methods ===
 
 // GetTasksByPriority возвращает задачи определенного приоритета
-func (a *App) GetTasksByPriority(priorityStr string) ([]*models.Task, error) {
+func (a *App) GetTasksByPriority(priorityStr string) interface{} {
 	if a.TaskUseCase == nil {
-		return nil, fmt.Errorf("task use case not initialized")
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
 	}
 
-	var priority models.Priority
-	switch priorityStr {
-	case "low":
-		priority = models.PriorityLow
-	case "medium":
-		priority = models.PriorityMedium
-	case "high":
-		priority = models.PriorityHigh
-	default:
-		return nil, fmt.Errorf("invalid priority: %s", priorityStr)
+	ctx, span := a.operation("GetTasksByPriority")
+	defer span.End()
+
+	if !models.IsValidPriority(priorityStr) {
+		return a.respond(ctx, span, nil, validation.InvalidValue("priority", priorityStr))
 	}
 
 	filter := models.TaskFilter{
-		Priority: priority,
+		Priority: models.Priority(priorityStr),
 		Archived: false, // Исключаем архивные задачи
 	}
 	sort := models.TaskSort{
@@ -298,45 +653,29 @@ func (a *App) GetTasksByPriority(priorityStr string) ([]*models.Task, error) {
 		Order: models.SortOrderDesc,
 	}
 
-	return a.TaskUseCase.GetTasks(a.ctx, filter, sort)
+	tasks, err := a.TaskUseCase.GetTasks(ctx, filter, sort)
+	return a.respond(ctx, span, tasks, err)
 }
 
 // === Archive Methods ===
 
 // ArchiveTask отправляет задачу в архив
-func (a *App) ArchiveTask(id int) (*models.Task, error) {
+func (a *App) ArchiveTask(id int) interface{} {
 	if a.TaskUseCase == nil {
-		return nil, fmt.Errorf("task use case not initialized")
-	}
-
-	// Получаем текущую задачу
-	task, err := a.TaskUseCase.GetTaskByID(a.ctx, id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get task: %w", err)
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
 	}
 
-	// Проверяем, что задача выполнена
-	if task.Status != models.TaskStatusCompleted {
-		return nil, fmt.Errorf("task must be completed before archiving")
-	}
+	ctx, span := a.operation("ArchiveTask")
+	defer span.End()
 
-	// Обновляем задачу с archived = true
-	req := models.UpdateTaskRequest{
-		ID:          id,
-		Title:       task.Title,
-		Description: task.Description,
-		Priority:    task.Priority,
-		DueDate:     task.DueDate,
-		Archived:    true,
-	}
-
-	return a.TaskUseCase.UpdateTask(a.ctx, req)
+	task, err := a.TaskUseCase.ArchiveTask(ctx, id)
+	return a.respond(ctx, span, task, err)
 }
 
 // GetArchivedTasks возвращает все архивные задачи
-func (a *App) GetArchivedTasks() ([]*models.Task, error) {
+func (a *App) GetArchivedTasks() interface{} {
 	if a.TaskUseCase == nil {
-		return nil, fmt.Errorf("task use case not initialized")
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
 	}
 
 	filter := models.TaskFilter{
@@ -347,5 +686,395 @@ func (a *App) GetArchivedTasks() ([]*models.Task, error) {
 		Order: models.SortOrderDesc,
 	}
 
-	return a.TaskUseCase.GetTasks(a.ctx, filter, sort)
+	ctx, span := a.operation("GetArchivedTasks")
+	defer span.End()
+
+	tasks, err := a.TaskUseCase.GetTasks(ctx, filter, sort)
+	return a.respond(ctx, span, tasks, err)
+}
+
+// === Time Tracking Methods ===
+
+// SetTaskEstimate задает оценку задачи в минутах; 0 снимает оценку
+func (a *App) SetTaskEstimate(id int, minutes int) interface{} {
+	if a.TaskUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("task use case"))
+	}
+
+	ctx, span := a.operation("SetTaskEstimate")
+	defer span.End()
+
+	req := models.SetEstimateRequest{ID: id}
+	if minutes != 0 {
+		req.EstimateMinutes = &minutes
+	}
+
+	task, err := a.TaskUseCase.SetTaskEstimate(ctx, req)
+	return a.respond(ctx, span, task, err)
+}
+
+// StartTimer запускает таймер по задаче. Если таймер уже идет, возвращается ошибка CONFLICT
+func (a *App) StartTimer(taskID int, note string) interface{} {
+	if a.TimeUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("time tracking use case"))
+	}
+
+	ctx, span := a.operation("StartTimer")
+	defer span.End()
+
+	entry, err := a.TimeUseCase.StartTimer(ctx, models.StartTimerRequest{TaskID: taskID, Note: note})
+	return a.respond(ctx, span, entry, err)
+}
+
+// StopTimer останавливает запущенный таймер
+func (a *App) StopTimer() interface{} {
+	if a.TimeUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("time tracking use case"))
+	}
+
+	ctx, span := a.operation("StopTimer")
+	defer span.End()
+
+	entry, err := a.TimeUseCase.StopTimer(ctx)
+	return a.respond(ctx, span, entry, err)
+}
+
+// GetActiveTimer возвращает запущенный таймер или null. Таймер хранится в БД,
+// поэтому после перезапуска приложения продолжает идти
+func (a *App) GetActiveTimer() interface{} {
+	if a.TimeUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("time tracking use case"))
+	}
+
+	ctx, span := a.operation("GetActiveTimer")
+	defer span.End()
+
+	entry, err := a.TimeUseCase.GetActiveTimer(ctx)
+	return a.respond(ctx, span, entry, err)
+}
+
+// AddTimeEntry добавляет затраченное время вручную
+func (a *App) AddTimeEntry(req models.CreateTimeEntryRequest) interface{} {
+	if a.TimeUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("time tracking use case"))
+	}
+
+	ctx, span := a.operation("AddTimeEntry")
+	defer span.End()
+
+	entry, err := a.TimeUseCase.AddTimeEntry(ctx, req)
+	return a.respond(ctx, span, entry, err)
+}
+
+// DeleteTimeEntry удаляет запись времени
+func (a *App) DeleteTimeEntry(id int64) interface{} {
+	if a.TimeUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("time tracking use case"))
+	}
+
+	ctx, span := a.operation("DeleteTimeEntry")
+	defer span.End()
+
+	return a.respond(ctx, span, nil, a.TimeUseCase.DeleteTimeEntry(ctx, id))
+}
+
+// GetTaskTimeEntries возвращает записи времени задачи
+func (a *App) GetTaskTimeEntries(taskID int) interface{} {
+	if a.TimeUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("time tracking use case"))
+	}
+
+	ctx, span := a.operation("GetTaskTimeEntries")
+	defer span.End()
+
+	entries, err := a.TimeUseCase.GetTaskTimeEntries(ctx, taskID)
+	return a.respond(ctx, span, entries, err)
+}
+
+// GetTimeReport возвращает итоги времени по задачам, проектам или дням за период
+func (a *App) GetTimeReport(req models.TimeReportRequest) interface{} {
+	if a.TimeUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("time tracking use case"))
+	}
+
+	ctx, span := a.operation("GetTimeReport")
+	defer span.End()
+
+	report, err := a.TimeUseCase.GetTimeReport(ctx, req)
+	return a.respond(ctx, span, report, err)
+}
+
+// === Comment Methods ===
+
+// AddTaskComment добавляет к задаче комментарий с телом в Markdown
+func (a *App) AddTaskComment(taskID int, body string) interface{} {
+	if a.CommentUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("comment use case"))
+	}
+
+	ctx, span := a.operation("AddTaskComment")
+	defer span.End()
+
+	comment, err := a.CommentUseCase.AddComment(ctx, models.CreateCommentRequest{TaskID: taskID, Body: body})
+	return a.respond(ctx, span, comment, err)
+}
+
+// UpdateTaskComment изменяет комментарий; предыдущая версия сохраняется в истории правок
+func (a *App) UpdateTaskComment(id int64, body string) interface{} {
+	if a.CommentUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("comment use case"))
+	}
+
+	ctx, span := a.operation("UpdateTaskComment")
+	defer span.End()
+
+	comment, err := a.CommentUseCase.UpdateComment(ctx, models.UpdateCommentRequest{ID: id, Body: body})
+	return a.respond(ctx, span, comment, err)
+}
+
+// DeleteTaskComment удаляет комментарий
+func (a *App) DeleteTaskComment(id int64) interface{} {
+	if a.CommentUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("comment use case"))
+	}
+
+	ctx, span := a.operation("DeleteTaskComment")
+	defer span.End()
+
+	return a.respond(ctx, span, nil, a.CommentUseCase.DeleteComment(ctx, id))
+}
+
+// GetTaskComments возвращает комментарии задачи: order "asc" (по умолчанию) -
+// от старых к новым, "desc" - от новых к старым
+func (a *App) GetTaskComments(taskID int, order string) interface{} {
+	if a.CommentUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("comment use case"))
+	}
+
+	ctx, span := a.operation("GetTaskComments")
+	defer span.End()
+
+	comments, err := a.CommentUseCase.GetTaskComments(ctx, taskID, models.SortOrder(order))
+	return a.respond(ctx, span, comments, err)
+}
+
+// GetCommentRevisions возвращает предыдущие версии комментария
+func (a *App) GetCommentRevisions(id int64) interface{} {
+	if a.CommentUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("comment use case"))
+	}
+
+	ctx, span := a.operation("GetCommentRevisions")
+	defer span.End()
+
+	revisions, err := a.CommentUseCase.GetCommentRevisions(ctx, id)
+	return a.respond(ctx, span, revisions, err)
+}
+
+// GetTaskHistory возвращает последние события задачи (изменения, переходы,
+// комментарии); limit 0 - значение по умолчанию
+func (a *App) GetTaskHistory(taskID int, limit int) interface{} {
+	if a.CommentUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("comment use case"))
+	}
+
+	ctx, span := a.operation("GetTaskHistory")
+	defer span.End()
+
+	history, err := a.CommentUseCase.GetTaskHistory(ctx, taskID, limit)
+	return a.respond(ctx, span, history, err)
+}
+
+// === Attachment Methods ===
+
+// AddAttachment прикрепляет к задаче локальный файл, выбранный в диалоге открытия
+func (a *App) AddAttachment(taskID int, path string) interface{} {
+	if a.AttachmentUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("attachment use case"))
+	}
+
+	ctx, span := a.operation("AddAttachment")
+	defer span.End()
+
+	attachment, err := a.AttachmentUseCase.AddAttachmentFromFile(ctx, taskID, path)
+	return a.respond(ctx, span, attachment, err)
+}
+
+// AddAttachmentData прикрепляет к задаче файл с содержимым в base64
+// (например, перетащенный в окно или вставленный из буфера обмена)
+func (a *App) AddAttachmentData(taskID int, fileName string, data string) interface{} {
+	if a.AttachmentUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("attachment use case"))
+	}
+
+	ctx, span := a.operation("AddAttachmentData")
+	defer span.End()
+
+	content, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return a.respond(ctx, span, nil, validation.InvalidValue("file", "base64"))
+	}
+
+	attachment, err := a.AttachmentUseCase.AddAttachment(ctx, models.CreateAttachmentRequest{TaskID: taskID, FileName: fileName}, content)
+	return a.respond(ctx, span, attachment, err)
+}
+
+// GetTaskAttachments возвращает вложения задачи без содержимого
+func (a *App) GetTaskAttachments(taskID int) interface{} {
+	if a.AttachmentUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("attachment use case"))
+	}
+
+	ctx, span := a.operation("GetTaskAttachments")
+	defer span.End()
+
+	attachments, err := a.AttachmentUseCase.GetTaskAttachments(ctx, taskID)
+	return a.respond(ctx, span, attachments, err)
+}
+
+// GetAttachmentData возвращает вложение с содержимым в base64 (поле data)
+func (a *App) GetAttachmentData(id int64) interface{} {
+	if a.AttachmentUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("attachment use case"))
+	}
+
+	ctx, span := a.operation("GetAttachmentData")
+	defer span.End()
+
+	content, err := a.AttachmentUseCase.GetAttachmentContent(ctx, id)
+	return a.respond(ctx, span, content, err)
+}
+
+// DeleteAttachment удаляет вложение
+func (a *App) DeleteAttachment(id int64) interface{} {
+	if a.AttachmentUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("attachment use case"))
+	}
+
+	ctx, span := a.operation("DeleteAttachment")
+	defer span.End()
+
+	return a.respond(ctx, span, nil, a.AttachmentUseCase.DeleteAttachment(ctx, id))
+}
+
+// === Settings Methods ===
+
+// GetSettings возвращает настройки приложения
+func (a *App) GetSettings() interface{} {
+	if a.SettingsUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("settings use case"))
+	}
+
+	ctx, span := a.operation("GetSettings")
+	defer span.End()
+
+	settings, err := a.SettingsUseCase.GetSettings(ctx)
+	return a.respond(ctx, span, settings, err)
+}
+
+// UpdateSettings сохраняет настройки приложения. Кэш языка сбрасывается сразу,
+// не дожидаясь события settings.updated, чтобы следующий вызов шел на новом языке
+func (a *App) UpdateSettings(settings models.AppSettings) interface{} {
+	if a.SettingsUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("settings use case"))
+	}
+
+	ctx, span := a.operation("UpdateSettings")
+	defer span.End()
+
+	updated, err := a.SettingsUseCase.UpdateSettings(ctx, settings)
+	if err == nil {
+		a.Locales.Invalidate()
+	}
+	return a.respond(ctx, span, updated, err)
+}
+
+// === Webhook Methods ===
+
+// CreateWebhook создает подписку на события задач. Секрет подписи возвращается только один раз
+func (a *App) CreateWebhook(req models.CreateWebhookRequest) interface{} {
+	if a.WebhookUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("webhook use case"))
+	}
+
+	ctx, span := a.operation("CreateWebhook")
+	defer span.End()
+
+	response, err := a.WebhookUseCase.CreateWebhook(ctx, req)
+	return a.respond(ctx, span, response, err)
+}
+
+// GetWebhooks возвращает все подписки
+func (a *App) GetWebhooks() interface{} {
+	if a.WebhookUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("webhook use case"))
+	}
+
+	ctx, span := a.operation("GetWebhooks")
+	defer span.End()
+
+	subscriptions, err := a.WebhookUseCase.GetWebhooks(ctx)
+	return a.respond(ctx, span, subscriptions, err)
+}
+
+// SetWebhookActive включает или отключает подписку
+func (a *App) SetWebhookActive(id int, active bool) interface{} {
+	if a.WebhookUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("webhook use case"))
+	}
+
+	ctx, span := a.operation("SetWebhookActive")
+	defer span.End()
+
+	return a.respond(ctx, span, nil, a.WebhookUseCase.SetWebhookActive(ctx, id, active))
+}
+
+// DeleteWebhook удаляет подписку
+func (a *App) DeleteWebhook(id int) interface{} {
+	if a.WebhookUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("webhook use case"))
+	}
+
+	ctx, span := a.operation("DeleteWebhook")
+	defer span.End()
+
+	return a.respond(ctx, span, nil, a.WebhookUseCase.DeleteWebhook(ctx, id))
+}
+
+// GetWebhookDeliveries возвращает журнал доставок подписки
+func (a *App) GetWebhookDeliveries(id int, limit int) interface{} {
+	if a.WebhookUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("webhook use case"))
+	}
+
+	ctx, span := a.operation("GetWebhookDeliveries")
+	defer span.End()
+
+	deliveries, err := a.WebhookUseCase.GetWebhookDeliveries(ctx, id, limit)
+	return a.respond(ctx, span, deliveries, err)
+}
+
+// GetWebhookDeliveryAttempts возвращает журнал попыток доставки
+func (a *App) GetWebhookDeliveryAttempts(deliveryID int64) interface{} {
+	if a.WebhookUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("webhook use case"))
+	}
+
+	ctx, span := a.operation("GetWebhookDeliveryAttempts")
+	defer span.End()
+
+	attempts, err := a.WebhookUseCase.GetDeliveryAttempts(ctx, deliveryID)
+	return a.respond(ctx, span, attempts, err)
+}
+
+// RetryWebhookDelivery повторяет недоставленное событие, в том числе из dead-letter
+func (a *App) RetryWebhookDelivery(deliveryID int64) interface{} {
+	if a.WebhookUseCase == nil {
+		return utils.WailsResponse(nil, errNotInitialized("webhook use case"))
+	}
+
+	ctx, span := a.operation("RetryWebhookDelivery")
+	defer span.End()
+
+	return a.respond(ctx, span, nil, a.WebhookUseCase.RetryDelivery(ctx, deliveryID))
 }
@@ -3,20 +3,35 @@ package app
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"time"
 	"todo-app/app/config"
+	"todo-app/app/diagnostics"
+	"todo-app/app/realtime"
+	"todo-app/app/reminders"
 	"todo-app/app/usecases"
+	"todo-app/internal/i18n"
 	"todo-app/internal/utils"
 )
 
 // App представляет основную структуру приложения
 type App struct {
-	ctx              context.Context
-	db               *sql.DB
-	config           *config.Config
-	logger           *utils.Logger
-	TaskUseCase      usecases.TaskUseCase
-	AnalyticsUseCase usecases.AnalyticsUseCase
-	ExportUseCase    usecases.ExportUseCase
+	ctx               context.Context
+	db                *sql.DB
+	config            *config.Config
+	logger            *utils.Logger
+	TaskUseCase       usecases.TaskUseCase
+	AnalyticsUseCase  usecases.AnalyticsUseCase
+	ExportUseCase     usecases.ExportUseCase
+	WebhookUseCase    usecases.WebhookUseCase
+	SettingsUseCase   usecases.SettingsUseCase
+	TimeUseCase       usecases.TimeTrackingUseCase
+	CommentUseCase    usecases.CommentUseCase
+	AttachmentUseCase usecases.AttachmentUseCase
+	Realtime          *realtime.Hub
+	Reminders         *reminders.Scheduler
+	Diagnostics       *diagnostics.Diagnostics
+	Locales           *i18n.Resolver
 }
 
 // GetContext возвращает контекст приложения
@@ -54,10 +69,16 @@ func (a *App) Shutdown(ctx context.Context) {
 	}
 }
 
-// HealthCheck проверяет состояние приложения
+// HealthCheck проверяет состояние приложения. Деградированный режим
+// (медленная БД, повторы запросов) ошибкой не считается
 func (a *App) HealthCheck() error {
 	if a.db != nil {
-		return a.db.Ping()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if health := utils.CheckDBHealth(ctx, a.db, utils.DefaultDBHealthThresholds); health.Status == utils.DBStatusUnavailable {
+			return fmt.Errorf("database is unavailable: %s", health.Error)
+		}
 	}
 	return nil
 }
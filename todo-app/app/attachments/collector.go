@@ -0,0 +1,188 @@
+package attachments
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"sync"
+	"time"
+	"todo-app/app/repository"
+	"todo-app/internal/utils"
+)
+
+// MinGracePeriod минимальный возраст содержимого без ссылок перед удалением.
+// Он должен покрывать время между записью файла и коммитом строки в БД
+const MinGracePeriod = time.Minute
+
+// CollectorConfig содержит настройки сборки мусора
+type CollectorConfig struct {
+	Interval    time.Duration // интервал сборки
+	GracePeriod time.Duration // минимальный возраст содержимого без ссылок перед удалением
+}
+
+// CollectResult описывает результат одной сборки
+type CollectResult struct {
+	Removed      int   `json:"removed"`       // удалено содержимого без ссылок
+	FreedBytes   int64 `json:"freed_bytes"`   // освобождено байт
+	StaleUploads int   `json:"stale_uploads"` // удалено временных файлов прерванных загрузок
+}
+
+// Collector периодически удаляет из хранилища содержимое, на которое не
+// ссылается ни одно вложение. Свежее содержимое не трогается в течение
+// GracePeriod: между записью файла и коммитом строки в БД ссылки еще нет
+type Collector struct {
+	repo   repository.AttachmentRepository
+	store  *Store
+	config CollectorConfig
+	logger *utils.Logger
+	now    func() time.Time
+
+	wake    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	startMu sync.Mutex
+	running bool
+}
+
+// NewCollector создает сборщик мусора хранилища вложений
+func NewCollector(repo repository.AttachmentRepository, store *Store, config CollectorConfig, logger *utils.Logger) *Collector {
+	if logger == nil {
+		logger = utils.DefaultLogger()
+	}
+	if config.Interval <= 0 {
+		config.Interval = time.Hour
+	}
+	if config.GracePeriod < MinGracePeriod {
+		config.GracePeriod = MinGracePeriod
+	}
+
+	return &Collector{
+		repo:   repo,
+		store:  store,
+		config: config,
+		logger: logger,
+		now:    time.Now,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Notify запускает внеочередную сборку
+func (c *Collector) Notify() {
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start запускает периодическую сборку. Первая сборка - через Interval
+func (c *Collector) Start() {
+	c.startMu.Lock()
+	defer c.startMu.Unlock()
+
+	if c.running {
+		return
+	}
+	c.running = true
+	c.stop = make(chan struct{})
+	c.done = make(chan struct{})
+
+	go c.run()
+}
+
+// Stop останавливает сборку, дожидаясь завершения текущего прохода
+func (c *Collector) Stop() {
+	c.startMu.Lock()
+	defer c.startMu.Unlock()
+
+	if !c.running {
+		return
+	}
+	c.running = false
+
+	close(c.stop)
+	<-c.done
+}
+
+// Running сообщает, запущена ли периодическая сборка
+func (c *Collector) Running() bool {
+	c.startMu.Lock()
+	defer c.startMu.Unlock()
+	return c.running
+}
+
+// run основной цикл
+func (c *Collector) run() {
+	defer close(c.done)
+
+	timer := time.NewTimer(c.config.Interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-c.wake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		case <-timer.C:
+		}
+
+		result, err := c.Collect(context.Background())
+		if err != nil {
+			c.logger.Error("Failed to collect unreferenced attachments", map[string]interface{}{
+				"error": err.Error(),
+			})
+		} else if result.Removed > 0 || result.StaleUploads > 0 {
+			c.logger.Info("Unreferenced attachments removed", map[string]interface{}{
+				"removed":       result.Removed,
+				"freed_bytes":   result.FreedBytes,
+				"stale_uploads": result.StaleUploads,
+			})
+		}
+
+		timer.Reset(c.config.Interval)
+	}
+}
+
+// Collect удаляет содержимое без ссылок старше GracePeriod и временные файлы
+// прерванных загрузок. Множество ссылок читается до обхода хранилища, поэтому
+// содержимое, записанное во время сборки, защищено GracePeriod
+func (c *Collector) Collect(ctx context.Context) (*CollectResult, error) {
+	cutoff := c.now().Add(-c.config.GracePeriod)
+
+	referenced, err := c.repo.GetReferencedHashes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get referenced attachments: %w", err)
+	}
+
+	result := &CollectResult{}
+	err = c.store.Walk(func(hash string, info fs.FileInfo) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, ok := referenced[hash]; ok || !info.ModTime().Before(cutoff) {
+			return nil
+		}
+
+		if err := c.store.Remove(hash); err != nil {
+			return err
+		}
+		result.Removed++
+		result.FreedBytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to collect attachments: %w", err)
+	}
+
+	result.StaleUploads, err = c.store.removeStaleUploads(cutoff)
+	if err != nil {
+		return result, fmt.Errorf("failed to remove stale uploads: %w", err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package attachments
+
+import (
+	"mime"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// SniffLength - сколько первых байт нужно DetectContentType
+const SniffLength = 512
+
+// Limits содержит ограничения на загружаемые файлы
+type Limits struct {
+	MaxSize      int64    // байт; 0 - без ограничения
+	AllowedTypes []string // MIME типы или шаблоны вида image/*; пустой список - любые
+}
+
+// Allows проверяет, разрешен ли MIME тип
+func (l Limits) Allows(contentType string) bool {
+	if len(l.AllowedTypes) == 0 {
+		return true
+	}
+
+	mediaType := baseType(contentType)
+	for _, pattern := range l.AllowedTypes {
+		if matched, _ := path.Match(strings.ToLower(pattern), mediaType); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectContentType определяет MIME тип по первым байтам содержимого.
+// Расширение имени используется, только когда содержимое не дает точного
+// ответа: неизвестный двоичный формат, zip-контейнер (docx, odt) или простой
+// текст (csv, markdown). Поэтому HTML с расширением .png останется text/html
+func DetectContentType(fileName string, head []byte) string {
+	sniffed := baseType(http.DetectContentType(head))
+	byExtension := baseType(mime.TypeByExtension(strings.ToLower(filepath.Ext(fileName))))
+
+	switch {
+	case byExtension == "":
+		return sniffed
+	case sniffed == "application/octet-stream",
+		sniffed == "application/zip" && strings.HasPrefix(byExtension, "application/"),
+		sniffed == "text/plain" && strings.HasPrefix(byExtension, "text/"):
+		return byExtension
+	default:
+		return sniffed
+	}
+}
+
+// baseType возвращает MIME тип без параметров в нижнем регистре
+func baseType(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		return mediaType
+	}
+	return strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0]))
+}
@@ -0,0 +1,193 @@
+package attachments
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrTooLarge возвращается, когда содержимое превышает ограничение размера
+var ErrTooLarge = errors.New("attachment exceeds size limit")
+
+// ErrInvalidHash возвращается для ключа, не являющегося SHA-256 в hex
+var ErrInvalidHash = errors.New("invalid attachment hash")
+
+// Каталоги хранилища
+const (
+	blobsDir = "blobs"
+	tmpDir   = "tmp"
+)
+
+// Blob описывает сохраненное содержимое
+type Blob struct {
+	Hash    string // SHA-256 содержимого в hex, ключ в хранилище
+	Size    int64
+	Created bool // false - такое содержимое уже было в хранилище
+}
+
+// Store хранит содержимое вложений в каталоге с адресацией по SHA-256:
+// blobs/ab/abcdef... Одинаковые файлы хранятся один раз. Запись атомарна:
+// файл пишется во временный каталог и переименовывается после подсчета хэша
+type Store struct {
+	dir string
+}
+
+// NewStore создает хранилище в каталоге dir, создавая его при необходимости
+func NewStore(dir string) (*Store, error) {
+	for _, sub := range []string{blobsDir, tmpDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create attachment store: %w", err)
+		}
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Dir возвращает каталог хранилища
+func (s *Store) Dir() string {
+	return s.dir
+}
+
+// Put сохраняет содержимое r. При maxSize > 0 содержимое больше maxSize
+// отклоняется с ErrTooLarge и не сохраняется. Повторное сохранение того же
+// содержимого обновляет время изменения файла, чтобы сборка мусора не удалила
+// его до записи ссылки в БД
+func (s *Store) Put(r io.Reader, maxSize int64) (*Blob, error) {
+	tmp, err := os.CreateTemp(filepath.Join(s.dir, tmpDir), "upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // после переименования ничего не удаляет
+
+	if maxSize > 0 {
+		r = io.LimitReader(r, maxSize+1)
+	}
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hasher), r)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write attachment: %w", err)
+	}
+	if maxSize > 0 && size > maxSize {
+		return nil, ErrTooLarge
+	}
+
+	blob := &Blob{Hash: hex.EncodeToString(hasher.Sum(nil)), Size: size}
+	path := s.path(blob.Hash)
+
+	if _, err := os.Stat(path); err == nil {
+		now := time.Now()
+		if err := os.Chtimes(path, now, now); err != nil {
+			return nil, fmt.Errorf("failed to touch attachment: %w", err)
+		}
+		return blob, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	blob.Created = true
+	return blob, nil
+}
+
+// Open открывает содержимое по хэшу
+func (s *Store) Open(hash string) (*os.File, error) {
+	if !validHash(hash) {
+		return nil, ErrInvalidHash
+	}
+	return os.Open(s.path(hash))
+}
+
+// ReadAll читает содержимое по хэшу целиком
+func (s *Store) ReadAll(hash string) ([]byte, error) {
+	if !validHash(hash) {
+		return nil, ErrInvalidHash
+	}
+	return os.ReadFile(s.path(hash))
+}
+
+// Remove удаляет содержимое; отсутствующий файл ошибкой не считается
+func (s *Store) Remove(hash string) error {
+	if !validHash(hash) {
+		return ErrInvalidHash
+	}
+	if err := os.Remove(s.path(hash)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Walk обходит сохраненное содержимое. Посторонние файлы в каталоге пропускаются
+func (s *Store) Walk(fn func(hash string, info fs.FileInfo) error) error {
+	return filepath.WalkDir(filepath.Join(s.dir, blobsDir), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !validHash(entry.Name()) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil // удален параллельно
+			}
+			return err
+		}
+		return fn(entry.Name(), info)
+	})
+}
+
+// removeStaleUploads удаляет временные файлы прерванных загрузок старше before
+func (s *Store) removeStaleUploads(before time.Time) (int, error) {
+	entries, err := os.ReadDir(filepath.Join(s.dir, tmpDir))
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(before) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(s.dir, tmpDir, entry.Name())); err == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// path возвращает путь к содержимому: первые два символа хэша - подкаталог
+func (s *Store) path(hash string) string {
+	return filepath.Join(s.dir, blobsDir, hash[:2], hash)
+}
+
+// validHash проверяет, что ключ - SHA-256 в нижнем регистре hex. Заодно
+// исключает выход за пределы каталога через ключ из БД
+func validHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+	for _, c := range hash {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
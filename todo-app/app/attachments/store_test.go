@@ -0,0 +1,143 @@
+package attachments
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"todo-app/app/models"
+)
+
+// memoryAttachmentRepository хранит ссылки на содержимое в памяти
+type memoryAttachmentRepository struct {
+	hashes map[string]struct{}
+}
+
+func (r *memoryAttachmentRepository) Create(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error) {
+	r.hashes[attachment.SHA256] = struct{}{}
+	return attachment, nil
+}
+
+func (r *memoryAttachmentRepository) GetByID(ctx context.Context, id int64) (*models.Attachment, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (r *memoryAttachmentRepository) Delete(ctx context.Context, id int64) error {
+	return errors.New("not implemented")
+}
+
+func (r *memoryAttachmentRepository) GetByTask(ctx context.Context, taskID int) ([]*models.Attachment, error) {
+	return nil, nil
+}
+
+func (r *memoryAttachmentRepository) GetReferencedHashes(ctx context.Context) (map[string]struct{}, error) {
+	return r.hashes, nil
+}
+
+func TestStore_PutDeduplicatesAndLimitsSize(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	first, err := store.Put(strings.NewReader("hello"), 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	second, err := store.Put(strings.NewReader("hello"), 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// sha256("hello")
+	const hash = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if first.Hash != hash || first.Size != 5 || !first.Created {
+		t.Errorf("Expected new blob %s, got %+v", hash, first)
+	}
+	if second.Hash != hash || second.Created {
+		t.Errorf("Expected deduplicated blob, got %+v", second)
+	}
+
+	data, err := store.ReadAll(hash)
+	if err != nil || string(data) != "hello" {
+		t.Errorf("Expected stored content, got %q, %v", data, err)
+	}
+
+	if _, err := store.Put(strings.NewReader("hello!"), 5); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge, got %v", err)
+	}
+	if _, err := store.ReadAll("../../etc/passwd"); !errors.Is(err, ErrInvalidHash) {
+		t.Errorf("Expected ErrInvalidHash, got %v", err)
+	}
+
+	uploads, _ := os.ReadDir(filepath.Join(store.Dir(), tmpDir))
+	if len(uploads) != 0 {
+		t.Errorf("Expected no temporary files, got %d", len(uploads))
+	}
+}
+
+func TestCollector_RemovesOnlyOldUnreferencedBlobs(t *testing.T) {
+	store, err := NewStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	referenced, _ := store.Put(strings.NewReader("kept"), 0)
+	orphan, _ := store.Put(strings.NewReader("orphan"), 0)
+	fresh, _ := store.Put(strings.NewReader("fresh"), 0)
+
+	old := time.Now().Add(-2 * time.Hour)
+	for _, hash := range []string{referenced.Hash, orphan.Hash} {
+		if err := os.Chtimes(store.path(hash), old, old); err != nil {
+			t.Fatalf("Failed to age blob: %v", err)
+		}
+	}
+
+	repo := &memoryAttachmentRepository{hashes: map[string]struct{}{referenced.Hash: {}}}
+	collector := NewCollector(repo, store, CollectorConfig{GracePeriod: time.Hour}, nil)
+
+	result, err := collector.Collect(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Removed != 1 || result.FreedBytes != orphan.Size {
+		t.Errorf("Expected orphan removed, got %+v", result)
+	}
+
+	for hash, exists := range map[string]bool{referenced.Hash: true, orphan.Hash: false, fresh.Hash: true} {
+		if _, err := os.Stat(store.path(hash)); (err == nil) != exists {
+			t.Errorf("Blob %s: expected exists=%v, got err %v", hash, exists, err)
+		}
+	}
+}
+
+func TestDetectContentType(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		head     string
+		want     string
+	}{
+		{"sniffed png", "image.png", "\x89PNG\r\n\x1a\n", "image/png"},
+		{"content wins over extension", "image.png", "<html><body>", "text/html"},
+		{"csv by extension", "report.csv", "a,b\n1,2\n", "text/csv"},
+		{"unknown binary", "data.bin", "\x00\x01\x02", "application/octet-stream"},
+		{"pdf", "doc", "%PDF-1.7", "application/pdf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectContentType(tt.fileName, []byte(tt.head)); got != tt.want {
+				t.Errorf("DetectContentType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	limits := Limits{AllowedTypes: []string{"image/*", "application/pdf"}}
+	if !limits.Allows("image/png") || !limits.Allows("application/pdf; charset=binary") || limits.Allows("text/html") {
+		t.Errorf("Unexpected Allows result for %v", limits.AllowedTypes)
+	}
+}
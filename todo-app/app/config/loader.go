@@ -0,0 +1,385 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigPathEnv переменная окружения с путем к файлу конфигурации
+const ConfigPathEnv = "TODO_APP_CONFIG"
+
+// configDirName каталог приложения внутри каталога конфигурации пользователя
+const configDirName = "todo-app"
+
+// configFileNames имена файла конфигурации в порядке поиска
+var configFileNames = []string{"config.yaml", "config.yml", "config.toml"}
+
+// redactedValue заменяет значения полей с тегом secret при выводе
+const redactedValue = "******"
+
+// FieldError представляет ошибку значения одного поля конфигурации
+type FieldError struct {
+	Field   string `json:"field"` // путь ключа, например database.port
+	Message string `json:"message"`
+}
+
+// Error реализует интерфейс error
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors содержит все ошибки валидации конфигурации
+type ValidationErrors []FieldError
+
+// Add добавляет ошибку поля
+func (e *ValidationErrors) Add(field, message string) {
+	*e = append(*e, FieldError{Field: field, Message: message})
+}
+
+// Error реализует интерфейс error
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ErrOrNil возвращает nil, если ошибок нет
+func (e ValidationErrors) ErrOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// UnknownKeysError возвращается, если в файле или флагах есть ключи,
+// которых нет в Config (обычно опечатка)
+type UnknownKeysError struct {
+	Source string
+	Keys   []string
+}
+
+// Error реализует интерфейс error
+func (e *UnknownKeysError) Error() string {
+	return fmt.Sprintf("unknown config keys in %s: %s", e.Source, strings.Join(e.Keys, ", "))
+}
+
+// Flags содержит параметры командной строки, влияющие на конфигурацию
+type Flags struct {
+	ConfigPath string   // --config: путь к файлу конфигурации
+	Overrides  []string // --set key=value, применяются последними
+}
+
+// stringList накапливает значения повторяющегося флага
+type stringList []string
+
+func (l *stringList) String() string     { return strings.Join(*l, ",") }
+func (l *stringList) Set(v string) error { *l = append(*l, v); return nil }
+
+// ParseFlags разбирает флаги конфигурации и возвращает оставшиеся аргументы
+// (подкоманды, например "config print")
+func ParseFlags(args []string) (*Flags, []string, error) {
+	flags := &Flags{}
+	var overrides stringList
+	var logLevel, environment string
+
+	fs := flag.NewFlagSet("todo-app", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&flags.ConfigPath, "config", "", "path to config file")
+	fs.Var(&overrides, "set", "override config key, e.g. --set database.port=5433")
+	fs.StringVar(&logLevel, "log-level", "", "logger level")
+	fs.StringVar(&environment, "env", "", "application environment")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse flags: %w", err)
+	}
+
+	// Флаги-сокращения применяются раньше --set, чтобы --set имел приоритет
+	if logLevel != "" {
+		flags.Overrides = append(flags.Overrides, "logger.level="+logLevel)
+	}
+	if environment != "" {
+		flags.Overrides = append(flags.Overrides, "app.environment="+environment)
+	}
+	flags.Overrides = append(flags.Overrides, overrides...)
+
+	return flags, fs.Args(), nil
+}
+
+// DefaultConfigDir возвращает каталог конфигурации приложения
+// (например, ~/.config/todo-app в Linux)
+func DefaultConfigDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine user config dir: %w", err)
+	}
+	return filepath.Join(dir, configDirName), nil
+}
+
+// Loader загружает конфигурацию слоями: значения по умолчанию, файл,
+// переменные окружения и флаги командной строки
+type Loader struct {
+	Path      string   // явный путь к файлу; пустой - поиск в каталоге конфигурации
+	Overrides []string // key=value из флагов
+
+	file string
+}
+
+// NewLoader создает загрузчик по флагам командной строки
+func NewLoader(flags *Flags) *Loader {
+	if flags == nil {
+		flags = &Flags{}
+	}
+
+	path := flags.ConfigPath
+	if path == "" {
+		path = os.Getenv(ConfigPathEnv)
+	}
+
+	return &Loader{
+		Path:      path,
+		Overrides: flags.Overrides,
+	}
+}
+
+// File возвращает путь к загруженному файлу или пустую строку, если файл не найден
+func (l *Loader) File() string {
+	return l.file
+}
+
+// Load возвращает итоговую конфигурацию. Валидация не выполняется:
+// вызывающий код решает, как сообщать об ошибках Validate
+func (l *Loader) Load() (*Config, error) {
+	config, err := l.LoadFile()
+	if err != nil {
+		return nil, err
+	}
+
+	applyEnv(config)
+
+	for _, override := range l.Overrides {
+		if err := config.Set(override); err != nil {
+			return nil, err
+		}
+	}
+
+	return config, nil
+}
+
+// LoadFile возвращает значения по умолчанию, дополненные файлом конфигурации
+// (без переменных окружения и флагов)
+func (l *Loader) LoadFile() (*Config, error) {
+	config := NewDefaultConfig()
+
+	path, err := l.resolve()
+	if err != nil {
+		return nil, err
+	}
+	l.file = path
+
+	if path == "" {
+		return config, nil
+	}
+
+	if err := config.LoadFile(path); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// resolve находит файл конфигурации. Отсутствие файла в каталоге по умолчанию
+// не является ошибкой, отсутствие явно указанного файла - является
+func (l *Loader) resolve() (string, error) {
+	if l.Path != "" {
+		if _, err := os.Stat(l.Path); err != nil {
+			return "", fmt.Errorf("config file %s is not accessible: %w", l.Path, err)
+		}
+		return l.Path, nil
+	}
+
+	dir, err := DefaultConfigDir()
+	if err != nil {
+		// Без каталога пользователя работаем на значениях по умолчанию и окружении
+		return "", nil
+	}
+
+	for _, name := range configFileNames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", nil
+}
+
+// LoadFile накладывает на конфигурацию значения из YAML или TOML файла.
+// Формат определяется по расширению; неизвестные ключи считаются ошибкой
+func (c *Config) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var node yaml.Node
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		var values map[string]interface{}
+		if _, err := toml.Decode(string(data), &values); err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+		// TOML приводится к YAML, чтобы проверка ключей и декодирование были общими
+		if data, err = yaml.Marshal(values); err != nil {
+			return fmt.Errorf("failed to convert config file %s: %w", path, err)
+		}
+		fallthrough
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	default:
+		return fmt.Errorf("unsupported config file format: %s", path)
+	}
+
+	return c.apply(&node, path)
+}
+
+// Set применяет переопределение вида "section.key=value". Значение
+// разбирается как YAML, поэтому списки задаются как "[a, b]"
+func (c *Config) Set(override string) error {
+	key, value, ok := strings.Cut(override, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return fmt.Errorf("invalid config override %q: expected key=value", override)
+	}
+
+	var valueNode yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &valueNode); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+	if len(valueNode.Content) > 0 {
+		node = valueNode.Content[0]
+	}
+
+	// Строим вложенный документ {section: {key: value}}
+	parts := strings.Split(key, ".")
+	for i := len(parts) - 1; i >= 0; i-- {
+		node = &yaml.Node{
+			Kind:    yaml.MappingNode,
+			Content: []*yaml.Node{{Kind: yaml.ScalarNode, Value: parts[i]}, node},
+		}
+	}
+
+	return c.apply(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{node}}, "flag --set "+key)
+}
+
+// apply проверяет ключи документа и декодирует его поверх текущих значений
+func (c *Config) apply(node *yaml.Node, source string) error {
+	if node.Kind == 0 || len(node.Content) == 0 {
+		// Пустой файл
+		return nil
+	}
+
+	var unknown []string
+	collectUnknownKeys(node.Content[0], reflect.TypeOf(*c), "", &unknown)
+	if len(unknown) > 0 {
+		return &UnknownKeysError{Source: source, Keys: unknown}
+	}
+
+	if err := node.Decode(c); err != nil {
+		return fmt.Errorf("invalid value in %s: %w", source, err)
+	}
+
+	return nil
+}
+
+// collectUnknownKeys сравнивает ключи YAML с тегами структуры
+func collectUnknownKeys(node *yaml.Node, t reflect.Type, prefix string, unknown *[]string) {
+	if node.Kind != yaml.MappingNode || t.Kind() != reflect.Struct {
+		return
+	}
+
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name != "" && name != "-" {
+			fields[name] = t.Field(i).Type
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		fieldType, ok := fields[key]
+		if !ok {
+			*unknown = append(*unknown, path)
+			continue
+		}
+
+		collectUnknownKeys(node.Content[i+1], fieldType, path, unknown)
+	}
+}
+
+// Redacted возвращает копию конфигурации, в которой значения полей
+// с тегом secret заменены маской
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	redact(reflect.ValueOf(&redacted).Elem())
+	return &redacted
+}
+
+// redact рекурсивно маскирует секретные поля
+func redact(v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		secret := v.Type().Field(i).Tag.Get("secret") == "true"
+
+		switch {
+		case field.Kind() == reflect.Struct:
+			redact(field)
+		case secret && field.Kind() == reflect.String && field.Len() > 0:
+			field.SetString(redactedValue)
+		case secret && field.Kind() == reflect.Slice && field.Len() > 0:
+			// Новый срез, чтобы не изменить исходную конфигурацию
+			masked := make([]string, field.Len())
+			for j := range masked {
+				masked[j] = redactedValue
+			}
+			field.Set(reflect.ValueOf(masked))
+		}
+	}
+}
+
+// WriteYAML выводит конфигурацию в формате YAML со скрытыми секретами
+func (c *Config) WriteYAML(w io.Writer) error {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFile создает файл конфигурации во временном каталоге
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoader_LayeredPrecedence(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+database:
+  host: db.internal
+  port: 6000
+logger:
+  level: warn
+realtime:
+  debounce: 250ms
+`)
+	t.Setenv("DB_PORT", "7000")
+	t.Setenv("LOG_LEVEL", "error")
+
+	loader := NewLoader(&Flags{ConfigPath: path, Overrides: []string{"logger.level=debug", "server.api_keys=[a, b]"}})
+	cfg, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	// Файл перекрывает значения по умолчанию
+	if cfg.Database.Host != "db.internal" || cfg.Realtime.Debounce != 250*time.Millisecond {
+		t.Errorf("Expected file values, got host=%s debounce=%s", cfg.Database.Host, cfg.Realtime.Debounce)
+	}
+	// Окружение перекрывает файл
+	if cfg.Database.Port != 7000 {
+		t.Errorf("Expected port from env, got %d", cfg.Database.Port)
+	}
+	// Флаги перекрывают окружение
+	if cfg.Logger.Level != "debug" || len(cfg.Server.APIKeys) != 2 {
+		t.Errorf("Expected flag values, got level=%s keys=%v", cfg.Logger.Level, cfg.Server.APIKeys)
+	}
+	// Незаданные ключи сохраняют значения по умолчанию
+	if cfg.Database.User != "todo_user" || cfg.Realtime.MaxDelay != time.Second {
+		t.Errorf("Expected defaults to be kept, got user=%s max_delay=%s", cfg.Database.User, cfg.Realtime.MaxDelay)
+	}
+	if loader.File() != path {
+		t.Errorf("Expected loaded file %s, got %s", path, loader.File())
+	}
+}
+
+func TestLoader_TOMLAndUnknownKeys(t *testing.T) {
+	path := writeFile(t, "config.toml", `
+[database]
+port = 6000
+hots = "typo"
+
+[webhooks]
+timeout = "3s"
+retries = 5
+`)
+
+	_, err := NewLoader(&Flags{ConfigPath: path}).Load()
+
+	var unknown *UnknownKeysError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("Expected UnknownKeysError, got %v", err)
+	}
+	if strings.Join(unknown.Keys, ",") != "database.hots,webhooks.retries" {
+		t.Errorf("Unexpected unknown keys: %v", unknown.Keys)
+	}
+
+	path = writeFile(t, "config.toml", "[webhooks]\ntimeout = \"3s\"\n")
+	cfg, err := NewLoader(&Flags{ConfigPath: path}).Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.Webhooks.Timeout != 3*time.Second {
+		t.Errorf("Expected timeout from TOML, got %s", cfg.Webhooks.Timeout)
+	}
+}
+
+func TestConfig_ValidateReturnsAllFieldErrors(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Database.Port = 0
+	cfg.Logger.Level = "verbose"
+	cfg.Server.Enabled = true
+	cfg.Attachments.GCGracePeriod = 0
+
+	var errs ValidationErrors
+	if !errors.As(cfg.Validate(), &errs) {
+		t.Fatal("Expected ValidationErrors")
+	}
+
+	fields := make(map[string]bool)
+	for _, fieldErr := range errs {
+		fields[fieldErr.Field] = true
+	}
+	for _, field := range []string{"database.port", "logger.level", "server.api_keys", "attachments.gc_grace_period"} {
+		if !fields[field] {
+			t.Errorf("Expected error for %s, got %v", field, errs)
+		}
+	}
+
+	if err := NewDefaultConfig().Validate(); err != nil {
+		t.Errorf("Expected default config to be valid, got %v", err)
+	}
+}
+
+func TestConfig_WriteYAMLRedactsSecrets(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Database.Password = "super-secret"
+	cfg.Server.APIKeys = []string{"key-1"}
+
+	var buf bytes.Buffer
+	if err := cfg.WriteYAML(&buf); err != nil {
+		t.Fatalf("WriteYAML failed: %v", err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "super-secret") || strings.Contains(out, "key-1") {
+		t.Errorf("Secrets leaked into output:\n%s", out)
+	}
+	if !strings.Contains(out, redactedValue) {
+		t.Errorf("Expected redacted marker in output:\n%s", out)
+	}
+
+	// Исходная конфигурация не изменяется
+	if cfg.Database.Password != "super-secret" || cfg.Server.APIKeys[0] != "key-1" {
+		t.Error("Redaction modified the original config")
+	}
+}
@@ -0,0 +1,13 @@
+//go:build linux
+
+package config
+
+import (
+	"os"
+	"syscall"
+)
+
+// reloadSignals возвращает сигналы, по которым перечитывается конфигурация
+func reloadSignals() []os.Signal {
+	return []os.Signal{syscall.SIGHUP}
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package config
+
+import "os"
+
+// reloadSignals возвращает сигналы, по которым перечитывается конфигурация.
+// Вне Linux перезагрузка выполняется только по изменению файла
+func reloadSignals() []os.Signal {
+	return nil
+}
@@ -0,0 +1,357 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+	"todo-app/internal/utils"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// reloadDebounce пауза после изменения файла: редакторы сохраняют файл
+// несколькими операциями (запись во временный файл, переименование)
+const reloadDebounce = 200 * time.Millisecond
+
+// ReloadResult описывает результат перезагрузки конфигурации
+type ReloadResult struct {
+	Applied  []string // ключи, примененные без перезапуска
+	Rejected []string // ключи, требующие перезапуска; действуют старые значения
+}
+
+// RestartRequiredError сообщает об изменениях, которые вступят в силу только после перезапуска
+type RestartRequiredError struct {
+	Keys []string
+}
+
+// Error реализует интерфейс error
+func (e *RestartRequiredError) Error() string {
+	return fmt.Sprintf("changes require restart and were not applied: %s", strings.Join(e.Keys, ", "))
+}
+
+// Watcher перечитывает конфигурацию при изменении файла (и по SIGHUP в Linux),
+// валидирует ее и применяет поля с тегом reload:"live". Остальные изменения
+// (например, параметры подключения к БД) отклоняются до перезапуска
+type Watcher struct {
+	loader *Loader
+	logger *utils.Logger
+
+	mu          sync.Mutex
+	current     *Config
+	subscribers map[uint64]func(old, new *Config)
+	nextID      uint64
+
+	reloadMu sync.Mutex
+	files    *fsnotify.Watcher
+	signals  chan os.Signal
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewWatcher создает наблюдатель за конфигурацией, загруженной loader
+func NewWatcher(loader *Loader, current *Config, logger *utils.Logger) *Watcher {
+	if logger == nil {
+		logger = utils.DefaultLogger()
+	}
+
+	return &Watcher{
+		loader:      loader,
+		logger:      logger,
+		current:     current,
+		subscribers: make(map[uint64]func(old, new *Config)),
+	}
+}
+
+// Current возвращает действующую конфигурацию. Возвращаемое значение
+// не изменяется: перезагрузка создает новый экземпляр
+func (w *Watcher) Current() *Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// subscribe регистрирует получателя всех изменений
+func (w *Watcher) subscribe(fn func(old, new *Config)) func() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.nextID++
+	id := w.nextID
+	w.subscribers[id] = fn
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.subscribers, id)
+	}
+}
+
+// Subscribe подписывает fn на изменения секции конфигурации. fn вызывается
+// только если значение, возвращаемое section, изменилось:
+//
+//	config.Subscribe(watcher, func(c *config.Config) config.LoggerConfig { return c.Logger },
+//		func(old, new config.LoggerConfig) { ... })
+func Subscribe[T any](w *Watcher, section func(*Config) T, fn func(old, new T)) func() {
+	return w.subscribe(func(oldConfig, newConfig *Config) {
+		oldValue, newValue := section(oldConfig), section(newConfig)
+		if !reflect.DeepEqual(oldValue, newValue) {
+			fn(oldValue, newValue)
+		}
+	})
+}
+
+// Reload перечитывает конфигурацию и применяет допустимые изменения.
+// Ошибка загрузки или валидации оставляет текущую конфигурацию без изменений;
+// при отклоненных ключах возвращается RestartRequiredError вместе с результатом
+func (w *Watcher) Reload() (*ReloadResult, error) {
+	w.reloadMu.Lock()
+	defer w.reloadMu.Unlock()
+
+	next, err := w.loader.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload config: %w", err)
+	}
+
+	if err := next.Validate(); err != nil {
+		return nil, fmt.Errorf("reloaded config is invalid: %w", err)
+	}
+
+	current := w.Current()
+	merged, result := mergeLive(current, next)
+
+	// Перекрестные проверки могут связывать live поле нового файла
+	// с полем, которое сохраняет значение до перезапуска
+	if err := merged.Validate(); err != nil {
+		return nil, fmt.Errorf("reloaded config is invalid with current restart-only settings: %w", err)
+	}
+
+	if len(result.Applied) > 0 {
+		w.mu.Lock()
+		w.current = merged
+		subscribers := make([]func(old, new *Config), 0, len(w.subscribers))
+		for _, fn := range w.subscribers {
+			subscribers = append(subscribers, fn)
+		}
+		w.mu.Unlock()
+
+		for _, fn := range subscribers {
+			w.notify(fn, current, merged)
+		}
+	}
+
+	if len(result.Rejected) > 0 {
+		return result, &RestartRequiredError{Keys: result.Rejected}
+	}
+
+	return result, nil
+}
+
+// notify вызывает подписчика с изоляцией паники
+func (w *Watcher) notify(fn func(old, new *Config), old, new *Config) {
+	defer func() {
+		if r := recover(); r != nil {
+			w.logger.Error("Config change subscriber panicked", map[string]interface{}{
+				"panic": fmt.Sprintf("%v", r),
+				"stack": string(debug.Stack()),
+			})
+		}
+	}()
+
+	fn(old, new)
+}
+
+// mergeLive копирует в текущую конфигурацию изменившиеся поля с тегом reload:"live"
+func mergeLive(current, next *Config) (*Config, *ReloadResult) {
+	merged := *current
+	result := &ReloadResult{}
+	mergeFields(reflect.ValueOf(&merged).Elem(), reflect.ValueOf(next).Elem(), "", false, result)
+	return &merged, result
+}
+
+// mergeFields рекурсивно сравнивает поля; тег live наследуется вложенными полями
+func mergeFields(dst, src reflect.Value, prefix string, live bool, result *ReloadResult) {
+	t := dst.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+		fieldLive := live || field.Tag.Get("reload") == "live"
+
+		if field.Type.Kind() == reflect.Struct {
+			mergeFields(dst.Field(i), src.Field(i), path, fieldLive, result)
+			continue
+		}
+
+		if reflect.DeepEqual(dst.Field(i).Interface(), src.Field(i).Interface()) {
+			continue
+		}
+
+		if fieldLive {
+			dst.Field(i).Set(src.Field(i))
+			result.Applied = append(result.Applied, path)
+		} else {
+			result.Rejected = append(result.Rejected, path)
+		}
+	}
+}
+
+// Start начинает наблюдение за каталогом файла конфигурации и сигналами
+// перезагрузки. Каталог отслеживается целиком, так как редакторы заменяют файл
+func (w *Watcher) Start() error {
+	dir, err := w.watchDir()
+	if err != nil {
+		return err
+	}
+
+	if dir != "" {
+		files, err := fsnotify.NewWatcher()
+		if err != nil {
+			return fmt.Errorf("failed to create config file watcher: %w", err)
+		}
+		if err := files.Add(dir); err != nil {
+			files.Close()
+			return fmt.Errorf("failed to watch config dir %s: %w", dir, err)
+		}
+		w.files = files
+	}
+
+	if signals := reloadSignals(); len(signals) > 0 {
+		w.signals = make(chan os.Signal, 1)
+		signal.Notify(w.signals, signals...)
+	}
+
+	w.stop = make(chan struct{})
+	w.done = make(chan struct{})
+	go w.run()
+
+	w.logger.Info("Config watcher started", map[string]interface{}{
+		"dir":  dir,
+		"file": w.loader.File(),
+	})
+
+	return nil
+}
+
+// watchDir возвращает каталог для наблюдения: каталог загруженного файла,
+// а если файла нет - каталог конфигурации пользователя (если он существует)
+func (w *Watcher) watchDir() (string, error) {
+	if file := w.loader.File(); file != "" {
+		return filepath.Dir(file), nil
+	}
+
+	if w.loader.Path != "" {
+		return filepath.Dir(w.loader.Path), nil
+	}
+
+	dir, err := DefaultConfigDir()
+	if err != nil {
+		return "", nil
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", nil
+	}
+
+	return dir, nil
+}
+
+// isConfigFile проверяет, относится ли событие файловой системы к файлу конфигурации
+func (w *Watcher) isConfigFile(name string) bool {
+	if file := w.loader.File(); file != "" {
+		return filepath.Clean(name) == filepath.Clean(file)
+	}
+
+	base := filepath.Base(name)
+	for _, candidate := range configFileNames {
+		if base == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+// Stop прекращает наблюдение
+func (w *Watcher) Stop() {
+	if w.stop == nil {
+		return
+	}
+
+	close(w.stop)
+	<-w.done
+	w.stop = nil
+
+	if w.signals != nil {
+		signal.Stop(w.signals)
+	}
+	if w.files != nil {
+		w.files.Close()
+	}
+}
+
+// run обрабатывает события файловой системы и сигналы
+func (w *Watcher) run() {
+	defer close(w.done)
+
+	var fileEvents <-chan fsnotify.Event
+	var fileErrors <-chan error
+	if w.files != nil {
+		fileEvents = w.files.Events
+		fileErrors = w.files.Errors
+	}
+
+	debounce := time.NewTimer(time.Hour)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case event := <-fileEvents:
+			if w.isConfigFile(event.Name) && event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) != 0 {
+				debounce.Reset(reloadDebounce)
+			}
+		case err := <-fileErrors:
+			w.logger.Warn("Config file watcher error", map[string]interface{}{
+				"error": err.Error(),
+			})
+		case sig := <-w.signals:
+			w.logger.Info("Config reload requested by signal", map[string]interface{}{
+				"signal": sig.String(),
+			})
+			w.reloadAndLog()
+		case <-debounce.C:
+			w.reloadAndLog()
+		}
+	}
+}
+
+// reloadAndLog выполняет перезагрузку и сообщает результат в лог
+func (w *Watcher) reloadAndLog() {
+	result, err := w.Reload()
+
+	if result != nil && len(result.Applied) > 0 {
+		w.logger.Info("Config reloaded", map[string]interface{}{
+			"applied": strings.Join(result.Applied, ", "),
+		})
+	}
+
+	if err != nil {
+		w.logger.Warn("Config reload was not fully applied", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	if result != nil && len(result.Applied) == 0 {
+		w.logger.Debug("Config reloaded without changes")
+	}
+}
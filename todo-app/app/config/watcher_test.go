@@ -0,0 +1,135 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestWatcher_ReloadAppliesLiveChangesOnly(t *testing.T) {
+	path := writeFile(t, "config.yaml", "logger:\n  level: info\n")
+	loader := NewLoader(&Flags{ConfigPath: path})
+	current, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	watcher := NewWatcher(loader, current, nil)
+
+	var loggerChanges []LoggerConfig
+	Subscribe(watcher, func(c *Config) LoggerConfig { return c.Logger }, func(old, new LoggerConfig) {
+		loggerChanges = append(loggerChanges, new)
+	})
+	remindersCalls := 0
+	Subscribe(watcher, func(c *Config) RemindersConfig { return c.Reminders }, func(old, new RemindersConfig) {
+		remindersCalls++
+	})
+
+	content := "logger:\n  level: debug\ndatabase:\n  host: other-host\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to update config file: %v", err)
+	}
+
+	result, err := watcher.Reload()
+
+	var restartErr *RestartRequiredError
+	if !errors.As(err, &restartErr) || len(restartErr.Keys) != 1 || restartErr.Keys[0] != "database.host" {
+		t.Fatalf("Expected database.host to require restart, got %v", err)
+	}
+	if len(result.Applied) != 1 || result.Applied[0] != "logger.level" {
+		t.Errorf("Expected logger.level to be applied, got %v", result.Applied)
+	}
+
+	cfg := watcher.Current()
+	if cfg.Logger.Level != "debug" || cfg.Database.Host != "localhost" {
+		t.Errorf("Expected live change only, got level=%s host=%s", cfg.Logger.Level, cfg.Database.Host)
+	}
+	if current.Logger.Level != "info" {
+		t.Error("Reload modified the previous config instance")
+	}
+
+	// Подписчик секции вызывается только при изменении этой секции
+	if len(loggerChanges) != 1 || loggerChanges[0].Level != "debug" || remindersCalls != 0 {
+		t.Errorf("Unexpected notifications: logger=%v reminders=%d", loggerChanges, remindersCalls)
+	}
+}
+
+func TestWatcher_RejectsInvalidConfig(t *testing.T) {
+	path := writeFile(t, "config.yaml", "reminders:\n  lead_time: 5m\n")
+	loader := NewLoader(&Flags{ConfigPath: path})
+	current, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	watcher := NewWatcher(loader, current, nil)
+	notified := false
+	Subscribe(watcher, func(c *Config) *Config { return c }, func(old, new *Config) { notified = true })
+
+	os.WriteFile(path, []byte("reminders:\n  lead_time: 10m\n  interval: 1ms\n"), 0o600)
+	if _, err := watcher.Reload(); err == nil {
+		t.Fatal("Expected validation error")
+	}
+
+	os.WriteFile(path, []byte("reminders:\n  lead_tme: 10m\n"), 0o600)
+	if _, err := watcher.Reload(); err == nil {
+		t.Fatal("Expected unknown key error")
+	}
+
+	if watcher.Current() != current || notified {
+		t.Error("Invalid config must not replace the current one")
+	}
+}
+
+func TestWatcher_ValidatesMergedConfig(t *testing.T) {
+	path := writeFile(t, "config.yaml", "logger:\n  level: info\n")
+	loader := NewLoader(&Flags{ConfigPath: path})
+	current, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	// Значение, применяемое только после перезапуска, несовместимо с остальной конфигурацией
+	current.Server.Enabled = true
+	watcher := NewWatcher(loader, current, nil)
+
+	os.WriteFile(path, []byte("logger:\n  level: debug\n"), 0o600)
+	if _, err := watcher.Reload(); err == nil {
+		t.Fatal("Expected validation error for the merged config")
+	}
+	if watcher.Current() != current {
+		t.Error("Invalid merged config must not replace the current one")
+	}
+}
+
+func TestWatcher_ReloadsOnFileChange(t *testing.T) {
+	path := writeFile(t, "config.yaml", "server:\n  rate_limit:\n    requests_per_minute: 60\n")
+	loader := NewLoader(&Flags{ConfigPath: path})
+	current, err := loader.Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	watcher := NewWatcher(loader, current, nil)
+	changes := make(chan RateLimitConfig, 1)
+	Subscribe(watcher, func(c *Config) RateLimitConfig { return c.Server.RateLimit }, func(old, new RateLimitConfig) {
+		changes <- new
+	})
+
+	if err := watcher.Start(); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	defer watcher.Stop()
+
+	os.WriteFile(path, []byte("server:\n  rate_limit:\n    requests_per_minute: 5\n"), 0o600)
+
+	select {
+	case change := <-changes:
+		if change.RequestsPerMinute != 5 {
+			t.Errorf("Expected new rate limit, got %+v", change)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected reload after file change")
+	}
+}
@@ -4,10 +4,24 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
+	"todo-app/app/attachments"
 	"todo-app/app/config"
+	"todo-app/app/diagnostics"
+	"todo-app/app/events"
+	"todo-app/app/metrics"
+	"todo-app/app/models"
+	"todo-app/app/realtime"
+	"todo-app/app/reminders"
 	"todo-app/app/repository"
+	"todo-app/app/server"
 	"todo-app/app/services"
 	"todo-app/app/usecases"
+	"todo-app/app/webhooks"
+	"todo-app/database"
+	"todo-app/internal/i18n"
+	"todo-app/internal/middleware"
+	"todo-app/internal/tracing"
 	"todo-app/internal/utils"
 
 	_ "github.com/lib/pq"
@@ -18,22 +32,79 @@ type Container struct {
 	// Database
 	DB *sql.DB
 
+	// QueryStats статистика SQL запросов (nil, если инструментирование отключено)
+	QueryStats *utils.QueryRecorder
+
+	// dbConnection параметры подключения с разрешенным паролем;
+	// нужны LISTEN/NOTIFY, который открывает отдельное соединение
+	dbConnection utils.DatabaseConfig
+
 	// Repositories
-	TaskRepository repository.TaskRepository
+	TaskRepository       repository.TaskRepository
+	OutboxRepository     repository.OutboxRepository
+	TxManager            repository.TxManager
+	WebhookRepository    repository.WebhookRepository
+	ReminderRepository   repository.ReminderRepository
+	SettingsRepository   repository.SettingsRepository
+	TimeRepository       repository.TimeEntryRepository
+	CommentRepository    repository.CommentRepository
+	AttachmentRepository repository.AttachmentRepository
+
+	// Events
+	EventBus          *events.Bus
+	Outbox            *events.Outbox
+	WebhookDispatcher *webhooks.Dispatcher
+
+	// Realtime
+	RealtimeHub  *realtime.Hub
+	TaskListener *realtime.PostgresListener
+
+	// HTTP
+	HTTPServer *server.Server
+
+	// Reminders
+	Reminders *reminders.Scheduler
+
+	// Attachments хранилище содержимого вложений и его сборка мусора
+	AttachmentStore     *attachments.Store
+	AttachmentCollector *attachments.Collector
+
+	// Diagnostics
+	Diagnostics *diagnostics.Diagnostics
+
+	// Metrics (nil если отключены)
+	Metrics *metrics.Metrics
+
+	// Tracing (nil если отключена)
+	Tracer *tracing.Tracer
+
+	// Locales язык сообщений пользователю по настройкам
+	Locales *i18n.Resolver
 
 	// Services
-	TaskService services.TaskService
+	TaskService         services.TaskService
+	WebhookService      services.WebhookService
+	SettingsService     services.SettingsService
+	TimeTrackingService services.TimeTrackingService
+	CommentService      services.CommentService
+	AttachmentService   services.AttachmentService
 
 	// UseCases
-	TaskUseCase      usecases.TaskUseCase
-	AnalyticsUseCase usecases.AnalyticsUseCase
-	ExportUseCase    usecases.ExportUseCase
+	TaskUseCase       usecases.TaskUseCase
+	AnalyticsUseCase  usecases.AnalyticsUseCase
+	ExportUseCase     usecases.ExportUseCase
+	WebhookUseCase    usecases.WebhookUseCase
+	SettingsUseCase   usecases.SettingsUseCase
+	TimeUseCase       usecases.TimeTrackingUseCase
+	CommentUseCase    usecases.CommentUseCase
+	AttachmentUseCase usecases.AttachmentUseCase
 
 	// Utils
 	Logger *utils.Logger
 
 	// Config
-	Config *config.Config
+	Config        *config.Config
+	ConfigWatcher *config.Watcher
 }
 
 // NewContainer создает и инициализирует новый DI контейнер
@@ -47,14 +118,26 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		return nil, fmt.Errorf("failed to initialize logger: %w", err)
 	}
 
+	if err := container.initTracing(); err != nil {
+		return nil, fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+
 	if err := container.initDatabase(); err != nil {
 		return nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
 
+	if err := container.runMigrations(); err != nil {
+		return nil, fmt.Errorf("failed to run migrations: %w", err)
+	}
+
 	if err := container.initRepositories(); err != nil {
 		return nil, fmt.Errorf("failed to initialize repositories: %w", err)
 	}
 
+	if err := container.initEvents(); err != nil {
+		return nil, fmt.Errorf("failed to initialize events: %w", err)
+	}
+
 	if err := container.initServices(); err != nil {
 		return nil, fmt.Errorf("failed to initialize services: %w", err)
 	}
@@ -63,30 +146,52 @@ func NewContainer(cfg *config.Config) (*Container, error) {
 		return nil, fmt.Errorf("failed to initialize use cases: %w", err)
 	}
 
+	container.initReminders()
+	container.initAttachmentCollector()
+	container.initDiagnostics()
+
+	if err := container.initServer(); err != nil {
+		return nil, fmt.Errorf("failed to initialize HTTP server: %w", err)
+	}
+
 	container.Logger.Info("DI Container initialized successfully")
 	return container, nil
 }
 
+// logRotation преобразует настройки ротации логов (используются и для файла трасс)
+func (c *Container) logRotation() utils.RotationConfig {
+	return utils.RotationConfig{
+		MaxSize:    int64(c.Config.Logger.Rotation.MaxSizeMB) * 1024 * 1024,
+		Interval:   c.Config.Logger.Rotation.Interval,
+		MaxAge:     c.Config.Logger.Rotation.MaxAge,
+		MaxBackups: c.Config.Logger.Rotation.MaxBackups,
+		Compress:   c.Config.Logger.Rotation.Compress,
+	}
+}
+
+// samplingConfig преобразует настройки семплирования DEBUG логов
+func samplingConfig(cfg config.LogSamplingConfig) utils.SamplingConfig {
+	return utils.SamplingConfig{
+		Initial:    cfg.Initial,
+		Thereafter: cfg.Thereafter,
+		Tick:       cfg.Tick,
+	}
+}
+
 // initLogger инициализирует логгер
 func (c *Container) initLogger() error {
+	// Неизвестный уровень отклоняется Config.Validate, здесь остается INFO
+	level, _ := utils.ParseLogLevel(c.Config.Logger.Level)
+
 	loggerConfig := utils.LoggerConfig{
-		Level:      utils.INFO,
-		JSONFormat: c.Config.Logger.JSONFormat,
-		LogFile:    c.Config.Logger.LogFile,
-	}
-
-	// Устанавливаем уровень логирования
-	switch c.Config.Logger.Level {
-	case "debug":
-		loggerConfig.Level = utils.DEBUG
-	case "info":
-		loggerConfig.Level = utils.INFO
-	case "warn":
-		loggerConfig.Level = utils.WARN
-	case "error":
-		loggerConfig.Level = utils.ERROR
-	default:
-		loggerConfig.Level = utils.INFO
+		Level:         level,
+		JSONFormat:    c.Config.Logger.JSONFormat,
+		LogFile:       c.Config.Logger.LogFile,
+		Rotation:      c.logRotation(),
+		DebugSampling: samplingConfig(c.Config.Logger.DebugSampling),
+	}
+	if c.Config.Logger.Backend == "slog" {
+		loggerConfig.NewHandler = utils.DefaultSlogHandler
 	}
 
 	logger, err := utils.NewLogger(loggerConfig)
@@ -100,17 +205,51 @@ func (c *Container) initLogger() error {
 	return nil
 }
 
+// initTracing создает глобальный трассировщик с экспортером из конфигурации
+func (c *Container) initTracing() error {
+	if !c.Config.Tracing.Enabled {
+		return nil
+	}
+
+	var exporter tracing.Exporter
+	switch c.Config.Tracing.Exporter {
+	case "file":
+		fileExporter, err := tracing.NewFileExporter(c.Config.Tracing.File, c.logRotation())
+		if err != nil {
+			return err
+		}
+		exporter = fileExporter
+	default:
+		exporter = tracing.NewStdoutExporter()
+	}
+
+	c.Tracer = tracing.NewTracer(tracing.Config{
+		Service:     c.Config.App.Name,
+		Exporter:    exporter,
+		SampleRatio: c.Config.Tracing.SampleRatio,
+	})
+	tracing.SetTracer(c.Tracer)
+
+	c.Logger.Info("Tracing enabled", map[string]interface{}{
+		"exporter":     c.Config.Tracing.Exporter,
+		"sample_ratio": c.Config.Tracing.SampleRatio,
+	})
+	return nil
+}
+
 // initDatabase инициализирует подключение к базе данных
 func (c *Container) initDatabase() error {
 	c.Logger.Info("Initializing database connection")
 
-	dbConfig := utils.DatabaseConfig{
-		Host:     c.Config.Database.Host,
-		Port:     c.Config.Database.Port,
-		User:     c.Config.Database.User,
-		Password: c.Config.Database.Password,
-		DBName:   c.Config.Database.DBName,
-		SSLMode:  c.Config.Database.SSLMode,
+	dbConfig, err := c.Config.DatabaseConnection()
+	if err != nil {
+		return fmt.Errorf("failed to resolve database credentials: %w", err)
+	}
+
+	if c.Config.Database.Queries.Instrument {
+		c.QueryStats = utils.NewQueryRecorder(c.Config.Database.Queries.SlowThreshold)
+		c.QueryStats.SetLogQueries(c.Config.Database.Queries.LogAll)
+		dbConfig.Queries = c.QueryStats
 	}
 
 	db, err := utils.InitDB(dbConfig)
@@ -124,11 +263,18 @@ func (c *Container) initDatabase() error {
 	}
 
 	c.DB = db
+	c.dbConnection = dbConfig
+
+	repository.SetReadRetryPolicy(utils.RetryPolicy{
+		MaxAttempts:    c.Config.Database.Retry.ReadAttempts,
+		InitialBackoff: c.Config.Database.Retry.InitialBackoff,
+		MaxBackoff:     c.Config.Database.Retry.MaxBackoff,
+	})
 	c.Logger.Info("Database connection established successfully",
 		map[string]interface{}{
-			"host": c.Config.Database.Host,
-			"port": c.Config.Database.Port,
-			"db":   c.Config.Database.DBName,
+			"host": dbConfig.Host,
+			"port": dbConfig.Port,
+			"db":   dbConfig.DBName,
 		})
 
 	return nil
@@ -150,38 +296,31 @@ func (c *Container) runMigrations() error {
 		return fmt.Errorf("failed to create migrations table: %w", err)
 	}
 
-	// Список миграций в порядке выполнения
-	migrations := []struct {
-		version string
-		file    string
-	}{
-		{"001", "database/migrations/001_create_tasks_table.up.sql"},
-		{"002", "database/migrations/002_add_indexes.up.sql"},
+	migrations, err := database.Migrations()
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
 	}
 
 	for _, migration := range migrations {
-		applied, err := migrationHelper.IsMigrationApplied(migration.version)
+		applied, err := migrationHelper.IsMigrationApplied(migration.Version)
 		if err != nil {
-			return fmt.Errorf("failed to check migration %s: %w", migration.version, err)
+			return fmt.Errorf("failed to check migration %s: %w", migration.Version, err)
 		}
 
 		if applied {
 			c.Logger.Debug("Migration already applied", map[string]interface{}{
-				"version": migration.version,
+				"version": migration.Version,
 			})
 			continue
 		}
 
 		c.Logger.Info("Applying migration", map[string]interface{}{
-			"version": migration.version,
-			"file":    migration.file,
+			"version": migration.Version,
+			"name":    migration.Name,
 		})
 
-		// Здесь можно добавить логику выполнения SQL файлов
-		// Для простоты пропускаем реальное выполнение
-
-		if err := migrationHelper.MarkMigrationApplied(migration.version); err != nil {
-			return fmt.Errorf("failed to mark migration as applied: %w", err)
+		if err := migrationHelper.ApplyMigration(migration.Version, migration.UpSQL); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", migration.Version, err)
 		}
 	}
 
@@ -196,16 +335,171 @@ func (c *Container) initRepositories() error {
 	// Task Repository
 	c.TaskRepository = repository.NewPostgresTaskRepository(c.DB)
 
+	// Outbox Repository
+	c.OutboxRepository = repository.NewPostgresOutboxRepository(c.DB)
+
+	// Transaction Manager
+	c.TxManager = repository.NewTxManager(c.DB)
+
+	// Webhook Repository
+	c.WebhookRepository = repository.NewPostgresWebhookRepository(c.DB)
+
+	// Reminder Repository
+	c.ReminderRepository = repository.NewPostgresReminderRepository(c.DB)
+
+	// Settings Repository
+	c.SettingsRepository = repository.NewPostgresSettingsRepository(c.DB)
+
+	// Time Entry Repository
+	c.TimeRepository = repository.NewPostgresTimeEntryRepository(c.DB)
+
+	// Comment Repository
+	c.CommentRepository = repository.NewPostgresCommentRepository(c.DB)
+
+	// Attachment Repository
+	c.AttachmentRepository = repository.NewPostgresAttachmentRepository(c.DB)
+
+	if c.Tracer != nil {
+		c.TaskRepository = repository.NewTaskRepositoryWithTracing(c.TaskRepository)
+		c.OutboxRepository = repository.NewOutboxRepositoryWithTracing(c.OutboxRepository)
+		c.WebhookRepository = repository.NewWebhookRepositoryWithTracing(c.WebhookRepository)
+		c.ReminderRepository = repository.NewReminderRepositoryWithTracing(c.ReminderRepository)
+		c.SettingsRepository = repository.NewSettingsRepositoryWithTracing(c.SettingsRepository)
+		c.TimeRepository = repository.NewTimeEntryRepositoryWithTracing(c.TimeRepository)
+		c.CommentRepository = repository.NewCommentRepositoryWithTracing(c.CommentRepository)
+		c.AttachmentRepository = repository.NewAttachmentRepositoryWithTracing(c.AttachmentRepository)
+	}
+
+	c.initLocales()
+
 	c.Logger.Info("Repositories initialized successfully")
 	return nil
 }
 
+// localeCacheTTL время, на которое кэшируется язык из настроек
+const localeCacheTTL = 5 * time.Second
+
+// initLocales создает определение языка вызова по настройкам пользователя
+func (c *Container) initLocales() {
+	c.Locales = i18n.NewResolver(func(ctx context.Context) (string, error) {
+		settings, err := c.SettingsRepository.GetSettings(ctx)
+		if err != nil {
+			return "", err
+		}
+		return settings.Language, nil
+	}, localeCacheTTL)
+}
+
+// initEvents инициализирует шину событий и публикацию из outbox
+func (c *Container) initEvents() error {
+	c.Logger.Info("Initializing event bus")
+
+	c.EventBus = events.NewBus(events.BusConfig{
+		AsyncWorkers: c.Config.Events.AsyncWorkers,
+		QueueSize:    c.Config.Events.QueueSize,
+	}, c.Logger)
+
+	c.Outbox = events.NewOutbox(c.OutboxRepository, c.EventBus, events.OutboxConfig{
+		PollInterval: c.Config.Events.OutboxPollInterval,
+		BatchSize:    c.Config.Events.OutboxBatchSize,
+		Retry: utils.RetryPolicy{
+			MaxAttempts:    c.Config.Events.OutboxMaxAttempts,
+			InitialBackoff: time.Second,
+			MaxBackoff:     5 * time.Minute,
+		},
+	}, c.Logger)
+
+	// Webhooks подписываются до запуска relay, чтобы не пропустить отложенные события
+	if c.Config.Webhooks.Enabled {
+		c.WebhookDispatcher = webhooks.NewDispatcher(c.WebhookRepository, nil, webhooks.Config{
+			MaxAttempts:    c.Config.Webhooks.MaxAttempts,
+			InitialBackoff: c.Config.Webhooks.InitialBackoff,
+			MaxBackoff:     c.Config.Webhooks.MaxBackoff,
+			Timeout:        c.Config.Webhooks.Timeout,
+			PollInterval:   c.Config.Webhooks.PollInterval,
+
+			AllowPrivateTargets: c.Config.Webhooks.AllowPrivateTargets,
+		}, c.Logger)
+		c.WebhookDispatcher.Attach(c.EventBus)
+		c.WebhookDispatcher.Start()
+	}
+
+	if c.Config.Realtime.Enabled {
+		c.initRealtime()
+	}
+
+	// Публикуем события, оставшиеся после предыдущего запуска, и запускаем relay
+	c.Outbox.Start()
+
+	c.Logger.Info("Event bus initialized successfully")
+	return nil
+}
+
+// initRealtime инициализирует рассылку изменений задач клиентам
+func (c *Container) initRealtime() {
+	c.RealtimeHub = realtime.NewHub(c.TaskRepository, realtime.Config{
+		Debounce: c.Config.Realtime.Debounce,
+		MaxDelay: c.Config.Realtime.MaxDelay,
+	}, c.Logger)
+	c.RealtimeHub.Attach(c.EventBus)
+
+	if !c.Config.Realtime.ListenNotify {
+		return
+	}
+
+	// Без LISTEN/NOTIFY приложение продолжает работать, но не видит изменения других процессов
+	c.TaskListener = realtime.NewPostgresListener(c.dbConnection.DSN(), c.RealtimeHub, c.Logger)
+	if err := c.TaskListener.Start(); err != nil {
+		c.Logger.Warn("Task change listener is not available", map[string]interface{}{
+			"error": err.Error(),
+		})
+		c.TaskListener = nil
+	}
+}
+
 // initServices инициализирует сервисы
 func (c *Container) initServices() error {
 	c.Logger.Info("Initializing services")
 
-	// Task Service
-	c.TaskService = services.NewTaskService(c.TaskRepository)
+	// Task Service со статусами и переходами из конфигурации
+	workflow, err := c.Config.Workflow.Build()
+	if err != nil {
+		return fmt.Errorf("invalid workflow configuration: %w", err)
+	}
+	c.TaskService = services.NewTaskServiceWithEvents(c.TaskRepository, c.TxManager, c.Outbox, workflow)
+
+	// Webhook Service
+	c.WebhookService = services.NewWebhookService(c.WebhookRepository, c.WebhookDispatcher, c.Config.Webhooks.AllowPrivateTargets)
+
+	// Settings Service
+	c.SettingsService = services.NewSettingsService(c.SettingsRepository, c.TxManager, c.Outbox)
+
+	// Time Tracking Service
+	c.TimeTrackingService = services.NewTimeTrackingService(c.TimeRepository, c.TaskRepository)
+
+	// Comment Service: история задачи читается из outbox
+	c.CommentService = services.NewCommentService(c.CommentRepository, c.TaskRepository, c.OutboxRepository, c.TxManager, c.Outbox)
+
+	// Attachment Service: содержимое в локальном хранилище, метаданные в БД
+	dir, err := c.Config.Attachments.StorageDir()
+	if err != nil {
+		return fmt.Errorf("failed to resolve attachments directory: %w", err)
+	}
+	c.AttachmentStore, err = attachments.NewStore(dir)
+	if err != nil {
+		return err
+	}
+	c.AttachmentService = services.NewAttachmentService(c.AttachmentRepository, c.TaskRepository, c.AttachmentStore,
+		attachmentLimits(c.Config.Attachments), c.TxManager, c.Outbox)
+
+	if c.Tracer != nil {
+		c.TaskService = services.NewTaskServiceWithTracing(c.TaskService)
+		c.WebhookService = services.NewWebhookServiceWithTracing(c.WebhookService)
+		c.SettingsService = services.NewSettingsServiceWithTracing(c.SettingsService)
+		c.TimeTrackingService = services.NewTimeTrackingServiceWithTracing(c.TimeTrackingService)
+		c.CommentService = services.NewCommentServiceWithTracing(c.CommentService)
+		c.AttachmentService = services.NewAttachmentServiceWithTracing(c.AttachmentService)
+	}
 
 	c.Logger.Info("Services initialized successfully")
 	return nil
@@ -216,28 +510,276 @@ func (c *Container) initUseCases() error {
 	c.Logger.Info("Initializing use cases")
 
 	// Task UseCase
-	c.TaskUseCase = usecases.NewTaskUseCase(c.TaskService)
+	c.TaskUseCase = usecases.NewTaskUseCaseWithSettings(c.TaskService, c.SettingsService)
 
 	// Analytics UseCase
-	c.AnalyticsUseCase = usecases.NewAnalyticsUseCase(c.TaskService)
+	c.AnalyticsUseCase = usecases.NewAnalyticsUseCaseWithTimeTracking(c.TaskService, c.SettingsService, c.TimeTrackingService)
 
 	// Export UseCase
-	c.ExportUseCase = usecases.NewExportUseCase(c.TaskService)
+	c.ExportUseCase = usecases.NewExportUseCaseWithComments(c.TaskService, c.TimeTrackingService, c.CommentService)
+
+	// Webhook UseCase
+	c.WebhookUseCase = usecases.NewWebhookUseCase(c.WebhookService)
+
+	// Settings UseCase
+	c.SettingsUseCase = usecases.NewSettingsUseCase(c.SettingsService)
+
+	// Time Tracking UseCase
+	c.TimeUseCase = usecases.NewTimeTrackingUseCase(c.TimeTrackingService, c.SettingsService)
+
+	// Comment UseCase
+	c.CommentUseCase = usecases.NewCommentUseCase(c.CommentService)
+
+	// Attachment UseCase
+	c.AttachmentUseCase = usecases.NewAttachmentUseCase(c.AttachmentService)
+
+	if c.Config.Metrics.Enabled {
+		c.initMetrics()
+	}
+
+	// Трассировка снаружи метрик: span включает время их учета
+	if c.Tracer != nil {
+		c.TaskUseCase = usecases.NewTaskUseCaseWithTracing(c.TaskUseCase)
+		c.AnalyticsUseCase = usecases.NewAnalyticsUseCaseWithTracing(c.AnalyticsUseCase)
+		c.ExportUseCase = usecases.NewExportUseCaseWithTracing(c.ExportUseCase)
+		c.WebhookUseCase = usecases.NewWebhookUseCaseWithTracing(c.WebhookUseCase)
+		c.SettingsUseCase = usecases.NewSettingsUseCaseWithTracing(c.SettingsUseCase)
+		c.TimeUseCase = usecases.NewTimeTrackingUseCaseWithTracing(c.TimeUseCase)
+		c.CommentUseCase = usecases.NewCommentUseCaseWithTracing(c.CommentUseCase)
+		c.AttachmentUseCase = usecases.NewAttachmentUseCaseWithTracing(c.AttachmentUseCase)
+	}
 
 	c.Logger.Info("Use cases initialized successfully")
 	return nil
 }
 
+// initMetrics создает метрики и оборачивает ими use cases. Статистика задач
+// для gauges берется из необернутого AnalyticsUseCase, чтобы scrape не попадал
+// в гистограмму задержек
+func (c *Container) initMetrics() {
+	analytics := c.AnalyticsUseCase
+
+	queues := map[string]metrics.QueueFunc{
+		"outbox": c.Outbox.PendingCount,
+		// Планировщик создается после use cases
+		"reminders": func(ctx context.Context) (int, error) {
+			if c.Reminders == nil || !c.Config.Reminders.Enabled {
+				return 0, nil
+			}
+			return c.Reminders.Pending(ctx)
+		},
+	}
+	if c.WebhookDispatcher != nil {
+		queues["webhooks"] = func(ctx context.Context) (int, error) {
+			return c.WebhookRepository.CountDeliveries(ctx, models.WebhookDeliveryPending)
+		}
+	}
+
+	c.Metrics = metrics.NewMetrics(metrics.Options{
+		DB:        c.DB,
+		Queues:    queues,
+		TaskStats: analytics.GetTasksStats,
+	})
+
+	c.TaskUseCase = usecases.NewTaskUseCaseWithMetrics(c.TaskUseCase, c.Metrics)
+	c.AnalyticsUseCase = usecases.NewAnalyticsUseCaseWithMetrics(analytics, c.Metrics)
+	c.ExportUseCase = usecases.NewExportUseCaseWithMetrics(c.ExportUseCase, c.Metrics)
+	c.WebhookUseCase = usecases.NewWebhookUseCaseWithMetrics(c.WebhookUseCase, c.Metrics)
+	c.SettingsUseCase = usecases.NewSettingsUseCaseWithMetrics(c.SettingsUseCase, c.Metrics)
+	c.TimeUseCase = usecases.NewTimeTrackingUseCaseWithMetrics(c.TimeUseCase, c.Metrics)
+	c.CommentUseCase = usecases.NewCommentUseCaseWithMetrics(c.CommentUseCase, c.Metrics)
+	c.AttachmentUseCase = usecases.NewAttachmentUseCaseWithMetrics(c.AttachmentUseCase, c.Metrics)
+}
+
+// initServer запускает HTTP сервер для внешних клиентов
+func (c *Container) initServer() error {
+	if !c.Config.Server.Enabled || c.RealtimeHub == nil {
+		return nil
+	}
+
+	c.Logger.Info("Initializing HTTP server")
+
+	c.HTTPServer = server.NewServer(server.Config{
+		Address:        c.Config.Server.Address,
+		APIKeys:        c.Config.Server.APIKeys,
+		AllowedOrigins: c.Config.Server.AllowedOrigins,
+		RateLimit:      rateLimitConfig(c.Config.Server.RateLimit),
+	}, c.Logger)
+
+	c.HTTPServer.HandleWebSocket(realtime.NewWebSocketHandler(c.RealtimeHub, c.TaskRepository, realtime.WebSocketConfig{
+		PingInterval: c.Config.Server.WebSocketPingInterval,
+		WriteTimeout: c.Config.Server.WebSocketWriteTimeout,
+		SendBuffer:   c.Config.Server.WebSocketSendBuffer,
+	}, c.Logger))
+
+	c.HTTPServer.HandlePublic(server.HealthzPath, c.Diagnostics.LivenessHandler())
+	c.HTTPServer.HandlePublic(server.ReadyzPath, c.Diagnostics.ReadinessHandler())
+	c.HTTPServer.Handle(server.DiagnosticsPath, c.Diagnostics.ReportHandler())
+
+	if c.Metrics != nil {
+		if c.Config.Metrics.Public {
+			c.HTTPServer.HandlePublic(c.Config.Metrics.Path, c.Metrics.Handler())
+		} else {
+			c.HTTPServer.Handle(c.Config.Metrics.Path, c.Metrics.Handler())
+		}
+	}
+
+	if err := c.HTTPServer.Start(); err != nil {
+		return err
+	}
+
+	c.Logger.Info("HTTP server initialized successfully")
+	return nil
+}
+
+// initReminders запускает планировщик напоминаний. Напоминания расходуются
+// только при наличии подписчика (окна приложения)
+func (c *Container) initReminders() {
+	c.Reminders = reminders.NewScheduler(c.ReminderRepository, remindersConfig(c.Config.Reminders), c.Logger)
+	c.Reminders.Start()
+}
+
+// initAttachmentCollector запускает сборку мусора хранилища вложений
+func (c *Container) initAttachmentCollector() {
+	c.AttachmentCollector = attachments.NewCollector(c.AttachmentRepository, c.AttachmentStore, attachments.CollectorConfig{
+		Interval:    c.Config.Attachments.GCInterval,
+		GracePeriod: c.Config.Attachments.GCGracePeriod,
+	}, c.Logger)
+	c.AttachmentCollector.Start()
+}
+
+// initDiagnostics создает сборщик отчета о состоянии с фоновыми задачами контейнера
+func (c *Container) initDiagnostics() {
+	jobs := []diagnostics.Job{
+		{
+			Name:    "outbox",
+			Enabled: true,
+			Running: c.Outbox.Running,
+			Queue:   c.Outbox.PendingCount,
+		},
+		{
+			Name:    "reminders",
+			Enabled: c.Config.Reminders.Enabled,
+			Running: c.Reminders.Running,
+			Queue:   c.Reminders.Pending,
+		},
+	}
+
+	webhookJob := diagnostics.Job{Name: "webhooks", Enabled: c.WebhookDispatcher != nil}
+	if c.WebhookDispatcher != nil {
+		webhookJob.Running = c.WebhookDispatcher.Running
+		webhookJob.Queue = func(ctx context.Context) (int, error) {
+			return c.WebhookRepository.CountDeliveries(ctx, models.WebhookDeliveryPending)
+		}
+	}
+	jobs = append(jobs, webhookJob)
+
+	listenerJob := diagnostics.Job{
+		Name:    "task_listener",
+		Enabled: c.Config.Realtime.Enabled && c.Config.Realtime.ListenNotify,
+		Running: func() bool { return c.TaskListener != nil && c.TaskListener.Running() },
+	}
+	jobs = append(jobs, listenerJob)
+
+	jobs = append(jobs, diagnostics.Job{
+		Name:    "attachments_gc",
+		Enabled: true,
+		Running: c.AttachmentCollector.Running,
+	})
+
+	c.Diagnostics = diagnostics.NewDiagnostics(diagnostics.Options{
+		DB:        c.DB,
+		Config:    c.Config,
+		Reminders: c.Reminders,
+		Queries:   c.QueryStats,
+		Jobs:      jobs,
+	})
+}
+
+// rateLimitConfig преобразует настройки конфигурации в настройки middleware
+func rateLimitConfig(cfg config.RateLimitConfig) middleware.RateLimiterConfig {
+	return middleware.RateLimiterConfig{
+		RequestsPerMinute: cfg.RequestsPerMinute,
+		BurstSize:         cfg.Burst,
+	}
+}
+
+// attachmentLimits преобразует настройки конфигурации в ограничения вложений
+func attachmentLimits(cfg config.AttachmentsConfig) attachments.Limits {
+	return attachments.Limits{
+		MaxSize:      int64(cfg.MaxSizeMB) * 1024 * 1024,
+		AllowedTypes: cfg.AllowedTypes,
+	}
+}
+
+// remindersConfig преобразует настройки конфигурации в настройки планировщика
+func remindersConfig(cfg config.RemindersConfig) reminders.Config {
+	return reminders.Config{
+		Enabled:   cfg.Enabled,
+		LeadTime:  cfg.LeadTime,
+		Interval:  cfg.Interval,
+		BatchSize: cfg.BatchSize,
+	}
+}
+
+// WatchConfig начинает отслеживать изменения конфигурации и применяет
+// к компонентам контейнера изменения, не требующие перезапуска
+func (c *Container) WatchConfig(loader *config.Loader) error {
+	c.ConfigWatcher = config.NewWatcher(loader, c.Config, c.Logger)
+
+	config.Subscribe(c.ConfigWatcher, func(cfg *config.Config) config.LoggerConfig { return cfg.Logger },
+		func(old, new config.LoggerConfig) {
+			if level, err := utils.ParseLogLevel(new.Level); err == nil {
+				c.Logger.SetLevel(level)
+			}
+			c.Logger.SetJSONFormat(new.JSONFormat)
+			c.Logger.SetDebugSampling(samplingConfig(new.DebugSampling))
+		})
+
+	config.Subscribe(c.ConfigWatcher, func(cfg *config.Config) config.DatabaseQueriesConfig { return cfg.Database.Queries },
+		func(old, new config.DatabaseQueriesConfig) {
+			if c.QueryStats != nil {
+				c.QueryStats.SetSlowThreshold(new.SlowThreshold)
+				c.QueryStats.SetLogQueries(new.LogAll)
+			}
+		})
+
+	config.Subscribe(c.ConfigWatcher, func(cfg *config.Config) config.RateLimitConfig { return cfg.Server.RateLimit },
+		func(old, new config.RateLimitConfig) {
+			if c.HTTPServer != nil {
+				c.HTTPServer.SetRateLimit(rateLimitConfig(new))
+			}
+		})
+
+	config.Subscribe(c.ConfigWatcher, func(cfg *config.Config) config.RemindersConfig { return cfg.Reminders },
+		func(old, new config.RemindersConfig) {
+			if c.Reminders != nil {
+				c.Reminders.UpdateConfig(remindersConfig(new))
+			}
+		})
+
+	return c.ConfigWatcher.Start()
+}
+
 // NewApp создает и инициализирует новое приложение с зависимостями
 func (c *Container) NewApp(ctx context.Context) *App {
 	return &App{
-		ctx:              ctx,
-		db:               c.DB,
-		config:           c.Config,
-		logger:           c.Logger,
-		TaskUseCase:      c.TaskUseCase,
-		AnalyticsUseCase: c.AnalyticsUseCase,
-		ExportUseCase:    c.ExportUseCase,
+		ctx:               ctx,
+		db:                c.DB,
+		config:            c.Config,
+		logger:            c.Logger,
+		TaskUseCase:       c.TaskUseCase,
+		AnalyticsUseCase:  c.AnalyticsUseCase,
+		ExportUseCase:     c.ExportUseCase,
+		WebhookUseCase:    c.WebhookUseCase,
+		SettingsUseCase:   c.SettingsUseCase,
+		TimeUseCase:       c.TimeUseCase,
+		CommentUseCase:    c.CommentUseCase,
+		AttachmentUseCase: c.AttachmentUseCase,
+		Realtime:          c.RealtimeHub,
+		Reminders:         c.Reminders,
+		Diagnostics:       c.Diagnostics,
+		Locales:           c.Locales,
 	}
 }
 
@@ -245,19 +787,70 @@ func (c *Container) NewApp(ctx context.Context) *App {
 func (c *Container) Close() error {
 	c.Logger.Info("Closing container resources")
 
+	if c.ConfigWatcher != nil {
+		c.ConfigWatcher.Stop()
+	}
+
+	if c.Reminders != nil {
+		c.Reminders.Stop()
+	}
+
+	if c.AttachmentCollector != nil {
+		c.AttachmentCollector.Stop()
+	}
+
+	// Внешние клиенты отключаются первыми, чтобы не получать неполные изменения
+	if c.HTTPServer != nil {
+		c.HTTPServer.Stop()
+	}
+
+	// Сначала останавливаем публикацию, затем дожидаемся асинхронных подписчиков
+	if c.Outbox != nil {
+		c.Outbox.Stop()
+	}
+
+	if c.EventBus != nil {
+		c.EventBus.Close()
+	}
+
+	if c.WebhookDispatcher != nil {
+		c.WebhookDispatcher.Stop()
+	}
+
+	if c.TaskListener != nil {
+		c.TaskListener.Stop()
+	}
+
+	if c.RealtimeHub != nil {
+		c.RealtimeHub.Close()
+	}
+
 	if c.DB != nil {
 		utils.CloseDB(c.DB)
 	}
 
+	if c.Tracer != nil {
+		tracing.SetTracer(nil)
+		if err := c.Tracer.Close(); err != nil {
+			c.Logger.Warn("Failed to close trace exporter", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+
 	c.Logger.Info("Container resources closed successfully")
+	if err := c.Logger.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
 	return nil
 }
 
-// HealthCheck проверяет состояние всех компонентов
+// HealthCheck проверяет состояние всех компонентов. Деградированный режим
+// БД ошибкой не считается: приложение продолжает работать
 func (c *Container) HealthCheck() error {
 	// Проверяем базу данных
-	if err := c.DB.Ping(); err != nil {
-		return fmt.Errorf("database health check failed: %w", err)
+	if health := c.DatabaseHealth(); health.Status == utils.DBStatusUnavailable {
+		return fmt.Errorf("database health check failed: %s", health.Error)
 	}
 
 	// Можно добавить другие проверки (Redis, внешние API и т.д.)
@@ -265,15 +858,38 @@ func (c *Container) HealthCheck() error {
 	return nil
 }
 
+// DatabaseHealth возвращает состояние подключения к БД
+func (c *Container) DatabaseHealth() utils.DBHealth {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return utils.CheckDBHealth(ctx, c.DB, utils.DefaultDBHealthThresholds)
+}
+
 // GetDependencies возвращает информацию о зависимостях для отладки
 func (c *Container) GetDependencies() map[string]interface{} {
 	return map[string]interface{}{
 		"database_connected": c.DB != nil,
 		"task_repository":    c.TaskRepository != nil,
+		"outbox_repository":  c.OutboxRepository != nil,
+		"event_bus":          c.EventBus != nil,
+		"outbox":             c.Outbox != nil,
+		"webhook_dispatcher": c.WebhookDispatcher != nil,
+		"webhook_usecase":    c.WebhookUseCase != nil,
+		"realtime_hub":       c.RealtimeHub != nil,
+		"task_listener":      c.TaskListener != nil,
+		"http_server":        c.HTTPServer != nil,
+		"reminders":          c.Reminders != nil,
+		"attachment_gc":      c.AttachmentCollector != nil,
+		"config_watcher":     c.ConfigWatcher != nil,
+		"diagnostics":        c.Diagnostics != nil,
 		"task_service":       c.TaskService != nil,
 		"task_usecase":       c.TaskUseCase != nil,
 		"analytics_usecase":  c.AnalyticsUseCase != nil,
 		"export_usecase":     c.ExportUseCase != nil,
+		"settings_usecase":   c.SettingsUseCase != nil,
+		"time_usecase":       c.TimeUseCase != nil,
+		"comment_usecase":    c.CommentUseCase != nil,
+		"attachment_usecase": c.AttachmentUseCase != nil,
 		"logger":             c.Logger != nil,
 		"config":             c.Config != nil,
 	}
@@ -0,0 +1,461 @@
+package diagnostics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"syscall"
+	"time"
+	"todo-app/app/config"
+	"todo-app/app/models"
+	"todo-app/app/reminders"
+	"todo-app/database"
+	"todo-app/internal/utils"
+
+	"github.com/lib/pq"
+)
+
+// Пороги свободного места на диске с логами
+const (
+	diskWarningBytes = 1 << 30   // 1 GiB
+	diskErrorBytes   = 100 << 20 // 100 MiB
+)
+
+// topQueries сколько самых затратных запросов попадает в отчет
+const topQueries = 20
+
+// checkTimeout ограничивает время сбора отчета
+const checkTimeout = 5 * time.Second
+
+// Job описывает фоновую задачу для отчета
+type Job struct {
+	Name    string
+	Enabled bool
+	Running func() bool
+	Queue   func(ctx context.Context) (int, error) // nil, если очереди нет
+}
+
+// Options содержит источники данных для отчета. Любое поле может быть nil
+type Options struct {
+	DB        *sql.DB
+	Config    *config.Config
+	Reminders *reminders.Scheduler
+	Queries   *utils.QueryRecorder
+	Jobs      []Job
+}
+
+// Diagnostics собирает отчет о состоянии приложения: БД, миграции,
+// фоновые задачи, место на диске и сборка. Каждая проблема сопровождается
+// рекомендацией по исправлению
+type Diagnostics struct {
+	options Options
+	now     func() time.Time
+}
+
+// NewDiagnostics создает сборщик отчета
+func NewDiagnostics(options Options) *Diagnostics {
+	return &Diagnostics{options: options, now: time.Now}
+}
+
+// Report собирает полный отчет
+func (d *Diagnostics) Report(ctx context.Context) *models.DiagnosticsReport {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	report := &models.DiagnosticsReport{
+		GeneratedAt: d.now(),
+		Build:       d.buildInfo(),
+		Jobs:        []models.JobDiagnostics{},
+		Checks:      []models.DiagnosticCheck{},
+	}
+
+	d.checkDatabase(ctx, report)
+	if report.Database.Status != utils.DBStatusUnavailable {
+		d.checkMigrations(report)
+		d.checkReminders(ctx, report)
+	}
+	d.checkJobs(ctx, report)
+	d.checkLogDisk(report)
+	d.checkQueries(report)
+
+	report.Status = models.DiagnosticOK
+	for _, check := range report.Checks {
+		if severity(check.Status) > severity(report.Status) {
+			report.Status = check.Status
+		}
+	}
+	report.Ready = report.Database.Status != utils.DBStatusUnavailable &&
+		report.Migrations.Error == "" && len(report.Migrations.Pending) == 0
+
+	return report
+}
+
+// Ready проверяет готовность обслуживать запросы: БД доступна и все миграции применены
+func (d *Diagnostics) Ready(ctx context.Context) (bool, []models.DiagnosticCheck) {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	report := &models.DiagnosticsReport{}
+	d.checkDatabase(ctx, report)
+	if report.Database.Status != utils.DBStatusUnavailable {
+		d.checkMigrations(report)
+	}
+
+	ready := report.Database.Status != utils.DBStatusUnavailable &&
+		report.Migrations.Error == "" && len(report.Migrations.Pending) == 0
+	return ready, report.Checks
+}
+
+// severity упорядочивает статусы проверок
+func severity(status models.DiagnosticStatus) int {
+	switch status {
+	case models.DiagnosticError:
+		return 2
+	case models.DiagnosticWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// addCheck добавляет результат проверки
+func addCheck(report *models.DiagnosticsReport, name string, status models.DiagnosticStatus, message, fix string) {
+	report.Checks = append(report.Checks, models.DiagnosticCheck{
+		Name:    name,
+		Status:  status,
+		Message: message,
+		Fix:     fix,
+	})
+}
+
+// buildInfo собирает сведения о сборке
+func (d *Diagnostics) buildInfo() models.BuildInfo {
+	info := models.BuildInfo{
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+	if cfg := d.options.Config; cfg != nil {
+		info.AppVersion = cfg.App.Version
+		info.Environment = cfg.App.Environment
+	}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	info.Module = build.Main.Path
+	if build.Main.Version != "" && build.Main.Version != "(devel)" {
+		info.Module += "@" + build.Main.Version
+	}
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.time":
+			info.RevisionTime = setting.Value
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+
+	return info
+}
+
+// checkDatabase проверяет подключение и пул соединений
+func (d *Diagnostics) checkDatabase(ctx context.Context, report *models.DiagnosticsReport) {
+	health := utils.CheckDBHealth(ctx, d.options.DB, utils.DefaultDBHealthThresholds)
+	stats := health.Stats
+
+	report.Database = models.DatabaseDiagnostics{
+		Status:            health.Status,
+		LatencyMs:         float64(health.Latency.Microseconds()) / 1000,
+		Error:             health.Error,
+		DegradedReasons:   health.Reasons,
+		MaxOpen:           stats.MaxOpenConnections,
+		Open:              stats.OpenConnections,
+		InUse:             stats.InUse,
+		Idle:              stats.Idle,
+		WaitCount:         stats.WaitCount,
+		WaitMs:            stats.WaitDuration.Milliseconds(),
+		MaxIdleClosed:     stats.MaxIdleClosed,
+		MaxLifetimeClosed: stats.MaxLifetimeClosed,
+	}
+
+	switch health.Status {
+	case utils.DBStatusUnavailable:
+		addCheck(report, "database", models.DiagnosticError,
+			"database is unavailable: "+health.Error, d.databaseFix(health.Error))
+	case utils.DBStatusDegraded:
+		addCheck(report, "database", models.DiagnosticWarning,
+			"database is degraded: "+strings.Join(health.Reasons, "; "),
+			"check database server load and network; if the pool is exhausted, increase DB_MAX_OPEN_CONNS")
+	default:
+		addCheck(report, "database", models.DiagnosticOK,
+			fmt.Sprintf("connected, ping %s", health.Latency.Round(time.Microsecond)), "")
+	}
+}
+
+// databaseFix подбирает рекомендацию по ошибке подключения
+func (d *Diagnostics) databaseFix(message string) string {
+	target := "the configured host"
+	if cfg := d.options.Config; cfg != nil {
+		target = fmt.Sprintf("%s:%d", cfg.Database.Host, cfg.Database.Port)
+	}
+
+	switch {
+	case d.options.DB == nil:
+		return "check the database settings (todo-app config print --effective)"
+	case strings.Contains(message, "password authentication failed") || strings.Contains(message, "no password"):
+		return "check the database password: DB_PASSWORD, DB_PASSWORD_FILE, DATABASE_URL, ~/.pgpass or 'todo-app secret set database.password'"
+	case strings.Contains(message, "does not exist") && strings.Contains(message, "database"):
+		return "create the database (make setup-db) or fix DB_NAME"
+	case strings.Contains(message, "role") && strings.Contains(message, "does not exist"):
+		return "create the database user or fix DB_USER"
+	case strings.Contains(message, "SSL"):
+		return "check DB_SSLMODE: the server and client SSL settings do not match"
+	default:
+		return fmt.Sprintf("make sure PostgreSQL is running and reachable at %s (DB_HOST, DB_PORT)", target)
+	}
+}
+
+// checkMigrations сравнивает примененные и встроенные миграции
+func (d *Diagnostics) checkMigrations(report *models.DiagnosticsReport) {
+	migrations := &report.Migrations
+
+	available, err := database.Migrations()
+	if err != nil {
+		migrations.Error = err.Error()
+		addCheck(report, "migrations", models.DiagnosticError, "failed to load embedded migrations: "+err.Error(),
+			"the build is broken; reinstall the application")
+		return
+	}
+	for _, migration := range available {
+		migrations.Available = append(migrations.Available, migration.Version)
+	}
+
+	applied, err := utils.NewMigrationHelper(d.options.DB).AppliedMigrations()
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "42P01" {
+			// Таблицы schema_migrations еще нет - не применена ни одна миграция
+			applied = nil
+		} else {
+			migrations.Error = err.Error()
+			addCheck(report, "migrations", models.DiagnosticError, err.Error(),
+				"check that the database user can read schema_migrations")
+			return
+		}
+	}
+	migrations.Applied = applied
+	if migrations.Applied == nil {
+		migrations.Applied = []string{}
+	}
+
+	appliedSet := make(map[string]bool, len(applied))
+	for _, version := range applied {
+		appliedSet[version] = true
+	}
+	availableSet := make(map[string]bool, len(available))
+	for _, version := range migrations.Available {
+		availableSet[version] = true
+		if !appliedSet[version] {
+			migrations.Pending = append(migrations.Pending, version)
+		}
+	}
+	for _, version := range applied {
+		if !availableSet[version] {
+			migrations.Unknown = append(migrations.Unknown, version)
+		}
+	}
+
+	switch {
+	case len(migrations.Pending) > 0:
+		addCheck(report, "migrations", models.DiagnosticError,
+			fmt.Sprintf("%d pending migrations: %s", len(migrations.Pending), strings.Join(migrations.Pending, ", ")),
+			"start the application with DB_RUN_MIGRATIONS=true (database.run_migrations) to apply them")
+	case len(migrations.Unknown) > 0:
+		addCheck(report, "migrations", models.DiagnosticWarning,
+			fmt.Sprintf("database has migrations unknown to this build: %s", strings.Join(migrations.Unknown, ", ")),
+			"the schema is newer than the application; upgrade the application")
+	default:
+		addCheck(report, "migrations", models.DiagnosticOK,
+			fmt.Sprintf("%d of %d applied", len(applied), len(available)), "")
+	}
+}
+
+// checkReminders считает задачи, ожидающие напоминания
+func (d *Diagnostics) checkReminders(ctx context.Context, report *models.DiagnosticsReport) {
+	scheduler := d.options.Reminders
+	if scheduler == nil {
+		return
+	}
+
+	pending, err := scheduler.Pending(ctx)
+	if err != nil {
+		addCheck(report, "reminders", models.DiagnosticWarning, "failed to count pending reminders: "+err.Error(),
+			"apply pending migrations (task_reminders table)")
+		return
+	}
+	report.PendingReminders = pending
+
+	enabled := scheduler.Config().Enabled
+	switch {
+	case !enabled:
+		addCheck(report, "reminders", models.DiagnosticOK, "reminders are disabled", "")
+	case pending > scheduler.Config().BatchSize:
+		addCheck(report, "reminders", models.DiagnosticWarning,
+			fmt.Sprintf("%d reminders are waiting for delivery", pending),
+			"reminders are delivered while the application window is open; keep the application running")
+	default:
+		addCheck(report, "reminders", models.DiagnosticOK, fmt.Sprintf("%d pending", pending), "")
+	}
+}
+
+// checkJobs проверяет фоновые задачи и их очереди
+func (d *Diagnostics) checkJobs(ctx context.Context, report *models.DiagnosticsReport) {
+	for _, job := range d.options.Jobs {
+		status := models.JobDiagnostics{Name: job.Name, Enabled: job.Enabled, Queue: -1}
+		if job.Running != nil {
+			status.Running = job.Running()
+		}
+		if job.Queue != nil && report.Database.Status != utils.DBStatusUnavailable {
+			if queue, err := job.Queue(ctx); err != nil {
+				status.Error = err.Error()
+			} else {
+				status.Queue = queue
+			}
+		}
+		report.Jobs = append(report.Jobs, status)
+
+		name := "job:" + job.Name
+		switch {
+		case !job.Enabled:
+			addCheck(report, name, models.DiagnosticOK, "disabled", "")
+		case !status.Running:
+			addCheck(report, name, models.DiagnosticError, "enabled but not running",
+				"restart the application; check the log for startup errors")
+		case status.Error != "":
+			addCheck(report, name, models.DiagnosticWarning, "failed to read queue: "+status.Error, "")
+		case status.Queue >= 0:
+			addCheck(report, name, models.DiagnosticOK, fmt.Sprintf("running, queue %d", status.Queue), "")
+		default:
+			addCheck(report, name, models.DiagnosticOK, "running", "")
+		}
+	}
+}
+
+// checkLogDisk проверяет свободное место на диске с файлом логов
+func (d *Diagnostics) checkLogDisk(report *models.DiagnosticsReport) {
+	cfg := d.options.Config
+	if cfg == nil || cfg.Logger.LogFile == "" {
+		return
+	}
+
+	dir := filepath.Dir(cfg.Logger.LogFile)
+	disk := &models.DiskDiagnostics{Path: dir}
+	report.LogDisk = disk
+
+	free, total, err := diskUsage(dir)
+	if err != nil {
+		disk.Error = err.Error()
+		fix := "check LOG_FILE"
+		if errors.Is(err, os.ErrNotExist) || errors.Is(err, syscall.ENOENT) {
+			fix = "create the log directory " + dir + " or change LOG_FILE"
+		}
+		addCheck(report, "log_disk", models.DiagnosticError, "cannot check log directory: "+err.Error(), fix)
+		return
+	}
+	disk.FreeBytes, disk.TotalBytes = free, total
+
+	message := fmt.Sprintf("%s free of %s in %s", formatBytes(free), formatBytes(total), dir)
+	fix := "free disk space or move LOG_FILE to another disk"
+	switch {
+	case free < diskErrorBytes:
+		addCheck(report, "log_disk", models.DiagnosticError, message, fix)
+	case free < diskWarningBytes:
+		addCheck(report, "log_disk", models.DiagnosticWarning, message, fix)
+	default:
+		addCheck(report, "log_disk", models.DiagnosticOK, message, "")
+	}
+}
+
+// formatBytes форматирует размер в двоичных единицах
+func formatBytes(size uint64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// checkQueries добавляет статистику SQL запросов; медленные запросы - предупреждение
+func (d *Diagnostics) checkQueries(report *models.DiagnosticsReport) {
+	if d.options.Queries == nil {
+		return
+	}
+
+	stats := d.options.Queries.Snapshot()
+	queries := &models.QueryDiagnostics{
+		Since:           d.options.Queries.Since(),
+		SlowThresholdMs: milliseconds(d.options.Queries.SlowThreshold()),
+		TotalQueries:    len(stats),
+		Top:             []models.QueryStats{},
+	}
+
+	var slowest *utils.QueryStat
+	for i := range stats {
+		stat := &stats[i]
+		if stat.SlowCalls > 0 && (slowest == nil || stat.Max > slowest.Max) {
+			slowest = stat
+		}
+		if i < topQueries {
+			queries.Top = append(queries.Top, models.QueryStats{
+				Query:     stat.Query,
+				Calls:     stat.Calls,
+				Errors:    stat.Errors,
+				SlowCalls: stat.SlowCalls,
+				Rows:      stat.Rows,
+				TotalMs:   milliseconds(stat.Total),
+				AvgMs:     milliseconds(stat.Avg()),
+				MaxMs:     milliseconds(stat.Max),
+				LastError: stat.LastError,
+			})
+		}
+	}
+	report.Queries = queries
+
+	if slowest == nil {
+		report.Checks = append(report.Checks, models.DiagnosticCheck{
+			Name:    "queries",
+			Status:  models.DiagnosticOK,
+			Message: fmt.Sprintf("%d distinct queries, none slower than %s", len(stats), d.options.Queries.SlowThreshold()),
+		})
+		return
+	}
+
+	report.Checks = append(report.Checks, models.DiagnosticCheck{
+		Name:   "queries",
+		Status: models.DiagnosticWarning,
+		Message: fmt.Sprintf("%d slow calls of %q (max %s)",
+			slowest.SlowCalls, slowest.Query, slowest.Max.Round(time.Millisecond)),
+		Fix: "Run EXPLAIN ANALYZE for the query and add a matching index, or raise database.queries.slow_threshold",
+	})
+}
+
+// milliseconds переводит длительность в миллисекунды
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,157 @@
+package diagnostics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"todo-app/app/config"
+	"todo-app/app/models"
+	"todo-app/database"
+	"todo-app/internal/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+// appliedRows возвращает строки schema_migrations со всеми версиями, кроме skip
+func appliedRows(t *testing.T, skip ...string) *sqlmock.Rows {
+	t.Helper()
+	migrations, err := database.Migrations()
+	if err != nil {
+		t.Fatalf("Failed to load migrations: %v", err)
+	}
+
+	skipped := make(map[string]bool)
+	for _, version := range skip {
+		skipped[version] = true
+	}
+
+	rows := sqlmock.NewRows([]string{"version"})
+	for _, migration := range migrations {
+		if !skipped[migration.Version] {
+			rows.AddRow(migration.Version)
+		}
+	}
+	return rows
+}
+
+func findCheck(report *models.DiagnosticsReport, name string) *models.DiagnosticCheck {
+	for i := range report.Checks {
+		if report.Checks[i].Name == name {
+			return &report.Checks[i]
+		}
+	}
+	return nil
+}
+
+func TestReport_PendingMigrationsAndJobs(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectPing()
+	mock.ExpectQuery(`SELECT version FROM schema_migrations`).WillReturnRows(appliedRows(t, "008"))
+
+	diag := NewDiagnostics(Options{
+		DB:     db,
+		Config: config.NewDefaultConfig(),
+		Jobs: []Job{
+			{Name: "outbox", Enabled: true, Running: func() bool { return true },
+				Queue: func(ctx context.Context) (int, error) { return 7, nil }},
+			{Name: "webhooks", Enabled: true, Running: func() bool { return false }},
+			{Name: "listener", Enabled: false},
+		},
+	})
+
+	report := diag.Report(context.Background())
+
+	if report.Database.Status != "ok" {
+		t.Errorf("Expected database ok, got %+v", report.Database)
+	}
+	if len(report.Migrations.Pending) != 1 || report.Migrations.Pending[0] != "008" || report.Ready {
+		t.Errorf("Expected pending migration 008 and not ready, got %+v ready=%t", report.Migrations, report.Ready)
+	}
+	if check := findCheck(report, "migrations"); check == nil || check.Status != models.DiagnosticError || check.Fix == "" {
+		t.Errorf("Expected migrations error with fix, got %+v", check)
+	}
+
+	if len(report.Jobs) != 3 || report.Jobs[0].Queue != 7 || report.Jobs[1].Running {
+		t.Errorf("Unexpected jobs: %+v", report.Jobs)
+	}
+	if check := findCheck(report, "job:webhooks"); check == nil || check.Status != models.DiagnosticError {
+		t.Errorf("Expected stopped job to be an error, got %+v", check)
+	}
+	if report.Status != models.DiagnosticError {
+		t.Errorf("Expected overall error, got %s", report.Status)
+	}
+	if report.Build.GoVersion == "" || report.Build.AppVersion != "1.0.0" {
+		t.Errorf("Unexpected build info: %+v", report.Build)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestReadinessHandler(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	diag := NewDiagnostics(Options{DB: db, Config: config.NewDefaultConfig()})
+
+	mock.ExpectPing()
+	mock.ExpectQuery(`SELECT version FROM schema_migrations`).WillReturnRows(appliedRows(t))
+
+	rec := httptest.NewRecorder()
+	diag.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected 200 when schema is current, got %d: %s", rec.Code, rec.Body)
+	}
+
+	mock.ExpectPing().WillReturnError(errors.New("password authentication failed for user \"todo_user\""))
+
+	rec = httptest.NewRecorder()
+	diag.ReadinessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 when database is unavailable, got %d", rec.Code)
+	}
+
+	// Рекомендация зависит от ошибки подключения
+	mock.ExpectPing().WillReturnError(errors.New("password authentication failed for user \"todo_user\""))
+	report := diag.Report(context.Background())
+	if check := findCheck(report, "database"); check == nil || check.Fix == "" || report.Ready {
+		t.Errorf("Expected database error with password fix, got %+v", check)
+	}
+
+	rec = httptest.NewRecorder()
+	diag.LivenessHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected liveness 200, got %d", rec.Code)
+	}
+}
+
+func TestReport_SlowQueries(t *testing.T) {
+	recorder := utils.NewQueryRecorder(100 * time.Millisecond)
+	recorder.Record(context.Background(), "SELECT * FROM tasks WHERE id = 1", 10*time.Millisecond, 1, nil)
+	recorder.Record(context.Background(), "SELECT * FROM tasks WHERE title ILIKE '%a%'", 300*time.Millisecond, 20, nil)
+
+	diag := NewDiagnostics(Options{Queries: recorder})
+	report := diag.Report(context.Background())
+
+	if report.Queries == nil || report.Queries.TotalQueries != 2 || report.Queries.Top[0].SlowCalls != 1 {
+		t.Fatalf("Unexpected query diagnostics: %+v", report.Queries)
+	}
+	if report.Queries.Top[0].Query != "SELECT * FROM tasks WHERE title ILIKE ?" {
+		t.Errorf("Expected normalized slowest query first, got %q", report.Queries.Top[0].Query)
+	}
+	if check := findCheck(report, "queries"); check == nil || check.Status != models.DiagnosticWarning || check.Fix == "" {
+		t.Errorf("Expected slow query warning, got %+v", check)
+	}
+}
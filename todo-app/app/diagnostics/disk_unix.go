@@ -0,0 +1,17 @@
+//go:build !windows
+
+package diagnostics
+
+import "syscall"
+
+// diskUsage возвращает свободное (доступное пользователю) и общее место
+// на файловой системе с каталогом path
+func diskUsage(path string) (free, total uint64, err error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0, err
+	}
+
+	blockSize := uint64(stat.Bsize)
+	return uint64(stat.Bavail) * blockSize, uint64(stat.Blocks) * blockSize, nil
+}
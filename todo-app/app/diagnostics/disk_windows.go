@@ -0,0 +1,21 @@
+//go:build windows
+
+package diagnostics
+
+import "golang.org/x/sys/windows"
+
+// diskUsage возвращает свободное (доступное пользователю) и общее место
+// на томе с каталогом path
+func diskUsage(path string) (free, total uint64, err error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var available, totalBytes, totalFree uint64
+	if err := windows.GetDiskFreeSpaceEx(pathPtr, &available, &totalBytes, &totalFree); err != nil {
+		return 0, 0, err
+	}
+
+	return available, totalBytes, nil
+}
@@ -0,0 +1,49 @@
+package diagnostics
+
+import (
+	"encoding/json"
+	"net/http"
+	"todo-app/app/models"
+)
+
+// LivenessHandler отвечает 200, пока процесс обслуживает запросы (/healthz)
+func (d *Diagnostics) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"status": models.DiagnosticOK,
+			"time":   d.now().UTC(),
+		})
+	})
+}
+
+// ReadinessHandler отвечает 200, если БД доступна и схема актуальна, иначе 503 (/readyz)
+func (d *Diagnostics) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ready, checks := d.Ready(r.Context())
+
+		status := http.StatusOK
+		if !ready {
+			status = http.StatusServiceUnavailable
+		}
+
+		writeJSON(w, status, map[string]interface{}{
+			"ready":  ready,
+			"checks": checks,
+		})
+	})
+}
+
+// ReportHandler возвращает полный отчет (models.DiagnosticsReport)
+func (d *Diagnostics) ReportHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, d.Report(r.Context()))
+	})
+}
+
+// writeJSON отправляет ответ в формате JSON
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
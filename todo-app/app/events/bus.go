@@ -0,0 +1,241 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"todo-app/internal/utils"
+)
+
+// Handler обрабатывает событие. Ошибка обработчика логируется и не влияет
+// на остальных подписчиков; ошибки синхронных обработчиков возвращает Publish
+type Handler func(ctx context.Context, event Event) error
+
+// ErrBusClosed возвращается Publish после закрытия шины
+var ErrBusClosed = errors.New("event bus is closed")
+
+// AllEvents используется для подписки на события любого типа
+const AllEvents EventType = "*"
+
+// subscription представляет одного подписчика шины
+type subscription struct {
+	id      uint64
+	handler Handler
+	async   bool
+}
+
+// asyncJob представляет отложенный вызов асинхронного подписчика
+type asyncJob struct {
+	ctx     context.Context
+	event   Event
+	handler Handler
+}
+
+// BusConfig содержит настройки шины событий
+type BusConfig struct {
+	AsyncWorkers int // количество воркеров для асинхронных подписчиков
+	QueueSize    int // размер очереди асинхронных вызовов
+}
+
+// Bus представляет in-process шину доменных событий
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[EventType][]subscription
+	nextID      uint64
+	logger      *utils.Logger
+
+	queue    chan asyncJob
+	wg       sync.WaitGroup
+	inflight sync.WaitGroup // публикации, которые могут писать в queue
+	closed   bool
+}
+
+// NewBus создает шину событий и запускает воркеры асинхронных подписчиков
+func NewBus(config BusConfig, logger *utils.Logger) *Bus {
+	if config.AsyncWorkers <= 0 {
+		config.AsyncWorkers = 4
+	}
+	if config.QueueSize <= 0 {
+		config.QueueSize = 256
+	}
+	if logger == nil {
+		logger = utils.DefaultLogger()
+	}
+
+	bus := &Bus{
+		subscribers: make(map[EventType][]subscription),
+		logger:      logger,
+		queue:       make(chan asyncJob, config.QueueSize),
+	}
+
+	for i := 0; i < config.AsyncWorkers; i++ {
+		bus.wg.Add(1)
+		go bus.worker()
+	}
+
+	return bus
+}
+
+// Subscribe регистрирует синхронного подписчика. Он вызывается в горутине публикации
+// и должен быть быстрым. Возвращает функцию отписки
+func (b *Bus) Subscribe(eventType EventType, handler Handler) func() {
+	return b.subscribe(eventType, handler, false)
+}
+
+// SubscribeAsync регистрирует асинхронного подписчика, вызываемого воркерами шины
+func (b *Bus) SubscribeAsync(eventType EventType, handler Handler) func() {
+	return b.subscribe(eventType, handler, true)
+}
+
+// On регистрирует типизированного синхронного подписчика на события типа T
+func On[T Event](b *Bus, handler func(ctx context.Context, event T) error) func() {
+	var zero T
+	return b.Subscribe(zero.Type(), typed(handler))
+}
+
+// OnAsync регистрирует типизированного асинхронного подписчика на события типа T
+func OnAsync[T Event](b *Bus, handler func(ctx context.Context, event T) error) func() {
+	var zero T
+	return b.SubscribeAsync(zero.Type(), typed(handler))
+}
+
+// typed адаптирует типизированный обработчик к Handler
+func typed[T Event](handler func(ctx context.Context, event T) error) Handler {
+	return func(ctx context.Context, event Event) error {
+		typedEvent, ok := event.(T)
+		if !ok {
+			return fmt.Errorf("unexpected event %T for type %s", event, event.Type())
+		}
+		return handler(ctx, typedEvent)
+	}
+}
+
+// subscribe добавляет подписчика
+func (b *Bus) subscribe(eventType EventType, handler Handler, async bool) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := b.nextID
+	b.subscribers[eventType] = append(b.subscribers[eventType], subscription{
+		id:      id,
+		handler: handler,
+		async:   async,
+	})
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		subs := b.subscribers[eventType]
+		for i, sub := range subs {
+			if sub.id == id {
+				b.subscribers[eventType] = append(subs[:i:i], subs[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Publish доставляет событие подписчикам: синхронным сразу, асинхронным через очередь.
+// Паника или ошибка одного подписчика не влияет на остальных. Возвращает
+// объединенную ошибку синхронных подписчиков, ошибку контекста, если очередь
+// асинхронных вызовов не освободилась, или ErrBusClosed
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		b.logger.Warn("Event published after bus was closed", map[string]interface{}{
+			"event_type": event.Type(),
+		})
+		return ErrBusClosed
+	}
+
+	subs := make([]subscription, 0, len(b.subscribers[event.Type()])+len(b.subscribers[AllEvents]))
+	subs = append(subs, b.subscribers[event.Type()]...)
+	subs = append(subs, b.subscribers[AllEvents]...)
+
+	// Close закрывает очередь только после завершения начатых публикаций
+	b.inflight.Add(1)
+	b.mu.RUnlock()
+	defer b.inflight.Done()
+
+	var errs []error
+
+	// Асинхронные вызовы не должны отменяться вместе с контекстом публикации
+	asyncCtx := context.WithoutCancel(ctx)
+	for _, sub := range subs {
+		if !sub.async {
+			continue
+		}
+		select {
+		case b.queue <- asyncJob{ctx: asyncCtx, event: event, handler: sub.handler}:
+		case <-ctx.Done():
+			errs = append(errs, fmt.Errorf("async handler queue is full: %w", ctx.Err()))
+		}
+	}
+
+	for _, sub := range subs {
+		if !sub.async {
+			if err := b.invoke(ctx, event, sub.handler); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close останавливает прием событий и дожидается обработки очереди
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	// Воркеры продолжают разбирать очередь, поэтому ожидающие публикации завершатся
+	b.inflight.Wait()
+	close(b.queue)
+
+	b.wg.Wait()
+}
+
+// worker обрабатывает очередь асинхронных вызовов
+func (b *Bus) worker() {
+	defer b.wg.Done()
+
+	for job := range b.queue {
+		_ = b.invoke(job.ctx, job.event, job.handler) // ошибка уже в логе, повтора нет
+	}
+}
+
+// invoke вызывает обработчик с изоляцией паники. Паника возвращается как ошибка
+func (b *Bus) invoke(ctx context.Context, event Event, handler Handler) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("Event handler panicked", map[string]interface{}{
+				"event_type": event.Type(),
+				"event_id":   event.Meta().ID,
+				"panic":      fmt.Sprintf("%v", r),
+				"stack":      string(debug.Stack()),
+			})
+			err = fmt.Errorf("%s handler panicked: %v", event.Type(), r)
+		}
+	}()
+
+	if err := handler(ctx, event); err != nil {
+		b.logger.Error("Event handler failed", map[string]interface{}{
+			"event_type": event.Type(),
+			"event_id":   event.Meta().ID,
+			"error":      err.Error(),
+		})
+		return fmt.Errorf("%s handler failed: %w", event.Type(), err)
+	}
+
+	return nil
+}
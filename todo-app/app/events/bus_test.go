@@ -0,0 +1,178 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+	"todo-app/app/models"
+)
+
+func TestBus_PublishSyncAndTyped(t *testing.T) {
+	bus := NewBus(BusConfig{}, nil)
+	defer bus.Close()
+
+	var received []EventType
+	bus.Subscribe(AllEvents, func(ctx context.Context, event Event) error {
+		received = append(received, event.Type())
+		return nil
+	})
+
+	var completedID int
+	On(bus, func(ctx context.Context, event TaskCompleted) error {
+		completedID = event.TaskID
+		return nil
+	})
+
+	task := &models.Task{ID: 7, Title: "Test Task"}
+	bus.Publish(context.Background(), NewTaskCreated(task))
+	bus.Publish(context.Background(), NewTaskCompleted(task))
+
+	if len(received) != 2 || received[0] != TypeTaskCreated || received[1] != TypeTaskCompleted {
+		t.Errorf("Expected created and completed events, got %v", received)
+	}
+
+	if completedID != 7 {
+		t.Errorf("Expected typed handler to receive task 7, got %d", completedID)
+	}
+}
+
+func TestBus_HandlerIsolation(t *testing.T) {
+	bus := NewBus(BusConfig{}, nil)
+	defer bus.Close()
+
+	bus.Subscribe(TypeTaskCreated, func(ctx context.Context, event Event) error {
+		panic("handler panic")
+	})
+	bus.Subscribe(TypeTaskCreated, func(ctx context.Context, event Event) error {
+		return errors.New("handler error")
+	})
+
+	called := false
+	bus.Subscribe(TypeTaskCreated, func(ctx context.Context, event Event) error {
+		called = true
+		return nil
+	})
+
+	err := bus.Publish(context.Background(), NewTaskCreated(&models.Task{ID: 1}))
+
+	if !called {
+		t.Error("Expected subscriber to be called despite failing neighbours")
+	}
+	if err == nil {
+		t.Error("Expected Publish to return errors of failed subscribers")
+	}
+}
+
+func TestBus_AsyncSubscriberAndUnsubscribe(t *testing.T) {
+	bus := NewBus(BusConfig{AsyncWorkers: 2, QueueSize: 8}, nil)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	OnAsync(bus, func(ctx context.Context, event TaskDeleted) error {
+		defer wg.Done()
+		if ctx.Err() != nil {
+			t.Error("Expected async handler context to survive publisher cancellation")
+		}
+		return nil
+	})
+
+	calls := 0
+	unsubscribe := bus.Subscribe(TypeTaskDeleted, func(ctx context.Context, event Event) error {
+		calls++
+		return nil
+	})
+	unsubscribe()
+
+	bus.Publish(ctx, NewTaskDeleted(&models.Task{ID: 3}))
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Async handler was not called")
+	}
+
+	bus.Close()
+
+	if calls != 0 {
+		t.Errorf("Expected unsubscribed handler not to be called, got %d calls", calls)
+	}
+}
+
+func TestBus_PublishDoesNotBlockOnFullQueue(t *testing.T) {
+	bus := NewBus(BusConfig{AsyncWorkers: 1, QueueSize: 1}, nil)
+
+	release := make(chan struct{})
+	bus.SubscribeAsync(TypeTaskCreated, func(ctx context.Context, event Event) error {
+		<-release
+		return nil
+	})
+
+	// Первое событие занимает воркер, второе - очередь
+	event := NewTaskCreated(&models.Task{ID: 1})
+	bus.Publish(context.Background(), event)
+	bus.Publish(context.Background(), event)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := bus.Publish(ctx, event); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected publish to give up on full queue, got %v", err)
+	}
+
+	// Подписка берет блокировку на запись, пока очередь полна
+	done := make(chan struct{})
+	go func() {
+		bus.Subscribe(TypeTaskDeleted, func(ctx context.Context, event Event) error { return nil })
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Subscribe blocked by a full async queue")
+	}
+
+	close(release)
+	bus.Close()
+
+	if err := bus.Publish(context.Background(), event); !errors.Is(err, ErrBusClosed) {
+		t.Errorf("Expected ErrBusClosed, got %v", err)
+	}
+}
+
+func TestDecode_RestoresEventWithID(t *testing.T) {
+	event := NewTaskUpdated(&models.Task{ID: 5, Title: "New"}, &models.Task{ID: 5, Title: "Old"})
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("Failed to marshal event: %v", err)
+	}
+
+	decoded, err := Decode(TypeTaskUpdated, 42, payload)
+	if err != nil {
+		t.Fatalf("Failed to decode event: %v", err)
+	}
+
+	updated, ok := decoded.(TaskUpdated)
+	if !ok {
+		t.Fatalf("Expected TaskUpdated, got %T", decoded)
+	}
+
+	if updated.Meta().ID != 42 || updated.TaskID != 5 || updated.Previous == nil || updated.Previous.Title != "Old" {
+		t.Errorf("Unexpected decoded event: %+v", updated)
+	}
+
+	if _, err := Decode("unknown.event", 1, payload); err == nil {
+		t.Error("Expected error for unknown event type")
+	}
+}
@@ -0,0 +1,372 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+	"todo-app/app/models"
+)
+
+// EventType представляет тип доменного события
+type EventType string
+
+const (
+	TypeTaskCreated   EventType = "task.created"
+	TypeTaskUpdated   EventType = "task.updated"
+	TypeTaskCompleted EventType = "task.completed"
+	TypeTaskReopened  EventType = "task.reopened"
+	TypeTaskStatus    EventType = "task.status_changed"
+	TypeTaskMoved     EventType = "task.moved"
+	TypeTaskDeleted   EventType = "task.deleted"
+	TypeTaskArchived  EventType = "task.archived"
+
+	TypeCommentAdded   EventType = "comment.added"
+	TypeCommentEdited  EventType = "comment.edited"
+	TypeCommentDeleted EventType = "comment.deleted"
+
+	TypeAttachmentAdded   EventType = "attachment.added"
+	TypeAttachmentDeleted EventType = "attachment.deleted"
+
+	TypeSettingsUpdated EventType = "settings.updated"
+)
+
+// Event представляет доменное событие, публикуемое через шину
+type Event interface {
+	// Type возвращает тип события
+	Type() EventType
+
+	// Meta возвращает общие метаданные события
+	Meta() Metadata
+}
+
+// TaskEvent представляет событие жизненного цикла задачи
+type TaskEvent interface {
+	Event
+
+	// Subject возвращает ID задачи и ее снимок на момент события
+	Subject() (int, *models.Task)
+}
+
+// Metadata содержит общие поля всех событий
+type Metadata struct {
+	ID         int64     `json:"-"` // ID записи в outbox, заполняется при доставке
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Meta возвращает метаданные события
+func (m Metadata) Meta() Metadata {
+	return m
+}
+
+// TaskPayload содержит общие данные событий задачи
+type TaskPayload struct {
+	Metadata
+	TaskID int          `json:"task_id"`
+	Task   *models.Task `json:"task,omitempty"`
+}
+
+// Subject возвращает ID задачи и ее снимок
+func (p TaskPayload) Subject() (int, *models.Task) {
+	return p.TaskID, p.Task
+}
+
+// TaskCreated публикуется после создания задачи
+type TaskCreated struct {
+	TaskPayload
+}
+
+// Type возвращает тип события
+func (TaskCreated) Type() EventType { return TypeTaskCreated }
+
+// TaskUpdated публикуется после изменения полей задачи
+type TaskUpdated struct {
+	TaskPayload
+	Previous *models.Task `json:"previous,omitempty"`
+}
+
+// Type возвращает тип события
+func (TaskUpdated) Type() EventType { return TypeTaskUpdated }
+
+// TaskCompleted публикуется после выполнения задачи
+type TaskCompleted struct {
+	TaskPayload
+}
+
+// Type возвращает тип события
+func (TaskCompleted) Type() EventType { return TypeTaskCompleted }
+
+// TaskReopened публикуется после возврата выполненной задачи в работу
+type TaskReopened struct {
+	TaskPayload
+}
+
+// Type возвращает тип события
+func (TaskReopened) Type() EventType { return TypeTaskReopened }
+
+// TaskStatusChanged публикуется после перехода задачи между статусами workflow,
+// не являющегося выполнением или возвратом в работу
+type TaskStatusChanged struct {
+	TaskPayload
+	From models.TaskStatus `json:"from"`
+	To   models.TaskStatus `json:"to"`
+}
+
+// Type возвращает тип события
+func (TaskStatusChanged) Type() EventType { return TypeTaskStatus }
+
+// TaskMoved публикуется после переноса задачи в ручном порядке;
+// пустая колонка - общий список
+type TaskMoved struct {
+	TaskPayload
+	Column models.TaskStatus `json:"column,omitempty"`
+}
+
+// Type возвращает тип события
+func (TaskMoved) Type() EventType { return TypeTaskMoved }
+
+// TaskDeleted публикуется после удаления задачи, Task содержит последний снимок
+type TaskDeleted struct {
+	TaskPayload
+}
+
+// Type возвращает тип события
+func (TaskDeleted) Type() EventType { return TypeTaskDeleted }
+
+// TaskArchived публикуется после переноса задачи в архив
+type TaskArchived struct {
+	TaskPayload
+}
+
+// Type возвращает тип события
+func (TaskArchived) Type() EventType { return TypeTaskArchived }
+
+// CommentPayload содержит общие данные событий комментария. События комментариев
+// относятся к задаче, поэтому попадают в ее историю и фильтры подписок
+type CommentPayload struct {
+	TaskPayload
+	Comment *models.Comment `json:"comment"`
+}
+
+// CommentAdded публикуется после добавления комментария к задаче
+type CommentAdded struct {
+	CommentPayload
+}
+
+// Type возвращает тип события
+func (CommentAdded) Type() EventType { return TypeCommentAdded }
+
+// CommentEdited публикуется после изменения тела комментария
+type CommentEdited struct {
+	CommentPayload
+	PreviousBody string `json:"previous_body"`
+}
+
+// Type возвращает тип события
+func (CommentEdited) Type() EventType { return TypeCommentEdited }
+
+// CommentDeleted публикуется после удаления комментария, Comment содержит последнюю версию
+type CommentDeleted struct {
+	CommentPayload
+}
+
+// Type возвращает тип события
+func (CommentDeleted) Type() EventType { return TypeCommentDeleted }
+
+// AttachmentPayload содержит общие данные событий вложения. Содержимое файла
+// в событие не входит, только метаданные
+type AttachmentPayload struct {
+	TaskPayload
+	Attachment *models.Attachment `json:"attachment"`
+}
+
+// AttachmentAdded публикуется после прикрепления файла к задаче
+type AttachmentAdded struct {
+	AttachmentPayload
+}
+
+// Type возвращает тип события
+func (AttachmentAdded) Type() EventType { return TypeAttachmentAdded }
+
+// AttachmentDeleted публикуется после удаления вложения
+type AttachmentDeleted struct {
+	AttachmentPayload
+}
+
+// Type возвращает тип события
+func (AttachmentDeleted) Type() EventType { return TypeAttachmentDeleted }
+
+// SettingsUpdated публикуется после изменения настроек приложения
+type SettingsUpdated struct {
+	Metadata
+	Settings *models.AppSettings `json:"settings"`
+	Previous *models.AppSettings `json:"previous"`
+	Changed  []models.SettingKey `json:"changed"`
+}
+
+// Type возвращает тип события
+func (SettingsUpdated) Type() EventType { return TypeSettingsUpdated }
+
+// newTaskPayload создает данные события для задачи
+func newTaskPayload(task *models.Task) TaskPayload {
+	return TaskPayload{
+		Metadata: Metadata{OccurredAt: time.Now()},
+		TaskID:   task.ID,
+		Task:     task,
+	}
+}
+
+// NewTaskCreated создает событие создания задачи
+func NewTaskCreated(task *models.Task) TaskCreated {
+	return TaskCreated{TaskPayload: newTaskPayload(task)}
+}
+
+// NewTaskUpdated создает событие изменения задачи
+func NewTaskUpdated(task, previous *models.Task) TaskUpdated {
+	return TaskUpdated{TaskPayload: newTaskPayload(task), Previous: previous}
+}
+
+// NewTaskCompleted создает событие выполнения задачи
+func NewTaskCompleted(task *models.Task) TaskCompleted {
+	return TaskCompleted{TaskPayload: newTaskPayload(task)}
+}
+
+// NewTaskReopened создает событие возврата задачи в работу
+func NewTaskReopened(task *models.Task) TaskReopened {
+	return TaskReopened{TaskPayload: newTaskPayload(task)}
+}
+
+// NewTaskStatusChanged создает событие смены статуса задачи
+func NewTaskStatusChanged(task *models.Task, from models.TaskStatus) TaskStatusChanged {
+	return TaskStatusChanged{TaskPayload: newTaskPayload(task), From: from, To: task.Status}
+}
+
+// NewTaskMoved создает событие переноса задачи
+func NewTaskMoved(task *models.Task, column models.TaskStatus) TaskMoved {
+	return TaskMoved{TaskPayload: newTaskPayload(task), Column: column}
+}
+
+// NewTaskDeleted создает событие удаления задачи
+func NewTaskDeleted(task *models.Task) TaskDeleted {
+	return TaskDeleted{TaskPayload: newTaskPayload(task)}
+}
+
+// NewTaskArchived создает событие архивации задачи
+func NewTaskArchived(task *models.Task) TaskArchived {
+	return TaskArchived{TaskPayload: newTaskPayload(task)}
+}
+
+// newCommentPayload создает данные события комментария задачи
+func newCommentPayload(task *models.Task, comment *models.Comment) CommentPayload {
+	return CommentPayload{TaskPayload: newTaskPayload(task), Comment: comment}
+}
+
+// NewCommentAdded создает событие добавления комментария
+func NewCommentAdded(task *models.Task, comment *models.Comment) CommentAdded {
+	return CommentAdded{CommentPayload: newCommentPayload(task, comment)}
+}
+
+// NewCommentEdited создает событие изменения комментария
+func NewCommentEdited(task *models.Task, comment *models.Comment, previousBody string) CommentEdited {
+	return CommentEdited{CommentPayload: newCommentPayload(task, comment), PreviousBody: previousBody}
+}
+
+// NewCommentDeleted создает событие удаления комментария
+func NewCommentDeleted(task *models.Task, comment *models.Comment) CommentDeleted {
+	return CommentDeleted{CommentPayload: newCommentPayload(task, comment)}
+}
+
+// newAttachmentPayload создает данные события вложения задачи
+func newAttachmentPayload(task *models.Task, attachment *models.Attachment) AttachmentPayload {
+	return AttachmentPayload{TaskPayload: newTaskPayload(task), Attachment: attachment}
+}
+
+// NewAttachmentAdded создает событие прикрепления файла
+func NewAttachmentAdded(task *models.Task, attachment *models.Attachment) AttachmentAdded {
+	return AttachmentAdded{AttachmentPayload: newAttachmentPayload(task, attachment)}
+}
+
+// NewAttachmentDeleted создает событие удаления вложения
+func NewAttachmentDeleted(task *models.Task, attachment *models.Attachment) AttachmentDeleted {
+	return AttachmentDeleted{AttachmentPayload: newAttachmentPayload(task, attachment)}
+}
+
+// NewSettingsUpdated создает событие изменения настроек
+func NewSettingsUpdated(settings, previous *models.AppSettings) SettingsUpdated {
+	return SettingsUpdated{
+		Metadata: Metadata{OccurredAt: time.Now()},
+		Settings: settings,
+		Previous: previous,
+		Changed:  previous.ChangedKeys(settings),
+	}
+}
+
+// decoder восстанавливает событие из JSON и присваивает ему ID записи outbox
+type decoder func(id int64, payload []byte) (Event, error)
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = make(map[EventType]decoder)
+)
+
+// withID присваивает событию ID записи outbox
+type withID interface {
+	setID(id int64)
+}
+
+func (m *Metadata) setID(id int64) {
+	m.ID = id
+}
+
+// Register регистрирует тип события для восстановления из outbox
+func Register[T Event]() {
+	var zero T
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+
+	decoders[zero.Type()] = func(id int64, payload []byte) (Event, error) {
+		var event T
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return nil, err
+		}
+		if setter, ok := any(&event).(withID); ok {
+			setter.setID(id)
+		}
+		return event, nil
+	}
+}
+
+// Decode восстанавливает событие из типа и JSON представления
+func Decode(eventType EventType, id int64, payload []byte) (Event, error) {
+	decodersMu.RLock()
+	decode, ok := decoders[eventType]
+	decodersMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("unknown event type: %s", eventType)
+	}
+
+	event, err := decode(id, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", eventType, err)
+	}
+
+	return event, nil
+}
+
+func init() {
+	Register[TaskCreated]()
+	Register[TaskUpdated]()
+	Register[TaskCompleted]()
+	Register[TaskReopened]()
+	Register[TaskStatusChanged]()
+	Register[TaskMoved]()
+	Register[TaskDeleted]()
+	Register[TaskArchived]()
+	Register[CommentAdded]()
+	Register[CommentEdited]()
+	Register[CommentDeleted]()
+	Register[AttachmentAdded]()
+	Register[AttachmentDeleted]()
+	Register[SettingsUpdated]()
+}
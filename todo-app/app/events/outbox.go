@@ -0,0 +1,234 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+	"todo-app/app/models"
+	"todo-app/app/repository"
+	"todo-app/internal/utils"
+)
+
+// Recorder определяет интерфейс записи событий вместе с изменением данных
+type Recorder interface {
+	// Record сохраняет событие в текущей транзакции (если она есть в контексте)
+	Record(ctx context.Context, event Event) error
+
+	// Notify сообщает, что транзакция с событиями зафиксирована и их можно публиковать
+	Notify()
+}
+
+// OutboxConfig содержит настройки публикации событий из outbox
+type OutboxConfig struct {
+	PollInterval time.Duration     // интервал опроса outbox (страховка на случай потери Notify)
+	BatchSize    int               // максимальное количество событий за один проход
+	Retry        utils.RetryPolicy // повтор события, синхронный подписчик которого вернул ошибку
+}
+
+// Outbox реализует Recorder поверх транзакционного outbox и публикует
+// зафиксированные события в шину. Неопубликованные после сбоя события
+// доставляются при следующем запуске, поэтому подписчики должны быть идемпотентны
+type Outbox struct {
+	repo   repository.OutboxRepository
+	bus    *Bus
+	logger *utils.Logger
+	config OutboxConfig
+	now    func() time.Time
+
+	notify  chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	startMu sync.Mutex
+	running bool
+}
+
+// NewOutbox создает новый outbox
+func NewOutbox(repo repository.OutboxRepository, bus *Bus, config OutboxConfig, logger *utils.Logger) *Outbox {
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.Retry.MaxAttempts <= 0 {
+		config.Retry.MaxAttempts = 10
+	}
+	if config.Retry.InitialBackoff <= 0 {
+		config.Retry.InitialBackoff = time.Second
+	}
+	if config.Retry.MaxBackoff <= 0 {
+		config.Retry.MaxBackoff = 5 * time.Minute
+	}
+	if logger == nil {
+		logger = utils.DefaultLogger()
+	}
+
+	return &Outbox{
+		repo:   repo,
+		bus:    bus,
+		logger: logger,
+		config: config,
+		now:    time.Now,
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// Record сохраняет событие в outbox
+func (o *Outbox) Record(ctx context.Context, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", event.Type(), err)
+	}
+
+	record := &models.OutboxEvent{
+		EventType: string(event.Type()),
+		Payload:   payload,
+		CreatedAt: event.Meta().OccurredAt,
+	}
+
+	if err := o.repo.Save(ctx, record); err != nil {
+		return fmt.Errorf("failed to record %s event: %w", event.Type(), err)
+	}
+
+	return nil
+}
+
+// Notify запускает внеочередную публикацию событий
+func (o *Outbox) Notify() {
+	select {
+	case o.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Start запускает фоновую публикацию событий. События, оставшиеся
+// неопубликованными после предыдущего запуска, публикуются сразу
+func (o *Outbox) Start() {
+	o.startMu.Lock()
+	defer o.startMu.Unlock()
+
+	if o.running {
+		return
+	}
+	o.running = true
+	o.stop = make(chan struct{})
+	o.done = make(chan struct{})
+
+	go o.run()
+	o.Notify()
+}
+
+// Stop останавливает публикацию, дожидаясь завершения текущего прохода
+func (o *Outbox) Stop() {
+	o.startMu.Lock()
+	defer o.startMu.Unlock()
+
+	if !o.running {
+		return
+	}
+	o.running = false
+
+	close(o.stop)
+	<-o.done
+}
+
+// Running сообщает, запущена ли фоновая публикация
+func (o *Outbox) Running() bool {
+	o.startMu.Lock()
+	defer o.startMu.Unlock()
+	return o.running
+}
+
+// run основной цикл публикации
+func (o *Outbox) run() {
+	defer close(o.done)
+
+	ticker := time.NewTicker(o.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-o.stop:
+			return
+		case <-o.notify:
+		case <-ticker.C:
+		}
+
+		if err := o.DispatchPending(context.Background()); err != nil {
+			o.logger.Error("Failed to dispatch outbox events", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+}
+
+// DispatchPending публикует все неопубликованные события. Событие, синхронный
+// подписчик которого вернул ошибку, остается в outbox и публикуется повторно
+// с экспоненциальной задержкой; после Retry.MaxAttempts попыток сохраняется ошибка
+func (o *Outbox) DispatchPending(ctx context.Context) error {
+	for {
+		pending, err := o.repo.GetPending(ctx, o.config.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, record := range pending {
+			event, err := Decode(EventType(record.EventType), record.ID, record.Payload)
+			if err != nil {
+				// Событие невозможно восстановить - сохраняем ошибку, запись исключается из очереди
+				o.logger.Error("Failed to decode outbox event", map[string]interface{}{
+					"event_id":   record.ID,
+					"event_type": record.EventType,
+					"error":      err.Error(),
+				})
+				if markErr := o.repo.MarkFailed(ctx, record.ID, err.Error()); markErr != nil {
+					return markErr
+				}
+				continue
+			}
+
+			if err := o.bus.Publish(ctx, event); err != nil {
+				if markErr := o.retryLater(ctx, record, err); markErr != nil {
+					return markErr
+				}
+				continue
+			}
+
+			if err := o.repo.MarkPublished(ctx, record.ID); err != nil {
+				return err
+			}
+		}
+
+		if len(pending) < o.config.BatchSize {
+			return nil
+		}
+	}
+}
+
+// retryLater откладывает повтор события или, если попытки исчерпаны, сохраняет ошибку
+func (o *Outbox) retryLater(ctx context.Context, record *models.OutboxEvent, publishErr error) error {
+	attempt := record.Attempts + 1
+	fields := map[string]interface{}{
+		"event_id":   record.ID,
+		"event_type": record.EventType,
+		"attempt":    attempt,
+		"error":      publishErr.Error(),
+	}
+
+	if attempt >= o.config.Retry.MaxAttempts {
+		o.logger.Error("Outbox event failed, giving up", fields)
+		return o.repo.MarkFailed(ctx, record.ID, publishErr.Error())
+	}
+
+	delay := o.config.Retry.Backoff(attempt)
+	fields["retry_in"] = delay.String()
+	o.logger.Warn("Outbox event failed, will retry", fields)
+	return o.repo.MarkRetry(ctx, record.ID, o.now().Add(delay))
+}
+
+// PendingCount возвращает количество неопубликованных событий
+func (o *Outbox) PendingCount(ctx context.Context) (int, error) {
+	return o.repo.CountPending(ctx)
+}
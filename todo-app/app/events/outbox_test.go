@@ -0,0 +1,147 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+	"todo-app/app/models"
+	"todo-app/internal/utils"
+)
+
+// memoryOutboxRepository хранит события outbox в памяти
+type memoryOutboxRepository struct {
+	now       func() time.Time
+	events    []*models.OutboxEvent
+	next      map[int64]time.Time
+	published map[int64]bool
+}
+
+func newMemoryOutbox(now func() time.Time, events ...*models.OutboxEvent) *memoryOutboxRepository {
+	return &memoryOutboxRepository{now: now, events: events, next: make(map[int64]time.Time), published: make(map[int64]bool)}
+}
+
+func (r *memoryOutboxRepository) Save(ctx context.Context, event *models.OutboxEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *memoryOutboxRepository) GetPending(ctx context.Context, limit int) ([]*models.OutboxEvent, error) {
+	var pending []*models.OutboxEvent
+	for _, event := range r.events {
+		next, delayed := r.next[event.ID]
+		if r.published[event.ID] || event.LastError != "" || (delayed && next.After(r.now())) {
+			continue
+		}
+		if len(pending) < limit {
+			pending = append(pending, event)
+		}
+	}
+	return pending, nil
+}
+
+func (r *memoryOutboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	r.published[id] = true
+	return nil
+}
+
+func (r *memoryOutboxRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time) error {
+	r.find(id).Attempts++
+	r.next[id] = nextAttemptAt
+	return nil
+}
+
+func (r *memoryOutboxRepository) MarkFailed(ctx context.Context, id int64, lastError string) error {
+	event := r.find(id)
+	event.Attempts++
+	event.LastError = lastError
+	return nil
+}
+
+func (r *memoryOutboxRepository) CountPending(ctx context.Context) (int, error) {
+	pending, err := r.GetPending(ctx, len(r.events)+1)
+	return len(pending), err
+}
+
+func (r *memoryOutboxRepository) GetByTask(ctx context.Context, taskID int, limit int) ([]*models.OutboxEvent, error) {
+	return nil, nil
+}
+
+func (r *memoryOutboxRepository) find(id int64) *models.OutboxEvent {
+	for _, event := range r.events {
+		if event.ID == id {
+			return event
+		}
+	}
+	return nil
+}
+
+func TestOutbox_RetriesEventWhenSubscriberFails(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	payload, _ := json.Marshal(NewTaskCreated(&models.Task{ID: 4, Title: "Task"}))
+	repo := newMemoryOutbox(clock, &models.OutboxEvent{ID: 1, EventType: string(TypeTaskCreated), Payload: payload})
+
+	bus := NewBus(BusConfig{}, nil)
+	defer bus.Close()
+
+	fail := true
+	calls := 0
+	bus.Subscribe(TypeTaskCreated, func(ctx context.Context, event Event) error {
+		calls++
+		if fail {
+			return errors.New("database is unavailable")
+		}
+		return nil
+	})
+
+	outbox := NewOutbox(repo, bus, OutboxConfig{
+		Retry: utils.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Minute, MaxBackoff: time.Minute},
+	}, nil)
+	outbox.now = clock
+
+	if err := outbox.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if repo.published[1] || repo.events[0].Attempts != 1 {
+		t.Fatalf("Expected failed event to stay pending, got %+v", repo.events[0])
+	}
+
+	// До истечения задержки событие не повторяется
+	if err := outbox.DispatchPending(context.Background()); err != nil || calls != 1 {
+		t.Fatalf("Expected retry to be delayed, got %d calls, %v", calls, err)
+	}
+
+	now = now.Add(time.Minute)
+	fail = false
+	if err := outbox.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !repo.published[1] || calls != 2 {
+		t.Errorf("Expected event published on retry, got published=%v after %d calls", repo.published[1], calls)
+	}
+}
+
+func TestOutbox_GivesUpAfterMaxAttempts(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	payload, _ := json.Marshal(NewTaskDeleted(&models.Task{ID: 5}))
+	repo := newMemoryOutbox(func() time.Time { return now },
+		&models.OutboxEvent{ID: 2, EventType: string(TypeTaskDeleted), Payload: payload, Attempts: 2})
+
+	bus := NewBus(BusConfig{}, nil)
+	defer bus.Close()
+	bus.Subscribe(AllEvents, func(ctx context.Context, event Event) error {
+		panic("broken subscriber")
+	})
+
+	outbox := NewOutbox(repo, bus, OutboxConfig{Retry: utils.RetryPolicy{MaxAttempts: 3}}, nil)
+	if err := outbox.DispatchPending(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if event := repo.events[0]; event.LastError == "" || event.Attempts != 3 || repo.published[2] {
+		t.Errorf("Expected event to be dead-lettered, got %+v", event)
+	}
+}
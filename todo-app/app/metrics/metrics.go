@@ -0,0 +1,193 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+	"todo-app/app/models"
+	"todo-app/internal/utils"
+)
+
+// Результаты операций в метке outcome
+const (
+	OutcomeSuccess  = "success"
+	OutcomeError    = "error"
+	OutcomeCanceled = "canceled" // контекст отменен или истек
+)
+
+// collectTimeout ограничивает сбор gauges из БД во время scrape
+const collectTimeout = 5 * time.Second
+
+// QueueFunc возвращает длину очереди фоновой задачи
+type QueueFunc func(ctx context.Context) (int, error)
+
+// Options содержит источники метрик, собираемых при scrape
+type Options struct {
+	DB        *sql.DB                                              // статистика пула соединений
+	Queues    map[string]QueueFunc                                 // очереди: outbox, reminders, webhooks
+	TaskStats func(ctx context.Context) (*models.TaskStats, error) // AnalyticsUseCase.GetTasksStats
+}
+
+// Metrics метрики приложения: операции над задачами, задержки use cases,
+// пул БД, очереди фоновых задач и количество задач
+type Metrics struct {
+	registry *Registry
+	options  Options
+
+	TaskOperations  *CounterVec
+	UseCaseDuration *HistogramVec
+
+	dbConnections *GaugeVec
+	dbWaitCount   *GaugeVec
+	dbWaitSeconds *GaugeVec
+	queueDepth    *GaugeVec
+	tasks         *GaugeVec
+	scrapeErrors  *CounterVec
+}
+
+// NewMetrics создает метрики и регистрирует сбор gauges из источников
+func NewMetrics(options Options) *Metrics {
+	r := NewRegistry()
+
+	m := &Metrics{
+		registry: r,
+		options:  options,
+
+		TaskOperations: r.NewCounterVec("todo_task_operations_total",
+			"Task operations by type and outcome.", "operation", "outcome"),
+		UseCaseDuration: r.NewHistogramVec("todo_usecase_duration_seconds",
+			"Use case call latency in seconds.", nil, "usecase", "method"),
+
+		dbConnections: r.NewGaugeVec("todo_db_connections",
+			"Database pool connections by state.", "state"),
+		dbWaitCount: r.NewGaugeVec("todo_db_wait_total",
+			"Total number of connections waited for."),
+		dbWaitSeconds: r.NewGaugeVec("todo_db_wait_seconds_total",
+			"Total time blocked waiting for a new connection."),
+		queueDepth: r.NewGaugeVec("todo_queue_depth",
+			"Pending items in background queues.", "queue"),
+		tasks: r.NewGaugeVec("todo_tasks",
+			"Number of tasks by state.", "state"),
+		scrapeErrors: r.NewCounterVec("todo_metrics_collect_errors_total",
+			"Errors while collecting metrics from a source.", "source"),
+	}
+
+	r.OnCollect(m.collectDB)
+	r.OnCollect(m.collectQueues)
+	r.OnCollect(m.collectTasks)
+
+	return m
+}
+
+// Registry возвращает реестр метрик
+func (m *Metrics) Registry() *Registry {
+	return m.registry
+}
+
+// ObserveTaskOperation учитывает операцию над задачей
+func (m *Metrics) ObserveTaskOperation(operation string, err error) {
+	m.TaskOperations.Inc(operation, Outcome(err))
+}
+
+// ObserveUseCase учитывает длительность вызова use case
+func (m *Metrics) ObserveUseCase(usecase, method string, start time.Time) {
+	m.UseCaseDuration.Observe(time.Since(start).Seconds(), usecase, method)
+}
+
+// Handler возвращает обработчик, отдающий метрики в текстовом формате Prometheus
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", ContentType)
+		w.Header().Set("Cache-Control", "no-store")
+		if err := m.registry.WriteText(r.Context(), w); err != nil {
+			utils.WarnContext(r.Context(), "Failed to write metrics", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	})
+}
+
+// Outcome классифицирует результат операции для метки outcome
+func Outcome(err error) string {
+	switch {
+	case err == nil:
+		return OutcomeSuccess
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return OutcomeCanceled
+	default:
+		return OutcomeError
+	}
+}
+
+// collectDB обновляет gauges пула соединений
+func (m *Metrics) collectDB(ctx context.Context) {
+	if m.options.DB == nil {
+		return
+	}
+
+	stats := m.options.DB.Stats()
+	m.dbConnections.Set(float64(stats.MaxOpenConnections), "max_open")
+	m.dbConnections.Set(float64(stats.OpenConnections), "open")
+	m.dbConnections.Set(float64(stats.InUse), "in_use")
+	m.dbConnections.Set(float64(stats.Idle), "idle")
+	m.dbWaitCount.Set(float64(stats.WaitCount))
+	m.dbWaitSeconds.Set(stats.WaitDuration.Seconds())
+}
+
+// collectQueues обновляет длины очередей; недоступная очередь удаляется из вывода
+func (m *Metrics) collectQueues(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, collectTimeout)
+	defer cancel()
+
+	for name, queue := range m.options.Queues {
+		if queue == nil {
+			continue
+		}
+
+		depth, err := queue(ctx)
+		if err != nil {
+			m.queueDepth.Delete(name)
+			m.scrapeErrors.Inc("queue_" + name)
+			utils.DebugContext(ctx, "Failed to collect queue depth", map[string]interface{}{
+				"queue": name,
+				"error": err.Error(),
+			})
+			continue
+		}
+		m.queueDepth.Set(float64(depth), name)
+	}
+}
+
+// collectTasks обновляет gauges количества задач из статистики
+func (m *Metrics) collectTasks(ctx context.Context) {
+	if m.options.TaskStats == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, collectTimeout)
+	defer cancel()
+
+	stats, err := m.options.TaskStats(ctx)
+	if err != nil {
+		m.scrapeErrors.Inc("task_stats")
+		utils.DebugContext(ctx, "Failed to collect task stats", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	m.tasks.Set(float64(stats.TotalTasks), "total")
+	m.tasks.Set(float64(stats.ActiveTasks), "active")
+	m.tasks.Set(float64(stats.CompletedTasks), "completed")
+	m.tasks.Set(float64(stats.OverdueTasks), "overdue")
+	m.tasks.Set(float64(stats.TodayTasks), "today")
+	m.tasks.Set(float64(stats.WeekTasks), "week")
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"todo-app/app/models"
+)
+
+func TestRegistry_WriteText(t *testing.T) {
+	r := NewRegistry()
+
+	counter := r.NewCounterVec("test_ops_total", "Operations.\nSecond line", "op")
+	counter.Inc(`say "hi"`)
+	counter.Add(2, "b")
+
+	hist := r.NewHistogramVec("test_duration_seconds", "Duration.", []float64{0.1, 1}, "method")
+	hist.Observe(0.05, "Get")
+	hist.Observe(0.5, "Get")
+	hist.Observe(3, "Get")
+
+	gauge := r.NewGaugeVec("test_depth", "Depth.")
+	r.OnCollect(func(ctx context.Context) { gauge.Set(7) })
+
+	var b strings.Builder
+	if err := r.WriteText(context.Background(), &b); err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+
+	want := `# HELP test_depth Depth.
+# TYPE test_depth gauge
+test_depth 7
+# HELP test_duration_seconds Duration.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{method="Get",le="0.1"} 1
+test_duration_seconds_bucket{method="Get",le="1"} 2
+test_duration_seconds_bucket{method="Get",le="+Inf"} 3
+test_duration_seconds_sum{method="Get"} 3.55
+test_duration_seconds_count{method="Get"} 3
+# HELP test_ops_total Operations.\nSecond line
+# TYPE test_ops_total counter
+test_ops_total{op="b"} 2
+test_ops_total{op="say \"hi\""} 1
+`
+	if got := b.String(); got != want {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m := NewMetrics(Options{
+		Queues: map[string]QueueFunc{
+			"outbox": func(context.Context) (int, error) { return 3, nil },
+			"broken": func(context.Context) (int, error) { return 0, errors.New("db down") },
+		},
+		TaskStats: func(context.Context) (*models.TaskStats, error) {
+			return &models.TaskStats{TotalTasks: 10, ActiveTasks: 4, OverdueTasks: 1}, nil
+		},
+	})
+
+	m.ObserveTaskOperation("create", nil)
+	m.ObserveTaskOperation("create", errors.New("invalid"))
+	m.ObserveTaskOperation("delete", context.Canceled)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != ContentType {
+		t.Fatalf("Unexpected response: %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		`todo_task_operations_total{operation="create",outcome="success"} 1`,
+		`todo_task_operations_total{operation="create",outcome="error"} 1`,
+		`todo_task_operations_total{operation="delete",outcome="canceled"} 1`,
+		`todo_queue_depth{queue="outbox"} 3`,
+		`todo_metrics_collect_errors_total{source="queue_broken"} 1`,
+		`todo_tasks{state="total"} 10`,
+		`todo_tasks{state="overdue"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Expected %q in:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, `queue="broken"`) {
+		t.Errorf("Failed queue must not be exported:\n%s", body)
+	}
+
+	rec = httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/metrics", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for POST, got %d", rec.Code)
+	}
+}
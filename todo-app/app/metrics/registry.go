@@ -0,0 +1,391 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType тип ответа в текстовом формате Prometheus
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefBuckets границы гистограммы по умолчанию (секунды), как в клиенте Prometheus
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// metric семейство метрик с одинаковым именем
+type metric interface {
+	name() string
+	write(w io.Writer)
+}
+
+// Registry хранит метрики и выводит их в текстовом формате Prometheus.
+// Функции OnCollect вызываются перед каждым выводом и обновляют gauges
+// из внешних источников (пул БД, очереди, статистика задач)
+type Registry struct {
+	mu         sync.Mutex
+	metrics    []metric
+	names      map[string]bool
+	collectors []func(ctx context.Context)
+}
+
+// NewRegistry создает пустой реестр
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+// register добавляет метрику; повторное имя - ошибка программиста
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.names[m.name()] {
+		panic(fmt.Sprintf("metrics: duplicate metric %q", m.name()))
+	}
+	r.names[m.name()] = true
+	r.metrics = append(r.metrics, m)
+}
+
+// OnCollect регистрирует функцию, обновляющую метрики перед выводом
+func (r *Registry) OnCollect(fn func(ctx context.Context)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, fn)
+}
+
+// WriteText обновляет метрики через OnCollect и выводит их в текстовом формате
+func (r *Registry) WriteText(ctx context.Context, w io.Writer) error {
+	r.mu.Lock()
+	collectors := append([]func(context.Context){}, r.collectors...)
+	metrics := append([]metric{}, r.metrics...)
+	r.mu.Unlock()
+
+	for _, collect := range collectors {
+		collect(ctx)
+	}
+
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].name() < metrics[j].name() })
+
+	var b strings.Builder
+	for _, m := range metrics {
+		m.write(&b)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// family общие поля семейства метрик
+type family struct {
+	metricName string
+	help       string
+	labels     []string
+}
+
+func (f *family) name() string {
+	return f.metricName
+}
+
+// header выводит строки HELP и TYPE
+func (f *family) header(w io.Writer, kind string) {
+	fmt.Fprintf(w, "# HELP %s %s\n", f.metricName, escapeHelp(f.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.metricName, kind)
+}
+
+// key объединяет значения меток в ключ серии
+func (f *family) key(values []string) string {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", f.metricName, len(f.labels), len(values)))
+	}
+	return strings.Join(values, "\xff")
+}
+
+// labelPairs форматирует метки серии: {a="1",b="2"}; extra добавляется последней (le для гистограмм)
+func (f *family) labelPairs(values []string, extra ...string) string {
+	if len(f.labels) == 0 && len(extra) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(f.labels)+1)
+	for i, label := range f.labels {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, label, escapeLabel(values[i])))
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extra[i], escapeLabel(extra[i+1])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// series значение одной серии
+type series struct {
+	values []string
+	value  float64
+}
+
+// CounterVec счетчик с метками
+type CounterVec struct {
+	family
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+// NewCounterVec создает и регистрирует счетчик
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		family: family{metricName: name, help: help, labels: labels},
+		series: make(map[string]*series),
+	}
+	r.register(c)
+	return c
+}
+
+// Inc увеличивает счетчик серии на 1
+func (c *CounterVec) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Add увеличивает счетчик серии на delta (delta >= 0)
+func (c *CounterVec) Add(delta float64, values ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("metrics: counter %s cannot decrease", c.metricName))
+	}
+
+	key := c.key(values)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	s, ok := c.series[key]
+	if !ok {
+		s = &series{values: append([]string{}, values...)}
+		c.series[key] = s
+	}
+	s.value += delta
+}
+
+// Value возвращает значение серии (для тестов и диагностики)
+func (c *CounterVec) Value(values ...string) float64 {
+	key := c.key(values)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if s, ok := c.series[key]; ok {
+		return s.value
+	}
+	return 0
+}
+
+func (c *CounterVec) write(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.header(w, "counter")
+	for _, s := range sortedSeries(c.series) {
+		fmt.Fprintf(w, "%s%s %s\n", c.metricName, c.labelPairs(s.values), formatFloat(s.value))
+	}
+}
+
+// GaugeVec измеряемое значение с метками
+type GaugeVec struct {
+	family
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+// NewGaugeVec создает и регистрирует gauge
+func (r *Registry) NewGaugeVec(name, help string, labels ...string) *GaugeVec {
+	g := &GaugeVec{
+		family: family{metricName: name, help: help, labels: labels},
+		series: make(map[string]*series),
+	}
+	r.register(g)
+	return g
+}
+
+// Set устанавливает значение серии
+func (g *GaugeVec) Set(value float64, values ...string) {
+	key := g.key(values)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	s, ok := g.series[key]
+	if !ok {
+		s = &series{values: append([]string{}, values...)}
+		g.series[key] = s
+	}
+	s.value = value
+}
+
+// Delete удаляет серию (например, источник недоступен)
+func (g *GaugeVec) Delete(values ...string) {
+	key := g.key(values)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.series, key)
+}
+
+// Value возвращает значение серии
+func (g *GaugeVec) Value(values ...string) float64 {
+	key := g.key(values)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if s, ok := g.series[key]; ok {
+		return s.value
+	}
+	return 0
+}
+
+func (g *GaugeVec) write(w io.Writer) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.header(w, "gauge")
+	for _, s := range sortedSeries(g.series) {
+		fmt.Fprintf(w, "%s%s %s\n", g.metricName, g.labelPairs(s.values), formatFloat(s.value))
+	}
+}
+
+// histogramSeries наблюдения одной серии гистограммы
+type histogramSeries struct {
+	values []string
+	counts []uint64 // по верхним границам buckets, не кумулятивно
+	sum    float64
+	count  uint64
+}
+
+// HistogramVec гистограмма с метками
+type HistogramVec struct {
+	family
+	buckets []float64
+	mu      sync.Mutex
+	series  map[string]*histogramSeries
+}
+
+// NewHistogramVec создает и регистрирует гистограмму; buckets nil - DefBuckets
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if buckets == nil {
+		buckets = DefBuckets
+	}
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+
+	h := &HistogramVec{
+		family:  family{metricName: name, help: help, labels: labels},
+		buckets: buckets,
+		series:  make(map[string]*histogramSeries),
+	}
+	r.register(h)
+	return h
+}
+
+// Observe добавляет наблюдение
+func (h *HistogramVec) Observe(value float64, values ...string) {
+	key := h.key(values)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.series[key]
+	if !ok {
+		s = &histogramSeries{
+			values: append([]string{}, values...),
+			counts: make([]uint64, len(h.buckets)),
+		}
+		h.series[key] = s
+	}
+
+	for i, upper := range h.buckets {
+		if value <= upper {
+			s.counts[i]++
+			break
+		}
+	}
+	s.sum += value
+	s.count++
+}
+
+// Count возвращает количество наблюдений серии
+func (h *HistogramVec) Count(values ...string) uint64 {
+	key := h.key(values)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if s, ok := h.series[key]; ok {
+		return s.count
+	}
+	return 0
+}
+
+func (h *HistogramVec) write(w io.Writer) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.header(w, "histogram")
+
+	keys := make([]string, 0, len(h.series))
+	for key := range h.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := h.series[key]
+
+		var cumulative uint64
+		for i, upper := range h.buckets {
+			cumulative += s.counts[i]
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.metricName, h.labelPairs(s.values, "le", formatFloat(upper)), cumulative)
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.metricName, h.labelPairs(s.values, "le", "+Inf"), s.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.metricName, h.labelPairs(s.values), formatFloat(s.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.metricName, h.labelPairs(s.values), s.count)
+	}
+}
+
+// sortedSeries возвращает серии в порядке ключей для стабильного вывода
+func sortedSeries(m map[string]*series) []*series {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]*series, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, m[key])
+	}
+	return result
+}
+
+// formatFloat форматирует число так, как его ожидает Prometheus
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// escapeLabel экранирует значение метки: \, " и перевод строки
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// escapeHelp экранирует текст HELP: \ и перевод строки
+func escapeHelp(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// AttachmentNameMaxLength - максимальная длина имени файла вложения
+const AttachmentNameMaxLength = 255
+
+// Attachment представляет метаданные файла, прикрепленного к задаче.
+// Содержимое хранится в хранилище вложений по SHA256 и может быть общим
+// у нескольких вложений
+type Attachment struct {
+	ID          int64     `json:"id" db:"id"`
+	TaskID      int       `json:"task_id" db:"task_id"`
+	FileName    string    `json:"file_name" db:"file_name"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Size        int64     `json:"size" db:"size"`
+	SHA256      string    `json:"sha256" db:"sha256"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// CreateAttachmentRequest представляет запрос на прикрепление файла.
+// Содержимое передается отдельно потоком
+type CreateAttachmentRequest struct {
+	TaskID   int    `json:"task_id" validate:"required,gt=0"`
+	FileName string `json:"file_name" validate:"max=255"`
+}
+
+// AttachmentContent содержит метаданные вложения вместе с содержимым
+type AttachmentContent struct {
+	*Attachment
+	Data []byte `json:"data"`
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// CommentMaxLength - максимальная длина тела комментария в символах
+const CommentMaxLength = 20000
+
+// Comment представляет комментарий (заметку о ходе работы) к задаче.
+// Body - Markdown; хранится как есть, отображение и очистка HTML - на стороне клиента
+type Comment struct {
+	ID        int64     `json:"id" db:"id"`
+	TaskID    int       `json:"task_id" db:"task_id"`
+	Body      string    `json:"body" db:"body"`
+	EditCount int       `json:"edit_count" db:"edit_count"` // количество сохраненных ревизий
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// IsEdited проверяет, редактировался ли комментарий
+func (c *Comment) IsEdited() bool {
+	return c.EditCount > 0
+}
+
+// CommentRevision представляет предыдущую версию тела комментария
+type CommentRevision struct {
+	ID         int64     `json:"id" db:"id"`
+	CommentID  int64     `json:"comment_id" db:"comment_id"`
+	Body       string    `json:"body" db:"body"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`   // с какого момента действовала версия
+	ReplacedAt time.Time `json:"replaced_at" db:"replaced_at"` // когда версия была заменена
+}
+
+// CreateCommentRequest представляет запрос на добавление комментария
+type CreateCommentRequest struct {
+	TaskID int    `json:"task_id" validate:"required,gt=0"`
+	Body   string `json:"body" validate:"max=20000"`
+}
+
+// UpdateCommentRequest представляет запрос на изменение тела комментария
+type UpdateCommentRequest struct {
+	ID   int64  `json:"id" validate:"required,gt=0"`
+	Body string `json:"body" validate:"max=20000"`
+}
+
+// NormalizeCommentBody приводит переводы строк к \n и убирает пустые строки
+// по краям тела комментария. Отступ первой строки сохраняется: в Markdown
+// он может означать блок кода
+func NormalizeCommentBody(body string) string {
+	body = strings.TrimRight(strings.ReplaceAll(body, "\r\n", "\n"), " \t\n")
+	for {
+		line, rest, found := strings.Cut(body, "\n")
+		if !found || strings.TrimSpace(line) != "" {
+			return body
+		}
+		body = rest
+	}
+}
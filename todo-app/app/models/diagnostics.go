@@ -0,0 +1,110 @@
+package models
+
+import "time"
+
+// DiagnosticStatus представляет результат проверки
+type DiagnosticStatus string
+
+const (
+	DiagnosticOK      DiagnosticStatus = "ok"
+	DiagnosticWarning DiagnosticStatus = "warning"
+	DiagnosticError   DiagnosticStatus = "error"
+)
+
+// DiagnosticCheck представляет одну проверку с рекомендацией по исправлению
+type DiagnosticCheck struct {
+	Name    string           `json:"name"`
+	Status  DiagnosticStatus `json:"status"`
+	Message string           `json:"message"`
+	Fix     string           `json:"fix,omitempty"`
+}
+
+// BuildInfo описывает сборку приложения
+type BuildInfo struct {
+	AppVersion   string `json:"app_version"`
+	Environment  string `json:"environment"`
+	GoVersion    string `json:"go_version"`
+	Module       string `json:"module"`
+	Revision     string `json:"revision,omitempty"`
+	RevisionTime string `json:"revision_time,omitempty"`
+	Modified     bool   `json:"modified"`
+	Platform     string `json:"platform"`
+}
+
+// DatabaseDiagnostics описывает подключение к БД и пул соединений (sql.DBStats)
+type DatabaseDiagnostics struct {
+	Status            string   `json:"status"` // ok, degraded, unavailable
+	LatencyMs         float64  `json:"latency_ms"`
+	Error             string   `json:"error,omitempty"`
+	DegradedReasons   []string `json:"degraded_reasons,omitempty"`
+	MaxOpen           int      `json:"max_open"`
+	Open              int      `json:"open"`
+	InUse             int      `json:"in_use"`
+	Idle              int      `json:"idle"`
+	WaitCount         int64    `json:"wait_count"`
+	WaitMs            int64    `json:"wait_ms"`
+	MaxIdleClosed     int64    `json:"max_idle_closed"`
+	MaxLifetimeClosed int64    `json:"max_lifetime_closed"`
+}
+
+// MigrationDiagnostics сравнивает примененные миграции со встроенными в сборку
+type MigrationDiagnostics struct {
+	Available []string `json:"available"`
+	Applied   []string `json:"applied"`
+	Pending   []string `json:"pending"` // есть в сборке, не применены
+	Unknown   []string `json:"unknown"` // применены, но неизвестны сборке (схема новее приложения)
+	Error     string   `json:"error,omitempty"`
+}
+
+// JobDiagnostics описывает фоновую задачу
+type JobDiagnostics struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Running bool   `json:"running"`
+	Queue   int    `json:"queue"` // -1 если размер очереди неизвестен
+	Error   string `json:"error,omitempty"`
+}
+
+// DiskDiagnostics описывает свободное место на диске с файлом логов
+type DiskDiagnostics struct {
+	Path       string `json:"path"`
+	FreeBytes  uint64 `json:"free_bytes"`
+	TotalBytes uint64 `json:"total_bytes"`
+	Error      string `json:"error,omitempty"`
+}
+
+// QueryStats агрегированная статистика нормализованного SQL запроса
+type QueryStats struct {
+	Query     string  `json:"query"`
+	Calls     int64   `json:"calls"`
+	Errors    int64   `json:"errors"`
+	SlowCalls int64   `json:"slow_calls"`
+	Rows      int64   `json:"rows"`
+	TotalMs   float64 `json:"total_ms"`
+	AvgMs     float64 `json:"avg_ms"`
+	MaxMs     float64 `json:"max_ms"`
+	LastError string  `json:"last_error,omitempty"`
+}
+
+// QueryDiagnostics описывает статистику SQL запросов с момента запуска
+type QueryDiagnostics struct {
+	Since           time.Time    `json:"since"`
+	SlowThresholdMs float64      `json:"slow_threshold_ms"`
+	TotalQueries    int          `json:"total_queries"` // различных запросов
+	Top             []QueryStats `json:"top"`           // по суммарному времени
+}
+
+// DiagnosticsReport представляет полный отчет о состоянии приложения
+type DiagnosticsReport struct {
+	GeneratedAt      time.Time            `json:"generated_at"`
+	Status           DiagnosticStatus     `json:"status"` // худший статус среди проверок
+	Ready            bool                 `json:"ready"`  // БД доступна и схема актуальна
+	Build            BuildInfo            `json:"build"`
+	Database         DatabaseDiagnostics  `json:"database"`
+	Migrations       MigrationDiagnostics `json:"migrations"`
+	PendingReminders int                  `json:"pending_reminders"`
+	Jobs             []JobDiagnostics     `json:"jobs"`
+	LogDisk          *DiskDiagnostics     `json:"log_disk,omitempty"` // nil, если логи пишутся только в stdout
+	Queries          *QueryDiagnostics    `json:"queries,omitempty"`  // nil, если учет запросов отключен
+	Checks           []DiagnosticCheck    `json:"checks"`
+}
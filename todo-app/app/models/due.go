@@ -0,0 +1,100 @@
+package models
+
+import (
+	"time"
+	"todo-app/internal/utils"
+)
+
+// Срок задачи хранится в timestamptz одним из двух способов:
+//   - срок со временем - момент времени, границы дня считаются в поясе пользователя
+//   - срок на весь день (due_all_day) - календарная дата, записанная полночью UTC;
+//     она не сдвигается при смене часового пояса и истекает с концом этой даты
+
+// DueDay возвращает календарную дату срока на весь день (полночь UTC)
+func DueDay(due time.Time) time.Time {
+	due = due.UTC()
+	return time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// NormalizeDueDate приводит срок к виду хранения: для задачи на весь день
+// берется календарная дата due в ее собственном поясе
+func NormalizeDueDate(due *time.Time, allDay bool) *time.Time {
+	if due == nil || !allDay {
+		return due
+	}
+	day := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	return &day
+}
+
+// DueWindow содержит границы фильтров today, week и overdue в часовом поясе
+// пользователя. Фильтр week - ближайшие 7 дней, начиная с текущего момента
+// (для задач на весь день - с сегодняшней даты)
+type DueWindow struct {
+	Now      time.Time // текущий момент в поясе пользователя
+	DayStart time.Time // начало сегодняшнего дня
+	DayEnd   time.Time // начало завтрашнего дня
+	WeekEnd  time.Time // начало дня через 7 дней
+
+	Today    time.Time // сегодняшняя дата полночью UTC (для сроков на весь день)
+	Tomorrow time.Time // завтрашняя дата полночью UTC
+	WeekDay  time.Time // дата через 7 дней полночью UTC
+}
+
+// NewDueWindow вычисляет границы для момента now в часовом поясе timezone.
+// Пустой или неизвестный пояс оставляет пояс now
+func NewDueWindow(now time.Time, timezone string) DueWindow {
+	if timezone != "" {
+		if local, err := utils.ConvertToTimezone(now, timezone); err == nil {
+			now = local
+		}
+	}
+
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	return DueWindow{
+		Now:      now,
+		DayStart: dayStart,
+		DayEnd:   dayStart.AddDate(0, 0, 1),
+		WeekEnd:  dayStart.AddDate(0, 0, 7),
+		Today:    today,
+		Tomorrow: today.AddDate(0, 0, 1),
+		WeekDay:  today.AddDate(0, 0, 7),
+	}
+}
+
+// IsPast проверяет, прошел ли срок: срок на весь день - после окончания даты
+func (w DueWindow) IsPast(due time.Time, allDay bool) bool {
+	if allDay {
+		return DueDay(due).Before(w.Today)
+	}
+	return due.Before(w.Now)
+}
+
+// IsOverdue проверяет, просрочена ли незакрытая задача
+func (w DueWindow) IsOverdue(task *Task) bool {
+	return task.DueDate != nil && task.Status.IsOpen() && w.IsPast(*task.DueDate, task.DueAllDay)
+}
+
+// IsDueToday проверяет, приходится ли срок задачи на сегодня
+func (w DueWindow) IsDueToday(task *Task) bool {
+	if task.DueDate == nil {
+		return false
+	}
+	if task.DueAllDay {
+		return DueDay(*task.DueDate).Equal(w.Today)
+	}
+	return !task.DueDate.Before(w.DayStart) && task.DueDate.Before(w.DayEnd)
+}
+
+// IsDueThisWeek проверяет, наступает ли срок задачи в ближайшие 7 дней
+func (w DueWindow) IsDueThisWeek(task *Task) bool {
+	if task.DueDate == nil {
+		return false
+	}
+	if task.DueAllDay {
+		day := DueDay(*task.DueDate)
+		return !day.Before(w.Today) && day.Before(w.WeekDay)
+	}
+	return !task.DueDate.Before(w.Now) && task.DueDate.Before(w.WeekEnd)
+}
@@ -1,16 +1,102 @@
 package models
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // TaskFilter представляет фильтры для поиска задач
 type TaskFilter struct {
-	Status   TaskStatus `json:"status"`    // all, active, completed
+	Status   TaskStatus `json:"status"`    // all, active, completed или конкретный статус
 	Priority Priority   `json:"priority"`  // all, low, medium, high
 	DateType DateFilter `json:"date_type"` // all, today, week, overdue
-	Search   string     `json:"search"`    // поиск по заголовку и описанию
+	Search   string     `json:"search"`    // поиск по заголовку, описанию и комментариям
 	DueFrom  *time.Time `json:"due_from"`  // задачи с даты
 	DueTo    *time.Time `json:"due_to"`    // задачи до даты
 	Archived bool       `json:"archived"`  // показывать архивные задачи
+	Timezone string     `json:"timezone"`  // пояс для date_type; пустой - из настроек пользователя
+}
+
+// Matches проверяет, удовлетворяет ли задача фильтру, повторяя условия
+// выборки из БД. Используется для предикатов подписок, когда задача уже загружена.
+// Без Timezone границы дня считаются в поясе now. Комментарии в снимок задачи
+// не входят, поэтому Search сверяется только с заголовком и описанием
+func (f TaskFilter) Matches(task *Task, now time.Time) bool {
+	if task == nil {
+		return false
+	}
+
+	if statuses := f.Status.Expand(); statuses != nil && !containsStatus(statuses, task.Status) {
+		return false
+	}
+
+	if f.Priority != "" && f.Priority != "all" && task.Priority != f.Priority {
+		return false
+	}
+
+	if f.Search != "" {
+		search := strings.ToLower(f.Search)
+		if !strings.Contains(strings.ToLower(task.Title), search) &&
+			!strings.Contains(strings.ToLower(task.Description), search) {
+			return false
+		}
+	}
+
+	if f.DueFrom != nil && (task.DueDate == nil || task.DueDate.Before(*f.DueFrom)) {
+		return false
+	}
+
+	if f.DueTo != nil && (task.DueDate == nil || task.DueDate.After(*f.DueTo)) {
+		return false
+	}
+
+	window := NewDueWindow(now, f.Timezone)
+	switch f.DateType {
+	case DateFilterToday:
+		if !window.IsDueToday(task) {
+			return false
+		}
+	case DateFilterWeek:
+		if !window.IsDueThisWeek(task) {
+			return false
+		}
+	case DateFilterOverdue:
+		if !window.IsOverdue(task) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LogFields возвращает заданные условия фильтра для логов и атрибутов трассировки.
+// Текст поиска не включается: он может содержать данные пользователя
+func (f TaskFilter) LogFields() map[string]interface{} {
+	fields := map[string]interface{}{
+		"filter.archived": f.Archived,
+	}
+	if f.Status != "" {
+		fields["filter.status"] = string(f.Status)
+	}
+	if f.Priority != "" {
+		fields["filter.priority"] = string(f.Priority)
+	}
+	if f.DateType != "" {
+		fields["filter.date_type"] = string(f.DateType)
+	}
+	if f.Search != "" {
+		fields["filter.search_len"] = len(f.Search)
+	}
+	if f.DueFrom != nil {
+		fields["filter.due_from"] = f.DueFrom.Format(time.RFC3339)
+	}
+	if f.DueTo != nil {
+		fields["filter.due_to"] = f.DueTo.Format(time.RFC3339)
+	}
+	if f.Timezone != "" {
+		fields["filter.timezone"] = f.Timezone
+	}
+	return fields
 }
 
 // DateFilter представляет типы фильтрации по дате
@@ -25,10 +111,18 @@ const (
 
 // TaskSort представляет параметры сортировки задач
 type TaskSort struct {
-	Field SortField `json:"field"` // created_at, priority, due_date, title
+	Field SortField `json:"field"` // created_at, priority, due_date, title, manual
 	Order SortOrder `json:"order"` // asc, desc
 }
 
+// LogFields возвращает параметры сортировки для логов и атрибутов трассировки
+func (s TaskSort) LogFields() map[string]interface{} {
+	return map[string]interface{}{
+		"sort.field": string(s.Field),
+		"sort.order": string(s.Order),
+	}
+}
+
 // SortField представляет поле для сортировки
 type SortField string
 
@@ -39,6 +133,7 @@ const (
 	SortFieldDueDate   SortField = "due_date"
 	SortFieldTitle     SortField = "title"
 	SortFieldStatus    SortField = "status"
+	SortFieldManual    SortField = "manual" // ручной порядок в общем списке
 )
 
 // SortOrder представляет направление сортировки
@@ -52,7 +147,7 @@ const (
 // GetDefaultFilter возвращает фильтр по умолчанию
 func GetDefaultFilter() TaskFilter {
 	return TaskFilter{
-		Status:   TaskStatusActive,
+		Status:   StatusFilterActive,
 		Priority: "", // все приоритеты
 		DateType: DateFilterAll,
 		Search:   "",
@@ -67,11 +162,22 @@ func GetDefaultSort() TaskSort {
 	}
 }
 
-// IsValidStatus проверяет валидность статуса
+// IsValidStatus проверяет валидность статуса в фильтре: конкретный статус или группа
 func IsValidStatus(status string) bool {
-	return status == string(TaskStatusActive) ||
-		status == string(TaskStatusCompleted) ||
-		status == ""
+	switch TaskStatus(status) {
+	case "", StatusFilterActive, StatusFilterCompleted, StatusFilterAll:
+		return true
+	}
+	return TaskStatus(status).IsKnown()
+}
+
+func containsStatus(statuses []TaskStatus, status TaskStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
 }
 
 // IsValidPriority проверяет валидность приоритета
@@ -97,7 +203,8 @@ func IsValidSortField(field string) bool {
 		field == string(SortFieldPriority) ||
 		field == string(SortFieldDueDate) ||
 		field == string(SortFieldTitle) ||
-		field == string(SortFieldStatus)
+		field == string(SortFieldStatus) ||
+		field == string(SortFieldManual)
 }
 
 // IsValidSortOrder проверяет валидность направления сортировки
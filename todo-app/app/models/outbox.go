@@ -0,0 +1,27 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxEvent представляет доменное событие, сохраненное в транзакционный outbox
+type OutboxEvent struct {
+	ID          int64           `json:"id" db:"id"`
+	EventType   string          `json:"event_type" db:"event_type"`
+	Payload     json.RawMessage `json:"payload" db:"payload"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	PublishedAt *time.Time      `json:"published_at" db:"published_at"`
+	Attempts    int             `json:"attempts" db:"attempts"`
+	LastError   string          `json:"last_error" db:"last_error"`
+}
+
+// TaskHistoryEntry представляет событие из истории задачи: изменения полей,
+// переходы статусов, комментарии. Data - данные события в том виде, в котором
+// они были записаны в outbox
+type TaskHistoryEntry struct {
+	ID         int64           `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
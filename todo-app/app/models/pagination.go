@@ -0,0 +1,53 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// TaskCursor представляет позицию в упорядоченном списке задач для keyset-пагинации.
+// Курсор привязан к полю и направлению сортировки, для которых он был выдан.
+type TaskCursor struct {
+	Field SortField `json:"f"`
+	Order SortOrder `json:"o"`
+	Value string    `json:"v"` // значение поля сортировки последней задачи страницы
+	ID    int       `json:"i"` // id последней задачи страницы (tie-breaker)
+}
+
+// TaskPage представляет одну страницу задач, полученную по курсору
+type TaskPage struct {
+	Tasks      []*Task `json:"tasks"`
+	HasNext    bool    `json:"has_next"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+// EncodeTaskCursor кодирует курсор в непрозрачную строку для клиента
+func EncodeTaskCursor(cursor TaskCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeTaskCursor декодирует курсор, полученный от клиента
+func DecodeTaskCursor(encoded string) (*TaskCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", err)
+	}
+
+	cursor := &TaskCursor{}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return nil, fmt.Errorf("malformed cursor: %w", err)
+	}
+
+	if cursor.ID <= 0 || !IsValidSortField(string(cursor.Field)) || !IsValidSortOrder(string(cursor.Order)) {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	return cursor, nil
+}
+
+// MatchesSort проверяет, что курсор был выдан для указанной сортировки
+func (c *TaskCursor) MatchesSort(sort TaskSort) bool {
+	return c.Field == sort.Field && c.Order == sort.Order
+}
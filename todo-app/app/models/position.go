@@ -0,0 +1,36 @@
+package models
+
+// Ручной порядок задач хранится разреженными дробными позициями: при переносе
+// задача получает середину между соседями, поэтому остальные задачи не сдвигаются.
+// Когда зазор между соседями исчерпан, позиции области перенумеровываются с шагом PositionStep
+const (
+	PositionStep   = 1024.0
+	MinPositionGap = 1e-6
+)
+
+// PositionIn возвращает позицию задачи в общем списке (пустая колонка)
+// или в колонке статуса на доске
+func (t *Task) PositionIn(column TaskStatus) float64 {
+	if column == "" {
+		return t.Position
+	}
+	return t.BoardPosition
+}
+
+// PositionBetween возвращает позицию между соседями; nil - соседа нет (начало или конец).
+// false означает, что зазор исчерпан и позиции нужно перенумеровать
+func PositionBetween(before, after *float64) (float64, bool) {
+	switch {
+	case before == nil && after == nil:
+		return PositionStep, true
+	case after == nil:
+		return *before + PositionStep, true
+	case before == nil:
+		return *after - PositionStep, true
+	}
+
+	if *after-*before < MinPositionGap {
+		return 0, false
+	}
+	return *before + (*after-*before)/2, true
+}
@@ -0,0 +1,41 @@
+package models
+
+import "testing"
+
+func TestPositionBetween(t *testing.T) {
+	at := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name          string
+		before, after *float64
+		want          float64
+		ok            bool
+	}{
+		{"empty list", nil, nil, PositionStep, true},
+		{"to the end", at(2048), nil, 2048 + PositionStep, true},
+		{"to the start", nil, at(1024), 0, true},
+		{"between", at(1024), at(2048), 1536, true},
+		{"fractional", at(1), at(1.5), 1.25, true},
+		{"gap exhausted", at(1), at(1 + MinPositionGap/2), 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := PositionBetween(tt.before, tt.after)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("%s: expected %v (%v), got %v (%v)", tt.name, tt.want, tt.ok, got, ok)
+		}
+	}
+
+	// Повторные вставки в один зазор рано или поздно требуют перенумерации
+	before, after := 1024.0, 2048.0
+	for i := 0; ; i++ {
+		position, ok := PositionBetween(&before, &after)
+		if !ok {
+			if i < 20 {
+				t.Errorf("Gap exhausted too early after %d inserts", i)
+			}
+			break
+		}
+		after = position
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// RecurrenceFrequency представляет частоту повторения задачи
+type RecurrenceFrequency string
+
+const (
+	RecurrenceDaily   RecurrenceFrequency = "daily"
+	RecurrenceWeekly  RecurrenceFrequency = "weekly"
+	RecurrenceMonthly RecurrenceFrequency = "monthly"
+	RecurrenceYearly  RecurrenceFrequency = "yearly"
+)
+
+// Recurrence описывает повторение, найденное в строке быстрого добавления
+type Recurrence struct {
+	Frequency RecurrenceFrequency `json:"frequency"`
+	Interval  int                 `json:"interval"`            // каждые N периодов
+	Weekday   string              `json:"weekday,omitempty"`   // день недели для weekly: monday ... sunday
+	MonthDay  int                 `json:"month_day,omitempty"` // число месяца для monthly
+}
+
+// QuickAddResult представляет результат разбора строки быстрого добавления
+type QuickAddResult struct {
+	Input      string      `json:"input"`
+	Title      string      `json:"title"`
+	Priority   Priority    `json:"priority"` // пустой - приоритет по умолчанию из настроек
+	DueDate    *time.Time  `json:"due_date"`
+	DueAllDay  bool        `json:"due_all_day"`
+	Project    string      `json:"project"`
+	Tags       []string    `json:"tags"`
+	Recurrence *Recurrence `json:"recurrence"` // подсказка: повторение пока не сохраняется
+}
+
+// CreateTaskRequest возвращает запрос на создание задачи из результата разбора
+func (r QuickAddResult) CreateTaskRequest() CreateTaskRequest {
+	return CreateTaskRequest{
+		Title:     r.Title,
+		Priority:  r.Priority,
+		DueDate:   r.DueDate,
+		DueAllDay: r.DueAllDay,
+		Project:   r.Project,
+		Tags:      r.Tags,
+	}
+}
@@ -0,0 +1,32 @@
+package models
+
+// TaskChangeKind представляет вид изменения задачи для подписчиков в реальном времени
+type TaskChangeKind string
+
+const (
+	TaskChangeAdded   TaskChangeKind = "added"
+	TaskChangeChanged TaskChangeKind = "changed"
+	TaskChangeRemoved TaskChangeKind = "removed"
+)
+
+// TaskChange представляет изменение одной задачи
+type TaskChange struct {
+	Kind   TaskChangeKind `json:"kind"`
+	TaskID int            `json:"task_id"`
+	Task   *Task          `json:"task,omitempty"` // отсутствует для removed
+}
+
+// TaskChangeBatch представляет пачку изменений, отправляемую клиентам.
+// Seq увеличивается на единицу с каждой пачкой: пропуск номера или Resync
+// означает, что клиент должен заново загрузить список задач
+type TaskChangeBatch struct {
+	Seq     uint64       `json:"seq"`
+	Changes []TaskChange `json:"changes"`
+	Resync  bool         `json:"resync"`
+}
+
+// TaskSyncSnapshot представляет полный список задач для повторной синхронизации клиента
+type TaskSyncSnapshot struct {
+	Seq   uint64  `json:"seq"` // номер последней пачки, учтенной в снимке
+	Tasks []*Task `json:"tasks"`
+}
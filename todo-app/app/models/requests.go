@@ -8,6 +8,11 @@ type CreateTaskRequest struct {
 	Description string     `json:"description" validate:"max=1000"`
 	Priority    Priority   `json:"priority" validate:"oneof=low medium high"`
 	DueDate     *time.Time `json:"due_date"`
+	DueAllDay   bool       `json:"due_all_day"` // срок на весь день: значимы только дата due_date
+	Project     string     `json:"project" validate:"max=100"`
+	Tags        []string   `json:"tags" validate:"max=20,dive,min=1,max=50"`
+	// Оценка в минутах; nil - без оценки
+	EstimateMinutes *int `json:"estimate_minutes" validate:"omitempty,min=1,max=100000"`
 }
 
 // UpdateTaskRequest представляет запрос на обновление задачи
@@ -17,9 +22,16 @@ type UpdateTaskRequest struct {
 	Description string     `json:"description" validate:"max=1000"`
 	Priority    Priority   `json:"priority" validate:"oneof=low medium high"`
 	DueDate     *time.Time `json:"due_date"`
+	DueAllDay   bool       `json:"due_all_day"`
 	Archived    bool       `json:"archived"`
 }
 
+// SetEstimateRequest представляет запрос на изменение оценки задачи
+type SetEstimateRequest struct {
+	ID              int  `json:"id" validate:"required,gt=0"`
+	EstimateMinutes *int `json:"estimate_minutes" validate:"omitempty,min=1,max=100000"` // nil снимает оценку
+}
+
 // ToggleTaskStatusRequest представляет запрос на изменение статуса задачи
 type ToggleTaskStatusRequest struct {
 	ID int `json:"id" validate:"required,gt=0"`
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"time"
+	"todo-app/internal/utils"
+)
 
 // TaskResponse представляет ответ с информацией о задаче
 type TaskResponse struct {
@@ -10,35 +13,52 @@ type TaskResponse struct {
 	Status      TaskStatus `json:"status"`
 	Priority    Priority   `json:"priority"`
 	DueDate     *time.Time `json:"due_date"`
+	DueAllDay   bool       `json:"due_all_day"`
+	Project     string     `json:"project"`
+	Tags        []string   `json:"tags"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	CompletedAt *time.Time `json:"completed_at"`
+	StartedAt   *time.Time `json:"started_at"`
+	CancelledAt *time.Time `json:"cancelled_at"`
 	IsOverdue   bool       `json:"is_overdue"`
+	// Ручной порядок в списке и на доске
+	Position      float64 `json:"position"`
+	BoardPosition float64 `json:"board_position"`
+	// Оценка трудозатрат в минутах
+	EstimateMinutes *int `json:"estimate_minutes"`
 }
 
 // TaskListResponse представляет ответ со списком задач
 type TaskListResponse struct {
-	Tasks      []*TaskResponse `json:"tasks"`
-	TotalCount int             `json:"total_count"`
-	Filter     TaskFilter      `json:"filter"`
-	Sort       TaskSort        `json:"sort"`
+	Tasks      []*TaskResponse       `json:"tasks"`
+	TotalCount int                   `json:"total_count"`
+	Filter     TaskFilter            `json:"filter"`
+	Sort       TaskSort              `json:"sort"`
+	NextCursor string                `json:"next_cursor,omitempty"` // непрозрачный курсор следующей страницы
+	Pagination *utils.PaginationMeta `json:"pagination,omitempty"`
 }
 
 // DashboardStats представляет статистику для дашборда
 type DashboardStats struct {
 	TaskStats         TaskStats          `json:"task_stats"`
 	PriorityBreakdown map[Priority]int   `json:"priority_breakdown"`
-	StatusBreakdown   map[TaskStatus]int `json:"status_breakdown"`
+	StatusBreakdown   map[TaskStatus]int `json:"status_breakdown"` // по статусам текущего workflow
+	Workflow          *Workflow          `json:"workflow"`
 	RecentTasks       []*TaskResponse    `json:"recent_tasks"`
 	UpcomingTasks     []*TaskResponse    `json:"upcoming_tasks"`
 }
 
-// AppSettings представляет настройки приложения
-type AppSettings struct {
-	Theme           string `json:"theme" validate:"oneof=light dark"`
-	Language        string `json:"language" validate:"oneof=en ru"`
-	NotificationsOn bool   `json:"notifications_on"`
-	AutoSave        bool   `json:"auto_save"`
+// BoardColumn представляет колонку доски: задачи одного статуса в ручном порядке
+type BoardColumn struct {
+	Status TaskStatus      `json:"status"`
+	Tasks  []*TaskResponse `json:"tasks"`
+	Count  int             `json:"count"`
+}
+
+// Board представляет задачи, сгруппированные по статусам workflow
+type Board struct {
+	Columns []*BoardColumn `json:"columns"`
 }
 
 // ErrorResponse представляет ответ с ошибкой
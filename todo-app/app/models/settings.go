@@ -0,0 +1,217 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// SettingsSchemaVersion текущая версия набора ключей настроек. Версия
+// увеличивается, когда новый ключ нужно вывести из уже сохраненных значений;
+// шаг перехода добавляется в settingsUpgrades
+const SettingsSchemaVersion = 2
+
+// SettingKey представляет ключ настройки в хранилище
+type SettingKey string
+
+const (
+	SettingSchemaVersion   SettingKey = "schema_version"
+	SettingTheme           SettingKey = "theme"
+	SettingLanguage        SettingKey = "language"
+	SettingNotificationsOn SettingKey = "notifications_on"
+	SettingAutoSave        SettingKey = "auto_save"
+	SettingDefaultPriority SettingKey = "default_priority"
+	SettingWeekStart       SettingKey = "week_start"
+	SettingDateFormat      SettingKey = "date_format"
+	SettingTimezone        SettingKey = "timezone"
+)
+
+// Первый день недели
+const (
+	WeekStartMonday = "monday"
+	WeekStartSunday = "sunday"
+)
+
+// Форматы отображения дат
+const (
+	DateFormatISO      = "YYYY-MM-DD"
+	DateFormatEuropean = "DD.MM.YYYY"
+	DateFormatUS       = "MM/DD/YYYY"
+)
+
+// AppSettings представляет настройки приложения
+type AppSettings struct {
+	SchemaVersion   int      `json:"schema_version"`
+	Theme           string   `json:"theme" validate:"oneof=light dark"`
+	Language        string   `json:"language" validate:"oneof=en ru"`
+	NotificationsOn bool     `json:"notifications_on"`
+	AutoSave        bool     `json:"auto_save"`
+	DefaultPriority Priority `json:"default_priority" validate:"oneof=low medium high"`
+	WeekStart       string   `json:"week_start" validate:"oneof=monday sunday"`
+	DateFormat      string   `json:"date_format" validate:"oneof=YYYY-MM-DD DD.MM.YYYY MM/DD/YYYY"`
+	Timezone        string   `json:"timezone" validate:"required,timezone"`
+}
+
+// DefaultAppSettings возвращает настройки по умолчанию
+func DefaultAppSettings() *AppSettings {
+	return &AppSettings{
+		SchemaVersion:   SettingsSchemaVersion,
+		Theme:           "light",
+		Language:        "en",
+		NotificationsOn: true,
+		AutoSave:        true,
+		DefaultPriority: PriorityMedium,
+		WeekStart:       WeekStartMonday,
+		DateFormat:      DateFormatISO,
+		Timezone:        "UTC",
+	}
+}
+
+// Location возвращает часовой пояс пользователя; неизвестный пояс заменяется UTC
+func (s *AppSettings) Location() *time.Location {
+	if s == nil || s.Timezone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// settingDefinition описывает преобразование поля AppSettings в значение хранилища
+type settingDefinition struct {
+	key SettingKey
+	get func(s *AppSettings) string
+	set func(s *AppSettings, value string) error
+}
+
+// stringSetting описывает строковую настройку
+func stringSetting(key SettingKey, field func(s *AppSettings) *string) settingDefinition {
+	return settingDefinition{
+		key: key,
+		get: func(s *AppSettings) string { return *field(s) },
+		set: func(s *AppSettings, value string) error {
+			*field(s) = value
+			return nil
+		},
+	}
+}
+
+// boolSetting описывает логическую настройку
+func boolSetting(key SettingKey, field func(s *AppSettings) *bool) settingDefinition {
+	return settingDefinition{
+		key: key,
+		get: func(s *AppSettings) string { return strconv.FormatBool(*field(s)) },
+		set: func(s *AppSettings, value string) error {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return err
+			}
+			*field(s) = parsed
+			return nil
+		},
+	}
+}
+
+// settingDefinitions перечисляет хранимые настройки. Новая настройка - это поле
+// AppSettings и строка здесь; миграция схемы БД не нужна
+var settingDefinitions = []settingDefinition{
+	stringSetting(SettingTheme, func(s *AppSettings) *string { return &s.Theme }),
+	stringSetting(SettingLanguage, func(s *AppSettings) *string { return &s.Language }),
+	boolSetting(SettingNotificationsOn, func(s *AppSettings) *bool { return &s.NotificationsOn }),
+	boolSetting(SettingAutoSave, func(s *AppSettings) *bool { return &s.AutoSave }),
+	stringSetting(SettingDefaultPriority, func(s *AppSettings) *string { return (*string)(&s.DefaultPriority) }),
+	stringSetting(SettingWeekStart, func(s *AppSettings) *string { return &s.WeekStart }),
+	stringSetting(SettingDateFormat, func(s *AppSettings) *string { return &s.DateFormat }),
+	stringSetting(SettingTimezone, func(s *AppSettings) *string { return &s.Timezone }),
+}
+
+// settingsUpgrades содержит шаги перехода хранилища с версии N на N+1
+var settingsUpgrades = map[int]func(values map[SettingKey]string){
+	// 1 -> 2: добавлены default_priority, week_start, date_format и timezone.
+	// Начало недели и формат даты выводятся из выбранного ранее языка
+	1: func(values map[SettingKey]string) {
+		if values[SettingLanguage] == "ru" {
+			setIfMissing(values, SettingWeekStart, WeekStartMonday)
+			setIfMissing(values, SettingDateFormat, DateFormatEuropean)
+		} else {
+			setIfMissing(values, SettingWeekStart, WeekStartSunday)
+			setIfMissing(values, SettingDateFormat, DateFormatUS)
+		}
+	},
+}
+
+// setIfMissing задает значение ключа, если оно еще не сохранено
+func setIfMissing(values map[SettingKey]string, key SettingKey, value string) {
+	if _, ok := values[key]; !ok {
+		values[key] = value
+	}
+}
+
+// Values возвращает настройки в виде пар ключ-значение вместе с версией схемы
+func (s *AppSettings) Values() map[SettingKey]string {
+	version := s.SchemaVersion
+	if version < SettingsSchemaVersion {
+		version = SettingsSchemaVersion
+	}
+
+	values := make(map[SettingKey]string, len(settingDefinitions)+1)
+	values[SettingSchemaVersion] = strconv.Itoa(version)
+	for _, definition := range settingDefinitions {
+		values[definition.key] = definition.get(s)
+	}
+	return values
+}
+
+// SettingsFromValues восстанавливает настройки из пар ключ-значение.
+// Хранилище старой версии поднимается шагами settingsUpgrades (без версии
+// считается версией 1); отсутствующие и нечитаемые значения заменяются
+// значениями по умолчанию, неизвестные ключи игнорируются. Версия, записанная
+// более новой сборкой, сохраняется, чтобы не повторить ее шаги перехода
+func SettingsFromValues(values map[SettingKey]string) *AppSettings {
+	settings := DefaultAppSettings()
+	if len(values) == 0 {
+		return settings
+	}
+
+	upgraded := make(map[SettingKey]string, len(values))
+	for key, value := range values {
+		upgraded[key] = value
+	}
+
+	version := 1
+	if stored, err := strconv.Atoi(values[SettingSchemaVersion]); err == nil && stored > 0 {
+		version = stored
+	}
+	for v := version; v < SettingsSchemaVersion; v++ {
+		if upgrade, ok := settingsUpgrades[v]; ok {
+			upgrade(upgraded)
+		}
+	}
+	if version > SettingsSchemaVersion {
+		settings.SchemaVersion = version
+	}
+
+	for _, definition := range settingDefinitions {
+		// Нечитаемое значение не меняет поле, остается значение по умолчанию
+		if value, ok := upgraded[definition.key]; ok {
+			_ = definition.set(settings, value)
+		}
+	}
+
+	return settings
+}
+
+// ChangedKeys возвращает ключи настроек, значения которых отличаются в next
+func (s *AppSettings) ChangedKeys(next *AppSettings) []SettingKey {
+	before, after := s.Values(), next.Values()
+
+	var changed []SettingKey
+	for _, definition := range settingDefinitions {
+		if before[definition.key] != after[definition.key] {
+			changed = append(changed, definition.key)
+		}
+	}
+
+	return changed
+}
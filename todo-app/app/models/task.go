@@ -14,20 +14,116 @@ type Task struct {
 	Status      TaskStatus `json:"status" db:"status"`
 	Priority    Priority   `json:"priority" db:"priority"`
 	DueDate     *time.Time `json:"due_date" db:"due_date"`
+	DueAllDay   bool       `json:"due_all_day" db:"due_all_day"` // срок - дата без времени
+	Project     string     `json:"project" db:"project"`
+	Tags        []string   `json:"tags" db:"tags"`
 	Archived    bool       `json:"archived" db:"archived"`
 	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
 	CompletedAt *time.Time `json:"completed_at" db:"completed_at"`
+	StartedAt   *time.Time `json:"started_at" db:"started_at"`
+	CancelledAt *time.Time `json:"cancelled_at" db:"cancelled_at"`
+	// Ручной порядок: в общем списке и в колонке статуса на доске
+	Position      float64 `json:"position" db:"position"`
+	BoardPosition float64 `json:"board_position" db:"board_position"`
+	// Оценка трудозатрат в минутах; nil - задача не оценена
+	EstimateMinutes *int `json:"estimate_minutes" db:"estimate_minutes"`
 }
 
 // TaskStatus представляет статус задачи
 type TaskStatus string
 
 const (
-	TaskStatusActive    TaskStatus = "active"
-	TaskStatusCompleted TaskStatus = "completed"
+	TaskStatusTodo       TaskStatus = "todo"
+	TaskStatusInProgress TaskStatus = "in_progress"
+	TaskStatusWaiting    TaskStatus = "waiting"
+	TaskStatusDone       TaskStatus = "done"
+	TaskStatusCancelled  TaskStatus = "cancelled"
 )
 
+// Группы статусов для фильтрации
+const (
+	StatusFilterActive    TaskStatus = "active"    // все незакрытые статусы
+	StatusFilterCompleted TaskStatus = "completed" // выполненные задачи
+	StatusFilterAll       TaskStatus = "all"
+)
+
+// TaskStatuses возвращает все поддерживаемые статусы задач
+func TaskStatuses() []TaskStatus {
+	return []TaskStatus{TaskStatusTodo, TaskStatusInProgress, TaskStatusWaiting, TaskStatusDone, TaskStatusCancelled}
+}
+
+// OpenStatuses возвращает статусы задач, над которыми еще ведется работа
+func OpenStatuses() []TaskStatus {
+	return []TaskStatus{TaskStatusTodo, TaskStatusInProgress, TaskStatusWaiting}
+}
+
+// IsKnown проверяет, что статус поддерживается приложением
+func (ts TaskStatus) IsKnown() bool {
+	for _, status := range TaskStatuses() {
+		if ts == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOpen проверяет, что задача еще не закрыта
+func (ts TaskStatus) IsOpen() bool {
+	return ts == TaskStatusTodo || ts == TaskStatusInProgress || ts == TaskStatusWaiting
+}
+
+// IsClosed проверяет, что задача выполнена или отменена
+func (ts TaskStatus) IsClosed() bool {
+	return ts == TaskStatusDone || ts == TaskStatusCancelled
+}
+
+// Expand возвращает конкретные статусы, соответствующие значению фильтра.
+// Пустой результат означает отсутствие ограничения по статусу
+func (ts TaskStatus) Expand() []TaskStatus {
+	switch ts {
+	case "", StatusFilterAll:
+		return nil
+	case StatusFilterActive:
+		return OpenStatuses()
+	case StatusFilterCompleted:
+		return []TaskStatus{TaskStatusDone}
+	default:
+		return []TaskStatus{ts}
+	}
+}
+
+// ApplyStatus переводит задачу в статус и обновляет отметки времени переходов
+func (t *Task) ApplyStatus(status TaskStatus, now time.Time) {
+	switch status {
+	case TaskStatusTodo:
+		t.StartedAt = nil
+	case TaskStatusInProgress:
+		if t.StartedAt == nil {
+			t.StartedAt = &now
+		}
+	}
+
+	if status == TaskStatusDone {
+		if t.Status != TaskStatusDone || t.CompletedAt == nil {
+			t.CompletedAt = &now
+		}
+	} else {
+		t.CompletedAt = nil
+	}
+
+	if status == TaskStatusCancelled {
+		if t.Status != TaskStatusCancelled || t.CancelledAt == nil {
+			t.CancelledAt = &now
+		}
+	} else {
+		t.CancelledAt = nil
+	}
+
+	t.Status = status
+	t.UpdatedAt = now
+}
+
 // Value реализует driver.Valuer для TaskStatus
 func (ts TaskStatus) Value() (driver.Value, error) {
 	return string(ts), nil
@@ -36,7 +132,7 @@ func (ts TaskStatus) Value() (driver.Value, error) {
 // Scan реализует sql.Scanner для TaskStatus
 func (ts *TaskStatus) Scan(value interface{}) error {
 	if value == nil {
-		*ts = TaskStatusActive
+		*ts = TaskStatusTodo
 		return nil
 	}
 	switch s := value.(type) {
@@ -0,0 +1,251 @@
+package models
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// TimeEntry представляет отрезок времени, затраченного на задачу. Запущенный
+// таймер - запись без EndedAt; он хранится в БД и переживает перезапуск приложения
+type TimeEntry struct {
+	ID        int64      `json:"id" db:"id"`
+	TaskID    int        `json:"task_id" db:"task_id"`
+	StartedAt time.Time  `json:"started_at" db:"started_at"`
+	EndedAt   *time.Time `json:"ended_at" db:"ended_at"`
+	Note      string     `json:"note" db:"note"`
+	Manual    bool       `json:"manual" db:"manual"` // добавлена вручную, а не таймером
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	// Поля задачи для отчетов, только для чтения
+	TaskTitle string `json:"task_title" db:"task_title"`
+	Project   string `json:"project" db:"project"`
+	// Длительность на момент запроса; у запущенного таймера растет
+	DurationSeconds int64 `json:"duration_seconds"`
+}
+
+// IsRunning проверяет, что запись - запущенный таймер
+func (e *TimeEntry) IsRunning() bool {
+	return e.EndedAt == nil
+}
+
+// End возвращает окончание отрезка; для запущенного таймера - now
+func (e *TimeEntry) End(now time.Time) time.Time {
+	if e.EndedAt != nil {
+		return *e.EndedAt
+	}
+	return now
+}
+
+// Duration возвращает длительность отрезка на момент now
+func (e *TimeEntry) Duration(now time.Time) time.Duration {
+	if d := e.End(now).Sub(e.StartedAt); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// TimeEntryFilter ограничивает выборку записей времени. Нулевые значения
+// не ограничивают; From и To отбирают записи, пересекающие интервал
+type TimeEntryFilter struct {
+	TaskID int        `json:"task_id"`
+	From   *time.Time `json:"from"`
+	To     *time.Time `json:"to"`
+}
+
+// StartTimerRequest представляет запрос на запуск таймера по задаче
+type StartTimerRequest struct {
+	TaskID int    `json:"task_id" validate:"required,gt=0"`
+	Note   string `json:"note" validate:"max=500"`
+}
+
+// CreateTimeEntryRequest представляет запрос на ручное добавление времени
+type CreateTimeEntryRequest struct {
+	TaskID    int       `json:"task_id" validate:"required,gt=0"`
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+	Note      string    `json:"note" validate:"max=500"`
+}
+
+// TimeGroup представляет группировку итогов учета времени
+type TimeGroup string
+
+const (
+	TimeGroupTask    TimeGroup = "task"
+	TimeGroupProject TimeGroup = "project"
+	TimeGroupDay     TimeGroup = "day" // календарный день в часовом поясе пользователя
+)
+
+// IsValidTimeGroup проверяет группировку итогов
+func IsValidTimeGroup(group string) bool {
+	switch TimeGroup(group) {
+	case TimeGroupTask, TimeGroupProject, TimeGroupDay:
+		return true
+	default:
+		return false
+	}
+}
+
+// TimeReportRequest представляет запрос итогов времени за период
+type TimeReportRequest struct {
+	GroupBy TimeGroup  `json:"group_by"`
+	From    *time.Time `json:"from"`
+	To      *time.Time `json:"to"`
+}
+
+// TimeTotal представляет итог времени по группе
+type TimeTotal struct {
+	Key     string `json:"key"`   // ID задачи, проект (пустой - без проекта) или дата YYYY-MM-DD
+	Label   string `json:"label"` // название задачи; для проекта и дня совпадает с ключом
+	Seconds int64  `json:"seconds"`
+	Entries int    `json:"entries"` // записи, попавшие в группу
+}
+
+// TimeReport представляет итоги времени за период
+type TimeReport struct {
+	GroupBy      TimeGroup    `json:"group_by"`
+	From         *time.Time   `json:"from"`
+	To           *time.Time   `json:"to"`
+	Totals       []*TimeTotal `json:"totals"`
+	TotalSeconds int64        `json:"total_seconds"`
+}
+
+// SummarizeTime считает итоги записей по группам. Отрезки обрезаются границами
+// периода, а при группировке по дням делятся по полуночи в поясе loc.
+// Задачи и проекты упорядочены по убыванию времени, дни - по дате
+func SummarizeTime(entries []*TimeEntry, group TimeGroup, from, to *time.Time, loc *time.Location, now time.Time) *TimeReport {
+	report := &TimeReport{GroupBy: group, From: from, To: to, Totals: []*TimeTotal{}}
+	totals := make(map[string]*TimeTotal)
+
+	add := func(key, label string, d time.Duration) {
+		total, ok := totals[key]
+		if !ok {
+			total = &TimeTotal{Key: key, Label: label}
+			totals[key] = total
+			report.Totals = append(report.Totals, total)
+		}
+		total.Seconds += int64(d / time.Second)
+		total.Entries++
+		report.TotalSeconds += int64(d / time.Second)
+	}
+
+	for _, entry := range entries {
+		start, end := entry.StartedAt, entry.End(now)
+		if from != nil && start.Before(*from) {
+			start = *from
+		}
+		if to != nil && end.After(*to) {
+			end = *to
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		switch group {
+		case TimeGroupProject:
+			add(entry.Project, entry.Project, end.Sub(start))
+		case TimeGroupDay:
+			for day := start.In(loc); day.Before(end); {
+				midnight := time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc)
+				until := end
+				if midnight.Before(end) {
+					until = midnight
+				}
+				key := day.Format("2006-01-02")
+				add(key, key, until.Sub(day))
+				day = midnight
+			}
+		default:
+			add(strconv.Itoa(entry.TaskID), entry.TaskTitle, end.Sub(start))
+		}
+	}
+
+	sort.SliceStable(report.Totals, func(i, j int) bool {
+		a, b := report.Totals[i], report.Totals[j]
+		if group == TimeGroupDay || a.Seconds == b.Seconds {
+			return a.Key < b.Key
+		}
+		return a.Seconds > b.Seconds
+	})
+
+	return report
+}
+
+// EstimateSeconds возвращает оценку задачи в секундах; 0 - оценки нет
+func (t *Task) EstimateSeconds() int64 {
+	if t.EstimateMinutes == nil {
+		return 0
+	}
+	return int64(*t.EstimateMinutes) * 60
+}
+
+// EstimateVariance сравнивает оценку задачи с затраченным временем
+type EstimateVariance struct {
+	TaskID          int        `json:"task_id"`
+	Title           string     `json:"title"`
+	Project         string     `json:"project"`
+	Status          TaskStatus `json:"status"`
+	EstimateSeconds int64      `json:"estimate_seconds"` // 0 - без оценки
+	ActualSeconds   int64      `json:"actual_seconds"`
+	VarianceSeconds int64      `json:"variance_seconds"` // факт минус оценка; больше 0 - перерасход
+	Ratio           float64    `json:"ratio"`            // факт к оценке; 0 без оценки
+}
+
+// EstimateReport представляет сравнение оценок с фактом по задачам
+type EstimateReport struct {
+	Tasks []*EstimateVariance `json:"tasks"`
+	// Итоги по задачам с оценкой
+	EstimatedSeconds int64   `json:"estimated_seconds"`
+	ActualSeconds    int64   `json:"actual_seconds"`
+	VarianceSeconds  int64   `json:"variance_seconds"`
+	Accuracy         float64 `json:"accuracy"` // факт к оценке в целом
+	OverEstimate     int     `json:"over_estimate"`
+	WithinEstimate   int     `json:"within_estimate"`
+	// Время, затраченное на задачи без оценки
+	UnestimatedSeconds int64 `json:"unestimated_seconds"`
+}
+
+// NewEstimateReport строит отчет по задачам с оценкой или учтенным временем.
+// tracked - затраченное время в секундах по ID задачи
+func NewEstimateReport(tasks []*Task, tracked map[int]int64) *EstimateReport {
+	report := &EstimateReport{Tasks: []*EstimateVariance{}}
+
+	for _, task := range tasks {
+		estimate, actual := task.EstimateSeconds(), tracked[task.ID]
+		if estimate == 0 && actual == 0 {
+			continue
+		}
+
+		variance := &EstimateVariance{
+			TaskID:          task.ID,
+			Title:           task.Title,
+			Project:         task.Project,
+			Status:          task.Status,
+			EstimateSeconds: estimate,
+			ActualSeconds:   actual,
+		}
+		report.Tasks = append(report.Tasks, variance)
+
+		if estimate == 0 {
+			report.UnestimatedSeconds += actual
+			continue
+		}
+
+		variance.VarianceSeconds = actual - estimate
+		variance.Ratio = float64(actual) / float64(estimate)
+
+		report.EstimatedSeconds += estimate
+		report.ActualSeconds += actual
+		if actual > estimate {
+			report.OverEstimate++
+		} else {
+			report.WithinEstimate++
+		}
+	}
+
+	report.VarianceSeconds = report.ActualSeconds - report.EstimatedSeconds
+	if report.EstimatedSeconds > 0 {
+		report.Accuracy = float64(report.ActualSeconds) / float64(report.EstimatedSeconds)
+	}
+
+	return report
+}
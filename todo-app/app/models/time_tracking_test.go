@@ -0,0 +1,96 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeTime_DaysSplitAtLocalMidnight(t *testing.T) {
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	at := func(day, hour, min int) time.Time { return time.Date(2026, 3, day, hour, min, 0, 0, loc) }
+	ended := func(v time.Time) *time.Time { return &v }
+
+	entries := []*TimeEntry{
+		{TaskID: 1, TaskTitle: "Report", StartedAt: at(1, 23, 0), EndedAt: ended(at(2, 1, 30))},
+		{TaskID: 2, TaskTitle: "Review", StartedAt: at(2, 10, 0), EndedAt: ended(at(2, 10, 45))},
+		// Запущенный таймер считается до now
+		{TaskID: 1, TaskTitle: "Report", StartedAt: at(3, 9, 0)},
+	}
+
+	report := SummarizeTime(entries, TimeGroupDay, nil, nil, loc, at(3, 9, 20))
+
+	want := []struct {
+		key     string
+		seconds int64
+	}{
+		{"2026-03-01", 60 * 60},
+		{"2026-03-02", 90*60 + 45*60},
+		{"2026-03-03", 20 * 60},
+	}
+	if len(report.Totals) != len(want) {
+		t.Fatalf("Expected %d days, got %d", len(want), len(report.Totals))
+	}
+	for i, w := range want {
+		if got := report.Totals[i]; got.Key != w.key || got.Seconds != w.seconds {
+			t.Errorf("Day %d: expected %s=%d, got %s=%d", i, w.key, w.seconds, got.Key, got.Seconds)
+		}
+	}
+	if report.TotalSeconds != 3*60*60+35*60 {
+		t.Errorf("Expected total %d, got %d", 3*60*60+35*60, report.TotalSeconds)
+	}
+}
+
+func TestSummarizeTime_ClipsToPeriod(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2026, 3, 1, hour, 0, 0, 0, time.UTC) }
+	ended := func(v time.Time) *time.Time { return &v }
+
+	entries := []*TimeEntry{
+		{TaskID: 1, Project: "work", StartedAt: at(8), EndedAt: ended(at(11))},
+		{TaskID: 2, Project: "home", StartedAt: at(12), EndedAt: ended(at(13))},
+		{TaskID: 3, Project: "work", StartedAt: at(14), EndedAt: ended(at(15))},
+		{TaskID: 4, Project: "work", StartedAt: at(16), EndedAt: ended(at(17))},
+	}
+	from, to := at(10), at(15)
+
+	report := SummarizeTime(entries, TimeGroupProject, &from, &to, time.UTC, at(18))
+
+	if len(report.Totals) != 2 {
+		t.Fatalf("Expected 2 projects, got %d", len(report.Totals))
+	}
+	if work := report.Totals[0]; work.Key != "work" || work.Seconds != 2*60*60 || work.Entries != 2 {
+		t.Errorf("Expected work first with 2h in 2 entries, got %+v", work)
+	}
+	if home := report.Totals[1]; home.Key != "home" || home.Seconds != 60*60 {
+		t.Errorf("Expected home with 1h, got %+v", home)
+	}
+}
+
+func TestNewEstimateReport(t *testing.T) {
+	minutes := func(v int) *int { return &v }
+
+	tasks := []*Task{
+		{ID: 1, Title: "Over", EstimateMinutes: minutes(60)},
+		{ID: 2, Title: "Within", EstimateMinutes: minutes(120)},
+		{ID: 3, Title: "No estimate"},
+		{ID: 4, Title: "Untouched"},
+	}
+	tracked := map[int]int64{1: 90 * 60, 2: 60 * 60, 3: 30 * 60}
+
+	report := NewEstimateReport(tasks, tracked)
+
+	if len(report.Tasks) != 3 {
+		t.Fatalf("Expected 3 tasks in report, got %d", len(report.Tasks))
+	}
+	if over := report.Tasks[0]; over.VarianceSeconds != 30*60 || over.Ratio != 1.5 {
+		t.Errorf("Expected 30m overrun with ratio 1.5, got %+v", over)
+	}
+	if report.OverEstimate != 1 || report.WithinEstimate != 1 {
+		t.Errorf("Expected 1 over and 1 within estimate, got %d and %d", report.OverEstimate, report.WithinEstimate)
+	}
+	if report.EstimatedSeconds != 180*60 || report.ActualSeconds != 150*60 {
+		t.Errorf("Expected 180m estimated and 150m actual, got %d and %d", report.EstimatedSeconds, report.ActualSeconds)
+	}
+	if report.UnestimatedSeconds != 30*60 {
+		t.Errorf("Expected 30m without estimate, got %d", report.UnestimatedSeconds)
+	}
+}
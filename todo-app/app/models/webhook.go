@@ -0,0 +1,86 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebhookSubscription представляет подписку внешнего сервиса на события задач
+type WebhookSubscription struct {
+	ID          int         `json:"id" db:"id"`
+	URL         string      `json:"url" db:"url"`
+	Secret      string      `json:"-" db:"secret"` // ключ подписи HMAC-SHA256, не отдается клиенту
+	EventTypes  []string    `json:"event_types" db:"event_types"`
+	Filter      *TaskFilter `json:"filter" db:"filter"` // необязательный предикат по задаче
+	Description string      `json:"description" db:"description"`
+	Active      bool        `json:"active" db:"active"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// HandlesEvent проверяет, подписан ли webhook на тип события
+func (s *WebhookSubscription) HandlesEvent(eventType string) bool {
+	for _, t := range s.EventTypes {
+		if t == eventType || t == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus представляет состояние доставки webhook
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // ожидает первой или повторной попытки
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered" // получатель ответил 2xx
+	WebhookDeliveryDead      WebhookDeliveryStatus = "dead"      // попытки исчерпаны (dead-letter)
+)
+
+// WebhookDelivery представляет доставку одного события одной подписке
+type WebhookDelivery struct {
+	ID             int64                 `json:"id" db:"id"`
+	SubscriptionID int                   `json:"subscription_id" db:"subscription_id"`
+	EventID        int64                 `json:"event_id" db:"event_id"`
+	EventType      string                `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage       `json:"payload" db:"payload"`
+	Status         WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts       int                   `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time             `json:"next_attempt_at" db:"next_attempt_at"`
+	LastStatusCode int                   `json:"last_status_code" db:"last_status_code"`
+	LastError      string                `json:"last_error" db:"last_error"`
+	CreatedAt      time.Time             `json:"created_at" db:"created_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at" db:"delivered_at"`
+}
+
+// WebhookAttempt представляет запись журнала попыток доставки
+type WebhookAttempt struct {
+	ID         int64     `json:"id" db:"id"`
+	DeliveryID int64     `json:"delivery_id" db:"delivery_id"`
+	Attempt    int       `json:"attempt" db:"attempt"`
+	StatusCode int       `json:"status_code" db:"status_code"`
+	Error      string    `json:"error" db:"error"`
+	DurationMs int64     `json:"duration_ms" db:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// Succeeded проверяет, была ли попытка успешной
+func (a *WebhookAttempt) Succeeded() bool {
+	return a.Error == "" && a.StatusCode >= 200 && a.StatusCode < 300
+}
+
+// CreateWebhookRequest представляет запрос на создание подписки
+type CreateWebhookRequest struct {
+	URL         string      `json:"url" validate:"required,url,max=2048"`
+	EventTypes  []string    `json:"event_types" validate:"required,min=1,dive,required"`
+	Filter      *TaskFilter `json:"filter"`
+	Secret      string      `json:"secret" validate:"omitempty,min=16,max=255"` // если пуст, генерируется
+	Description string      `json:"description" validate:"max=255"`
+}
+
+// CreateWebhookResponse возвращает созданную подписку и ее секрет.
+// Секрет показывается только один раз, при создании
+type CreateWebhookResponse struct {
+	Webhook *WebhookSubscription `json:"webhook"`
+	Secret  string               `json:"secret"`
+}
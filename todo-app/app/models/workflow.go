@@ -0,0 +1,116 @@
+package models
+
+import "fmt"
+
+// Workflow описывает используемые статусы задач и разрешенные переходы между ними
+type Workflow struct {
+	Statuses    []TaskStatus                `json:"statuses"`    // порядок колонок и разбивки статистики
+	Transitions map[TaskStatus][]TaskStatus `json:"transitions"` // разрешенные переходы из статуса
+}
+
+// DefaultWorkflow возвращает workflow по умолчанию со всеми статусами
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		Statuses: TaskStatuses(),
+		Transitions: map[TaskStatus][]TaskStatus{
+			TaskStatusTodo:       {TaskStatusInProgress, TaskStatusWaiting, TaskStatusDone, TaskStatusCancelled},
+			TaskStatusInProgress: {TaskStatusTodo, TaskStatusWaiting, TaskStatusDone, TaskStatusCancelled},
+			TaskStatusWaiting:    {TaskStatusTodo, TaskStatusInProgress, TaskStatusDone, TaskStatusCancelled},
+			TaskStatusDone:       {TaskStatusTodo, TaskStatusInProgress},
+			TaskStatusCancelled:  {TaskStatusTodo},
+		},
+	}
+}
+
+// NewWorkflow создает workflow из конфигурации. Пустой список статусов означает
+// workflow по умолчанию, пустые переходы - переходы по умолчанию между выбранными статусами.
+// Статусы todo и done обязательны: в них создаются и завершаются задачи
+func NewWorkflow(statuses []TaskStatus, transitions map[TaskStatus][]TaskStatus) (*Workflow, error) {
+	if len(statuses) == 0 {
+		statuses = TaskStatuses()
+	}
+
+	workflow := &Workflow{Statuses: make([]TaskStatus, 0, len(statuses))}
+	for _, status := range statuses {
+		if !status.IsKnown() {
+			return nil, fmt.Errorf("unknown status %q", status)
+		}
+		if workflow.Has(status) {
+			return nil, fmt.Errorf("duplicate status %q", status)
+		}
+		workflow.Statuses = append(workflow.Statuses, status)
+	}
+	for _, required := range []TaskStatus{TaskStatusTodo, TaskStatusDone} {
+		if !workflow.Has(required) {
+			return nil, fmt.Errorf("status %q is required", required)
+		}
+	}
+
+	if len(transitions) == 0 {
+		transitions = DefaultWorkflow().Transitions
+	} else {
+		for from, targets := range transitions {
+			if !workflow.Has(from) {
+				return nil, fmt.Errorf("transition from unknown status %q", from)
+			}
+			for _, to := range targets {
+				if !workflow.Has(to) {
+					return nil, fmt.Errorf("transition %s -> %s: unknown status %q", from, to, to)
+				}
+				if to == from {
+					return nil, fmt.Errorf("transition %s -> %s: status cannot transition to itself", from, to)
+				}
+			}
+		}
+	}
+
+	// Переходы в статусы вне workflow отбрасываются
+	workflow.Transitions = make(map[TaskStatus][]TaskStatus, len(workflow.Statuses))
+	for _, from := range workflow.Statuses {
+		allowed := []TaskStatus{}
+		for _, to := range transitions[from] {
+			if workflow.Has(to) {
+				allowed = append(allowed, to)
+			}
+		}
+		workflow.Transitions[from] = allowed
+	}
+
+	return workflow, nil
+}
+
+// Has проверяет, что статус входит в workflow
+func (w *Workflow) Has(status TaskStatus) bool {
+	for _, s := range w.Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransition проверяет, разрешен ли переход между статусами. Задачу в статусе
+// вне workflow (оставшемся после его сужения) можно перевести в любой статус workflow
+func (w *Workflow) CanTransition(from, to TaskStatus) bool {
+	if !w.Has(to) || from == to {
+		return false
+	}
+	if !w.Has(from) {
+		return true
+	}
+	for _, allowed := range w.Transitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleTarget возвращает статус для быстрого переключения задачи:
+// незакрытая задача выполняется, закрытая возвращается в todo
+func (w *Workflow) ToggleTarget(status TaskStatus) TaskStatus {
+	if status.IsClosed() {
+		return TaskStatusTodo
+	}
+	return TaskStatusDone
+}
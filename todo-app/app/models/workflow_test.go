@@ -0,0 +1,121 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDefaultWorkflow_Transitions(t *testing.T) {
+	workflow := DefaultWorkflow()
+
+	allowed := [][2]TaskStatus{
+		{TaskStatusTodo, TaskStatusInProgress},
+		{TaskStatusWaiting, TaskStatusDone},
+		{TaskStatusDone, TaskStatusTodo},
+		{TaskStatusCancelled, TaskStatusTodo},
+	}
+	for _, tt := range allowed {
+		if !workflow.CanTransition(tt[0], tt[1]) {
+			t.Errorf("Expected %s -> %s to be allowed", tt[0], tt[1])
+		}
+	}
+
+	forbidden := [][2]TaskStatus{
+		{TaskStatusDone, TaskStatusCancelled},
+		{TaskStatusCancelled, TaskStatusDone},
+		{TaskStatusTodo, TaskStatusTodo},
+		{TaskStatusTodo, "active"},
+	}
+	for _, tt := range forbidden {
+		if workflow.CanTransition(tt[0], tt[1]) {
+			t.Errorf("Expected %s -> %s to be forbidden", tt[0], tt[1])
+		}
+	}
+
+	if workflow.ToggleTarget(TaskStatusWaiting) != TaskStatusDone || workflow.ToggleTarget(TaskStatusCancelled) != TaskStatusTodo {
+		t.Errorf("Toggle should complete open tasks and reopen closed ones")
+	}
+}
+
+func TestNewWorkflow_Config(t *testing.T) {
+	// Без waiting переходы по умолчанию в него отбрасываются
+	workflow, err := NewWorkflow([]TaskStatus{TaskStatusTodo, TaskStatusInProgress, TaskStatusDone}, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if workflow.Has(TaskStatusWaiting) || workflow.CanTransition(TaskStatusTodo, TaskStatusWaiting) {
+		t.Errorf("Status outside workflow should not be reachable: %+v", workflow.Transitions)
+	}
+	if !workflow.CanTransition(TaskStatusInProgress, TaskStatusDone) {
+		t.Errorf("Default transitions between selected statuses should be kept")
+	}
+
+	invalid := []struct {
+		name        string
+		statuses    []TaskStatus
+		transitions map[TaskStatus][]TaskStatus
+	}{
+		{"unknown status", []TaskStatus{TaskStatusTodo, TaskStatusDone, "blocked"}, nil},
+		{"missing done", []TaskStatus{TaskStatusTodo, TaskStatusInProgress}, nil},
+		{"duplicate", []TaskStatus{TaskStatusTodo, TaskStatusDone, TaskStatusTodo}, nil},
+		{"unknown target", nil, map[TaskStatus][]TaskStatus{TaskStatusTodo: {"blocked"}}},
+		{"self transition", nil, map[TaskStatus][]TaskStatus{TaskStatusTodo: {TaskStatusTodo}}},
+	}
+	for _, tt := range invalid {
+		if _, err := NewWorkflow(tt.statuses, tt.transitions); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+func TestTask_ApplyStatus_Timestamps(t *testing.T) {
+	start := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	task := &Task{Status: TaskStatusTodo}
+
+	task.ApplyStatus(TaskStatusInProgress, start)
+	if task.StartedAt == nil || !task.StartedAt.Equal(start) {
+		t.Fatalf("StartedAt should be set on start, got %v", task.StartedAt)
+	}
+
+	// Возврат из ожидания не сбрасывает начало работы
+	task.ApplyStatus(TaskStatusWaiting, start.Add(time.Hour))
+	task.ApplyStatus(TaskStatusInProgress, start.Add(2*time.Hour))
+	if !task.StartedAt.Equal(start) {
+		t.Errorf("StartedAt should keep the first start, got %v", task.StartedAt)
+	}
+
+	done := start.Add(3 * time.Hour)
+	task.ApplyStatus(TaskStatusDone, done)
+	if task.CompletedAt == nil || !task.CompletedAt.Equal(done) || task.CancelledAt != nil {
+		t.Errorf("Unexpected timestamps after done: completed %v, cancelled %v", task.CompletedAt, task.CancelledAt)
+	}
+
+	task.ApplyStatus(TaskStatusTodo, done.Add(time.Hour))
+	if task.CompletedAt != nil || task.StartedAt != nil {
+		t.Errorf("Reopening to todo should clear timestamps: started %v, completed %v", task.StartedAt, task.CompletedAt)
+	}
+
+	task.ApplyStatus(TaskStatusCancelled, done.Add(2*time.Hour))
+	if task.CancelledAt == nil || task.Status != TaskStatusCancelled {
+		t.Errorf("CancelledAt should be set on cancel, got %v", task.CancelledAt)
+	}
+}
+
+func TestTaskFilter_StatusGroups(t *testing.T) {
+	now := time.Now()
+	waiting := &Task{Status: TaskStatusWaiting}
+	cancelled := &Task{Status: TaskStatusCancelled}
+
+	if !(TaskFilter{Status: StatusFilterActive}).Matches(waiting, now) {
+		t.Errorf("Active filter should include waiting tasks")
+	}
+	if (TaskFilter{Status: StatusFilterCompleted}).Matches(cancelled, now) {
+		t.Errorf("Completed filter should not include cancelled tasks")
+	}
+	if !(TaskFilter{Status: TaskStatusCancelled}).Matches(cancelled, now) {
+		t.Errorf("Exact status filter should match")
+	}
+	if !IsValidStatus("in_progress") || IsValidStatus("blocked") {
+		t.Errorf("Unexpected status validity")
+	}
+}
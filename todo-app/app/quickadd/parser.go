@@ -0,0 +1,688 @@
+// Package quickadd разбирает строку быстрого добавления задачи на английском
+// и русском: срок и время, относительные даты, приоритет, метки, проект и
+// повторение. Разбор детерминирован: все даты считаются от переданного
+// момента now в его часовом поясе
+package quickadd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"todo-app/app/models"
+	"unicode"
+)
+
+var (
+	isoDatePattern = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	dotDatePattern = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})(?:\.(\d{4}))?$`)
+	clockPattern   = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	ordinalPattern = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th|-го|-е)?$`)
+)
+
+// maxRelativeYears ограничивает относительный срок: "in 99999 days" остается
+// частью заголовка, а не превращается в дату вне диапазона БД
+const maxRelativeYears = 10
+
+// token - слово входной строки
+type token struct {
+	raw  string // исходное написание
+	word string // нижний регистр без завершающих знаков препинания
+	used bool   // слово распознано и не входит в заголовок
+}
+
+// parser хранит состояние разбора одной строки
+type parser struct {
+	now    time.Time
+	tokens []token
+
+	date    time.Time // календарная дата срока (полночь в поясе now)
+	hasDate bool
+	hour    int
+	minute  int
+	hasTime bool
+	moment  *time.Time // точный момент из "через 2 часа"
+
+	result models.QuickAddResult
+}
+
+// matcher пытается распознать конструкцию, начинающуюся со слова i,
+// и возвращает количество распознанных слов (0 - не распознано)
+type matcher func(p *parser, i int) int
+
+// matchers перечислены в порядке приоритета: повторение забирает свои
+// "on the 1st" раньше, чем их увидит разбор дат
+var matchers = []matcher{
+	(*parser).matchRecurrence,
+	(*parser).matchPriority,
+	(*parser).matchTag,
+	(*parser).matchProject,
+	(*parser).matchRelative,
+	(*parser).matchDayWord,
+	(*parser).matchWeekday,
+	(*parser).matchDate,
+	(*parser).matchTime,
+}
+
+// Parse разбирает строку быстрого добавления относительно момента now.
+// Срок без времени становится сроком на весь день; время без даты относится
+// к сегодняшнему дню или к завтрашнему, если это время уже прошло
+func Parse(input string, now time.Time) models.QuickAddResult {
+	p := &parser{
+		now:    now,
+		result: models.QuickAddResult{Input: input},
+	}
+
+	for _, field := range strings.Fields(input) {
+		p.tokens = append(p.tokens, token{
+			raw:  field,
+			word: strings.TrimRight(strings.ToLower(field), ",;."),
+		})
+	}
+
+	for i := 0; i < len(p.tokens); i++ {
+		if p.tokens[i].used {
+			continue
+		}
+		for _, match := range matchers {
+			if n := match(p, i); n > 0 {
+				for j := i; j < i+n; j++ {
+					p.tokens[j].used = true
+				}
+				i += n - 1
+				break
+			}
+		}
+	}
+
+	p.finish()
+	return p.result
+}
+
+// word возвращает нормализованное слово i или пустую строку, если его нет
+// или оно уже распознано
+func (p *parser) word(i int) string {
+	if i < 0 || i >= len(p.tokens) || p.tokens[i].used {
+		return ""
+	}
+	return p.tokens[i].word
+}
+
+// today возвращает начало сегодняшнего дня в поясе now
+func (p *parser) today() time.Time {
+	return time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+}
+
+// setDate запоминает дату срока; повторная дата в строке не перезаписывает первую
+func (p *parser) setDate(date time.Time) bool {
+	if p.hasDate || p.moment != nil {
+		return false
+	}
+	p.date, p.hasDate = date, true
+	return true
+}
+
+// setTime запоминает время срока
+func (p *parser) setTime(hour, minute int) bool {
+	if p.hasTime || p.moment != nil {
+		return false
+	}
+	p.hour, p.minute, p.hasTime = hour, minute, true
+	return true
+}
+
+// withPrefix распознает конструкцию match с необязательным предлогом из prefixes
+func (p *parser) withPrefix(i int, prefixes map[string]bool, match func(i int) int) int {
+	if prefixes[p.word(i)] {
+		if n := match(i + 1); n > 0 {
+			return n + 1
+		}
+	}
+	return match(i)
+}
+
+// matchPriority распознает маркеры приоритета: !high, !!!, !низкий
+func (p *parser) matchPriority(i int) int {
+	priority, ok := priorities[p.word(i)]
+	if !ok || p.result.Priority != "" {
+		return 0
+	}
+	p.result.Priority = priority
+	return 1
+}
+
+// matchTag распознает метки #tag
+func (p *parser) matchTag(i int) int {
+	name, ok := marker(p.word(i), '#')
+	if !ok {
+		return 0
+	}
+	for _, tag := range p.result.Tags {
+		if tag == name {
+			return 1
+		}
+	}
+	p.result.Tags = append(p.result.Tags, name)
+	return 1
+}
+
+// matchProject распознает проект +project (в исходном регистре)
+func (p *parser) matchProject(i int) int {
+	if _, ok := marker(p.word(i), '+'); !ok || p.result.Project != "" {
+		return 0
+	}
+	p.result.Project = strings.TrimRight(p.tokens[i].raw, ",;.")[1:]
+	return 1
+}
+
+// marker возвращает имя после префикса: имя начинается с буквы и состоит
+// из букв, цифр, "_" и "-"
+func marker(word string, prefix rune) (string, bool) {
+	name, ok := strings.CutPrefix(word, string(prefix))
+	if !ok || name == "" {
+		return "", false
+	}
+	for j, r := range name {
+		if j == 0 && !unicode.IsLetter(r) {
+			return "", false
+		}
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+			return "", false
+		}
+	}
+	return name, true
+}
+
+// matchRecurrence распознает повторение: every month on the 1st, every 2 weeks,
+// every friday, daily, каждый понедельник, каждые 3 дня, ежемесячно
+func (p *parser) matchRecurrence(i int) int {
+	if p.result.Recurrence != nil {
+		return 0
+	}
+
+	if frequency, ok := recurrenceWords[p.word(i)]; ok {
+		p.result.Recurrence = &models.Recurrence{Frequency: frequency, Interval: 1}
+		return 1 + p.matchRecurrenceAnchor(i+1)
+	}
+
+	if !everyWords[p.word(i)] {
+		return 0
+	}
+
+	n := 1
+	interval := 1
+	switch word := p.word(i + n); {
+	case word == "other":
+		interval = 2
+		n++
+	case isNumber(word):
+		interval, _ = strconv.Atoi(word)
+		n++
+	}
+
+	if weekday, ok := weekdays[p.word(i+n)]; ok && interval == 1 {
+		p.result.Recurrence = &models.Recurrence{
+			Frequency: models.RecurrenceWeekly,
+			Interval:  1,
+			Weekday:   strings.ToLower(weekday.String()),
+		}
+		return n + 1
+	}
+
+	u, ok := units[p.word(i+n)]
+	if !ok || u.frequency == "" || interval < 1 {
+		return 0
+	}
+	p.result.Recurrence = &models.Recurrence{Frequency: u.frequency, Interval: interval}
+	n++
+
+	return n + p.matchRecurrenceAnchor(i+n)
+}
+
+// matchRecurrenceAnchor распознает уточнение повторения: день недели для
+// weekly (on monday) и число месяца для monthly (on the 1st, 1 числа)
+func (p *parser) matchRecurrenceAnchor(i int) int {
+	recurrence := p.result.Recurrence
+
+	switch recurrence.Frequency {
+	case models.RecurrenceWeekly:
+		return p.withPrefix(i, onWords, func(i int) int {
+			weekday, ok := weekdays[p.word(i)]
+			if !ok {
+				return 0
+			}
+			recurrence.Weekday = strings.ToLower(weekday.String())
+			return 1
+		})
+	case models.RecurrenceMonthly:
+		day, n := p.monthDay(i)
+		if n > 0 {
+			recurrence.MonthDay = day
+		}
+		return n
+	}
+	return 0
+}
+
+// monthDay распознает число месяца: on the 1st, the 15th, 1 числа, 1-го числа
+func (p *parser) monthDay(i int) (int, int) {
+	n := 0
+	if p.word(i) == "on" {
+		n++
+	}
+	if p.word(i+n) == "the" {
+		n++
+	}
+
+	match := ordinalPattern.FindStringSubmatch(p.word(i + n))
+	if match == nil {
+		return 0, 0
+	}
+	day := atoi(match[1])
+	if day < 1 || day > 31 {
+		return 0, 0
+	}
+	hasSuffix := match[0] != match[1]
+
+	switch {
+	case p.word(i+n+1) == "числа":
+		return day, n + 2
+	case hasSuffix && strings.HasSuffix(match[0], "-го"):
+		return day, n + 1
+	case hasSuffix && n > 0:
+		// Английское число требует суффикса и предлога: on the 1st
+		return day, n + 1
+	}
+	return 0, 0
+}
+
+// matchRelative распознает относительный срок: in 3 days, in a week,
+// in 2 hours, через 2 дня, через неделю
+func (p *parser) matchRelative(i int) int {
+	word := p.word(i)
+	if word != "in" && word != "через" {
+		return 0
+	}
+
+	n := 1
+	count := 1
+	switch next := p.word(i + 1); {
+	case isNumber(next):
+		var err error
+		if count, err = strconv.Atoi(next); err != nil || count > maxRelativeYears*366 {
+			return 0
+		}
+		n++
+	case word == "in" && (next == "a" || next == "an"):
+		n++
+	case word == "in":
+		// "in" без числа - обычный предлог заголовка
+		return 0
+	}
+
+	u, ok := units[p.word(i+n)]
+	if !ok {
+		return 0
+	}
+
+	limit := p.today().AddDate(maxRelativeYears, 0, 0)
+
+	if u.duration > 0 {
+		if p.hasDate || p.hasTime || p.moment != nil {
+			return 0
+		}
+		moment := p.now.Add(time.Duration(count) * u.duration)
+		if moment.After(limit) {
+			return 0
+		}
+		p.moment = &moment
+		return n + 1
+	}
+
+	date := addPeriod(p.today(), u.frequency, count)
+	if date.After(limit) || !p.setDate(date) {
+		return 0
+	}
+	return n + 1
+}
+
+// matchDayWord распознает today, tomorrow, day after tomorrow, next week,
+// next month, сегодня, завтра, послезавтра, на следующей неделе, в следующем месяце
+func (p *parser) matchDayWord(i int) int {
+	today := p.today()
+
+	days := map[string]int{"today": 0, "tomorrow": 1, "сегодня": 0, "завтра": 1, "послезавтра": 2}
+	if offset, ok := days[p.word(i)]; ok {
+		if !p.setDate(today.AddDate(0, 0, offset)) {
+			return 0
+		}
+		return 1
+	}
+
+	if p.word(i) == "day" && p.word(i+1) == "after" && p.word(i+2) == "tomorrow" {
+		if !p.setDate(today.AddDate(0, 0, 2)) {
+			return 0
+		}
+		return 3
+	}
+
+	n := 0
+	if p.word(i) == "на" || p.word(i) == "в" {
+		n++
+	}
+	if !nextWords[p.word(i+n)] && p.word(i+n) != "следующем" {
+		return 0
+	}
+
+	switch p.word(i + n + 1) {
+	case "week", "неделе":
+		// Начало следующей недели - понедельник
+		offset := (int(time.Monday) - int(today.Weekday()) + 7) % 7
+		if offset == 0 {
+			offset = 7
+		}
+		if !p.setDate(today.AddDate(0, 0, offset)) {
+			return 0
+		}
+		return n + 2
+	case "month", "месяце":
+		if !p.setDate(time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location())) {
+			return 0
+		}
+		return n + 2
+	}
+	return 0
+}
+
+// matchWeekday распознает день недели: friday, on friday, next friday,
+// в пятницу, в следующий вторник. Без "next" выбирается ближайший день,
+// включая сегодняшний; с "next" - строго после сегодняшнего
+func (p *parser) matchWeekday(i int) int {
+	return p.withPrefix(i, onWords, func(i int) int {
+		n := 0
+		strict := false
+		if nextWords[p.word(i)] || p.word(i) == "this" {
+			strict = p.word(i) != "this"
+			n++
+		}
+
+		weekday, ok := weekdays[p.word(i+n)]
+		if !ok {
+			return 0
+		}
+
+		today := p.today()
+		offset := (int(weekday) - int(today.Weekday()) + 7) % 7
+		if strict && offset == 0 {
+			offset = 7
+		}
+		if !p.setDate(today.AddDate(0, 0, offset)) {
+			return 0
+		}
+		return n + 1
+	})
+}
+
+// matchDate распознает дату: 2024-03-15, 15.03, 15.03.2024, march 15,
+// 15 march, 15 марта, on the 1st, 1 числа. Дата без года в прошлом
+// переносится на следующий год
+func (p *parser) matchDate(i int) int {
+	return p.withPrefix(i, onWords, func(i int) int {
+		if date, n := p.explicitDate(i); n > 0 {
+			if !p.setDate(date) {
+				return 0
+			}
+			return n
+		}
+
+		day, n := p.monthDay(i)
+		if n == 0 {
+			return 0
+		}
+		if !p.setDate(nextMonthDay(p.today(), day)) {
+			return 0
+		}
+		return n
+	})
+}
+
+// explicitDate распознает дату с явным месяцем
+func (p *parser) explicitDate(i int) (time.Time, int) {
+	today := p.today()
+	word := p.word(i)
+
+	if match := isoDatePattern.FindStringSubmatch(word); match != nil {
+		if date, ok := makeDate(today.Location(), atoi(match[1]), atoi(match[2]), atoi(match[3])); ok {
+			return date, 1
+		}
+		return time.Time{}, 0
+	}
+
+	if match := dotDatePattern.FindStringSubmatch(word); match != nil {
+		if match[3] != "" {
+			if date, ok := makeDate(today.Location(), atoi(match[3]), atoi(match[2]), atoi(match[1])); ok {
+				return date, 1
+			}
+			return time.Time{}, 0
+		}
+		if date, ok := upcomingDate(today, time.Month(atoi(match[2])), atoi(match[1])); ok {
+			return date, 1
+		}
+		return time.Time{}, 0
+	}
+
+	// march 15 / 15 march / 15 марта с необязательным годом
+	var month time.Month
+	var dayWord string
+	if m, ok := months[word]; ok {
+		month, dayWord = m, p.word(i+1)
+	} else if m, ok := months[p.word(i+1)]; ok {
+		month, dayWord = m, word
+	} else {
+		return time.Time{}, 0
+	}
+
+	match := ordinalPattern.FindStringSubmatch(dayWord)
+	if match == nil {
+		return time.Time{}, 0
+	}
+	day := atoi(match[1])
+
+	if year := p.word(i + 2); len(year) == 4 && isNumber(year) {
+		if date, ok := makeDate(today.Location(), atoi(year), int(month), day); ok {
+			return date, 3
+		}
+		return time.Time{}, 0
+	}
+
+	if date, ok := upcomingDate(today, month, day); ok {
+		return date, 2
+	}
+	return time.Time{}, 0
+}
+
+// matchTime распознает время: 9am, 9:30 pm, 21:00, at 9, в 9 утра, в 18:30,
+// noon, midnight, в полдень, в полночь
+func (p *parser) matchTime(i int) int {
+	named := map[string]int{"noon": 12, "midnight": 0, "полдень": 12, "полночь": 0}
+
+	if atWords[p.word(i)] {
+		if hour, ok := named[p.word(i+1)]; ok && p.setTime(hour, 0) {
+			return 2
+		}
+		if n := p.clock(i+1, true); n > 0 {
+			return n + 1
+		}
+	}
+
+	if hour, ok := named[p.word(i)]; ok && p.setTime(hour, 0) {
+		return 1
+	}
+	return p.clock(i, false)
+}
+
+// clock распознает время суток, начиная со слова i. Час без минут и
+// без am/pm принимается только после предлога (at 9, в 9)
+func (p *parser) clock(i int, afterPreposition bool) int {
+	match := clockPattern.FindStringSubmatch(p.word(i))
+	if match == nil {
+		return 0
+	}
+
+	hour := atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute = atoi(match[2])
+	}
+
+	n := 1
+	meridiem := match[3]
+	if meridiem == "" {
+		if m, ok := meridiems[p.word(i+1)]; ok && (afterPreposition || m == p.word(i+1)) {
+			meridiem = m
+			n++
+		}
+	}
+
+	if match[2] == "" && meridiem == "" && !afterPreposition {
+		return 0
+	}
+
+	switch meridiem {
+	case "am":
+		if hour > 12 {
+			return 0
+		}
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour > 12 {
+			return 0
+		}
+		if hour < 12 {
+			hour += 12
+		}
+	}
+
+	if hour > 23 || minute > 59 || !p.setTime(hour, minute) {
+		return 0
+	}
+	return n
+}
+
+// finish вычисляет срок из найденных даты, времени и повторения и собирает заголовок
+func (p *parser) finish() {
+	if recurrence := p.result.Recurrence; recurrence != nil && !p.hasDate && p.moment == nil {
+		// Срок повторяющейся задачи - ее ближайшее вхождение
+		today := p.today()
+		switch {
+		case recurrence.Weekday != "":
+			for offset := 0; offset < 7; offset++ {
+				date := today.AddDate(0, 0, offset)
+				if strings.EqualFold(date.Weekday().String(), recurrence.Weekday) {
+					p.setDate(date)
+					break
+				}
+			}
+		case recurrence.MonthDay > 0:
+			p.setDate(nextMonthDay(today, recurrence.MonthDay))
+		case !p.hasTime:
+			p.setDate(today)
+		}
+	}
+
+	switch {
+	case p.moment != nil:
+		p.result.DueDate = p.moment
+	case p.hasTime:
+		date := p.today()
+		if p.hasDate {
+			date = p.date
+		}
+		due := time.Date(date.Year(), date.Month(), date.Day(), p.hour, p.minute, 0, 0, p.now.Location())
+		if !p.hasDate && due.Before(p.now) {
+			due = due.AddDate(0, 0, 1)
+		}
+		p.result.DueDate = &due
+	case p.hasDate:
+		day := time.Date(p.date.Year(), p.date.Month(), p.date.Day(), 0, 0, 0, 0, time.UTC)
+		p.result.DueDate = &day
+		p.result.DueAllDay = true
+	}
+
+	title := make([]string, 0, len(p.tokens))
+	for _, t := range p.tokens {
+		if !t.used {
+			title = append(title, t.raw)
+		}
+	}
+	p.result.Title = strings.Join(title, " ")
+}
+
+// addPeriod прибавляет count периодов frequency к дате
+func addPeriod(date time.Time, frequency models.RecurrenceFrequency, count int) time.Time {
+	switch frequency {
+	case models.RecurrenceWeekly:
+		return date.AddDate(0, 0, 7*count)
+	case models.RecurrenceMonthly:
+		return date.AddDate(0, count, 0)
+	case models.RecurrenceYearly:
+		return date.AddDate(count, 0, 0)
+	default:
+		return date.AddDate(0, 0, count)
+	}
+}
+
+// nextMonthDay возвращает ближайшую дату с числом day, начиная с today;
+// месяцы без такого числа пропускаются
+func nextMonthDay(today time.Time, day int) time.Time {
+	for offset := 0; offset <= 12; offset++ {
+		first := time.Date(today.Year(), today.Month()+time.Month(offset), 1, 0, 0, 0, 0, today.Location())
+		if date, ok := makeDate(today.Location(), first.Year(), int(first.Month()), day); ok && !date.Before(today) {
+			return date
+		}
+	}
+	return today
+}
+
+// upcomingDate возвращает дату month/day в этом году или в следующем, если она прошла
+func upcomingDate(today time.Time, month time.Month, day int) (time.Time, bool) {
+	date, ok := makeDate(today.Location(), today.Year(), int(month), day)
+	if !ok {
+		return time.Time{}, false
+	}
+	if date.Before(today) {
+		return makeDate(today.Location(), today.Year()+1, int(month), day)
+	}
+	return date, true
+}
+
+// makeDate создает дату, отвергая несуществующие (31 февраля)
+func makeDate(location *time.Location, year, month, day int) (time.Time, bool) {
+	if month < 1 || month > 12 || day < 1 || day > 31 {
+		return time.Time{}, false
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, location)
+	if date.Day() != day {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func isNumber(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, r := range word {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
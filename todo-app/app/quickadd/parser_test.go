@@ -0,0 +1,143 @@
+package quickadd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	"todo-app/app/models"
+)
+
+// referenceTime - среда, 10 января 2024, 15:00 по Москве
+func referenceTime(t *testing.T) time.Time {
+	t.Helper()
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skipf("tzdata is not available: %v", err)
+	}
+	return time.Date(2024, 1, 10, 15, 0, 0, 0, moscow)
+}
+
+func TestParse_Example(t *testing.T) {
+	now := referenceTime(t)
+
+	got := Parse("Pay rent every month on the 1st !high #home tomorrow 9am", now)
+
+	want := time.Date(2024, 1, 11, 9, 0, 0, 0, now.Location())
+	if got.Title != "Pay rent" {
+		t.Errorf("Expected title %q, got %q", "Pay rent", got.Title)
+	}
+	if got.Priority != models.PriorityHigh {
+		t.Errorf("Expected high priority, got %q", got.Priority)
+	}
+	if !reflect.DeepEqual(got.Tags, []string{"home"}) {
+		t.Errorf("Expected tags [home], got %v", got.Tags)
+	}
+	if got.DueDate == nil || !got.DueDate.Equal(want) || got.DueAllDay {
+		t.Errorf("Expected due %v with time, got %v (all day %v)", want, got.DueDate, got.DueAllDay)
+	}
+	if !reflect.DeepEqual(got.Recurrence, &models.Recurrence{Frequency: models.RecurrenceMonthly, Interval: 1, MonthDay: 1}) {
+		t.Errorf("Unexpected recurrence: %+v", got.Recurrence)
+	}
+}
+
+func TestParse_Dates(t *testing.T) {
+	now := referenceTime(t)
+	day := func(year int, month time.Month, d int) *time.Time {
+		date := time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+		return &date
+	}
+	at := func(month time.Month, d, hour, minute int) *time.Time {
+		date := time.Date(2024, month, d, hour, minute, 0, 0, now.Location())
+		return &date
+	}
+
+	tests := []struct {
+		input  string
+		title  string
+		due    *time.Time
+		allDay bool
+	}{
+		{"Report friday", "Report", day(2024, 1, 12), true},
+		{"Report wednesday", "Report", day(2024, 1, 10), true},
+		{"Report next wednesday", "Report", day(2024, 1, 17), true},
+		{"Plan in 3 weeks", "Plan", day(2024, 1, 31), true},
+		{"Plan next month", "Plan", day(2024, 2, 1), true},
+		{"Party on december 31st", "Party", day(2024, 12, 31), true},
+		{"Renew visa 5 january", "Renew visa", day(2025, 1, 5), true},
+		{"Deadline 2024-02-29", "Deadline", day(2024, 2, 29), true},
+		{"Invoice on the 1st", "Invoice", day(2024, 2, 1), true},
+		{"Call at 9", "Call", at(1, 11, 9, 0), false},
+		{"Call at 9:30 pm", "Call", at(1, 10, 21, 30), false},
+		{"Lunch day after tomorrow noon", "Lunch", at(1, 12, 12, 0), false},
+
+		{"Сдать отчет 15 марта", "Сдать отчет", day(2024, 3, 15), true},
+		{"Купить молоко завтра в 18:30", "Купить молоко", at(1, 11, 18, 30), false},
+		{"Позвонить в пятницу в 7 вечера", "Позвонить", at(1, 12, 19, 0), false},
+		{"Встреча через 2 часа", "Встреча", at(1, 10, 17, 0), false},
+		{"Отпуск на следующей неделе", "Отпуск", day(2024, 1, 15), true},
+		{"Налоги 25.03", "Налоги", day(2024, 3, 25), true},
+
+		// Обычные слова и числа остаются в заголовке
+		{"Buy 2 apples in the shop", "Buy 2 apples in the shop", nil, false},
+		{"Купить хлеб в магазине", "Купить хлеб в магазине", nil, false},
+		{"Fix 1st draft", "Fix 1st draft", nil, false},
+
+		// Относительный срок дальше 10 лет не распознается
+		{"Plan in 99999999999 days", "Plan in 99999999999 days", nil, false},
+		{"Plan in 11 years", "Plan in 11 years", nil, false},
+		{"Ждать через 99999999999999999999 часов", "Ждать через 99999999999999999999 часов", nil, false},
+		{"Plan in 10 years", "Plan", day(2034, 1, 10), true},
+	}
+
+	for _, tt := range tests {
+		got := Parse(tt.input, now)
+
+		if got.Title != tt.title {
+			t.Errorf("%q: expected title %q, got %q", tt.input, tt.title, got.Title)
+		}
+		switch {
+		case tt.due == nil && got.DueDate != nil:
+			t.Errorf("%q: expected no due date, got %v", tt.input, got.DueDate)
+		case tt.due != nil && (got.DueDate == nil || !got.DueDate.Equal(*tt.due)):
+			t.Errorf("%q: expected due %v, got %v", tt.input, tt.due, got.DueDate)
+		}
+		if got.DueAllDay != tt.allDay {
+			t.Errorf("%q: expected all day %v, got %v", tt.input, tt.allDay, got.DueAllDay)
+		}
+	}
+}
+
+func TestParse_MarkersAndRecurrence(t *testing.T) {
+	now := referenceTime(t)
+
+	got := Parse("Тренировка каждый понедельник в 7 утра !низкий +Спорт #зал", now)
+
+	if got.Title != "Тренировка" || got.Priority != models.PriorityLow || got.Project != "Спорт" {
+		t.Errorf("Unexpected result: %+v", got)
+	}
+	if !reflect.DeepEqual(got.Tags, []string{"зал"}) {
+		t.Errorf("Expected tags [зал], got %v", got.Tags)
+	}
+	if got.Recurrence == nil || got.Recurrence.Frequency != models.RecurrenceWeekly || got.Recurrence.Weekday != "monday" {
+		t.Errorf("Expected weekly on monday, got %+v", got.Recurrence)
+	}
+	// Без явной даты срок - ближайшее вхождение повторения
+	if want := time.Date(2024, 1, 15, 7, 0, 0, 0, now.Location()); got.DueDate == nil || !got.DueDate.Equal(want) {
+		t.Errorf("Expected due %v, got %v", want, got.DueDate)
+	}
+
+	every := Parse("Water plants every 3 days", now)
+	if !reflect.DeepEqual(every.Recurrence, &models.Recurrence{Frequency: models.RecurrenceDaily, Interval: 3}) {
+		t.Errorf("Unexpected recurrence: %+v", every.Recurrence)
+	}
+
+	plain := Parse("Write tests", now)
+	if plain.Priority != "" || plain.Tags != nil || plain.Recurrence != nil || plain.DueDate != nil {
+		t.Errorf("Plain title should not produce attributes: %+v", plain)
+	}
+
+	req := got.CreateTaskRequest()
+	if req.Title != got.Title || req.Project != "Спорт" || req.DueDate != got.DueDate {
+		t.Errorf("CreateTaskRequest should carry parsed fields: %+v", req)
+	}
+}
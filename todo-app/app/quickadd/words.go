@@ -0,0 +1,116 @@
+package quickadd
+
+import (
+	"time"
+	"todo-app/app/models"
+)
+
+// Словари распознаваемых слов. Слова сравниваются в нижнем регистре
+// без завершающих знаков препинания
+
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday,
+
+	"понедельник": time.Monday, "пн": time.Monday,
+	"вторник": time.Tuesday, "вт": time.Tuesday,
+	"среда": time.Wednesday, "среду": time.Wednesday, "ср": time.Wednesday,
+	"четверг": time.Thursday, "чт": time.Thursday,
+	"пятница": time.Friday, "пятницу": time.Friday, "пт": time.Friday,
+	"суббота": time.Saturday, "субботу": time.Saturday, "сб": time.Saturday,
+	"воскресенье": time.Sunday, "вс": time.Sunday,
+}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+
+	"января": time.January, "январь": time.January,
+	"февраля": time.February, "февраль": time.February,
+	"марта": time.March, "март": time.March,
+	"апреля": time.April, "апрель": time.April,
+	"мая": time.May, "май": time.May,
+	"июня": time.June, "июнь": time.June,
+	"июля": time.July, "июль": time.July,
+	"августа": time.August, "август": time.August,
+	"сентября": time.September, "сентябрь": time.September,
+	"октября": time.October, "октябрь": time.October,
+	"ноября": time.November, "ноябрь": time.November,
+	"декабря": time.December, "декабрь": time.December,
+}
+
+// unit - единица относительного срока или период повторения
+type unit struct {
+	frequency models.RecurrenceFrequency // пусто для часов и минут
+	duration  time.Duration              // для часов и минут
+}
+
+var units = map[string]unit{
+	"day": {frequency: models.RecurrenceDaily}, "days": {frequency: models.RecurrenceDaily},
+	"week": {frequency: models.RecurrenceWeekly}, "weeks": {frequency: models.RecurrenceWeekly},
+	"month": {frequency: models.RecurrenceMonthly}, "months": {frequency: models.RecurrenceMonthly},
+	"year": {frequency: models.RecurrenceYearly}, "years": {frequency: models.RecurrenceYearly},
+	"hour": {duration: time.Hour}, "hours": {duration: time.Hour},
+	"minute": {duration: time.Minute}, "minutes": {duration: time.Minute}, "min": {duration: time.Minute}, "mins": {duration: time.Minute},
+
+	"день": {frequency: models.RecurrenceDaily}, "дня": {frequency: models.RecurrenceDaily}, "дней": {frequency: models.RecurrenceDaily},
+	"неделя": {frequency: models.RecurrenceWeekly}, "неделю": {frequency: models.RecurrenceWeekly},
+	"недели": {frequency: models.RecurrenceWeekly}, "недель": {frequency: models.RecurrenceWeekly},
+	"месяц": {frequency: models.RecurrenceMonthly}, "месяца": {frequency: models.RecurrenceMonthly}, "месяцев": {frequency: models.RecurrenceMonthly},
+	"год": {frequency: models.RecurrenceYearly}, "года": {frequency: models.RecurrenceYearly}, "лет": {frequency: models.RecurrenceYearly},
+	"час": {duration: time.Hour}, "часа": {duration: time.Hour}, "часов": {duration: time.Hour},
+	"минуту": {duration: time.Minute}, "минуты": {duration: time.Minute}, "минут": {duration: time.Minute},
+}
+
+var priorities = map[string]models.Priority{
+	"!high": models.PriorityHigh, "!h": models.PriorityHigh, "!1": models.PriorityHigh, "!!!": models.PriorityHigh,
+	"!medium": models.PriorityMedium, "!m": models.PriorityMedium, "!2": models.PriorityMedium, "!!": models.PriorityMedium,
+	"!low": models.PriorityLow, "!l": models.PriorityLow, "!3": models.PriorityLow,
+
+	"!высокий": models.PriorityHigh, "!важно": models.PriorityHigh,
+	"!средний": models.PriorityMedium,
+	"!низкий":  models.PriorityLow,
+}
+
+// Слова повторения без числа: daily, еженедельно...
+var recurrenceWords = map[string]models.RecurrenceFrequency{
+	"daily": models.RecurrenceDaily, "weekly": models.RecurrenceWeekly,
+	"monthly": models.RecurrenceMonthly, "yearly": models.RecurrenceYearly, "annually": models.RecurrenceYearly,
+
+	"ежедневно": models.RecurrenceDaily, "еженедельно": models.RecurrenceWeekly,
+	"ежемесячно": models.RecurrenceMonthly, "ежегодно": models.RecurrenceYearly,
+}
+
+// Уточнения времени суток после часа: 9 утра, 7 вечера
+var meridiems = map[string]string{
+	"am": "am", "a.m": "am", "утра": "am", "ночи": "am",
+	"pm": "pm", "p.m": "pm", "дня": "pm", "вечера": "pm",
+}
+
+var everyWords = setOf("every", "каждый", "каждую", "каждое", "каждые")
+var nextWords = setOf("next", "следующий", "следующую", "следующее", "следующей")
+var onWords = setOf("on", "в", "во")
+var atWords = setOf("at", "в")
+
+func setOf(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
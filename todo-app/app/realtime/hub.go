@@ -0,0 +1,249 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+	"todo-app/app/events"
+	"todo-app/app/models"
+	"todo-app/internal/utils"
+)
+
+// TaskLoader загружает актуальное состояние задачи
+type TaskLoader interface {
+	GetByID(ctx context.Context, id int) (*models.Task, error)
+}
+
+// Subscriber получает пачки изменений. Вызывается последовательно, в порядке Seq,
+// и не должен блокироваться: медленные получатели буферизуют пачки сами
+type Subscriber func(batch models.TaskChangeBatch)
+
+// Config содержит настройки группировки изменений
+type Config struct {
+	Debounce time.Duration // пауза без изменений, после которой пачка отправляется
+	MaxDelay time.Duration // максимальная задержка первого изменения в пачке
+}
+
+// Hub собирает изменения задач из шины событий и LISTEN/NOTIFY, объединяет
+// их по задаче и рассылает подписчикам пачками с последовательными номерами
+type Hub struct {
+	loader TaskLoader
+	config Config
+	logger *utils.Logger
+
+	mu           sync.Mutex
+	pending      map[int]*models.TaskChange
+	order        []int
+	resync       bool
+	timer        *time.Timer
+	firstPending time.Time
+
+	flushMu     sync.Mutex
+	seq         uint64
+	subscribers map[uint64]Subscriber
+	nextID      uint64
+}
+
+// NewHub создает новый хаб изменений
+func NewHub(loader TaskLoader, config Config, logger *utils.Logger) *Hub {
+	if config.Debounce <= 0 {
+		config.Debounce = 100 * time.Millisecond
+	}
+	if config.MaxDelay < config.Debounce {
+		config.MaxDelay = 10 * config.Debounce
+	}
+	if logger == nil {
+		logger = utils.DefaultLogger()
+	}
+
+	return &Hub{
+		loader:      loader,
+		config:      config,
+		logger:      logger,
+		pending:     make(map[int]*models.TaskChange),
+		subscribers: make(map[uint64]Subscriber),
+	}
+}
+
+// Attach подписывает хаб на события задач из шины
+func (h *Hub) Attach(bus *events.Bus) func() {
+	return bus.Subscribe(events.AllEvents, func(ctx context.Context, event events.Event) error {
+		taskEvent, ok := event.(events.TaskEvent)
+		if !ok {
+			return nil
+		}
+
+		id, task := taskEvent.Subject()
+		switch event.Type() {
+		case events.TypeTaskCreated:
+			h.Push(models.TaskChange{Kind: models.TaskChangeAdded, TaskID: id, Task: task})
+		case events.TypeTaskDeleted:
+			h.Push(models.TaskChange{Kind: models.TaskChangeRemoved, TaskID: id})
+		default:
+			h.Push(models.TaskChange{Kind: models.TaskChangeChanged, TaskID: id, Task: task})
+		}
+		return nil
+	})
+}
+
+// Subscribe регистрирует подписчика и возвращает функцию отписки
+func (h *Hub) Subscribe(subscriber Subscriber) func() {
+	h.flushMu.Lock()
+	defer h.flushMu.Unlock()
+
+	h.nextID++
+	id := h.nextID
+	h.subscribers[id] = subscriber
+
+	return func() {
+		h.flushMu.Lock()
+		defer h.flushMu.Unlock()
+		delete(h.subscribers, id)
+	}
+}
+
+// Seq возвращает номер последней отправленной пачки
+func (h *Hub) Seq() uint64 {
+	h.flushMu.Lock()
+	defer h.flushMu.Unlock()
+	return h.seq
+}
+
+// Push добавляет изменение в текущую пачку. Изменение без Task
+// (например, из NOTIFY) будет дополнено актуальным состоянием при отправке
+func (h *Hub) Push(change models.TaskChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	existing, ok := h.pending[change.TaskID]
+	if !ok {
+		h.pending[change.TaskID] = &change
+		h.order = append(h.order, change.TaskID)
+		h.schedule()
+		return
+	}
+
+	switch {
+	case change.Kind == models.TaskChangeRemoved:
+		existing.Kind = models.TaskChangeRemoved
+		existing.Task = nil
+	case existing.Kind == models.TaskChangeAdded:
+		// Задача еще не была отправлена клиентам - остается добавлением
+		existing.Task = change.Task
+	case existing.Kind == models.TaskChangeRemoved:
+		// Удаленная и снова появившаяся задача для клиента - изменение
+		existing.Kind = models.TaskChangeChanged
+		existing.Task = change.Task
+	default:
+		existing.Kind = change.Kind
+		existing.Task = change.Task
+	}
+
+	h.schedule()
+}
+
+// RequestResync просит клиентов заново загрузить список задач
+// (например, после переподключения к БД, когда уведомления могли быть потеряны)
+func (h *Hub) RequestResync(reason string) {
+	h.logger.Info("Realtime resync requested", map[string]interface{}{
+		"reason": reason,
+	})
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.resync = true
+	h.schedule()
+}
+
+// schedule откладывает отправку пачки: каждое изменение продлевает ожидание
+// на Debounce, но не дольше MaxDelay с момента первого изменения
+func (h *Hub) schedule() {
+	if h.timer == nil {
+		h.firstPending = time.Now()
+		h.timer = time.AfterFunc(h.config.Debounce, h.Flush)
+		return
+	}
+
+	if time.Since(h.firstPending)+h.config.Debounce <= h.config.MaxDelay {
+		h.timer.Reset(h.config.Debounce)
+	}
+}
+
+// Flush немедленно отправляет накопленные изменения
+func (h *Hub) Flush() {
+	h.flushMu.Lock()
+	defer h.flushMu.Unlock()
+
+	h.mu.Lock()
+	if h.timer != nil {
+		h.timer.Stop()
+		h.timer = nil
+	}
+	pending, order, resync := h.pending, h.order, h.resync
+	h.pending = make(map[int]*models.TaskChange)
+	h.order = nil
+	h.resync = false
+	h.mu.Unlock()
+
+	if len(order) == 0 && !resync {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	changes := make([]models.TaskChange, 0, len(order))
+	for _, id := range order {
+		change := pending[id]
+
+		if change.Kind != models.TaskChangeRemoved && change.Task == nil {
+			task, err := h.loader.GetByID(ctx, id)
+			if err != nil {
+				// Состояние задачи неизвестно - клиенты перечитают список целиком
+				h.logger.Warn("Failed to load changed task, requesting resync", map[string]interface{}{
+					"task_id": id,
+					"error":   err.Error(),
+				})
+				resync = true
+				continue
+			}
+			change.Task = task
+		}
+
+		changes = append(changes, *change)
+	}
+
+	h.seq++
+	batch := models.TaskChangeBatch{
+		Seq:     h.seq,
+		Changes: changes,
+		Resync:  resync,
+	}
+
+	for _, subscriber := range h.subscribers {
+		h.deliver(subscriber, batch)
+	}
+}
+
+// deliver вызывает подписчика с изоляцией паники
+func (h *Hub) deliver(subscriber Subscriber, batch models.TaskChangeBatch) {
+	defer func() {
+		if r := recover(); r != nil {
+			h.logger.Error("Realtime subscriber panicked", map[string]interface{}{
+				"seq":   batch.Seq,
+				"panic": fmt.Sprintf("%v", r),
+				"stack": string(debug.Stack()),
+			})
+		}
+	}()
+
+	subscriber(batch)
+}
+
+// Close отправляет оставшиеся изменения
+func (h *Hub) Close() {
+	h.Flush()
+}
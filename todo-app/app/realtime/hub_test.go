@@ -0,0 +1,145 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+	"todo-app/app/models"
+)
+
+// stubLoader возвращает задачи из памяти
+type stubLoader struct {
+	mu    sync.Mutex
+	tasks map[int]*models.Task
+	calls int
+}
+
+func (l *stubLoader) GetByID(ctx context.Context, id int) (*models.Task, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls++
+	task, ok := l.tasks[id]
+	if !ok {
+		return nil, fmt.Errorf("task with id %d not found", id)
+	}
+	return task, nil
+}
+
+// collect подписывается на хаб и собирает пачки
+func collect(hub *Hub) (func() []models.TaskChangeBatch, func()) {
+	var mu sync.Mutex
+	var batches []models.TaskChangeBatch
+	unsubscribe := hub.Subscribe(func(batch models.TaskChangeBatch) {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, batch)
+	})
+	return func() []models.TaskChangeBatch {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]models.TaskChangeBatch(nil), batches...)
+	}, unsubscribe
+}
+
+func TestHub_CoalescesChangesPerTask(t *testing.T) {
+	loader := &stubLoader{tasks: map[int]*models.Task{
+		2: {ID: 2, Title: "Changed externally"},
+	}}
+	hub := NewHub(loader, Config{Debounce: time.Hour, MaxDelay: time.Hour}, nil)
+	batches, unsubscribe := collect(hub)
+	defer unsubscribe()
+
+	// Создание и последующее изменение до отправки остаются добавлением
+	hub.Push(models.TaskChange{Kind: models.TaskChangeAdded, TaskID: 1, Task: &models.Task{ID: 1, Title: "v1"}})
+	hub.Push(models.TaskChange{Kind: models.TaskChangeChanged, TaskID: 1, Task: &models.Task{ID: 1, Title: "v2"}})
+
+	// Несколько NOTIFY об одной задаче загружают ее один раз
+	hub.Push(models.TaskChange{Kind: models.TaskChangeChanged, TaskID: 2})
+	hub.Push(models.TaskChange{Kind: models.TaskChangeChanged, TaskID: 2})
+
+	// Удаление перекрывает предыдущие изменения
+	hub.Push(models.TaskChange{Kind: models.TaskChangeChanged, TaskID: 3, Task: &models.Task{ID: 3}})
+	hub.Push(models.TaskChange{Kind: models.TaskChangeRemoved, TaskID: 3})
+
+	hub.Flush()
+
+	got := batches()
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 batch, got %d", len(got))
+	}
+
+	batch := got[0]
+	if batch.Seq != 1 || batch.Resync || len(batch.Changes) != 3 {
+		t.Fatalf("Unexpected batch: %+v", batch)
+	}
+
+	if c := batch.Changes[0]; c.Kind != models.TaskChangeAdded || c.Task.Title != "v2" {
+		t.Errorf("Expected task 1 added with latest state, got %+v", c)
+	}
+	if c := batch.Changes[1]; c.Kind != models.TaskChangeChanged || c.Task == nil || c.Task.Title != "Changed externally" {
+		t.Errorf("Expected task 2 loaded from repository, got %+v", c)
+	}
+	if c := batch.Changes[2]; c.Kind != models.TaskChangeRemoved || c.Task != nil {
+		t.Errorf("Expected task 3 removed, got %+v", c)
+	}
+	if loader.calls != 1 {
+		t.Errorf("Expected 1 repository call, got %d", loader.calls)
+	}
+}
+
+func TestHub_DebouncesAndNumbersBatches(t *testing.T) {
+	hub := NewHub(&stubLoader{}, Config{Debounce: 20 * time.Millisecond, MaxDelay: 200 * time.Millisecond}, nil)
+	batches, unsubscribe := collect(hub)
+	defer unsubscribe()
+
+	for i := 1; i <= 5; i++ {
+		hub.Push(models.TaskChange{Kind: models.TaskChangeAdded, TaskID: i, Task: &models.Task{ID: i}})
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for len(batches()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	got := batches()
+	if len(got) != 1 || len(got[0].Changes) != 5 {
+		t.Fatalf("Expected a single debounced batch with 5 changes, got %+v", got)
+	}
+
+	hub.Push(models.TaskChange{Kind: models.TaskChangeRemoved, TaskID: 1})
+	hub.Flush()
+
+	got = batches()
+	if len(got) != 2 || got[1].Seq != got[0].Seq+1 || hub.Seq() != got[1].Seq {
+		t.Errorf("Expected consecutive sequence numbers, got %+v", got)
+	}
+}
+
+func TestHub_RequestsResync(t *testing.T) {
+	hub := NewHub(&stubLoader{tasks: map[int]*models.Task{}}, Config{Debounce: time.Hour, MaxDelay: time.Hour}, nil)
+	batches, unsubscribe := collect(hub)
+	defer unsubscribe()
+
+	hub.RequestResync("test")
+	hub.Flush()
+
+	// Задачу не удалось загрузить - клиенты должны перечитать список
+	hub.Push(models.TaskChange{Kind: models.TaskChangeChanged, TaskID: 42})
+	hub.Flush()
+
+	// Пустой сброс не создает пачку
+	hub.Flush()
+
+	got := batches()
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 batches, got %d", len(got))
+	}
+
+	for _, batch := range got {
+		if !batch.Resync || len(batch.Changes) != 0 {
+			t.Errorf("Expected resync batch without changes, got %+v", batch)
+		}
+	}
+}
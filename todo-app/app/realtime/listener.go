@@ -0,0 +1,147 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+	"todo-app/app/models"
+	"todo-app/internal/utils"
+
+	"github.com/lib/pq"
+)
+
+// NotifyChannel канал PostgreSQL, в который триггер tasks пишет изменения
+const NotifyChannel = "task_changes"
+
+// notification представляет полезную нагрузку NOTIFY от триггера tasks
+type notification struct {
+	Op string `json:"op"` // INSERT, UPDATE, DELETE
+	ID int    `json:"id"`
+}
+
+// PostgresListener слушает LISTEN/NOTIFY, чтобы видеть изменения,
+// сделанные другими процессами напрямую в БД
+type PostgresListener struct {
+	dsn    string
+	hub    *Hub
+	logger *utils.Logger
+
+	listener *pq.Listener
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// NewPostgresListener создает слушателя уведомлений об изменениях задач
+func NewPostgresListener(dsn string, hub *Hub, logger *utils.Logger) *PostgresListener {
+	if logger == nil {
+		logger = utils.DefaultLogger()
+	}
+
+	return &PostgresListener{
+		dsn:    dsn,
+		hub:    hub,
+		logger: logger,
+	}
+}
+
+// Start подключается к БД и начинает прослушивание
+func (l *PostgresListener) Start() error {
+	l.listener = pq.NewListener(l.dsn, time.Second, time.Minute, l.handleEvent)
+
+	if err := l.listener.Listen(NotifyChannel); err != nil {
+		l.listener.Close()
+		return fmt.Errorf("failed to listen on %s: %w", NotifyChannel, err)
+	}
+
+	l.stop = make(chan struct{})
+	l.done = make(chan struct{})
+	go l.run()
+
+	return nil
+}
+
+// Stop прекращает прослушивание и закрывает соединение
+func (l *PostgresListener) Stop() {
+	if l.stop == nil {
+		return
+	}
+
+	close(l.stop)
+	<-l.done
+	l.stop = nil
+
+	if err := l.listener.Close(); err != nil {
+		l.logger.Warn("Failed to close task change listener", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+}
+
+// Running сообщает, запущено ли прослушивание
+func (l *PostgresListener) Running() bool {
+	return l.stop != nil
+}
+
+// run обрабатывает уведомления и периодически проверяет соединение
+func (l *PostgresListener) run() {
+	defer close(l.done)
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case n := <-l.listener.Notify:
+			if n == nil {
+				// pq отправляет nil после переподключения: уведомления за время разрыва потеряны
+				l.hub.RequestResync("database listener reconnected")
+				continue
+			}
+			l.handleNotification(n.Extra)
+		case <-time.After(90 * time.Second):
+			go l.listener.Ping()
+		}
+	}
+}
+
+// handleNotification преобразует уведомление в изменение задачи
+func (l *PostgresListener) handleNotification(payload string) {
+	var n notification
+	if err := json.Unmarshal([]byte(payload), &n); err != nil || n.ID <= 0 {
+		l.logger.Warn("Invalid task change notification", map[string]interface{}{
+			"payload": payload,
+		})
+		return
+	}
+
+	change := models.TaskChange{TaskID: n.ID}
+	switch n.Op {
+	case "INSERT":
+		change.Kind = models.TaskChangeAdded
+	case "DELETE":
+		change.Kind = models.TaskChangeRemoved
+	default:
+		change.Kind = models.TaskChangeChanged
+	}
+
+	l.hub.Push(change)
+}
+
+// handleEvent логирует состояние соединения слушателя
+func (l *PostgresListener) handleEvent(event pq.ListenerEventType, err error) {
+	switch event {
+	case pq.ListenerEventDisconnected:
+		fields := map[string]interface{}{}
+		if err != nil {
+			fields["error"] = err.Error()
+		}
+		l.logger.Warn("Task change listener disconnected", fields)
+	case pq.ListenerEventReconnected:
+		l.logger.Info("Task change listener reconnected")
+	case pq.ListenerEventConnectionAttemptFailed:
+		if err != nil {
+			l.logger.Debug("Task change listener reconnect attempt failed", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+}
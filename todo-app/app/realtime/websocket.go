@@ -0,0 +1,488 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+	"todo-app/app/models"
+	"todo-app/internal/utils"
+
+	"github.com/gorilla/websocket"
+)
+
+// Типы сообщений WebSocket протокола
+const (
+	// От клиента
+	MessageSubscribe = "subscribe" // установить или заменить фильтр подписки
+
+	// От сервера
+	MessageSnapshot = "snapshot" // полный список подходящих задач, заменяет состояние клиента
+	MessageAdd      = "add"      // задача начала подходить под фильтр
+	MessageChange   = "change"   // подходящая задача изменилась
+	MessageRemove   = "remove"   // задача удалена или перестала подходить под фильтр
+	MessageError    = "error"    // ошибка обработки сообщения клиента
+)
+
+// TaskQuerier загружает задачи, подходящие под фильтр
+type TaskQuerier interface {
+	GetAll(ctx context.Context, filter models.TaskFilter, sort models.TaskSort) ([]*models.Task, error)
+}
+
+// ClientMessage представляет сообщение от WebSocket клиента
+type ClientMessage struct {
+	Type   string            `json:"type"`
+	Filter models.TaskFilter `json:"filter"`
+}
+
+// ServerMessage представляет сообщение WebSocket клиенту
+type ServerMessage struct {
+	Type   string         `json:"type"`
+	Seq    uint64         `json:"seq"` // номер пачки хаба, после которой отправлено сообщение
+	TaskID int            `json:"task_id,omitempty"`
+	Task   *models.Task   `json:"task,omitempty"`
+	Tasks  []*models.Task `json:"tasks,omitempty"` // только для snapshot, отсутствует при пустом списке
+	Error  string         `json:"error,omitempty"`
+}
+
+// WebSocketConfig содержит настройки WebSocket соединений
+type WebSocketConfig struct {
+	PingInterval time.Duration // интервал heartbeat
+	PongTimeout  time.Duration // время ожидания ответа на ping
+	WriteTimeout time.Duration // таймаут записи одного сообщения
+	SendBuffer   int           // размер очереди исходящих сообщений одного клиента
+	MaxMessage   int64         // максимальный размер сообщения клиента в байтах
+	MaxDeferred  int           // максимум изменений, отложенных во время подготовки снимка
+
+	SnapshotRetry utils.RetryPolicy // задержка повторной загрузки снимка после ошибки
+}
+
+// WebSocketHandler обслуживает WebSocket подписки внешних клиентов.
+// Клиент отправляет subscribe с TaskFilter, получает snapshot и затем
+// инкрементальные add/change/remove для задач, подходящих под фильтр
+type WebSocketHandler struct {
+	hub      *Hub
+	tasks    TaskQuerier
+	config   WebSocketConfig
+	logger   *utils.Logger
+	upgrader websocket.Upgrader
+	now      func() time.Time
+
+	mu      sync.Mutex
+	clients map[*wsClient]struct{}
+}
+
+// NewWebSocketHandler создает обработчик WebSocket подписок. Проверка Origin
+// и API ключа выполняется middleware сервера, поэтому upgrader ее не повторяет
+func NewWebSocketHandler(hub *Hub, tasks TaskQuerier, config WebSocketConfig, logger *utils.Logger) *WebSocketHandler {
+	if config.PingInterval <= 0 {
+		config.PingInterval = 30 * time.Second
+	}
+	if config.PongTimeout <= config.PingInterval {
+		config.PongTimeout = config.PingInterval * 2
+	}
+	if config.WriteTimeout <= 0 {
+		config.WriteTimeout = 10 * time.Second
+	}
+	if config.SendBuffer <= 0 {
+		config.SendBuffer = 256
+	}
+	if config.MaxMessage <= 0 {
+		config.MaxMessage = 64 << 10
+	}
+	if config.MaxDeferred <= 0 {
+		config.MaxDeferred = 1000
+	}
+	if config.SnapshotRetry.InitialBackoff <= 0 {
+		config.SnapshotRetry = utils.RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 30 * time.Second}
+	}
+	if logger == nil {
+		logger = utils.DefaultLogger()
+	}
+
+	return &WebSocketHandler{
+		hub:    hub,
+		tasks:  tasks,
+		config: config,
+		logger: logger,
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		now:     time.Now,
+		clients: make(map[*wsClient]struct{}),
+	}
+}
+
+// ServeHTTP выполняет upgrade соединения и обслуживает его до отключения клиента
+func (h *WebSocketHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrader уже отправил клиенту ответ с ошибкой
+		h.logger.WarnContext(r.Context(), "WebSocket upgrade failed", map[string]interface{}{
+			"remote_addr": r.RemoteAddr,
+			"error":       err.Error(),
+		})
+		return
+	}
+
+	client := &wsClient{
+		handler:    h,
+		conn:       conn,
+		remoteAddr: r.RemoteAddr,
+		send:       make(chan ServerMessage, h.config.SendBuffer),
+		resync:     make(chan struct{}, 1),
+		done:       make(chan struct{}),
+	}
+
+	h.mu.Lock()
+	h.clients[client] = struct{}{}
+	h.mu.Unlock()
+
+	unsubscribe := h.hub.Subscribe(client.handleBatch)
+
+	h.logger.InfoContext(r.Context(), "WebSocket client connected", map[string]interface{}{
+		"remote_addr": r.RemoteAddr,
+	})
+
+	go client.writeLoop()
+	client.readLoop()
+
+	unsubscribe()
+	close(client.done)
+	client.stopRetry()
+	conn.Close()
+
+	h.mu.Lock()
+	delete(h.clients, client)
+	h.mu.Unlock()
+
+	h.logger.InfoContext(r.Context(), "WebSocket client disconnected", map[string]interface{}{
+		"remote_addr": r.RemoteAddr,
+	})
+}
+
+// Clients возвращает количество подключенных клиентов
+func (h *WebSocketHandler) Clients() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
+
+// Close закрывает все соединения. http.Server.Shutdown не закрывает
+// соединения после upgrade, поэтому сервер вызывает Close при остановке
+func (h *WebSocketHandler) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	deadline := time.Now().Add(time.Second)
+	message := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutdown")
+	for client := range h.clients {
+		client.conn.WriteControl(websocket.CloseMessage, message, deadline)
+		client.conn.Close()
+	}
+}
+
+// wsClient представляет одно WebSocket соединение
+type wsClient struct {
+	handler    *WebSocketHandler
+	conn       *websocket.Conn
+	remoteAddr string
+
+	send   chan ServerMessage // очередь исходящих сообщений
+	resync chan struct{}      // запрос на отправку нового снимка
+	done   chan struct{}
+
+	mu          sync.Mutex
+	subscribed  bool
+	filter      models.TaskFilter
+	visible     map[int]bool        // задачи, которые есть у клиента
+	syncing     bool                // снимок запрошен, изменения откладываются
+	deferred    []models.TaskChange // изменения, пришедшие во время подготовки снимка
+	deferredSeq uint64
+	dropped     bool        // отложенные изменения отброшены, нужен еще один снимок
+	failures    int         // подряд неудачных загрузок снимка
+	retry       *time.Timer // повторная загрузка снимка после ошибки
+}
+
+// readLoop читает сообщения клиента и продлевает таймаут по pong
+func (c *wsClient) readLoop() {
+	cfg := c.handler.config
+
+	c.conn.SetReadLimit(cfg.MaxMessage)
+	c.conn.SetReadDeadline(time.Now().Add(cfg.PongTimeout))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(cfg.PongTimeout))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var msg ClientMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			c.enqueue(ServerMessage{Type: MessageError, Error: "invalid message format"})
+			continue
+		}
+
+		switch msg.Type {
+		case MessageSubscribe:
+			c.mu.Lock()
+			c.subscribed = true
+			c.filter = msg.Filter
+			c.markSyncingLocked()
+			c.mu.Unlock()
+		default:
+			c.enqueue(ServerMessage{Type: MessageError, Error: fmt.Sprintf("unknown message type: %q", msg.Type)})
+		}
+	}
+}
+
+// writeLoop единственный писатель в соединение: отправляет сообщения,
+// снимки и heartbeat. Ошибка записи закрывает соединение
+func (c *wsClient) writeLoop() {
+	ticker := time.NewTicker(c.handler.config.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case msg := <-c.send:
+			if err := c.write(msg); err != nil {
+				c.conn.Close()
+				return
+			}
+		case <-c.resync:
+			select {
+			case <-c.done:
+				return
+			default:
+			}
+			if err := c.sendSnapshot(); err != nil {
+				c.conn.Close()
+				return
+			}
+		case <-ticker.C:
+			deadline := time.Now().Add(c.handler.config.WriteTimeout)
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				c.conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// write отправляет одно сообщение с таймаутом записи
+func (c *wsClient) write(msg ServerMessage) error {
+	c.conn.SetWriteDeadline(time.Now().Add(c.handler.config.WriteTimeout))
+	return c.conn.WriteJSON(msg)
+}
+
+// handleBatch переводит пачку хаба в сообщения для фильтра клиента.
+// Вызывается хабом и не блокируется: при переполнении очереди клиент
+// получит новый снимок вместо потерянных сообщений
+func (c *wsClient) handleBatch(batch models.TaskChangeBatch) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.subscribed {
+		return
+	}
+
+	if batch.Resync {
+		c.markSyncingLocked()
+		return
+	}
+
+	if c.syncing {
+		c.deferLocked(batch)
+		return
+	}
+
+	for _, msg := range c.diffLocked(batch.Changes, batch.Seq) {
+		select {
+		case c.send <- msg:
+		default:
+			c.handler.logger.Warn("WebSocket client is too slow, sending snapshot instead of changes", map[string]interface{}{
+				"remote_addr": c.remoteAddr,
+				"seq":         batch.Seq,
+			})
+			c.markSyncingLocked()
+			return
+		}
+	}
+}
+
+// diffLocked вычисляет сообщения для клиента и обновляет набор видимых задач
+func (c *wsClient) diffLocked(changes []models.TaskChange, seq uint64) []ServerMessage {
+	now := c.handler.now()
+	var messages []ServerMessage
+
+	for _, change := range changes {
+		wasVisible := c.visible[change.TaskID]
+		matches := change.Kind != models.TaskChangeRemoved && c.filter.Matches(change.Task, now)
+
+		switch {
+		case matches && !wasVisible:
+			c.visible[change.TaskID] = true
+			messages = append(messages, ServerMessage{Type: MessageAdd, Seq: seq, TaskID: change.TaskID, Task: change.Task})
+		case matches:
+			messages = append(messages, ServerMessage{Type: MessageChange, Seq: seq, TaskID: change.TaskID, Task: change.Task})
+		case wasVisible:
+			delete(c.visible, change.TaskID)
+			messages = append(messages, ServerMessage{Type: MessageRemove, Seq: seq, TaskID: change.TaskID})
+		}
+	}
+
+	return messages
+}
+
+// deferLocked откладывает изменения до отправки снимка. При превышении
+// MaxDeferred изменения отбрасываются, и после текущего снимка будет
+// загружен следующий, уже включающий их
+func (c *wsClient) deferLocked(batch models.TaskChangeBatch) {
+	c.deferredSeq = batch.Seq
+	if c.dropped {
+		return
+	}
+
+	if len(c.deferred)+len(batch.Changes) > c.handler.config.MaxDeferred {
+		c.handler.logger.Warn("Too many WebSocket changes during snapshot, dropping them for a fresh snapshot", map[string]interface{}{
+			"remote_addr": c.remoteAddr,
+			"seq":         batch.Seq,
+		})
+		c.deferred = nil
+		c.dropped = true
+		return
+	}
+
+	c.deferred = append(c.deferred, batch.Changes...)
+}
+
+// markSyncingLocked откладывает изменения до отправки снимка и будит писателя
+func (c *wsClient) markSyncingLocked() {
+	c.syncing = true
+	select {
+	case c.resync <- struct{}{}:
+	default:
+	}
+}
+
+// sendSnapshot загружает подходящие задачи и отправляет их клиенту вместе с
+// изменениями, пришедшими во время загрузки. Применение изменения, уже
+// учтенного в снимке, идемпотентно. Ошибка загрузки не закрывает соединение:
+// клиент получает error, а снимок загружается повторно с задержкой
+func (c *wsClient) sendSnapshot() error {
+	// Изменения, закоммиченные до чтения снимка, в него уже попадут
+	c.mu.Lock()
+	c.syncing = true
+	c.deferred = nil
+	c.dropped = false
+	filter := c.filter
+	c.mu.Unlock()
+
+	// Сообщения из очереди устарели: снимок их заменяет
+	for drained := false; !drained; {
+		select {
+		case <-c.send:
+		default:
+			drained = true
+		}
+	}
+
+	seq := c.handler.hub.Seq()
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.handler.config.WriteTimeout)
+	defer cancel()
+
+	tasks, err := c.handler.tasks.GetAll(ctx, filter, models.GetDefaultSort())
+	if err != nil {
+		delay := c.retrySnapshot()
+		c.handler.logger.Error("Failed to load WebSocket snapshot", map[string]interface{}{
+			"remote_addr": c.remoteAddr,
+			"retry_in":    delay.String(),
+			"error":       err.Error(),
+		})
+		return c.write(ServerMessage{Type: MessageError, Seq: seq, Error: "failed to load tasks"})
+	}
+
+	c.mu.Lock()
+	c.failures = 0
+	c.visible = make(map[int]bool, len(tasks))
+	for _, task := range tasks {
+		c.visible[task.ID] = true
+	}
+	var messages []ServerMessage
+	if c.dropped {
+		// Снимок уже неполон: изменения остаются отложенными до следующего
+		c.markSyncingLocked()
+	} else {
+		messages = c.diffLocked(c.deferred, c.deferredSeq)
+		c.syncing = false
+	}
+	c.deferred = nil
+	c.mu.Unlock()
+
+	if err := c.write(ServerMessage{Type: MessageSnapshot, Seq: seq, Tasks: tasks}); err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		if err := c.write(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// retrySnapshot планирует повторную загрузку снимка и возвращает задержку.
+// До нее изменения не копятся: следующий снимок их уже включит
+func (c *wsClient) retrySnapshot() time.Duration {
+	c.mu.Lock()
+	c.deferred = nil
+	c.dropped = true
+	c.failures++
+	delay := c.handler.config.SnapshotRetry.Backoff(c.failures)
+	if c.retry != nil {
+		c.retry.Stop()
+	}
+	c.retry = time.AfterFunc(delay, func() {
+		// Таймер мог сработать одновременно с отключением клиента
+		select {
+		case <-c.done:
+			return
+		default:
+		}
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.markSyncingLocked()
+	})
+	c.mu.Unlock()
+
+	return delay
+}
+
+// stopRetry отменяет запланированную повторную загрузку снимка
+func (c *wsClient) stopRetry() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.retry != nil {
+		c.retry.Stop()
+		c.retry = nil
+	}
+}
+
+// enqueue ставит служебное сообщение в очередь без блокировки
+func (c *wsClient) enqueue(msg ServerMessage) {
+	select {
+	case c.send <- msg:
+	default:
+	}
+}
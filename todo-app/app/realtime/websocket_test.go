@@ -0,0 +1,253 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"todo-app/app/models"
+	"todo-app/internal/utils"
+
+	"github.com/gorilla/websocket"
+)
+
+// stubQuerier возвращает задачи из памяти, отфильтрованные как в БД
+type stubQuerier struct {
+	mu    sync.Mutex
+	tasks []*models.Task
+	calls int
+	err   error
+}
+
+func (q *stubQuerier) GetAll(ctx context.Context, filter models.TaskFilter, sort models.TaskSort) ([]*models.Task, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.calls++
+	if q.err != nil {
+		return nil, q.err
+	}
+	var result []*models.Task
+	for _, task := range q.tasks {
+		if filter.Matches(task, time.Now()) {
+			result = append(result, task)
+		}
+	}
+	return result, nil
+}
+
+// dialWebSocket поднимает тестовый сервер и подключается к нему
+func dialWebSocket(t *testing.T, handler *WebSocketHandler) *websocket.Conn {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// readMessage читает сообщение сервера с таймаутом
+func readMessage(t *testing.T, conn *websocket.Conn) ServerMessage {
+	t.Helper()
+
+	var msg ServerMessage
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("Failed to read message: %v", err)
+	}
+	return msg
+}
+
+func TestWebSocket_SubscribeAndIncrementalChanges(t *testing.T) {
+	querier := &stubQuerier{tasks: []*models.Task{
+		{ID: 1, Title: "High", Priority: models.PriorityHigh},
+		{ID: 2, Title: "Low", Priority: models.PriorityLow},
+	}}
+	hub := NewHub(&stubLoader{}, Config{Debounce: time.Hour, MaxDelay: time.Hour}, nil)
+	conn := dialWebSocket(t, NewWebSocketHandler(hub, querier, WebSocketConfig{}, nil))
+
+	if err := conn.WriteJSON(ClientMessage{Type: MessageSubscribe, Filter: models.TaskFilter{Priority: models.PriorityHigh}}); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	snapshot := readMessage(t, conn)
+	if snapshot.Type != MessageSnapshot || len(snapshot.Tasks) != 1 || snapshot.Tasks[0].ID != 1 {
+		t.Fatalf("Expected snapshot with task 1, got %+v", snapshot)
+	}
+
+	// Задача 2 начинает подходить под фильтр, задача 1 перестает
+	hub.Push(models.TaskChange{Kind: models.TaskChangeChanged, TaskID: 2, Task: &models.Task{ID: 2, Priority: models.PriorityHigh}})
+	hub.Push(models.TaskChange{Kind: models.TaskChangeChanged, TaskID: 1, Task: &models.Task{ID: 1, Priority: models.PriorityLow}})
+	// Задача 3 не подходит и клиенту не отправляется
+	hub.Push(models.TaskChange{Kind: models.TaskChangeAdded, TaskID: 3, Task: &models.Task{ID: 3, Priority: models.PriorityLow}})
+	hub.Flush()
+
+	if msg := readMessage(t, conn); msg.Type != MessageAdd || msg.TaskID != 2 || msg.Seq != 1 {
+		t.Errorf("Expected add of task 2, got %+v", msg)
+	}
+	if msg := readMessage(t, conn); msg.Type != MessageRemove || msg.TaskID != 1 || msg.Task != nil {
+		t.Errorf("Expected remove of task 1, got %+v", msg)
+	}
+
+	hub.Push(models.TaskChange{Kind: models.TaskChangeChanged, TaskID: 2, Task: &models.Task{ID: 2, Title: "Renamed", Priority: models.PriorityHigh}})
+	hub.Push(models.TaskChange{Kind: models.TaskChangeRemoved, TaskID: 3})
+	hub.Flush()
+
+	if msg := readMessage(t, conn); msg.Type != MessageChange || msg.TaskID != 2 || msg.Task.Title != "Renamed" {
+		t.Errorf("Expected change of task 2, got %+v", msg)
+	}
+
+	// Resync хаба приводит к новому снимку
+	hub.RequestResync("test")
+	hub.Flush()
+
+	if msg := readMessage(t, conn); msg.Type != MessageSnapshot {
+		t.Errorf("Expected snapshot after resync, got %+v", msg)
+	}
+}
+
+func TestWebSocket_RejectsUnknownMessages(t *testing.T) {
+	hub := NewHub(&stubLoader{}, Config{}, nil)
+	conn := dialWebSocket(t, NewWebSocketHandler(hub, &stubQuerier{}, WebSocketConfig{}, nil))
+
+	if err := conn.WriteMessage(websocket.TextMessage, []byte("not json")); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	if msg := readMessage(t, conn); msg.Type != MessageError {
+		t.Errorf("Expected error message, got %+v", msg)
+	}
+
+	// Соединение остается открытым после ошибки
+	if err := conn.WriteJSON(map[string]string{"type": "unsubscribe-all"}); err != nil {
+		t.Fatalf("Failed to write: %v", err)
+	}
+	if msg := readMessage(t, conn); msg.Type != MessageError || !strings.Contains(msg.Error, "unsubscribe-all") {
+		t.Errorf("Expected unknown type error, got %+v", msg)
+	}
+}
+
+func TestWebSocket_SlowConsumerReceivesSnapshot(t *testing.T) {
+	querier := &stubQuerier{}
+	hub := NewHub(&stubLoader{}, Config{Debounce: time.Hour, MaxDelay: time.Hour}, nil)
+	handler := NewWebSocketHandler(hub, querier, WebSocketConfig{SendBuffer: 2}, nil)
+
+	client := &wsClient{
+		handler:    handler,
+		send:       make(chan ServerMessage, handler.config.SendBuffer),
+		resync:     make(chan struct{}, 1),
+		subscribed: true,
+		visible:    make(map[int]bool),
+	}
+
+	// Писатель не читает очередь: третье сообщение не помещается
+	var changes []models.TaskChange
+	for i := 1; i <= 3; i++ {
+		changes = append(changes, models.TaskChange{Kind: models.TaskChangeAdded, TaskID: i, Task: &models.Task{ID: i}})
+	}
+	client.handleBatch(models.TaskChangeBatch{Seq: 1, Changes: changes})
+
+	select {
+	case <-client.resync:
+	default:
+		t.Fatal("Expected snapshot request after queue overflow")
+	}
+
+	// Пока снимок не отправлен, изменения откладываются, а не теряются
+	client.handleBatch(models.TaskChangeBatch{Seq: 2, Changes: []models.TaskChange{{Kind: models.TaskChangeRemoved, TaskID: 1}}})
+	if !client.syncing || len(client.deferred) != 1 || len(client.send) != 2 {
+		t.Errorf("Expected deferred change while syncing, got syncing=%t deferred=%d queued=%d",
+			client.syncing, len(client.deferred), len(client.send))
+	}
+}
+
+func TestWebSocket_RetriesFailedSnapshot(t *testing.T) {
+	querier := &stubQuerier{tasks: []*models.Task{{ID: 1}}, err: errors.New("database is down")}
+	hub := NewHub(&stubLoader{}, Config{Debounce: time.Hour, MaxDelay: time.Hour}, nil)
+	conn := dialWebSocket(t, NewWebSocketHandler(hub, querier, WebSocketConfig{
+		SnapshotRetry: utils.RetryPolicy{InitialBackoff: 20 * time.Millisecond, MaxBackoff: 20 * time.Millisecond},
+	}, nil))
+
+	if err := conn.WriteJSON(ClientMessage{Type: MessageSubscribe}); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	if msg := readMessage(t, conn); msg.Type != MessageError {
+		t.Fatalf("Expected error while database is down, got %+v", msg)
+	}
+
+	querier.mu.Lock()
+	querier.err = nil
+	querier.mu.Unlock()
+
+	// Соединение остается открытым, снимок загружается повторно
+	if msg := readMessage(t, conn); msg.Type != MessageSnapshot || len(msg.Tasks) != 1 {
+		t.Errorf("Expected snapshot after retry, got %+v", msg)
+	}
+}
+
+func TestWebSocket_DeferredChangesAreCapped(t *testing.T) {
+	hub := NewHub(&stubLoader{}, Config{Debounce: time.Hour, MaxDelay: time.Hour}, nil)
+	handler := NewWebSocketHandler(hub, &stubQuerier{}, WebSocketConfig{MaxDeferred: 2}, nil)
+
+	client := &wsClient{
+		handler:    handler,
+		send:       make(chan ServerMessage, handler.config.SendBuffer),
+		resync:     make(chan struct{}, 1),
+		subscribed: true,
+		visible:    make(map[int]bool),
+		syncing:    true,
+	}
+
+	for i := 1; i <= 3; i++ {
+		client.handleBatch(models.TaskChangeBatch{Seq: uint64(i), Changes: []models.TaskChange{
+			{Kind: models.TaskChangeAdded, TaskID: i, Task: &models.Task{ID: i}},
+		}})
+	}
+	if len(client.deferred) != 0 || !client.dropped {
+		t.Fatalf("Expected deferred changes dropped, got deferred=%d dropped=%t", len(client.deferred), client.dropped)
+	}
+}
+
+func TestWebSocket_SnapshotRetryStopsOnDisconnect(t *testing.T) {
+	querier := &stubQuerier{err: errors.New("database is down")}
+	hub := NewHub(&stubLoader{}, Config{Debounce: time.Hour, MaxDelay: time.Hour}, nil)
+	handler := NewWebSocketHandler(hub, querier, WebSocketConfig{
+		SnapshotRetry: utils.RetryPolicy{InitialBackoff: 50 * time.Millisecond, MaxBackoff: 50 * time.Millisecond},
+	}, nil)
+	conn := dialWebSocket(t, handler)
+
+	if err := conn.WriteJSON(ClientMessage{Type: MessageSubscribe}); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	if msg := readMessage(t, conn); msg.Type != MessageError {
+		t.Fatalf("Expected error while database is down, got %+v", msg)
+	}
+
+	var client *wsClient
+	handler.mu.Lock()
+	for c := range handler.clients {
+		client = c
+	}
+	handler.mu.Unlock()
+
+	conn.Close()
+	for deadline := time.Now().Add(2 * time.Second); handler.Clients() > 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("Client was not disconnected")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Запланированный повтор отменен и не запрашивает снимок для отключенного клиента
+	time.Sleep(150 * time.Millisecond)
+	if len(client.resync) != 0 {
+		t.Error("Expected no snapshot request after disconnect")
+	}
+}
@@ -0,0 +1,225 @@
+package reminders
+
+import (
+	"context"
+	"sync"
+	"time"
+	"todo-app/app/models"
+	"todo-app/app/repository"
+	"todo-app/internal/utils"
+)
+
+// Config содержит настройки напоминаний о сроках задач
+type Config struct {
+	Enabled   bool
+	LeadTime  time.Duration // за сколько до срока напоминать
+	Interval  time.Duration // интервал проверки
+	BatchSize int           // максимальное количество напоминаний за проверку
+}
+
+// Subscriber получает задачи, о сроке которых нужно напомнить
+type Subscriber func(tasks []*models.Task)
+
+// Scheduler периодически находит задачи с приближающимся сроком и передает
+// их подписчикам. Настройки можно менять на лету через UpdateConfig
+type Scheduler struct {
+	repo   repository.ReminderRepository
+	logger *utils.Logger
+	now    func() time.Time
+
+	mu          sync.Mutex
+	config      Config
+	subscribers map[uint64]Subscriber
+	nextID      uint64
+
+	wake    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	startMu sync.Mutex
+	running bool
+}
+
+// NewScheduler создает планировщик напоминаний
+func NewScheduler(repo repository.ReminderRepository, config Config, logger *utils.Logger) *Scheduler {
+	if logger == nil {
+		logger = utils.DefaultLogger()
+	}
+
+	return &Scheduler{
+		repo:        repo,
+		logger:      logger,
+		now:         time.Now,
+		config:      normalize(config),
+		subscribers: make(map[uint64]Subscriber),
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// normalize подставляет значения по умолчанию
+func normalize(config Config) Config {
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 50
+	}
+	if config.LeadTime < 0 {
+		config.LeadTime = 0
+	}
+	return config
+}
+
+// Config возвращает текущие настройки
+func (s *Scheduler) Config() Config {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.config
+}
+
+// UpdateConfig применяет новые настройки и сразу выполняет проверку
+func (s *Scheduler) UpdateConfig(config Config) {
+	s.mu.Lock()
+	s.config = normalize(config)
+	s.mu.Unlock()
+
+	s.Notify()
+}
+
+// Subscribe регистрирует получателя напоминаний и возвращает функцию отписки
+func (s *Scheduler) Subscribe(subscriber Subscriber) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	id := s.nextID
+	s.subscribers[id] = subscriber
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers, id)
+	}
+}
+
+// Pending возвращает количество задач, ожидающих напоминания
+func (s *Scheduler) Pending(ctx context.Context) (int, error) {
+	config := s.Config()
+	return s.repo.CountDue(ctx, s.now().Add(config.LeadTime))
+}
+
+// Notify запускает внеочередную проверку
+func (s *Scheduler) Notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start запускает периодическую проверку
+func (s *Scheduler) Start() {
+	s.startMu.Lock()
+	defer s.startMu.Unlock()
+
+	if s.running {
+		return
+	}
+	s.running = true
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go s.run()
+	s.Notify()
+}
+
+// Stop останавливает проверку, дожидаясь завершения текущего прохода
+func (s *Scheduler) Stop() {
+	s.startMu.Lock()
+	defer s.startMu.Unlock()
+
+	if !s.running {
+		return
+	}
+	s.running = false
+
+	close(s.stop)
+	<-s.done
+}
+
+// Running сообщает, запущена ли периодическая проверка
+func (s *Scheduler) Running() bool {
+	s.startMu.Lock()
+	defer s.startMu.Unlock()
+	return s.running
+}
+
+// run основной цикл; интервал перечитывается после каждой проверки
+func (s *Scheduler) run() {
+	defer close(s.done)
+
+	timer := time.NewTimer(s.Config().Interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-s.wake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		case <-timer.C:
+		}
+
+		if _, err := s.Check(context.Background()); err != nil {
+			s.logger.Error("Failed to check task reminders", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+
+		timer.Reset(s.Config().Interval)
+	}
+}
+
+// Check отправляет напоминания о задачах со сроком в пределах LeadTime и
+// возвращает их количество. Без подписчиков напоминания не расходуются
+func (s *Scheduler) Check(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	config := s.config
+	subscribers := make([]Subscriber, 0, len(s.subscribers))
+	for _, subscriber := range s.subscribers {
+		subscribers = append(subscribers, subscriber)
+	}
+	s.mu.Unlock()
+
+	if !config.Enabled || len(subscribers) == 0 {
+		return 0, nil
+	}
+
+	now := s.now()
+	tasks, err := s.repo.GetDue(ctx, now.Add(config.LeadTime), config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(tasks) == 0 {
+		return 0, nil
+	}
+
+	for _, subscriber := range subscribers {
+		subscriber(tasks)
+	}
+
+	for _, task := range tasks {
+		if err := s.repo.MarkSent(ctx, task.ID, *task.DueDate, now); err != nil {
+			return 0, err
+		}
+	}
+
+	s.logger.Info("Task reminders sent", map[string]interface{}{
+		"count": len(tasks),
+	})
+
+	return len(tasks), nil
+}
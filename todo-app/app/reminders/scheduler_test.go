@@ -0,0 +1,109 @@
+package reminders
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+	"todo-app/app/models"
+)
+
+// memoryReminderRepository хранит напоминания в памяти
+type memoryReminderRepository struct {
+	mu    sync.Mutex
+	tasks []*models.Task
+	sent  map[int]time.Time
+}
+
+func newMemoryRepository(tasks ...*models.Task) *memoryReminderRepository {
+	return &memoryReminderRepository{tasks: tasks, sent: make(map[int]time.Time)}
+}
+
+func (r *memoryReminderRepository) GetDue(ctx context.Context, until time.Time, limit int) ([]*models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []*models.Task
+	for _, task := range r.tasks {
+		sent, ok := r.sent[task.ID]
+		if task.DueDate == nil || task.DueDate.After(until) || (ok && sent.Equal(*task.DueDate)) {
+			continue
+		}
+		if len(due) < limit {
+			due = append(due, task)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryReminderRepository) CountDue(ctx context.Context, until time.Time) (int, error) {
+	due, err := r.GetDue(ctx, until, len(r.tasks)+1)
+	return len(due), err
+}
+
+func (r *memoryReminderRepository) MarkSent(ctx context.Context, taskID int, dueDate, sentAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sent[taskID] = dueDate
+	return nil
+}
+
+func taskDueAt(id int, due time.Time) *models.Task {
+	return &models.Task{ID: id, Title: "task", DueDate: &due}
+}
+
+func TestScheduler_CheckSendsEachReminderOnce(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo := newMemoryRepository(
+		taskDueAt(1, now.Add(10*time.Minute)),
+		taskDueAt(2, now.Add(2*time.Hour)),
+	)
+
+	scheduler := NewScheduler(repo, Config{Enabled: true, LeadTime: 15 * time.Minute}, nil)
+	scheduler.now = func() time.Time { return now }
+
+	// Без подписчиков напоминания не расходуются
+	if sent, _ := scheduler.Check(context.Background()); sent != 0 {
+		t.Fatalf("Expected no reminders without subscribers, got %d", sent)
+	}
+	if pending, _ := scheduler.Pending(context.Background()); pending != 1 {
+		t.Fatalf("Expected 1 pending reminder, got %d", pending)
+	}
+
+	var received []int
+	scheduler.Subscribe(func(tasks []*models.Task) {
+		for _, task := range tasks {
+			received = append(received, task.ID)
+		}
+	})
+
+	sent, err := scheduler.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+	if sent != 1 || len(received) != 1 || received[0] != 1 {
+		t.Fatalf("Expected reminder for task 1, got sent=%d received=%v", sent, received)
+	}
+
+	if sent, _ := scheduler.Check(context.Background()); sent != 0 {
+		t.Errorf("Expected reminder to be sent once, got %d", sent)
+	}
+
+	// Увеличение LeadTime на лету захватывает вторую задачу
+	scheduler.UpdateConfig(Config{Enabled: true, LeadTime: 3 * time.Hour})
+	if sent, _ := scheduler.Check(context.Background()); sent != 1 {
+		t.Errorf("Expected reminder after lead time change, got %d", sent)
+	}
+}
+
+func TestScheduler_DisabledSendsNothing(t *testing.T) {
+	repo := newMemoryRepository(taskDueAt(1, time.Now()))
+	scheduler := NewScheduler(repo, Config{Enabled: false}, nil)
+	scheduler.Subscribe(func(tasks []*models.Task) {
+		t.Error("Unexpected reminder while disabled")
+	})
+
+	if sent, err := scheduler.Check(context.Background()); sent != 0 || err != nil {
+		t.Errorf("Expected nothing sent, got %d, %v", sent, err)
+	}
+}
@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"todo-app/app/models"
+)
+
+const attachmentColumns = `id, task_id, file_name, content_type, size, sha256, created_at`
+
+// postgresAttachmentRepository реализует AttachmentRepository для PostgreSQL
+type postgresAttachmentRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAttachmentRepository создает новый PostgreSQL репозиторий вложений
+func NewPostgresAttachmentRepository(db *sql.DB) AttachmentRepository {
+	return &postgresAttachmentRepository{db: db}
+}
+
+// Create сохраняет метаданные вложения
+func (r *postgresAttachmentRepository) Create(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error) {
+	query := `
+        INSERT INTO attachments (task_id, file_name, content_type, size, sha256, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6)
+        RETURNING ` + attachmentColumns
+
+	if attachment.CreatedAt.IsZero() {
+		attachment.CreatedAt = time.Now()
+	}
+
+	created := &models.Attachment{}
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		attachment.TaskID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.SHA256,
+		attachment.CreatedAt,
+	).Scan(attachmentScanTargets(created)...)
+
+	if err != nil {
+		return nil, dbError(err, "failed to create attachment")
+	}
+
+	return created, nil
+}
+
+// GetByID получает вложение по ID
+func (r *postgresAttachmentRepository) GetByID(ctx context.Context, id int64) (*models.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE id = $1`
+
+	attachment := &models.Attachment{}
+	err := retryRead(ctx, func() error {
+		return executor(ctx, r.db).QueryRowContext(ctx, query, id).Scan(attachmentScanTargets(attachment)...)
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, attachmentNotFound(id)
+		}
+		return nil, dbError(err, "failed to get attachment")
+	}
+
+	return attachment, nil
+}
+
+// Delete удаляет метаданные вложения. Содержимое остается в хранилище
+// до сборки мусора
+func (r *postgresAttachmentRepository) Delete(ctx context.Context, id int64) error {
+	result, err := executor(ctx, r.db).ExecContext(ctx, `DELETE FROM attachments WHERE id = $1`, id)
+	if err != nil {
+		return dbError(err, "failed to delete attachment")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return dbError(err, "failed to get affected rows")
+	}
+
+	if rowsAffected == 0 {
+		return attachmentNotFound(id)
+	}
+
+	return nil
+}
+
+// GetByTask получает вложения задачи по времени добавления
+func (r *postgresAttachmentRepository) GetByTask(ctx context.Context, taskID int) ([]*models.Attachment, error) {
+	query := `
+        SELECT ` + attachmentColumns + `
+        FROM attachments
+        WHERE task_id = $1
+        ORDER BY created_at, id`
+
+	var attachments []*models.Attachment
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, taskID)
+		if err != nil {
+			return dbError(err, "failed to get attachments")
+		}
+		defer rows.Close()
+
+		attachments = nil
+		for rows.Next() {
+			attachment := &models.Attachment{}
+			if err := rows.Scan(attachmentScanTargets(attachment)...); err != nil {
+				return dbError(err, "failed to scan attachment")
+			}
+			attachments = append(attachments, attachment)
+		}
+
+		if err := rows.Err(); err != nil {
+			return dbError(err, "rows iteration error")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// GetReferencedHashes возвращает множество хэшей содержимого, на которые
+// ссылается хотя бы одно вложение
+func (r *postgresAttachmentRepository) GetReferencedHashes(ctx context.Context) (map[string]struct{}, error) {
+	var hashes map[string]struct{}
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, `SELECT DISTINCT sha256 FROM attachments`)
+		if err != nil {
+			return dbError(err, "failed to get attachment hashes")
+		}
+		defer rows.Close()
+
+		hashes = make(map[string]struct{})
+		for rows.Next() {
+			var hash string
+			if err := rows.Scan(&hash); err != nil {
+				return dbError(err, "failed to scan attachment hash")
+			}
+			hashes[hash] = struct{}{}
+		}
+
+		if err := rows.Err(); err != nil {
+			return dbError(err, "rows iteration error")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hashes, nil
+}
+
+func attachmentScanTargets(attachment *models.Attachment) []interface{} {
+	return []interface{}{
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.SHA256,
+		&attachment.CreatedAt,
+	}
+}
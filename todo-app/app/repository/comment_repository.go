@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"todo-app/app/models"
+
+	"github.com/lib/pq"
+)
+
+const commentColumns = `id, task_id, body, edit_count, created_at, updated_at`
+
+// postgresCommentRepository реализует CommentRepository для PostgreSQL
+type postgresCommentRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresCommentRepository создает новый PostgreSQL репозиторий комментариев
+func NewPostgresCommentRepository(db *sql.DB) CommentRepository {
+	return &postgresCommentRepository{db: db}
+}
+
+// Create сохраняет комментарий
+func (r *postgresCommentRepository) Create(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	query := `
+        INSERT INTO task_comments (task_id, body, created_at, updated_at)
+        VALUES ($1, $2, $3, $3)
+        RETURNING ` + commentColumns
+
+	if comment.CreatedAt.IsZero() {
+		comment.CreatedAt = time.Now()
+	}
+
+	created := &models.Comment{}
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		comment.TaskID,
+		comment.Body,
+		comment.CreatedAt,
+	).Scan(commentScanTargets(created)...)
+
+	if err != nil {
+		return nil, dbError(err, "failed to create comment")
+	}
+
+	return created, nil
+}
+
+// GetByID получает комментарий по ID
+func (r *postgresCommentRepository) GetByID(ctx context.Context, id int64) (*models.Comment, error) {
+	query := `SELECT ` + commentColumns + ` FROM task_comments WHERE id = $1`
+
+	comment := &models.Comment{}
+	err := retryRead(ctx, func() error {
+		return executor(ctx, r.db).QueryRowContext(ctx, query, id).Scan(commentScanTargets(comment)...)
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, commentNotFound(id)
+		}
+		return nil, dbError(err, "failed to get comment")
+	}
+
+	return comment, nil
+}
+
+// Update заменяет тело комментария. Предыдущая версия сохраняется
+// в task_comment_revisions тем же запросом; строка блокируется, поэтому
+// при одновременной правке в ревизию попадает последняя версия
+func (r *postgresCommentRepository) Update(ctx context.Context, id int64, body string, updatedAt time.Time) (*models.Comment, error) {
+	query := `
+        WITH revision AS (
+            INSERT INTO task_comment_revisions (comment_id, body, created_at, replaced_at)
+            SELECT id, body, updated_at, $3 FROM task_comments WHERE id = $1 FOR UPDATE
+            RETURNING comment_id
+        )
+        UPDATE task_comments
+        SET body = $2, updated_at = $3, edit_count = edit_count + 1
+        WHERE id = (SELECT comment_id FROM revision)
+        RETURNING ` + commentColumns
+
+	comment := &models.Comment{}
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, id, body, updatedAt).Scan(commentScanTargets(comment)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, commentNotFound(id)
+		}
+		return nil, dbError(err, "failed to update comment")
+	}
+
+	return comment, nil
+}
+
+// Delete удаляет комментарий вместе с ревизиями
+func (r *postgresCommentRepository) Delete(ctx context.Context, id int64) error {
+	result, err := executor(ctx, r.db).ExecContext(ctx, `DELETE FROM task_comments WHERE id = $1`, id)
+	if err != nil {
+		return dbError(err, "failed to delete comment")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return dbError(err, "failed to get affected rows")
+	}
+
+	if rowsAffected == 0 {
+		return commentNotFound(id)
+	}
+
+	return nil
+}
+
+// GetByTask получает комментарии задачи по времени создания
+func (r *postgresCommentRepository) GetByTask(ctx context.Context, taskID int, order models.SortOrder) ([]*models.Comment, error) {
+	direction := "ASC"
+	if order == models.SortOrderDesc {
+		direction = "DESC"
+	}
+
+	query := fmt.Sprintf(`
+        SELECT `+commentColumns+`
+        FROM task_comments
+        WHERE task_id = $1
+        ORDER BY created_at %[1]s, id %[1]s`, direction)
+
+	return r.query(ctx, query, "failed to get comments", taskID)
+}
+
+// GetByTasks получает комментарии нескольких задач, сгруппированные по задаче
+func (r *postgresCommentRepository) GetByTasks(ctx context.Context, taskIDs []int) (map[int][]*models.Comment, error) {
+	grouped := make(map[int][]*models.Comment)
+	if len(taskIDs) == 0 {
+		return grouped, nil
+	}
+
+	query := `
+        SELECT ` + commentColumns + `
+        FROM task_comments
+        WHERE task_id = ANY($1)
+        ORDER BY task_id, created_at, id`
+
+	comments, err := r.query(ctx, query, "failed to get comments", pq.Array(taskIDs))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, comment := range comments {
+		grouped[comment.TaskID] = append(grouped[comment.TaskID], comment)
+	}
+
+	return grouped, nil
+}
+
+// GetRevisions получает предыдущие версии комментария от ранних к поздним
+func (r *postgresCommentRepository) GetRevisions(ctx context.Context, commentID int64) ([]*models.CommentRevision, error) {
+	query := `
+        SELECT id, comment_id, body, created_at, replaced_at
+        FROM task_comment_revisions
+        WHERE comment_id = $1
+        ORDER BY id`
+
+	var revisions []*models.CommentRevision
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, commentID)
+		if err != nil {
+			return dbError(err, "failed to get comment revisions")
+		}
+		defer rows.Close()
+
+		revisions = nil
+		for rows.Next() {
+			revision := &models.CommentRevision{}
+			err := rows.Scan(
+				&revision.ID,
+				&revision.CommentID,
+				&revision.Body,
+				&revision.CreatedAt,
+				&revision.ReplacedAt,
+			)
+			if err != nil {
+				return dbError(err, "failed to scan comment revision")
+			}
+			revisions = append(revisions, revision)
+		}
+
+		if err := rows.Err(); err != nil {
+			return dbError(err, "rows iteration error")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+// query выполняет выборку комментариев с повтором при временных ошибках
+func (r *postgresCommentRepository) query(ctx context.Context, query, message string, args ...interface{}) ([]*models.Comment, error) {
+	var comments []*models.Comment
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+		if err != nil {
+			return dbError(err, message)
+		}
+		defer rows.Close()
+
+		comments = nil
+		for rows.Next() {
+			comment := &models.Comment{}
+			if err := rows.Scan(commentScanTargets(comment)...); err != nil {
+				return dbError(err, "failed to scan comment")
+			}
+			comments = append(comments, comment)
+		}
+
+		if err := rows.Err(); err != nil {
+			return dbError(err, "rows iteration error")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// commentScanTargets возвращает поля комментария для Scan в порядке commentColumns
+func commentScanTargets(comment *models.Comment) []interface{} {
+	return []interface{}{
+		&comment.ID,
+		&comment.TaskID,
+		&comment.Body,
+		&comment.EditCount,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	}
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+	"todo-app/app/models"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestPostgresCommentRepository_UpdateKeepsRevision(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresCommentRepository(db)
+	editedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Предыдущая версия сохраняется тем же запросом, что и новая
+	mock.ExpectQuery(`WITH revision AS \(\s*INSERT INTO task_comment_revisions .+ FOR UPDATE.+UPDATE task_comments\s+SET body = \$2, updated_at = \$3, edit_count = edit_count \+ 1`).
+		WithArgs(int64(5), "**Done**", editedAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "body", "edit_count", "created_at", "updated_at"}).
+			AddRow(int64(5), 3, "**Done**", 1, editedAt.Add(-time.Hour), editedAt))
+	mock.ExpectQuery(`WITH revision AS`).
+		WithArgs(int64(6), "text", editedAt).
+		WillReturnError(sql.ErrNoRows)
+
+	comment, err := repo.Update(context.Background(), 5, "**Done**", editedAt)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !comment.IsEdited() || comment.Body != "**Done**" || !comment.UpdatedAt.Equal(editedAt) {
+		t.Errorf("Expected edited comment, got %+v", comment)
+	}
+
+	if _, err := repo.Update(context.Background(), 6, "text", editedAt); !errors.Is(err, ErrCommentNotFound) {
+		t.Errorf("Expected comment not found, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_SearchIncludesComments(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+
+	mock.ExpectQuery(`title ILIKE \$1 OR description ILIKE \$1 OR EXISTS \(SELECT 1 FROM task_comments c WHERE c.task_id = tasks.id AND c.body ILIKE \$1\)`).
+		WithArgs("%invoice%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+	count, err := repo.GetTasksCount(context.Background(), models.TaskFilter{Search: "invoice"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 tasks, got %d", count)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
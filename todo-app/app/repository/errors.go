@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"todo-app/internal/utils"
+
+	"github.com/lib/pq"
+)
+
+// Причины ошибок репозиториев. Каждая оборачивает общую причину utils, поэтому
+// errors.Is(err, ErrTaskNotFound) и errors.Is(err, utils.ErrNotFound) истинны одновременно
+var (
+	ErrTaskNotFound       = fmt.Errorf("task %w", utils.ErrNotFound)
+	ErrWebhookNotFound    = fmt.Errorf("webhook subscription %w", utils.ErrNotFound)
+	ErrDeliveryNotFound   = fmt.Errorf("undelivered webhook delivery %w", utils.ErrNotFound)
+	ErrTimeEntryNotFound  = fmt.Errorf("time entry %w", utils.ErrNotFound)
+	ErrTimerNotRunning    = fmt.Errorf("running timer %w", utils.ErrNotFound)
+	ErrTimerRunning       = fmt.Errorf("timer already running: %w", utils.ErrConflict)
+	ErrCommentNotFound    = fmt.Errorf("comment %w", utils.ErrNotFound)
+	ErrAttachmentNotFound = fmt.Errorf("attachment %w", utils.ErrNotFound)
+)
+
+// taskNotFound возвращает ошибку NOT_FOUND для задачи
+func taskNotFound(id int) error {
+	return utils.NewNotFoundError(fmt.Sprintf("task with id %d", id)).
+		WithCause(ErrTaskNotFound).
+		WithMessageKey("error.task_not_found", map[string]interface{}{"id": id})
+}
+
+// webhookNotFound возвращает ошибку NOT_FOUND для подписки
+func webhookNotFound(id int) error {
+	return utils.NewNotFoundError(fmt.Sprintf("webhook subscription with id %d", id)).
+		WithCause(ErrWebhookNotFound).
+		WithMessageKey("error.webhook_not_found", map[string]interface{}{"id": id})
+}
+
+// deliveryNotFound возвращает ошибку NOT_FOUND для недоставленного события
+func deliveryNotFound(id int64) error {
+	return utils.NewNotFoundError(fmt.Sprintf("undelivered webhook delivery with id %d", id)).
+		WithCause(ErrDeliveryNotFound).
+		WithMessageKey("error.delivery_not_found", map[string]interface{}{"id": id})
+}
+
+// timeEntryNotFound возвращает ошибку NOT_FOUND для записи времени
+func timeEntryNotFound(id int64) error {
+	return utils.NewNotFoundError(fmt.Sprintf("time entry with id %d", id)).
+		WithCause(ErrTimeEntryNotFound).
+		WithMessageKey("error.time_entry_not_found", map[string]interface{}{"id": id})
+}
+
+// timerNotRunning возвращает ошибку NOT_FOUND, если таймер не запущен
+func timerNotRunning() error {
+	return utils.NewNotFoundError("running timer").
+		WithCause(ErrTimerNotRunning).
+		WithMessageKey("error.timer_not_running", nil)
+}
+
+// timerRunning возвращает ошибку CONFLICT при запуске второго таймера
+func timerRunning(err error) error {
+	return utils.NewConflictError("another timer is already running").
+		WithCause(ErrTimerRunning).
+		WithMessageKey("error.timer_running", nil).
+		WithDetails(err.Error())
+}
+
+// commentNotFound возвращает ошибку NOT_FOUND для комментария
+func commentNotFound(id int64) error {
+	return utils.NewNotFoundError(fmt.Sprintf("comment with id %d", id)).
+		WithCause(ErrCommentNotFound).
+		WithMessageKey("error.comment_not_found", map[string]interface{}{"id": id})
+}
+
+// attachmentNotFound возвращает ошибку NOT_FOUND для вложения
+func attachmentNotFound(id int64) error {
+	return utils.NewNotFoundError(fmt.Sprintf("attachment with id %d", id)).
+		WithCause(ErrAttachmentNotFound).
+		WithMessageKey("error.attachment_not_found", map[string]interface{}{"id": id})
+}
+
+// dbError типизирует ошибку запроса к БД. Уже типизированные ошибки возвращаются
+// как есть; нарушение ограничений (класс SQLSTATE 23) - CONFLICT, истекший или
+// отмененный контекст - TIMEOUT, остальное - DATABASE_ERROR. Текст исходной
+// ошибки сохраняется в Details для логов и не показывается пользователю
+func dbError(err error, message string) error {
+	var appErr *utils.AppError
+	if errors.As(err, &appErr) {
+		return err
+	}
+
+	var typed *utils.AppError
+	var pqErr *pq.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled):
+		typed = utils.NewErrorWithCause(utils.ErrorTypeTimeout, message, err).WithCode(408)
+	case errors.As(err, &pqErr) && pqErr.Code.Class() == "23":
+		typed = utils.NewErrorWithCause(utils.ErrorTypeConflict, message, err).WithCode(409)
+	default:
+		typed = utils.NewDatabaseError(message, err)
+	}
+	return typed.WithDetails(err.Error())
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"todo-app/internal/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+func TestPostgresTaskRepository_NotFoundIsTyped(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+
+	mock.ExpectQuery(`SELECT (.+) FROM tasks WHERE id = \$1`).
+		WithArgs(7).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectExec(`DELETE FROM tasks WHERE id = \$1`).
+		WithArgs(7).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	_, getErr := repo.GetByID(context.Background(), 7)
+	deleteErr := repo.Delete(context.Background(), 7)
+
+	for _, err := range []error{getErr, deleteErr} {
+		// Типы сохраняются после обертки в сервисе и use case
+		wrapped := fmt.Errorf("failed to load task: %w", err)
+		if !errors.Is(wrapped, ErrTaskNotFound) || !errors.Is(wrapped, utils.ErrNotFound) {
+			t.Errorf("Expected task not found error, got %v", err)
+		}
+		if utils.GetErrorCode(wrapped) != 404 || !utils.IsErrorType(wrapped, utils.ErrorTypeNotFound) {
+			t.Errorf("Expected NOT_FOUND 404, got %v", err)
+		}
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDBError_Classification(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		sentinel error
+		code     int
+	}{
+		{"unique violation", &pq.Error{Code: "23505"}, utils.ErrConflict, 409},
+		{"deadline", fmt.Errorf("query: %w", context.DeadlineExceeded), utils.ErrTimeout, 408},
+		{"connection", &pq.Error{Code: "08006"}, utils.ErrDatabase, 500},
+		{"already typed", taskNotFound(1), ErrTaskNotFound, 404},
+	}
+
+	for _, tt := range tests {
+		err := dbError(tt.err, "failed to update task")
+		if !errors.Is(err, tt.sentinel) || utils.GetErrorCode(err) != tt.code {
+			t.Errorf("%s: expected %v/%d, got %v", tt.name, tt.sentinel, tt.code, err)
+		}
+		// Исходная ошибка драйвера остается доступной
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: cause lost: %v", tt.name, err)
+		}
+	}
+}
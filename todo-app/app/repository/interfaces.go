@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 	"todo-app/app/models"
 )
 
@@ -13,6 +14,10 @@ type TaskRepository interface {
 	// GetAll получает список задач с учетом фильтров и сортировки
 	GetAll(ctx context.Context, filter models.TaskFilter, sort models.TaskSort) ([]*models.Task, error)
 
+	// GetPage получает страницу задач keyset-методом: после курсора (если он задан),
+	// упорядоченную по полю сортировки и id. HasNext вычисляется без COUNT(*)
+	GetPage(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, after *models.TaskCursor, limit int) (*models.TaskPage, error)
+
 	// GetByID получает задачу по ID
 	GetByID(ctx context.Context, id int) (*models.Task, error)
 
@@ -22,14 +27,29 @@ type TaskRepository interface {
 	// Delete удаляет задачу по ID
 	Delete(ctx context.Context, id int) error
 
-	// MarkAsCompleted помечает задачу как выполненную
-	MarkAsCompleted(ctx context.Context, id int) error
+	// UpdateStatus сохраняет статус задачи и отметки времени переходов
+	UpdateStatus(ctx context.Context, task *models.Task) error
+
+	// Archive переносит задачу в архив
+	Archive(ctx context.Context, id int) error
+
+	// UpdatePosition сохраняет позицию задачи в общем списке (пустая колонка) или в колонке доски
+	UpdatePosition(ctx context.Context, id int, column models.TaskStatus, position float64) error
+
+	// LastPosition возвращает наибольшую позицию в списке или колонке; false - задач нет
+	LastPosition(ctx context.Context, column models.TaskStatus) (float64, bool, error)
 
-	// MarkAsActive помечает задачу как активную
-	MarkAsActive(ctx context.Context, id int) error
+	// RebalancePositions перенумеровывает позиции списка или колонки с исходным шагом
+	RebalancePositions(ctx context.Context, column models.TaskStatus) error
 
-	// GetTasksStats получает статистику по задачам
-	GetTasksStats(ctx context.Context) (*models.TaskStats, error)
+	// GetBoardTasks получает неархивные задачи в порядке колонок доски
+	GetBoardTasks(ctx context.Context, filter models.TaskFilter) ([]*models.Task, error)
+
+	// GetTasksStats получает статистику по задачам в часовом поясе timezone
+	GetTasksStats(ctx context.Context, timezone string) (*models.TaskStats, error)
+
+	// GetStatusCounts получает количество задач в каждом статусе
+	GetStatusCounts(ctx context.Context) (map[models.TaskStatus]int, error)
 
 	// GetTasksCount получает количество задач с учетом фильтра
 	GetTasksCount(ctx context.Context, filter models.TaskFilter) (int, error)
@@ -37,8 +57,8 @@ type TaskRepository interface {
 	// GetRecentTasks получает последние созданные задачи
 	GetRecentTasks(ctx context.Context, limit int) ([]*models.Task, error)
 
-	// GetUpcomingTasks получает задачи с ближайшими сроками
-	GetUpcomingTasks(ctx context.Context, limit int) ([]*models.Task, error)
+	// GetUpcomingTasks получает задачи с ближайшими сроками в часовом поясе timezone
+	GetUpcomingTasks(ctx context.Context, timezone string, limit int) ([]*models.Task, error)
 }
 
 // SettingsRepository определяет интерфейс для работы с настройками приложения
@@ -50,6 +70,152 @@ type SettingsRepository interface {
 	UpdateSettings(ctx context.Context, settings *models.AppSettings) error
 }
 
+// OutboxRepository определяет интерфейс транзакционного outbox доменных событий
+type OutboxRepository interface {
+	// Save сохраняет событие. При вызове внутри TxManager.WithinTransaction
+	// запись фиксируется вместе с изменением, породившим событие
+	Save(ctx context.Context, event *models.OutboxEvent) error
+
+	// GetPending получает неопубликованные события в порядке их записи
+	GetPending(ctx context.Context, limit int) ([]*models.OutboxEvent, error)
+
+	// MarkPublished отмечает событие опубликованным
+	MarkPublished(ctx context.Context, id int64) error
+
+	// MarkRetry увеличивает счетчик попыток и откладывает событие до nextAttemptAt
+	MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time) error
+
+	// MarkFailed увеличивает счетчик попыток и сохраняет последнюю ошибку;
+	// событие больше не публикуется
+	MarkFailed(ctx context.Context, id int64, lastError string) error
+
+	// CountPending возвращает количество неопубликованных событий
+	CountPending(ctx context.Context) (int, error)
+
+	// GetByTask получает последние limit событий задачи в порядке записи
+	GetByTask(ctx context.Context, taskID int, limit int) ([]*models.OutboxEvent, error)
+}
+
+// ReminderRepository определяет интерфейс для напоминаний о сроках задач
+type ReminderRepository interface {
+	// GetDue получает активные задачи со сроком не позже until,
+	// напоминание о текущем сроке которых еще не отправлено
+	GetDue(ctx context.Context, until time.Time, limit int) ([]*models.Task, error)
+
+	// CountDue возвращает количество задач, ожидающих напоминания
+	CountDue(ctx context.Context, until time.Time) (int, error)
+
+	// MarkSent отмечает напоминание о сроке dueDate отправленным
+	MarkSent(ctx context.Context, taskID int, dueDate time.Time, sentAt time.Time) error
+}
+
+// TimeEntryRepository определяет интерфейс для учета времени по задачам
+type TimeEntryRepository interface {
+	// Create сохраняет запись времени; запись без EndedAt запускает таймер.
+	// Второй запущенный таймер отклоняется с ошибкой CONFLICT
+	Create(ctx context.Context, entry *models.TimeEntry) (*models.TimeEntry, error)
+
+	// GetActive получает запущенный таймер; nil - таймер не запущен
+	GetActive(ctx context.Context) (*models.TimeEntry, error)
+
+	// Stop останавливает запущенный таймер в момент endedAt
+	Stop(ctx context.Context, endedAt time.Time) (*models.TimeEntry, error)
+
+	// Delete удаляет запись времени
+	Delete(ctx context.Context, id int64) error
+
+	// GetEntries получает записи, пересекающие период фильтра, в порядке начала
+	GetEntries(ctx context.Context, filter models.TimeEntryFilter) ([]*models.TimeEntry, error)
+
+	// GetTrackedByTask возвращает затраченное время в секундах по задачам на момент now
+	GetTrackedByTask(ctx context.Context, taskIDs []int, now time.Time) (map[int]int64, error)
+}
+
+// CommentRepository определяет интерфейс для работы с комментариями задач
+type CommentRepository interface {
+	// Create сохраняет комментарий
+	Create(ctx context.Context, comment *models.Comment) (*models.Comment, error)
+
+	// GetByID получает комментарий по ID
+	GetByID(ctx context.Context, id int64) (*models.Comment, error)
+
+	// Update заменяет тело комментария, сохраняя предыдущую версию в ревизиях
+	Update(ctx context.Context, id int64, body string, updatedAt time.Time) (*models.Comment, error)
+
+	// Delete удаляет комментарий вместе с ревизиями
+	Delete(ctx context.Context, id int64) error
+
+	// GetByTask получает комментарии задачи по времени создания в направлении order
+	GetByTask(ctx context.Context, taskID int, order models.SortOrder) ([]*models.Comment, error)
+
+	// GetByTasks получает комментарии нескольких задач, сгруппированные по задаче
+	GetByTasks(ctx context.Context, taskIDs []int) (map[int][]*models.Comment, error)
+
+	// GetRevisions получает предыдущие версии комментария от ранних к поздним
+	GetRevisions(ctx context.Context, commentID int64) ([]*models.CommentRevision, error)
+}
+
+// AttachmentRepository определяет интерфейс для работы с метаданными вложений
+type AttachmentRepository interface {
+	// Create сохраняет метаданные вложения
+	Create(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error)
+
+	// GetByID получает вложение по ID
+	GetByID(ctx context.Context, id int64) (*models.Attachment, error)
+
+	// Delete удаляет метаданные вложения; содержимое удаляет сборка мусора
+	Delete(ctx context.Context, id int64) error
+
+	// GetByTask получает вложения задачи по времени добавления
+	GetByTask(ctx context.Context, taskID int) ([]*models.Attachment, error)
+
+	// GetReferencedHashes возвращает хэши содержимого, на которое есть ссылки
+	GetReferencedHashes(ctx context.Context) (map[string]struct{}, error)
+}
+
+// WebhookRepository определяет интерфейс для работы с подписками и доставками webhooks
+type WebhookRepository interface {
+	// CreateSubscription создает подписку и возвращает ее с заполненным ID
+	CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error)
+
+	// GetSubscriptions получает все подписки
+	GetSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error)
+
+	// GetActiveSubscriptions получает включенные подписки
+	GetActiveSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error)
+
+	// GetSubscriptionByID получает подписку по ID
+	GetSubscriptionByID(ctx context.Context, id int) (*models.WebhookSubscription, error)
+
+	// SetSubscriptionActive включает или отключает подписку
+	SetSubscriptionActive(ctx context.Context, id int, active bool) error
+
+	// DeleteSubscription удаляет подписку вместе с журналом доставок
+	DeleteSubscription(ctx context.Context, id int) error
+
+	// CreateDelivery ставит доставку в очередь. Повторная доставка того же
+	// события той же подписке игнорируется; возвращает false, если запись уже была
+	CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) (bool, error)
+
+	// GetDueDeliveries получает ожидающие доставки, время попытки которых наступило
+	GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error)
+
+	// GetDeliveries получает последние доставки подписки
+	GetDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*models.WebhookDelivery, error)
+
+	// GetDeliveryAttempts получает журнал попыток доставки
+	GetDeliveryAttempts(ctx context.Context, deliveryID int64) ([]*models.WebhookAttempt, error)
+
+	// RecordAttempt сохраняет попытку в журнал и новое состояние доставки
+	RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error
+
+	// RequeueDelivery возвращает доставку (в том числе из dead-letter) в очередь
+	RequeueDelivery(ctx context.Context, id int64, at time.Time) error
+
+	// CountDeliveries возвращает количество доставок в указанном состоянии
+	CountDeliveries(ctx context.Context, status models.WebhookDeliveryStatus) (int, error)
+}
+
 // Repository объединяет все репозитории
 type Repository struct {
 	Task     TaskRepository
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"todo-app/app/models"
+)
+
+// postgresOutboxRepository реализует OutboxRepository для PostgreSQL
+type postgresOutboxRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresOutboxRepository создает новый PostgreSQL репозиторий outbox
+func NewPostgresOutboxRepository(db *sql.DB) OutboxRepository {
+	return &postgresOutboxRepository{db: db}
+}
+
+// Save сохраняет событие в outbox
+func (r *postgresOutboxRepository) Save(ctx context.Context, event *models.OutboxEvent) error {
+	query := `
+        INSERT INTO event_outbox (event_type, payload, created_at)
+        VALUES ($1, $2, $3)
+        RETURNING id`
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		event.EventType,
+		[]byte(event.Payload),
+		event.CreatedAt,
+	).Scan(&event.ID)
+
+	if err != nil {
+		return dbError(err, "failed to save outbox event")
+	}
+
+	return nil
+}
+
+// GetPending получает неопубликованные события, исключая записи с окончательной
+// ошибкой и отложенные до next_attempt_at
+func (r *postgresOutboxRepository) GetPending(ctx context.Context, limit int) ([]*models.OutboxEvent, error) {
+	query := `
+        SELECT id, event_type, payload, created_at, attempts, COALESCE(last_error, '')
+        FROM event_outbox
+        WHERE published_at IS NULL AND last_error IS NULL
+          AND (next_attempt_at IS NULL OR next_attempt_at <= $2)
+        ORDER BY id ASC
+        LIMIT $1`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, limit, time.Now())
+	if err != nil {
+		return nil, dbError(err, "failed to get pending outbox events")
+	}
+	defer rows.Close()
+
+	var events []*models.OutboxEvent
+	for rows.Next() {
+		event := &models.OutboxEvent{}
+		var payload []byte
+		err := rows.Scan(
+			&event.ID,
+			&event.EventType,
+			&payload,
+			&event.CreatedAt,
+			&event.Attempts,
+			&event.LastError,
+		)
+		if err != nil {
+			return nil, dbError(err, "failed to scan outbox event")
+		}
+		event.Payload = payload
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dbError(err, "rows iteration error")
+	}
+
+	return events, nil
+}
+
+// MarkPublished отмечает событие опубликованным
+func (r *postgresOutboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	query := `UPDATE event_outbox SET published_at = $2 WHERE id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, id, time.Now()); err != nil {
+		return dbError(err, "failed to mark outbox event as published")
+	}
+
+	return nil
+}
+
+// MarkRetry увеличивает счетчик попыток и откладывает событие до nextAttemptAt
+func (r *postgresOutboxRepository) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time) error {
+	query := `UPDATE event_outbox SET attempts = attempts + 1, next_attempt_at = $2 WHERE id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, id, nextAttemptAt); err != nil {
+		return dbError(err, "failed to schedule outbox event retry")
+	}
+
+	return nil
+}
+
+// MarkFailed сохраняет ошибку публикации, после чего событие больше не выбирается
+func (r *postgresOutboxRepository) MarkFailed(ctx context.Context, id int64, lastError string) error {
+	query := `UPDATE event_outbox SET attempts = attempts + 1, last_error = $2 WHERE id = $1`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, id, lastError); err != nil {
+		return dbError(err, "failed to mark outbox event as failed")
+	}
+
+	return nil
+}
+
+// CountPending возвращает количество неопубликованных событий
+func (r *postgresOutboxRepository) CountPending(ctx context.Context) (int, error) {
+	query := `SELECT COUNT(*) FROM event_outbox WHERE published_at IS NULL AND last_error IS NULL`
+
+	var count int
+	err := retryRead(ctx, func() error {
+		return executor(ctx, r.db).QueryRowContext(ctx, query).Scan(&count)
+	})
+	if err != nil {
+		return 0, dbError(err, "failed to count pending outbox events")
+	}
+
+	return count, nil
+}
+
+// GetByTask получает последние limit событий задачи в порядке записи.
+// Outbox хранит и опубликованные события, поэтому служит историей задачи
+func (r *postgresOutboxRepository) GetByTask(ctx context.Context, taskID int, limit int) ([]*models.OutboxEvent, error) {
+	query := `
+        SELECT id, event_type, payload, created_at
+        FROM (
+            SELECT id, event_type, payload, created_at
+            FROM event_outbox
+            WHERE payload->>'task_id' = $1
+            ORDER BY id DESC
+            LIMIT $2
+        ) recent
+        ORDER BY id ASC`
+
+	var events []*models.OutboxEvent
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, strconv.Itoa(taskID), limit)
+		if err != nil {
+			return dbError(err, "failed to get task events")
+		}
+		defer rows.Close()
+
+		events = nil
+		for rows.Next() {
+			event := &models.OutboxEvent{}
+			var payload []byte
+			if err := rows.Scan(&event.ID, &event.EventType, &payload, &event.CreatedAt); err != nil {
+				return dbError(err, "failed to scan task event")
+			}
+			event.Payload = payload
+			events = append(events, event)
+		}
+
+		if err := rows.Err(); err != nil {
+			return dbError(err, "rows iteration error")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"todo-app/app/models"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // postgresTaskRepository реализует TaskRepository для PostgreSQL
@@ -25,26 +27,34 @@ func NewPostgresTaskRepository(db *sql.DB) TaskRepository {
 // Create создает новую задачу
 func (r *postgresTaskRepository) Create(ctx context.Context, task *models.Task) (*models.Task, error) {
 	query := `
-        INSERT INTO tasks (title, description, status, priority, due_date, created_at, updated_at)
-        VALUES ($1, $2, $3, $4, $5, $6, $7)
-        RETURNING id, created_at, updated_at`
+        INSERT INTO tasks (title, description, status, priority, due_date, due_all_day, project, tags, created_at, updated_at,
+                           position, board_position, estimate_minutes)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10,
+                (SELECT COALESCE(MIN(position), 0) - $11 FROM tasks),
+                (SELECT COALESCE(MIN(board_position), 0) - $11 FROM tasks WHERE status = $3), $12)
+        RETURNING id, created_at, updated_at, position, board_position`
 
 	now := time.Now()
 	task.CreatedAt = now
 	task.UpdatedAt = now
 
-	err := r.db.QueryRowContext(ctx, query,
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
 		task.Title,
 		task.Description,
 		task.Status,
 		task.Priority,
 		task.DueDate,
+		task.DueAllDay,
+		task.Project,
+		pq.Array(task.Tags),
 		task.CreatedAt,
 		task.UpdatedAt,
-	).Scan(&task.ID, &task.CreatedAt, &task.UpdatedAt)
+		models.PositionStep, // новая задача - первая в списке и в колонке
+		task.EstimateMinutes,
+	).Scan(&task.ID, &task.CreatedAt, &task.UpdatedAt, &task.Position, &task.BoardPosition)
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to create task: %w", err)
+		return nil, dbError(err, "failed to create task")
 	}
 
 	return task, nil
@@ -56,69 +66,111 @@ func (r *postgresTaskRepository) GetAll(ctx context.Context, filter models.TaskF
 	orderClause := r.buildOrderClause(sort)
 
 	query := fmt.Sprintf(`
-        SELECT id, title, description, status, priority, due_date, created_at, updated_at, completed_at
+        SELECT `+taskColumns+`
         FROM tasks
         %s
         %s`, whereClause, orderClause)
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	var tasks []*models.Task
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+		if err != nil {
+			return dbError(err, "failed to get tasks")
+		}
+		defer rows.Close()
+
+		tasks, err = scanTasks(rows)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks: %w", err)
+		return nil, err
 	}
-	defer rows.Close()
+
+	return tasks, nil
+}
+
+// GetPage получает страницу задач с keyset-пагинацией
+func (r *postgresTaskRepository) GetPage(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, after *models.TaskCursor, limit int) (*models.TaskPage, error) {
+	whereClause, args := r.buildWhereClause(filter)
+	keyExpr, keyCast := r.keysetExpression(sort.Field)
+
+	orderDirection := "DESC"
+	comparison := "<"
+	if sort.Order == models.SortOrderAsc {
+		orderDirection = "ASC"
+		comparison = ">"
+	}
+
+	// Продолжаем строго после последней задачи предыдущей страницы
+	if after != nil {
+		condition := fmt.Sprintf("(%s, id) %s ($%d::%s, $%d)", keyExpr, comparison, len(args)+1, keyCast, len(args)+2)
+		args = append(args, after.Value, after.ID)
+		if whereClause == "" {
+			whereClause = "WHERE " + condition
+		} else {
+			whereClause += " AND " + condition
+		}
+	}
+
+	// Запрашиваем на одну задачу больше, чтобы узнать о наличии следующей страницы
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(`
+        SELECT `+taskColumns+`
+        FROM tasks
+        %s
+        ORDER BY %s %s, id %s
+        LIMIT $%d`, whereClause, keyExpr, orderDirection, orderDirection, len(args))
 
 	var tasks []*models.Task
-	for rows.Next() {
-		task := &models.Task{}
-		err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.Priority,
-			&task.DueDate,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-			&task.CompletedAt,
-		)
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan task: %w", err)
+			return dbError(err, "failed to get tasks page")
 		}
-		tasks = append(tasks, task)
+		defer rows.Close()
+
+		tasks, err = scanTasks(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("rows iteration error: %w", err)
+	page := &models.TaskPage{Tasks: tasks}
+	if len(tasks) > limit {
+		page.Tasks = tasks[:limit]
+		page.HasNext = true
+
+		last := page.Tasks[len(page.Tasks)-1]
+		page.NextCursor = models.EncodeTaskCursor(models.TaskCursor{
+			Field: sort.Field,
+			Order: sort.Order,
+			Value: r.keysetValue(sort.Field, last),
+			ID:    last.ID,
+		})
 	}
 
-	return tasks, nil
+	return page, nil
 }
 
 // GetByID получает задачу по ID
 func (r *postgresTaskRepository) GetByID(ctx context.Context, id int) (*models.Task, error) {
 	query := `
-        SELECT id, title, description, status, priority, due_date, created_at, updated_at, completed_at
+        SELECT ` + taskColumns + `
         FROM tasks 
         WHERE id = $1`
 
 	task := &models.Task{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&task.ID,
-		&task.Title,
-		&task.Description,
-		&task.Status,
-		&task.Priority,
-		&task.DueDate,
-		&task.CreatedAt,
-		&task.UpdatedAt,
-		&task.CompletedAt,
-	)
+	err := retryRead(ctx, func() error {
+		return executor(ctx, r.db).QueryRowContext(ctx, query, id).Scan(taskScanTargets(task)...)
+	})
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("task with id %d not found", id)
+			return nil, taskNotFound(id)
 		}
-		return nil, fmt.Errorf("failed to get task: %w", err)
+		return nil, dbError(err, "failed to get task")
 	}
 
 	return task, nil
@@ -128,26 +180,29 @@ func (r *postgresTaskRepository) GetByID(ctx context.Context, id int) (*models.T
 func (r *postgresTaskRepository) Update(ctx context.Context, task *models.Task) (*models.Task, error) {
 	query := `
         UPDATE tasks 
-        SET title = $2, description = $3, priority = $4, due_date = $5, updated_at = $6
+        SET title = $2, description = $3, priority = $4, due_date = $5, due_all_day = $6, updated_at = $7,
+            estimate_minutes = $8
         WHERE id = $1
         RETURNING updated_at`
 
 	task.UpdatedAt = time.Now()
 
-	err := r.db.QueryRowContext(ctx, query,
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
 		task.ID,
 		task.Title,
 		task.Description,
 		task.Priority,
 		task.DueDate,
+		task.DueAllDay,
 		task.UpdatedAt,
+		task.EstimateMinutes,
 	).Scan(&task.UpdatedAt)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("task with id %d not found", task.ID)
+			return nil, taskNotFound(task.ID)
 		}
-		return nil, fmt.Errorf("failed to update task: %w", err)
+		return nil, dbError(err, "failed to update task")
 	}
 
 	return task, nil
@@ -157,104 +212,158 @@ func (r *postgresTaskRepository) Update(ctx context.Context, task *models.Task)
 func (r *postgresTaskRepository) Delete(ctx context.Context, id int) error {
 	query := `DELETE FROM tasks WHERE id = $1`
 
-	result, err := r.db.ExecContext(ctx, query, id)
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("failed to delete task: %w", err)
+		return dbError(err, "failed to delete task")
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get affected rows: %w", err)
+		return dbError(err, "failed to get affected rows")
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("task with id %d not found", id)
+		return taskNotFound(id)
 	}
 
 	return nil
 }
 
-// MarkAsCompleted помечает задачу как выполненную
-func (r *postgresTaskRepository) MarkAsCompleted(ctx context.Context, id int) error {
+// UpdateStatus сохраняет статус задачи и отметки времени переходов.
+// При смене колонки задача становится первой в колонке нового статуса
+func (r *postgresTaskRepository) UpdateStatus(ctx context.Context, task *models.Task) error {
 	query := `
         UPDATE tasks 
-        SET status = $2, completed_at = $3, updated_at = $4
+        SET status = $2, started_at = $3, completed_at = $4, cancelled_at = $5, updated_at = $6,
+            board_position = CASE WHEN status = $2 THEN board_position
+                ELSE (SELECT COALESCE(MIN(board_position), 0) - $7 FROM tasks WHERE status = $2) END
         WHERE id = $1`
 
-	now := time.Now()
-
-	result, err := r.db.ExecContext(ctx, query, id, models.TaskStatusCompleted, &now, now)
+	result, err := executor(ctx, r.db).ExecContext(ctx, query,
+		task.ID,
+		task.Status,
+		task.StartedAt,
+		task.CompletedAt,
+		task.CancelledAt,
+		task.UpdatedAt,
+		models.PositionStep,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to mark task as completed: %w", err)
+		return dbError(err, "failed to update task status")
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get affected rows: %w", err)
+		return dbError(err, "failed to get affected rows")
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("task with id %d not found", id)
+		return taskNotFound(task.ID)
 	}
 
 	return nil
 }
 
-// MarkAsActive помечает задачу как активную
-func (r *postgresTaskRepository) MarkAsActive(ctx context.Context, id int) error {
+// Archive переносит задачу в архив
+func (r *postgresTaskRepository) Archive(ctx context.Context, id int) error {
 	query := `
         UPDATE tasks 
-        SET status = $2, completed_at = NULL, updated_at = $3
+        SET archived = TRUE, updated_at = $2
         WHERE id = $1`
 
-	now := time.Now()
-
-	result, err := r.db.ExecContext(ctx, query, id, models.TaskStatusActive, now)
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id, time.Now())
 	if err != nil {
-		return fmt.Errorf("failed to mark task as active: %w", err)
+		return dbError(err, "failed to archive task")
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get affected rows: %w", err)
+		return dbError(err, "failed to get affected rows")
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("task with id %d not found", id)
+		return taskNotFound(id)
 	}
 
 	return nil
 }
 
-// GetTasksStats получает статистику по задачам
-func (r *postgresTaskRepository) GetTasksStats(ctx context.Context) (*models.TaskStats, error) {
-	query := `
+// GetTasksStats получает статистику по задачам; сегодня, неделя и просрочка
+// считаются в часовом поясе timezone
+func (r *postgresTaskRepository) GetTasksStats(ctx context.Context, timezone string) (*models.TaskStats, error) {
+	window := models.NewDueWindow(time.Now(), timezone)
+
+	var args []interface{}
+	condition := func(dateType models.DateFilter) string {
+		condition, dueArgs := dueDateCondition(dateType, window, len(args)+1)
+		args = append(args, dueArgs...)
+		return condition
+	}
+
+	query := fmt.Sprintf(`
         SELECT 
             COUNT(*) as total_tasks,
-            COUNT(CASE WHEN status = 'active' THEN 1 END) as active_tasks,
-            COUNT(CASE WHEN status = 'completed' THEN 1 END) as completed_tasks,
-            COUNT(CASE WHEN status = 'active' AND due_date IS NOT NULL AND due_date < NOW() THEN 1 END) as overdue_tasks,
-            COUNT(CASE WHEN status = 'active' AND DATE(due_date) = CURRENT_DATE THEN 1 END) as today_tasks,
-            COUNT(CASE WHEN status = 'active' AND due_date BETWEEN NOW() AND NOW() + INTERVAL '7 days' THEN 1 END) as week_tasks
-        FROM tasks`
+            COUNT(CASE WHEN %s THEN 1 END) as active_tasks,
+            COUNT(CASE WHEN status = 'done' THEN 1 END) as completed_tasks,
+            COUNT(CASE WHEN %s THEN 1 END) as overdue_tasks,
+            COUNT(CASE WHEN %s AND %s THEN 1 END) as today_tasks,
+            COUNT(CASE WHEN %s AND %s THEN 1 END) as week_tasks
+        FROM tasks`,
+		openStatusCondition,
+		condition(models.DateFilterOverdue),
+		openStatusCondition, condition(models.DateFilterToday),
+		openStatusCondition, condition(models.DateFilterWeek),
+	)
 
 	stats := &models.TaskStats{}
-	err := r.db.QueryRowContext(ctx, query).Scan(
-		&stats.TotalTasks,
-		&stats.ActiveTasks,
-		&stats.CompletedTasks,
-		&stats.OverdueTasks,
-		&stats.TodayTasks,
-		&stats.WeekTasks,
-	)
+	err := retryRead(ctx, func() error {
+		return executor(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(
+			&stats.TotalTasks,
+			&stats.ActiveTasks,
+			&stats.CompletedTasks,
+			&stats.OverdueTasks,
+			&stats.TodayTasks,
+			&stats.WeekTasks,
+		)
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to get tasks stats: %w", err)
+		return nil, dbError(err, "failed to get tasks stats")
 	}
 
 	return stats, nil
 }
 
+// GetStatusCounts получает количество задач в каждом статусе
+func (r *postgresTaskRepository) GetStatusCounts(ctx context.Context) (map[models.TaskStatus]int, error) {
+	query := `SELECT status, COUNT(*) FROM tasks GROUP BY status`
+
+	var counts map[models.TaskStatus]int
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query)
+		if err != nil {
+			return dbError(err, "failed to get status counts")
+		}
+		defer rows.Close()
+
+		counts = make(map[models.TaskStatus]int)
+		for rows.Next() {
+			var status models.TaskStatus
+			var count int
+			if err := rows.Scan(&status, &count); err != nil {
+				return dbError(err, "failed to scan status count")
+			}
+			counts[status] = count
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
 // GetTasksCount получает количество задач с учетом фильтра
 func (r *postgresTaskRepository) GetTasksCount(ctx context.Context, filter models.TaskFilter) (int, error) {
 	whereClause, args := r.buildWhereClause(filter)
@@ -262,9 +371,11 @@ func (r *postgresTaskRepository) GetTasksCount(ctx context.Context, filter model
 	query := fmt.Sprintf(`SELECT COUNT(*) FROM tasks %s`, whereClause)
 
 	var count int
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	err := retryRead(ctx, func() error {
+		return executor(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&count)
+	})
 	if err != nil {
-		return 0, fmt.Errorf("failed to get tasks count: %w", err)
+		return 0, dbError(err, "failed to get tasks count")
 	}
 
 	return count, nil
@@ -273,73 +384,56 @@ func (r *postgresTaskRepository) GetTasksCount(ctx context.Context, filter model
 // GetRecentTasks получает последние созданные задачи
 func (r *postgresTaskRepository) GetRecentTasks(ctx context.Context, limit int) ([]*models.Task, error) {
 	query := `
-        SELECT id, title, description, status, priority, due_date, created_at, updated_at, completed_at
+        SELECT ` + taskColumns + `
         FROM tasks
         ORDER BY created_at DESC
         LIMIT $1`
 
-	rows, err := r.db.QueryContext(ctx, query, limit)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get recent tasks: %w", err)
-	}
-	defer rows.Close()
-
 	var tasks []*models.Task
-	for rows.Next() {
-		task := &models.Task{}
-		err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.Priority,
-			&task.DueDate,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-			&task.CompletedAt,
-		)
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, limit)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan task: %w", err)
+			return dbError(err, "failed to get recent tasks")
 		}
-		tasks = append(tasks, task)
+		defer rows.Close()
+
+		tasks, err = scanTasks(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return tasks, nil
 }
 
-// GetUpcomingTasks получает задачи с ближайшими сроками
-func (r *postgresTaskRepository) GetUpcomingTasks(ctx context.Context, limit int) ([]*models.Task, error) {
-	query := `
-        SELECT id, title, description, status, priority, due_date, created_at, updated_at, completed_at
+// GetUpcomingTasks получает задачи с ближайшими сроками; граница с просроченными
+// задачами та же, что в фильтре overdue для часового пояса timezone
+func (r *postgresTaskRepository) GetUpcomingTasks(ctx context.Context, timezone string, limit int) ([]*models.Task, error) {
+	boundary, args := dueBoundary(models.NewDueWindow(time.Now(), timezone), 1)
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+        SELECT `+taskColumns+`
         FROM tasks
-        WHERE status = 'active' AND due_date IS NOT NULL AND due_date >= NOW()
+        WHERE `+openStatusCondition+` AND due_date IS NOT NULL
+          AND due_date >= %s
         ORDER BY due_date ASC
-        LIMIT $1`
-
-	rows, err := r.db.QueryContext(ctx, query, limit)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get upcoming tasks: %w", err)
-	}
-	defer rows.Close()
+        LIMIT $%d`, boundary, len(args))
 
 	var tasks []*models.Task
-	for rows.Next() {
-		task := &models.Task{}
-		err := rows.Scan(
-			&task.ID,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.Priority,
-			&task.DueDate,
-			&task.CreatedAt,
-			&task.UpdatedAt,
-			&task.CompletedAt,
-		)
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan task: %w", err)
+			return dbError(err, "failed to get upcoming tasks")
 		}
-		tasks = append(tasks, task)
+		defer rows.Close()
+
+		tasks, err = scanTasks(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return tasks, nil
@@ -351,10 +445,20 @@ func (r *postgresTaskRepository) buildWhereClause(filter models.TaskFilter) (str
 	var args []interface{}
 	argIndex := 1
 
-	// Фильтр по статусу
-	if filter.Status != "" {
+	// Фильтр по статусу: конкретный статус или группа (active, completed)
+	switch statuses := filter.Status.Expand(); len(statuses) {
+	case 0:
+	case 1:
 		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
-		args = append(args, filter.Status)
+		args = append(args, statuses[0])
+		argIndex++
+	default:
+		values := make([]string, len(statuses))
+		for i, status := range statuses {
+			values[i] = string(status)
+		}
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", argIndex))
+		args = append(args, pq.Array(values))
 		argIndex++
 	}
 
@@ -365,22 +469,21 @@ func (r *postgresTaskRepository) buildWhereClause(filter models.TaskFilter) (str
 		argIndex++
 	}
 
-	// Поиск по тексту
+	// Поиск по тексту задачи и ее комментариев
 	if filter.Search != "" {
-		conditions = append(conditions, fmt.Sprintf("(title ILIKE $%d OR description ILIKE $%d)", argIndex, argIndex))
+		conditions = append(conditions, fmt.Sprintf(
+			"(title ILIKE $%[1]d OR description ILIKE $%[1]d OR EXISTS (SELECT 1 FROM task_comments c WHERE c.task_id = tasks.id AND c.body ILIKE $%[1]d))",
+			argIndex))
 		searchPattern := "%" + filter.Search + "%"
 		args = append(args, searchPattern)
 		argIndex++
 	}
 
-	// Фильтр по дате
-	switch filter.DateType {
-	case models.DateFilterToday:
-		conditions = append(conditions, "DATE(due_date) = CURRENT_DATE")
-	case models.DateFilterWeek:
-		conditions = append(conditions, "due_date BETWEEN NOW() AND NOW() + INTERVAL '7 days'")
-	case models.DateFilterOverdue:
-		conditions = append(conditions, "status = 'active' AND due_date IS NOT NULL AND due_date < NOW()")
+	// Фильтр по дате в часовом поясе пользователя
+	if condition, dueArgs := dueDateCondition(filter.DateType, models.NewDueWindow(time.Now(), filter.Timezone), argIndex); condition != "" {
+		conditions = append(conditions, condition)
+		args = append(args, dueArgs...)
+		argIndex += len(dueArgs)
 	}
 
 	// Диапазон дат
@@ -403,6 +506,38 @@ func (r *postgresTaskRepository) buildWhereClause(filter models.TaskFilter) (str
 	return "WHERE " + strings.Join(conditions, " AND "), args
 }
 
+// dueDateCondition возвращает условие фильтра по сроку (today, week, overdue)
+// в границах window и его параметры, начиная с $argIndex. Сроки со временем
+// сравниваются с моментами, сроки на весь день - с датами полночью UTC
+func dueDateCondition(dateType models.DateFilter, window models.DueWindow, argIndex int) (string, []interface{}) {
+	between := func(fromDate, toDate, from, to time.Time) (string, []interface{}) {
+		return fmt.Sprintf(
+				"due_date >= (CASE WHEN due_all_day THEN $%d ELSE $%d END)::timestamptz AND "+
+					"due_date < (CASE WHEN due_all_day THEN $%d ELSE $%d END)::timestamptz",
+				argIndex, argIndex+1, argIndex+2, argIndex+3),
+			[]interface{}{fromDate, from, toDate, to}
+	}
+
+	switch dateType {
+	case models.DateFilterToday:
+		return between(window.Today, window.Tomorrow, window.DayStart, window.DayEnd)
+	case models.DateFilterWeek:
+		return between(window.Today, window.WeekDay, window.Now, window.WeekEnd)
+	case models.DateFilterOverdue:
+		boundary, args := dueBoundary(window, argIndex)
+		return openStatusCondition + " AND due_date IS NOT NULL AND due_date < " + boundary, args
+	default:
+		return "", nil
+	}
+}
+
+// dueBoundary возвращает границу просрочки в window: текущий момент для сроков
+// со временем и сегодняшнюю дату для сроков на весь день
+func dueBoundary(window models.DueWindow, argIndex int) (string, []interface{}) {
+	return fmt.Sprintf("(CASE WHEN due_all_day THEN $%d ELSE $%d END)::timestamptz", argIndex, argIndex+1),
+		[]interface{}{window.Today, window.Now}
+}
+
 // buildOrderClause строит ORDER BY условие
 func (r *postgresTaskRepository) buildOrderClause(sort models.TaskSort) string {
 	var orderField string
@@ -416,6 +551,8 @@ func (r *postgresTaskRepository) buildOrderClause(sort models.TaskSort) string {
 		orderField = "due_date"
 	case models.SortFieldStatus:
 		orderField = "status"
+	case models.SortFieldManual:
+		orderField = "position"
 	default:
 		orderField = "created_at"
 	}
@@ -428,6 +565,118 @@ func (r *postgresTaskRepository) buildOrderClause(sort models.TaskSort) string {
 	return fmt.Sprintf("ORDER BY %s %s", orderField, orderDirection)
 }
 
+// keysetExpression возвращает SQL выражение ключа сортировки и тип для приведения значения курсора.
+// NULL в due_date заменяется на 'infinity', что сохраняет порядок PostgreSQL по умолчанию
+// (NULLS LAST для ASC, NULLS FIRST для DESC) и позволяет сравнивать строки целиком
+func (r *postgresTaskRepository) keysetExpression(field models.SortField) (string, string) {
+	switch field {
+	case models.SortFieldTitle:
+		return "title", "text"
+	case models.SortFieldPriority:
+		return "CASE priority WHEN 'high' THEN 1 WHEN 'medium' THEN 2 WHEN 'low' THEN 3 END", "int"
+	case models.SortFieldDueDate:
+		return "COALESCE(due_date, 'infinity'::timestamptz)", "timestamptz"
+	case models.SortFieldStatus:
+		return "status", "text"
+	case models.SortFieldManual:
+		return "position", "float8"
+	case models.SortFieldUpdatedAt:
+		return "updated_at", "timestamp"
+	default:
+		return "created_at", "timestamp"
+	}
+}
+
+// keysetValue возвращает значение ключа сортировки задачи в виде, пригодном для курсора
+func (r *postgresTaskRepository) keysetValue(field models.SortField, task *models.Task) string {
+	const timestampLayout = "2006-01-02 15:04:05.999999"
+
+	switch field {
+	case models.SortFieldTitle:
+		return task.Title
+	case models.SortFieldPriority:
+		switch task.Priority {
+		case models.PriorityHigh:
+			return "1"
+		case models.PriorityLow:
+			return "3"
+		default:
+			return "2"
+		}
+	case models.SortFieldDueDate:
+		if task.DueDate == nil {
+			return "infinity"
+		}
+		// Срок хранится в timestamptz, значение курсора содержит смещение
+		return task.DueDate.Format(timestampLayout + "Z07:00")
+	case models.SortFieldStatus:
+		return string(task.Status)
+	case models.SortFieldManual:
+		return strconv.FormatFloat(task.Position, 'g', -1, 64)
+	case models.SortFieldUpdatedAt:
+		return task.UpdatedAt.Format(timestampLayout)
+	default:
+		return task.CreatedAt.Format(timestampLayout)
+	}
+}
+
+// taskColumns - столбцы задачи в порядке taskScanTargets
+const taskColumns = "id, title, description, status, priority, due_date, due_all_day, project, tags, created_at, updated_at, completed_at, started_at, cancelled_at, position, board_position, estimate_minutes"
+
+// openStatusCondition - условие SQL для незакрытых задач (todo, in_progress, waiting)
+var openStatusCondition = statusInCondition("status", models.OpenStatuses())
+
+// statusInCondition возвращает условие вхождения столбца в список статусов.
+// Статусы - константы приложения, поэтому подставляются в запрос литералами
+func statusInCondition(column string, statuses []models.TaskStatus) string {
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = "'" + string(status) + "'"
+	}
+	return fmt.Sprintf("%s IN (%s)", column, strings.Join(values, ", "))
+}
+
+// taskScanTargets возвращает поля задачи для Scan в порядке taskColumns
+func taskScanTargets(task *models.Task) []interface{} {
+	return []interface{}{
+		&task.ID,
+		&task.Title,
+		&task.Description,
+		&task.Status,
+		&task.Priority,
+		&task.DueDate,
+		&task.DueAllDay,
+		&task.Project,
+		pq.Array(&task.Tags),
+		&task.CreatedAt,
+		&task.UpdatedAt,
+		&task.CompletedAt,
+		&task.StartedAt,
+		&task.CancelledAt,
+		&task.Position,
+		&task.BoardPosition,
+		&task.EstimateMinutes,
+	}
+}
+
+// scanTasks считывает задачи из результата запроса
+func scanTasks(rows *sql.Rows) ([]*models.Task, error) {
+	var tasks []*models.Task
+	for rows.Next() {
+		task := &models.Task{}
+		if err := rows.Scan(taskScanTargets(task)...); err != nil {
+			return nil, dbError(err, "failed to scan task")
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError(err, "rows iteration error")
+	}
+
+	return tasks, nil
+}
+
 // postgresSettingsRepository реализует SettingsRepository для PostgreSQL
 type postgresSettingsRepository struct {
 	db *sql.DB
@@ -438,60 +687,65 @@ func NewPostgresSettingsRepository(db *sql.DB) SettingsRepository {
 	return &postgresSettingsRepository{db: db}
 }
 
-// GetSettings получает настройки приложения
+// GetSettings получает настройки приложения. Пустое хранилище дает настройки
+// по умолчанию, хранилище старой версии схемы поднимается до текущей
 func (r *postgresSettingsRepository) GetSettings(ctx context.Context) (*models.AppSettings, error) {
-	query := `
-        SELECT theme, language, notifications_on, auto_save 
-        FROM app_settings 
-        WHERE id = 1`
-
-	settings := &models.AppSettings{}
-	err := r.db.QueryRowContext(ctx, query).Scan(
-		&settings.Theme,
-		&settings.Language,
-		&settings.NotificationsOn,
-		&settings.AutoSave,
-	)
+	query := `SELECT key, value FROM app_settings`
 
-	if err != nil {
-		if err == sql.ErrNoRows {
-			// Возвращаем настройки по умолчанию
-			return &models.AppSettings{
-				Theme:           "light",
-				Language:        "en",
-				NotificationsOn: true,
-				AutoSave:        true,
-			}, nil
+	var values map[models.SettingKey]string
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		values = make(map[models.SettingKey]string)
+		for rows.Next() {
+			var key, value string
+			if err := rows.Scan(&key, &value); err != nil {
+				return err
+			}
+			values[models.SettingKey(key)] = value
 		}
-		return nil, fmt.Errorf("failed to get settings: %w", err)
+		return rows.Err()
+	})
+
+	if err != nil {
+		return nil, dbError(err, "failed to get settings")
 	}
 
-	return settings, nil
+	return models.SettingsFromValues(values), nil
 }
 
-// UpdateSettings обновляет настройки приложения
+// UpdateSettings сохраняет все ключи настроек вместе с версией схемы;
+// неизвестные этой сборке ключи не удаляются
 func (r *postgresSettingsRepository) UpdateSettings(ctx context.Context, settings *models.AppSettings) error {
 	query := `
-        INSERT INTO app_settings (id, theme, language, notifications_on, auto_save, updated_at)
-        VALUES (1, $1, $2, $3, $4, $5)
-        ON CONFLICT (id) 
-        DO UPDATE SET 
-            theme = EXCLUDED.theme,
-            language = EXCLUDED.language,
-            notifications_on = EXCLUDED.notifications_on,
-            auto_save = EXCLUDED.auto_save,
-            updated_at = EXCLUDED.updated_at`
-
-	_, err := r.db.ExecContext(ctx, query,
-		settings.Theme,
-		settings.Language,
-		settings.NotificationsOn,
-		settings.AutoSave,
-		time.Now(),
-	)
+        INSERT INTO app_settings (key, value, updated_at)
+        SELECT key, value, $3
+        FROM unnest($1::text[], $2::text[]) AS s(key, value)
+        ON CONFLICT (key)
+        DO UPDATE SET
+            value = EXCLUDED.value,
+            updated_at = EXCLUDED.updated_at
+        WHERE app_settings.value IS DISTINCT FROM EXCLUDED.value`
+
+	values := settings.Values()
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, string(key))
+	}
+	sort.Strings(keys)
+
+	data := make([]string, len(keys))
+	for i, key := range keys {
+		data[i] = values[models.SettingKey(key)]
+	}
 
+	_, err := executor(ctx, r.db).ExecContext(ctx, query, pq.Array(keys), pq.Array(data), time.Now())
 	if err != nil {
-		return fmt.Errorf("failed to update settings: %w", err)
+		return dbError(err, "failed to update settings")
 	}
 
 	return nil
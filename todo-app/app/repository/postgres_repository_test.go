@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"testing"
 	"time"
 	"todo-app/app/models"
@@ -26,7 +27,7 @@ func TestPostgresTaskRepository_Create(t *testing.T) {
 	task := &models.Task{
 		Title:       "Test Task",
 		Description: "Test Description",
-		Status:      models.TaskStatusActive,
+		Status:      models.TaskStatusTodo,
 		Priority:    models.PriorityMedium,
 	}
 
@@ -36,9 +37,9 @@ func TestPostgresTaskRepository_Create(t *testing.T) {
 
 	// Настраиваем mock
 	mock.ExpectQuery(`INSERT INTO tasks`).
-		WithArgs(task.Title, task.Description, task.Status, task.Priority, sqlmock.AnyArg(), sqlmock.AnyArg()).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).
-			AddRow(expectedID, expectedTime, expectedTime))
+		WithArgs(task.Title, task.Description, task.Status, task.Priority, nil, false, "", nil, sqlmock.AnyArg(), sqlmock.AnyArg(), models.PositionStep, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "position", "board_position"}).
+			AddRow(expectedID, expectedTime, expectedTime, -models.PositionStep, -models.PositionStep))
 
 	// Выполняем тест
 	result, err := repo.Create(ctx, task)
@@ -71,13 +72,13 @@ func TestPostgresTaskRepository_Create_Error(t *testing.T) {
 	task := &models.Task{
 		Title:       "Test Task",
 		Description: "Test Description",
-		Status:      models.TaskStatusActive,
+		Status:      models.TaskStatusTodo,
 		Priority:    models.PriorityMedium,
 	}
 
 	// Настраиваем mock для возврата ошибки
 	mock.ExpectQuery(`INSERT INTO tasks`).
-		WithArgs(task.Title, task.Description, task.Status, task.Priority, sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WithArgs(task.Title, task.Description, task.Status, task.Priority, nil, false, "", nil, sqlmock.AnyArg(), sqlmock.AnyArg(), models.PositionStep, nil).
 		WillReturnError(sql.ErrConnDone)
 
 	// Выполняем тест
@@ -111,7 +112,7 @@ func TestPostgresTaskRepository_GetByID(t *testing.T) {
 		ID:          expectedID,
 		Title:       "Test Task",
 		Description: "Test Description",
-		Status:      models.TaskStatusActive,
+		Status:      models.TaskStatusTodo,
 		Priority:    models.PriorityHigh,
 		CreatedAt:   expectedTime,
 		UpdatedAt:   expectedTime,
@@ -121,10 +122,10 @@ func TestPostgresTaskRepository_GetByID(t *testing.T) {
 	mock.ExpectQuery(`SELECT (.+) FROM tasks WHERE id = \$1`).
 		WithArgs(expectedID).
 		WillReturnRows(sqlmock.NewRows([]string{
-			"id", "title", "description", "status", "priority", "due_date", "created_at", "updated_at", "completed_at",
+			"id", "title", "description", "status", "priority", "due_date", "due_all_day", "project", "tags", "created_at", "updated_at", "completed_at", "started_at", "cancelled_at", "position", "board_position", "estimate_minutes",
 		}).AddRow(
 			expectedTask.ID, expectedTask.Title, expectedTask.Description, expectedTask.Status,
-			expectedTask.Priority, nil, expectedTask.CreatedAt, expectedTask.UpdatedAt, nil,
+			expectedTask.Priority, nil, false, "", "{}", expectedTask.CreatedAt, expectedTask.UpdatedAt, nil, nil, nil, 1024.0, 1024.0, nil,
 		))
 
 	// Выполняем тест
@@ -190,21 +191,16 @@ func TestPostgresTaskRepository_Update(t *testing.T) {
 		ID:          1,
 		Title:       "Updated Task",
 		Description: "Updated Description",
-		Status:      models.TaskStatusCompleted,
+		Status:      models.TaskStatusDone,
 		Priority:    models.PriorityLow,
 	}
 
 	expectedTime := time.Now()
 
-	// Настраиваем mock
-	mock.ExpectQuery(`UPDATE tasks SET`).
-		WithArgs(task.Title, task.Description, task.Status, task.Priority, sqlmock.AnyArg(), sqlmock.AnyArg(), task.ID).
-		WillReturnRows(sqlmock.NewRows([]string{
-			"id", "title", "description", "status", "priority", "due_date", "created_at", "updated_at", "completed_at",
-		}).AddRow(
-			task.ID, task.Title, task.Description, task.Status, task.Priority,
-			nil, expectedTime, expectedTime, &expectedTime,
-		))
+	// Статус меняется отдельными операциями и в UPDATE не передается
+	mock.ExpectQuery(`UPDATE tasks\s+SET title = \$2`).
+		WithArgs(task.ID, task.Title, task.Description, task.Priority, nil, false, sqlmock.AnyArg(), nil).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(expectedTime))
 
 	// Выполняем тест
 	result, err := repo.Update(ctx, task)
@@ -278,3 +274,315 @@ func TestPostgresTaskRepository_Delete_NotFound(t *testing.T) {
 		t.Errorf("Unfulfilled expectations: %v", err)
 	}
 }
+
+func TestPostgresTaskRepository_GetPage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	ctx := context.Background()
+
+	sort := models.TaskSort{Field: models.SortFieldCreatedAt, Order: models.SortOrderDesc}
+	after := &models.TaskCursor{Field: sort.Field, Order: sort.Order, Value: "2024-01-10 12:00:00", ID: 10}
+	baseTime := time.Date(2024, 1, 10, 11, 0, 0, 0, time.UTC)
+
+	columns := []string{"id", "title", "description", "status", "priority", "due_date", "due_all_day", "project", "tags", "created_at", "updated_at", "completed_at", "started_at", "cancelled_at", "position", "board_position", "estimate_minutes"}
+	rows := sqlmock.NewRows(columns)
+	for i := 0; i < 3; i++ {
+		createdAt := baseTime.Add(-time.Duration(i) * time.Hour)
+		rows.AddRow(9-i, "Task", "", models.TaskStatusTodo, models.PriorityMedium, nil, false, "", "{}", createdAt, createdAt, nil, nil, nil, float64(i), float64(i), nil)
+	}
+
+	// Ожидаем keyset-условие после курсора и LIMIT на одну запись больше страницы
+	mock.ExpectQuery(`SELECT (.+) FROM tasks WHERE \(created_at, id\) < \(\$1::timestamp, \$2\) ORDER BY created_at DESC, id DESC LIMIT \$3`).
+		WithArgs(after.Value, after.ID, 3).
+		WillReturnRows(rows)
+
+	page, err := repo.GetPage(ctx, models.TaskFilter{}, sort, after, 2)
+
+	testutils.AssertNoError(t, err, "GetPage should not return error")
+	testutils.AssertEqual(t, 2, len(page.Tasks), "Page should be trimmed to limit")
+	testutils.AssertTrue(t, page.HasNext, "HasNext should be true when extra row is returned")
+
+	next, err := models.DecodeTaskCursor(page.NextCursor)
+	testutils.AssertNoError(t, err, "NextCursor should be decodable")
+	testutils.AssertEqual(t, 8, next.ID, "Cursor should point to the last task of the page")
+	testutils.AssertEqual(t, "2024-01-10 10:00:00", next.Value, "Cursor should carry the sort key of the last task")
+	testutils.AssertTrue(t, next.MatchesSort(sort), "Cursor should be bound to the requested sort")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_GetPage_LastPage(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	ctx := context.Background()
+
+	sort := models.TaskSort{Field: models.SortFieldDueDate, Order: models.SortOrderAsc}
+	now := time.Now()
+
+	mock.ExpectQuery(`SELECT (.+) FROM tasks WHERE status = \$1 ORDER BY COALESCE\(due_date, 'infinity'::timestamptz\) ASC, id ASC LIMIT \$2`).
+		WithArgs(models.TaskStatusTodo, 21).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"id", "title", "description", "status", "priority", "due_date", "due_all_day", "project", "tags", "created_at", "updated_at", "completed_at", "started_at", "cancelled_at", "position", "board_position", "estimate_minutes",
+		}).AddRow(1, "Only Task", "", models.TaskStatusTodo, models.PriorityHigh, nil, false, "", "{}", now, now, nil, nil, nil, 1024.0, 1024.0, nil))
+
+	page, err := repo.GetPage(ctx, models.TaskFilter{Status: models.TaskStatusTodo}, sort, nil, 20)
+
+	testutils.AssertNoError(t, err, "GetPage should not return error")
+	testutils.AssertEqual(t, 1, len(page.Tasks), "All rows should be returned")
+	testutils.AssertFalse(t, page.HasNext, "HasNext should be false on the last page")
+	testutils.AssertEqual(t, "", page.NextCursor, "NextCursor should be empty on the last page")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDecodeTaskCursor_Invalid(t *testing.T) {
+	_, err := models.DecodeTaskCursor("not-a-cursor")
+	testutils.AssertError(t, err, "Malformed cursor should be rejected")
+
+	encoded := models.EncodeTaskCursor(models.TaskCursor{Field: "unknown", Order: models.SortOrderAsc, ID: 1})
+	_, err = models.DecodeTaskCursor(encoded)
+	testutils.AssertError(t, err, "Cursor with unknown sort field should be rejected")
+}
+
+func TestPostgresSettingsRepository_GetSettings_Defaults(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresSettingsRepository(db)
+
+	mock.ExpectQuery(`SELECT key, value FROM app_settings`).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "value"}))
+
+	settings, err := repo.GetSettings(context.Background())
+
+	testutils.AssertNoError(t, err, "GetSettings should not return error")
+	testutils.AssertEqual(t, *models.DefaultAppSettings(), *settings, "Empty storage should yield defaults")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresSettingsRepository_GetSettings_UpgradesSchema(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresSettingsRepository(db)
+
+	// Хранилище версии 1: без schema_version и новых ключей, с неизвестным ключом
+	mock.ExpectQuery(`SELECT key, value FROM app_settings`).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "value"}).
+			AddRow("theme", "dark").
+			AddRow("language", "ru").
+			AddRow("auto_save", "not-a-bool").
+			AddRow("sidebar", "collapsed"))
+
+	settings, err := repo.GetSettings(context.Background())
+
+	testutils.AssertNoError(t, err, "GetSettings should not return error")
+	testutils.AssertEqual(t, models.SettingsSchemaVersion, settings.SchemaVersion, "Schema should be upgraded")
+	testutils.AssertEqual(t, "dark", settings.Theme, "Stored value should be kept")
+	testutils.AssertEqual(t, models.WeekStartMonday, settings.WeekStart, "Week start should be derived from language")
+	testutils.AssertEqual(t, models.DateFormatEuropean, settings.DateFormat, "Date format should be derived from language")
+	testutils.AssertEqual(t, "UTC", settings.Timezone, "Missing key should use default")
+	testutils.AssertTrue(t, settings.AutoSave, "Unreadable value should fall back to default")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresSettingsRepository_UpdateSettings(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresSettingsRepository(db)
+
+	settings := models.DefaultAppSettings()
+	settings.Timezone = "Europe/Moscow"
+
+	mock.ExpectExec(`INSERT INTO app_settings \(key, value, updated_at\)`).
+		WithArgs(
+			`{"auto_save","date_format","default_priority","language","notifications_on","schema_version","theme","timezone","week_start"}`,
+			`{"true","YYYY-MM-DD","medium","en","true","2","light","Europe/Moscow","monday"}`,
+			sqlmock.AnyArg(),
+		).
+		WillReturnResult(sqlmock.NewResult(0, 9))
+
+	err = repo.UpdateSettings(context.Background(), settings)
+
+	testutils.AssertNoError(t, err, "UpdateSettings should not return error")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestDueDateCondition_UsesUserTimezone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("tzdata is not available: %v", err)
+	}
+
+	// 23:30 UTC 10 января - в Токио уже 11 января
+	window := models.NewDueWindow(time.Date(2024, 1, 10, 23, 30, 0, 0, time.UTC), "Asia/Tokyo")
+
+	condition, args := dueDateCondition(models.DateFilterToday, window, 3)
+
+	testutils.AssertEqual(t,
+		"due_date >= (CASE WHEN due_all_day THEN $3 ELSE $4 END)::timestamptz AND "+
+			"due_date < (CASE WHEN due_all_day THEN $5 ELSE $6 END)::timestamptz",
+		condition, "Today condition should start from the given placeholder")
+	testutils.AssertEqual(t, 4, len(args), "Today condition should have four bounds")
+	testutils.AssertEqual(t, time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), args[0], "All-day bound should be the local date at UTC midnight")
+	testutils.AssertTrue(t, args[1].(time.Time).Equal(time.Date(2024, 1, 11, 0, 0, 0, 0, tokyo)), "Timed bound should be the local day start")
+
+	overdue, overdueArgs := dueDateCondition(models.DateFilterOverdue, window, 1)
+	testutils.AssertEqual(t,
+		"status IN ('todo', 'in_progress', 'waiting') AND due_date IS NOT NULL AND due_date < (CASE WHEN due_all_day THEN $1 ELSE $2 END)::timestamptz",
+		overdue, "Overdue condition should compare all-day tasks by date")
+	testutils.AssertEqual(t, 2, len(overdueArgs), "Overdue condition should have two bounds")
+
+	none, noneArgs := dueDateCondition("", window, 1)
+	testutils.AssertEqual(t, "", none, "Empty date filter should not add a condition")
+	testutils.AssertEqual(t, 0, len(noneArgs), "Empty date filter should not add arguments")
+}
+
+func TestPostgresTaskRepository_GetTasksStats_Timezone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+
+	args := make([]driver.Value, 10)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+
+	mock.ExpectQuery(`COUNT\(CASE WHEN status IN \('todo', 'in_progress', 'waiting'\) AND due_date IS NOT NULL AND due_date < \(CASE WHEN due_all_day THEN \$1 ELSE \$2 END\)::timestamptz THEN 1 END\) as overdue_tasks`).
+		WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{
+			"total_tasks", "active_tasks", "completed_tasks", "overdue_tasks", "today_tasks", "week_tasks",
+		}).AddRow(5, 3, 2, 1, 1, 2))
+
+	stats, err := repo.GetTasksStats(context.Background(), "Europe/Moscow")
+
+	testutils.AssertNoError(t, err, "GetTasksStats should not return error")
+	testutils.AssertEqual(t, 1, stats.OverdueTasks, "Overdue count should be scanned")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_GetUpcomingTasks_Timezone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+
+	// Сроки на весь день сравниваются с датой в поясе пользователя, а не UTC
+	today := models.NewDueWindow(time.Now(), "Pacific/Kiritimati").Today
+
+	mock.ExpectQuery(`due_date >= \(CASE WHEN due_all_day THEN \$1 ELSE \$2 END\)::timestamptz\s+ORDER BY due_date ASC\s+LIMIT \$3`).
+		WithArgs(today, sqlmock.AnyArg(), 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	tasks, err := repo.GetUpcomingTasks(context.Background(), "Pacific/Kiritimati", 5)
+
+	testutils.AssertNoError(t, err, "GetUpcomingTasks should not return error")
+	testutils.AssertEqual(t, 0, len(tasks), "No tasks should be returned")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_Create_ProjectAndTags(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+
+	task := &models.Task{
+		Title:    "Pay rent",
+		Status:   models.TaskStatusTodo,
+		Priority: models.PriorityHigh,
+		Project:  "home",
+		Tags:     []string{"bills", "monthly"},
+	}
+
+	mock.ExpectQuery(`INSERT INTO tasks \(title, description, status, priority, due_date, due_all_day, project, tags, created_at, updated_at,\s+position, board_position, estimate_minutes\)`).
+		WithArgs(task.Title, "", task.Status, task.Priority, nil, false, "home", `{"bills","monthly"}`, sqlmock.AnyArg(), sqlmock.AnyArg(), models.PositionStep, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "position", "board_position"}).
+			AddRow(7, time.Now(), time.Now(), -1024.0, 0.0))
+
+	result, err := repo.Create(context.Background(), task)
+
+	testutils.AssertNoError(t, err, "Create should not return error")
+	testutils.AssertEqual(t, 7, result.ID, "ID should be returned")
+	testutils.AssertEqual(t, -1024.0, result.Position, "New task should be placed first")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_RebalancePositions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+
+	// Колонка доски перенумеровывается только среди задач своего статуса
+	mock.ExpectExec(`UPDATE tasks t SET board_position = ranked.rn \* \$1::float8 FROM \( SELECT id, ROW_NUMBER\(\) OVER \(ORDER BY board_position, id\) AS rn FROM tasks WHERE status = \$2 \) ranked`).
+		WithArgs(models.PositionStep, models.TaskStatusInProgress).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	// Общий список - все задачи
+	mock.ExpectExec(`UPDATE tasks t SET position = ranked.rn \* \$1::float8 FROM \( SELECT id, ROW_NUMBER\(\) OVER \(ORDER BY position, id\) AS rn FROM tasks WHERE TRUE \) ranked`).
+		WithArgs(models.PositionStep).
+		WillReturnResult(sqlmock.NewResult(0, 5))
+
+	testutils.AssertNoError(t, repo.RebalancePositions(context.Background(), models.TaskStatusInProgress), "Board rebalance should not fail")
+	testutils.AssertNoError(t, repo.RebalancePositions(context.Background(), ""), "List rebalance should not fail")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"todo-app/app/models"
+)
+
+// dueReminderCondition условие незакрытых задач, ожидающих напоминания
+var dueReminderCondition = `
+        ` + statusInCondition("t.status", models.OpenStatuses()) + ` AND NOT t.archived
+          AND t.due_date IS NOT NULL AND t.due_date <= $1
+          AND NOT EXISTS (
+              SELECT 1 FROM task_reminders r
+              WHERE r.task_id = t.id AND r.due_date = t.due_date
+          )`
+
+// postgresReminderRepository реализует ReminderRepository для PostgreSQL
+type postgresReminderRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresReminderRepository создает новый PostgreSQL репозиторий напоминаний
+func NewPostgresReminderRepository(db *sql.DB) ReminderRepository {
+	return &postgresReminderRepository{db: db}
+}
+
+// GetDue получает задачи, ожидающие напоминания, в порядке срока
+func (r *postgresReminderRepository) GetDue(ctx context.Context, until time.Time, limit int) ([]*models.Task, error) {
+	query := `
+        SELECT ` + taskColumns + `
+        FROM tasks t
+        WHERE` + dueReminderCondition + `
+        ORDER BY t.due_date ASC, t.id ASC
+        LIMIT $2`
+
+	var tasks []*models.Task
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, until, limit)
+		if err != nil {
+			return dbError(err, "failed to get due reminders")
+		}
+		defer rows.Close()
+
+		tasks, err = scanTasks(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// CountDue возвращает количество задач, ожидающих напоминания
+func (r *postgresReminderRepository) CountDue(ctx context.Context, until time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM tasks t WHERE` + dueReminderCondition
+
+	var count int
+	err := retryRead(ctx, func() error {
+		return executor(ctx, r.db).QueryRowContext(ctx, query, until).Scan(&count)
+	})
+	if err != nil {
+		return 0, dbError(err, "failed to count due reminders")
+	}
+
+	return count, nil
+}
+
+// MarkSent отмечает напоминание отправленным
+func (r *postgresReminderRepository) MarkSent(ctx context.Context, taskID int, dueDate time.Time, sentAt time.Time) error {
+	query := `
+        INSERT INTO task_reminders (task_id, due_date, sent_at)
+        VALUES ($1, $2, $3)
+        ON CONFLICT (task_id) DO UPDATE SET due_date = EXCLUDED.due_date, sent_at = EXCLUDED.sent_at`
+
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, taskID, dueDate, sentAt); err != nil {
+		return dbError(err, fmt.Sprintf("failed to mark reminder for task %d", taskID))
+	}
+
+	return nil
+}
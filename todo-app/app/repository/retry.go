@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"todo-app/internal/utils"
+)
+
+// readRetry политика повтора идемпотентных чтений при временных ошибках БД
+var readRetry = struct {
+	sync.RWMutex
+	policy utils.RetryPolicy
+}{
+	policy: utils.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 50 * time.Millisecond,
+		MaxBackoff:     time.Second,
+	},
+}
+
+// SetReadRetryPolicy задает политику повтора чтений для всех репозиториев
+func SetReadRetryPolicy(policy utils.RetryPolicy) {
+	readRetry.Lock()
+	defer readRetry.Unlock()
+	readRetry.policy = policy
+}
+
+// retryRead выполняет идемпотентное чтение, повторяя его при временных ошибках
+// (обрыв соединения, перезапуск БД). Внутри транзакции повтор бессмысленен:
+// транзакция уже прервана, поэтому fn выполняется один раз.
+// fn должна полностью перечитывать результат, так как может вызываться повторно
+func retryRead(ctx context.Context, fn func() error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn()
+	}
+
+	readRetry.RLock()
+	policy := readRetry.policy
+	readRetry.RUnlock()
+
+	return utils.Retry(ctx, policy, fn, func(attempt int, delay time.Duration, err error) {
+		utils.WarnContext(ctx, "Retrying database read after transient error", map[string]interface{}{
+			"attempt":  attempt,
+			"retry_in": delay.Round(time.Millisecond).String(),
+			"error":    err.Error(),
+		})
+	})
+}
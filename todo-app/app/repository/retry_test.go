@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todo-app/app/models"
+	"todo-app/internal/utils"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+func useFastReadRetry(t *testing.T) {
+	t.Helper()
+	SetReadRetryPolicy(utils.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	t.Cleanup(func() {
+		SetReadRetryPolicy(utils.RetryPolicy{MaxAttempts: 3, InitialBackoff: 50 * time.Millisecond, MaxBackoff: time.Second})
+	})
+}
+
+func TestRetryRead_RetriesTransientErrors(t *testing.T) {
+	useFastReadRetry(t)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+	now := time.Now()
+
+	// Сервер БД перезапускается: первый запрос обрывается, второй успешен
+	mock.ExpectQuery(`SELECT id, title`).WithArgs(1).
+		WillReturnError(&pq.Error{Code: "57P01", Message: "terminating connection due to administrator command"})
+	mock.ExpectQuery(`SELECT id, title`).WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "title", "description", "status", "priority", "due_date", "due_all_day", "project", "tags", "created_at", "updated_at", "completed_at", "started_at", "cancelled_at", "position", "board_position", "estimate_minutes"}).
+			AddRow(1, "Task", "", "todo", "medium", nil, false, "", "{}", now, now, nil, nil, nil, 1024.0, 1024.0, nil))
+
+	task, err := repo.GetByID(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected read to succeed after retry, got %v", err)
+	}
+	if task.ID != 1 {
+		t.Errorf("Unexpected task: %+v", task)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRetryRead_DoesNotRetryPermanentErrors(t *testing.T) {
+	useFastReadRetry(t)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+
+	mock.ExpectQuery(`SELECT COUNT`).
+		WillReturnError(&pq.Error{Code: "42P01", Message: `relation "tasks" does not exist`})
+
+	if _, err := repo.GetTasksCount(context.Background(), models.TaskFilter{}); err == nil {
+		t.Fatal("Expected error")
+	}
+
+	// Исчерпание попыток возвращает последнюю ошибку
+	transient := &pq.Error{Code: "08006", Message: "connection failure"}
+	for i := 0; i < 3; i++ {
+		mock.ExpectQuery(`SELECT COUNT`).WillReturnError(transient)
+	}
+
+	_, err = repo.GetTasksCount(context.Background(), models.TaskFilter{})
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) || pqErr.Code != "08006" {
+		t.Errorf("Expected last transient error, got %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestRetryRead_NoRetryInsideTransaction(t *testing.T) {
+	useFastReadRetry(t)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewPostgresTaskRepository(db)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT COUNT`).WillReturnError(&pq.Error{Code: "40001", Message: "could not serialize access"})
+	mock.ExpectRollback()
+
+	err = NewTxManager(db).WithinTransaction(context.Background(), func(ctx context.Context) error {
+		_, err := repo.GetTasksCount(ctx, models.TaskFilter{})
+		return err
+	})
+	if err == nil {
+		t.Fatal("Expected error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestIsTransientDBError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{&pq.Error{Code: "57P03"}, true},
+		{&pq.Error{Code: "08001"}, true},
+		{&pq.Error{Code: "28P01"}, false}, // неверный пароль
+		{&pq.Error{Code: "23505"}, false}, // нарушение уникальности
+		{context.Canceled, false},
+		{errors.New("boom"), false},
+	}
+
+	for _, tc := range cases {
+		if got := utils.IsTransientDBError(tc.err); got != tc.want {
+			t.Errorf("IsTransientDBError(%v) = %v, want %v", tc.err, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"todo-app/app/models"
+)
+
+// positionColumn возвращает столбец позиции области ручного порядка:
+// общий список (пустая колонка) или колонка статуса на доске
+func positionColumn(column models.TaskStatus) string {
+	if column == "" {
+		return "position"
+	}
+	return "board_position"
+}
+
+// positionScope возвращает столбец позиции, условие отбора задач области
+// и его параметры, начиная с $argIndex
+func positionScope(column models.TaskStatus, argIndex int) (string, string, []interface{}) {
+	if column == "" {
+		return positionColumn(column), "TRUE", nil
+	}
+	return positionColumn(column), fmt.Sprintf("status = $%d", argIndex), []interface{}{column}
+}
+
+// UpdatePosition сохраняет позицию задачи в общем списке или в колонке доски
+func (r *postgresTaskRepository) UpdatePosition(ctx context.Context, id int, column models.TaskStatus, position float64) error {
+	query := fmt.Sprintf(`UPDATE tasks SET %s = $2, updated_at = $3 WHERE id = $1`, positionColumn(column))
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id, position, time.Now())
+	if err != nil {
+		return dbError(err, "failed to update task position")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return dbError(err, "failed to get affected rows")
+	}
+
+	if rowsAffected == 0 {
+		return taskNotFound(id)
+	}
+
+	return nil
+}
+
+// LastPosition возвращает наибольшую позицию в области; false - область пуста
+func (r *postgresTaskRepository) LastPosition(ctx context.Context, column models.TaskStatus) (float64, bool, error) {
+	scopeColumn, condition, args := positionScope(column, 1)
+	query := fmt.Sprintf(`SELECT MAX(%s) FROM tasks WHERE %s`, scopeColumn, condition)
+
+	var last *float64
+	err := retryRead(ctx, func() error {
+		return executor(ctx, r.db).QueryRowContext(ctx, query, args...).Scan(&last)
+	})
+	if err != nil {
+		return 0, false, dbError(err, "failed to get last task position")
+	}
+
+	if last == nil {
+		return 0, false, nil
+	}
+	return *last, true, nil
+}
+
+// RebalancePositions перенумеровывает позиции области с шагом models.PositionStep,
+// сохраняя текущий порядок
+func (r *postgresTaskRepository) RebalancePositions(ctx context.Context, column models.TaskStatus) error {
+	scopeColumn, condition, args := positionScope(column, 2)
+	query := fmt.Sprintf(`
+        UPDATE tasks t SET %[1]s = ranked.rn * $1::float8
+        FROM (
+            SELECT id, ROW_NUMBER() OVER (ORDER BY %[1]s, id) AS rn
+            FROM tasks
+            WHERE %[2]s
+        ) ranked
+        WHERE t.id = ranked.id`, scopeColumn, condition)
+
+	args = append([]interface{}{models.PositionStep}, args...)
+	if _, err := executor(ctx, r.db).ExecContext(ctx, query, args...); err != nil {
+		return dbError(err, "failed to rebalance task positions")
+	}
+
+	return nil
+}
+
+// GetBoardTasks получает неархивные задачи с учетом фильтра в порядке колонок доски.
+// Фильтр по статусу не применяется: доска показывает все колонки
+func (r *postgresTaskRepository) GetBoardTasks(ctx context.Context, filter models.TaskFilter) ([]*models.Task, error) {
+	filter.Status = ""
+	whereClause, args := r.buildWhereClause(filter)
+	if whereClause == "" {
+		whereClause = "WHERE NOT archived"
+	} else {
+		whereClause += " AND NOT archived"
+	}
+
+	query := fmt.Sprintf(`
+        SELECT `+taskColumns+`
+        FROM tasks
+        %s
+        ORDER BY board_position, id`, whereClause)
+
+	var tasks []*models.Task
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+		if err != nil {
+			return dbError(err, "failed to get board tasks")
+		}
+		defer rows.Close()
+
+		tasks, err = scanTasks(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
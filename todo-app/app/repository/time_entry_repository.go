@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"todo-app/app/models"
+
+	"github.com/lib/pq"
+)
+
+// activeTimerIndex - частичный уникальный индекс, допускающий один запущенный таймер
+const activeTimerIndex = "idx_time_entries_active"
+
+const timeEntryColumns = `e.id, e.task_id, e.started_at, e.ended_at, e.note, e.manual, e.created_at, t.title, t.project`
+
+// postgresTimeEntryRepository реализует TimeEntryRepository для PostgreSQL
+type postgresTimeEntryRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTimeEntryRepository создает новый PostgreSQL репозиторий учета времени
+func NewPostgresTimeEntryRepository(db *sql.DB) TimeEntryRepository {
+	return &postgresTimeEntryRepository{db: db}
+}
+
+// Create сохраняет запись времени или запускает таймер (запись без ended_at)
+func (r *postgresTimeEntryRepository) Create(ctx context.Context, entry *models.TimeEntry) (*models.TimeEntry, error) {
+	query := `
+        INSERT INTO time_entries (task_id, started_at, ended_at, note, manual)
+        VALUES ($1, $2, $3, $4, $5)
+        RETURNING id, created_at`
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		entry.TaskID,
+		entry.StartedAt,
+		entry.EndedAt,
+		entry.Note,
+		entry.Manual,
+	).Scan(&entry.ID, &entry.CreatedAt)
+
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Constraint == activeTimerIndex {
+			return nil, timerRunning(err)
+		}
+		return nil, dbError(err, "failed to create time entry")
+	}
+
+	entry.DurationSeconds = int64(entry.Duration(time.Now()) / time.Second)
+	return entry, nil
+}
+
+// GetActive получает запущенный таймер; nil - таймер не запущен
+func (r *postgresTimeEntryRepository) GetActive(ctx context.Context) (*models.TimeEntry, error) {
+	query := `
+        SELECT ` + timeEntryColumns + `
+        FROM time_entries e
+        JOIN tasks t ON t.id = e.task_id
+        WHERE e.ended_at IS NULL`
+
+	entry := &models.TimeEntry{}
+	err := retryRead(ctx, func() error {
+		return executor(ctx, r.db).QueryRowContext(ctx, query).Scan(timeEntryScanTargets(entry)...)
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, dbError(err, "failed to get running timer")
+	}
+
+	entry.DurationSeconds = int64(entry.Duration(time.Now()) / time.Second)
+	return entry, nil
+}
+
+// Stop останавливает запущенный таймер. Окончание не может быть раньше начала
+func (r *postgresTimeEntryRepository) Stop(ctx context.Context, endedAt time.Time) (*models.TimeEntry, error) {
+	query := `
+        UPDATE time_entries e
+        SET ended_at = GREATEST(e.started_at, $1)
+        FROM tasks t
+        WHERE t.id = e.task_id AND e.ended_at IS NULL
+        RETURNING ` + timeEntryColumns
+
+	entry := &models.TimeEntry{}
+	err := executor(ctx, r.db).QueryRowContext(ctx, query, endedAt).Scan(timeEntryScanTargets(entry)...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, timerNotRunning()
+		}
+		return nil, dbError(err, "failed to stop timer")
+	}
+
+	entry.DurationSeconds = int64(entry.Duration(endedAt) / time.Second)
+	return entry, nil
+}
+
+// Delete удаляет запись времени
+func (r *postgresTimeEntryRepository) Delete(ctx context.Context, id int64) error {
+	result, err := executor(ctx, r.db).ExecContext(ctx, `DELETE FROM time_entries WHERE id = $1`, id)
+	if err != nil {
+		return dbError(err, "failed to delete time entry")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return dbError(err, "failed to get affected rows")
+	}
+
+	if rowsAffected == 0 {
+		return timeEntryNotFound(id)
+	}
+
+	return nil
+}
+
+// GetEntries получает записи, пересекающие период фильтра, в порядке начала
+func (r *postgresTimeEntryRepository) GetEntries(ctx context.Context, filter models.TimeEntryFilter) ([]*models.TimeEntry, error) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.TaskID > 0 {
+		args = append(args, filter.TaskID)
+		conditions = append(conditions, fmt.Sprintf("e.task_id = $%d", len(args)))
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("(e.ended_at IS NULL OR e.ended_at > $%d)", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("e.started_at < $%d", len(args)))
+	}
+
+	whereClause := ""
+	if len(conditions) > 0 {
+		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	query := fmt.Sprintf(`
+        SELECT `+timeEntryColumns+`
+        FROM time_entries e
+        JOIN tasks t ON t.id = e.task_id
+        %s
+        ORDER BY e.started_at, e.id`, whereClause)
+
+	var entries []*models.TimeEntry
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+		if err != nil {
+			return dbError(err, "failed to get time entries")
+		}
+		defer rows.Close()
+
+		now := time.Now()
+		entries = nil
+		for rows.Next() {
+			entry := &models.TimeEntry{}
+			if err := rows.Scan(timeEntryScanTargets(entry)...); err != nil {
+				return dbError(err, "failed to scan time entry")
+			}
+			entry.DurationSeconds = int64(entry.Duration(now) / time.Second)
+			entries = append(entries, entry)
+		}
+
+		if err := rows.Err(); err != nil {
+			return dbError(err, "rows iteration error")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// GetTrackedByTask возвращает затраченное время в секундах по задачам;
+// запущенный таймер учитывается до момента now
+func (r *postgresTimeEntryRepository) GetTrackedByTask(ctx context.Context, taskIDs []int, now time.Time) (map[int]int64, error) {
+	tracked := make(map[int]int64, len(taskIDs))
+	if len(taskIDs) == 0 {
+		return tracked, nil
+	}
+
+	query := `
+        SELECT task_id, SUM(EXTRACT(EPOCH FROM GREATEST(COALESCE(ended_at, $2) - started_at, INTERVAL '0')))::bigint
+        FROM time_entries
+        WHERE task_id = ANY($1)
+        GROUP BY task_id`
+
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, pq.Array(taskIDs), now)
+		if err != nil {
+			return dbError(err, "failed to get tracked time")
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var taskID int
+			var seconds int64
+			if err := rows.Scan(&taskID, &seconds); err != nil {
+				return dbError(err, "failed to scan tracked time")
+			}
+			tracked[taskID] = seconds
+		}
+
+		if err := rows.Err(); err != nil {
+			return dbError(err, "rows iteration error")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tracked, nil
+}
+
+// timeEntryScanTargets возвращает поля записи для Scan в порядке timeEntryColumns
+func timeEntryScanTargets(entry *models.TimeEntry) []interface{} {
+	return []interface{}{
+		&entry.ID,
+		&entry.TaskID,
+		&entry.StartedAt,
+		&entry.EndedAt,
+		&entry.Note,
+		&entry.Manual,
+		&entry.CreatedAt,
+		&entry.TaskTitle,
+		&entry.Project,
+	}
+}
@@ -0,0 +1,602 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"todo-app/app/models"
+	"todo-app/internal/tracing"
+)
+
+// Декораторы репозиториев создают только дочерние span'ы (tracing.StartChild):
+// вызовы вне трассы (опрос outbox, напоминания, доставка webhooks) не порождают
+// корневых трасс на каждый тик
+
+// taskRepositoryWithTracing оборачивает вызовы TaskRepository span'ами
+type taskRepositoryWithTracing struct {
+	next TaskRepository
+}
+
+// NewTaskRepositoryWithTracing оборачивает TaskRepository трассировкой
+func NewTaskRepositoryWithTracing(next TaskRepository) TaskRepository {
+	return &taskRepositoryWithTracing{next: next}
+}
+
+func (r *taskRepositoryWithTracing) Create(ctx context.Context, task *models.Task) (*models.Task, error) {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.Create")
+	created, err := r.next.Create(ctx, task)
+	if created != nil {
+		span.SetAttribute("task.id", created.ID)
+	}
+	span.Finish(err)
+	return created, err
+}
+
+func (r *taskRepositoryWithTracing) GetAll(ctx context.Context, filter models.TaskFilter, sort models.TaskSort) ([]*models.Task, error) {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.GetAll")
+	span.SetAttributes(filter.LogFields())
+	span.SetAttributes(sort.LogFields())
+	tasks, err := r.next.GetAll(ctx, filter, sort)
+	span.SetAttribute("rows", len(tasks))
+	span.Finish(err)
+	return tasks, err
+}
+
+func (r *taskRepositoryWithTracing) GetPage(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, after *models.TaskCursor, limit int) (*models.TaskPage, error) {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.GetPage")
+	span.SetAttributes(filter.LogFields())
+	span.SetAttributes(sort.LogFields())
+	span.SetAttributes(map[string]interface{}{"cursor": after != nil, "limit": limit})
+	page, err := r.next.GetPage(ctx, filter, sort, after, limit)
+	if page != nil {
+		span.SetAttributes(map[string]interface{}{"rows": len(page.Tasks), "has_next": page.HasNext})
+	}
+	span.Finish(err)
+	return page, err
+}
+
+func (r *taskRepositoryWithTracing) GetByID(ctx context.Context, id int) (*models.Task, error) {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.GetByID")
+	span.SetAttribute("task.id", id)
+	task, err := r.next.GetByID(ctx, id)
+	span.Finish(err)
+	return task, err
+}
+
+func (r *taskRepositoryWithTracing) Update(ctx context.Context, task *models.Task) (*models.Task, error) {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.Update")
+	if task != nil {
+		span.SetAttribute("task.id", task.ID)
+	}
+	updated, err := r.next.Update(ctx, task)
+	span.Finish(err)
+	return updated, err
+}
+
+func (r *taskRepositoryWithTracing) Delete(ctx context.Context, id int) error {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.Delete")
+	span.SetAttribute("task.id", id)
+	err := r.next.Delete(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (r *taskRepositoryWithTracing) UpdateStatus(ctx context.Context, task *models.Task) error {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.UpdateStatus")
+	span.SetAttribute("task.id", task.ID)
+	span.SetAttribute("task.status", string(task.Status))
+	err := r.next.UpdateStatus(ctx, task)
+	span.Finish(err)
+	return err
+}
+
+func (r *taskRepositoryWithTracing) Archive(ctx context.Context, id int) error {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.Archive")
+	span.SetAttribute("task.id", id)
+	err := r.next.Archive(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (r *taskRepositoryWithTracing) UpdatePosition(ctx context.Context, id int, column models.TaskStatus, position float64) error {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.UpdatePosition")
+	span.SetAttribute("task.id", id)
+	span.SetAttribute("column", string(column))
+	err := r.next.UpdatePosition(ctx, id, column, position)
+	span.Finish(err)
+	return err
+}
+
+func (r *taskRepositoryWithTracing) LastPosition(ctx context.Context, column models.TaskStatus) (float64, bool, error) {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.LastPosition")
+	span.SetAttribute("column", string(column))
+	position, ok, err := r.next.LastPosition(ctx, column)
+	span.Finish(err)
+	return position, ok, err
+}
+
+func (r *taskRepositoryWithTracing) RebalancePositions(ctx context.Context, column models.TaskStatus) error {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.RebalancePositions")
+	span.SetAttribute("column", string(column))
+	err := r.next.RebalancePositions(ctx, column)
+	span.Finish(err)
+	return err
+}
+
+func (r *taskRepositoryWithTracing) GetBoardTasks(ctx context.Context, filter models.TaskFilter) ([]*models.Task, error) {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.GetBoardTasks")
+	span.SetAttributes(filter.LogFields())
+	tasks, err := r.next.GetBoardTasks(ctx, filter)
+	span.SetAttribute("rows", len(tasks))
+	span.Finish(err)
+	return tasks, err
+}
+
+func (r *taskRepositoryWithTracing) GetTasksStats(ctx context.Context, timezone string) (*models.TaskStats, error) {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.GetTasksStats")
+	stats, err := r.next.GetTasksStats(ctx, timezone)
+	span.Finish(err)
+	return stats, err
+}
+
+func (r *taskRepositoryWithTracing) GetStatusCounts(ctx context.Context) (map[models.TaskStatus]int, error) {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.GetStatusCounts")
+	counts, err := r.next.GetStatusCounts(ctx)
+	span.Finish(err)
+	return counts, err
+}
+
+func (r *taskRepositoryWithTracing) GetTasksCount(ctx context.Context, filter models.TaskFilter) (int, error) {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.GetTasksCount")
+	span.SetAttributes(filter.LogFields())
+	count, err := r.next.GetTasksCount(ctx, filter)
+	span.SetAttribute("count", count)
+	span.Finish(err)
+	return count, err
+}
+
+func (r *taskRepositoryWithTracing) GetRecentTasks(ctx context.Context, limit int) ([]*models.Task, error) {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.GetRecentTasks")
+	span.SetAttribute("limit", limit)
+	tasks, err := r.next.GetRecentTasks(ctx, limit)
+	span.SetAttribute("rows", len(tasks))
+	span.Finish(err)
+	return tasks, err
+}
+
+func (r *taskRepositoryWithTracing) GetUpcomingTasks(ctx context.Context, timezone string, limit int) ([]*models.Task, error) {
+	ctx, span := tracing.StartChild(ctx, "TaskRepository.GetUpcomingTasks")
+	span.SetAttribute("limit", limit)
+	tasks, err := r.next.GetUpcomingTasks(ctx, timezone, limit)
+	span.SetAttribute("rows", len(tasks))
+	span.Finish(err)
+	return tasks, err
+}
+
+// outboxRepositoryWithTracing оборачивает вызовы OutboxRepository span'ами
+type outboxRepositoryWithTracing struct {
+	next OutboxRepository
+}
+
+// NewOutboxRepositoryWithTracing оборачивает OutboxRepository трассировкой
+func NewOutboxRepositoryWithTracing(next OutboxRepository) OutboxRepository {
+	return &outboxRepositoryWithTracing{next: next}
+}
+
+func (r *outboxRepositoryWithTracing) Save(ctx context.Context, event *models.OutboxEvent) error {
+	ctx, span := tracing.StartChild(ctx, "OutboxRepository.Save")
+	if event != nil {
+		span.SetAttribute("event.type", event.EventType)
+	}
+	err := r.next.Save(ctx, event)
+	span.Finish(err)
+	return err
+}
+
+func (r *outboxRepositoryWithTracing) GetPending(ctx context.Context, limit int) ([]*models.OutboxEvent, error) {
+	ctx, span := tracing.StartChild(ctx, "OutboxRepository.GetPending")
+	span.SetAttribute("limit", limit)
+	events, err := r.next.GetPending(ctx, limit)
+	span.SetAttribute("rows", len(events))
+	span.Finish(err)
+	return events, err
+}
+
+func (r *outboxRepositoryWithTracing) MarkPublished(ctx context.Context, id int64) error {
+	ctx, span := tracing.StartChild(ctx, "OutboxRepository.MarkPublished")
+	span.SetAttribute("event.id", id)
+	err := r.next.MarkPublished(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (r *outboxRepositoryWithTracing) MarkRetry(ctx context.Context, id int64, nextAttemptAt time.Time) error {
+	ctx, span := tracing.StartChild(ctx, "OutboxRepository.MarkRetry")
+	span.SetAttribute("event.id", id)
+	err := r.next.MarkRetry(ctx, id, nextAttemptAt)
+	span.Finish(err)
+	return err
+}
+
+func (r *outboxRepositoryWithTracing) MarkFailed(ctx context.Context, id int64, lastError string) error {
+	ctx, span := tracing.StartChild(ctx, "OutboxRepository.MarkFailed")
+	span.SetAttribute("event.id", id)
+	err := r.next.MarkFailed(ctx, id, lastError)
+	span.Finish(err)
+	return err
+}
+
+func (r *outboxRepositoryWithTracing) CountPending(ctx context.Context) (int, error) {
+	ctx, span := tracing.StartChild(ctx, "OutboxRepository.CountPending")
+	count, err := r.next.CountPending(ctx)
+	span.SetAttribute("count", count)
+	span.Finish(err)
+	return count, err
+}
+
+func (r *outboxRepositoryWithTracing) GetByTask(ctx context.Context, taskID int, limit int) ([]*models.OutboxEvent, error) {
+	ctx, span := tracing.StartChild(ctx, "OutboxRepository.GetByTask")
+	span.SetAttributes(map[string]interface{}{"task.id": taskID, "limit": limit})
+	events, err := r.next.GetByTask(ctx, taskID, limit)
+	span.SetAttribute("rows", len(events))
+	span.Finish(err)
+	return events, err
+}
+
+// reminderRepositoryWithTracing оборачивает вызовы ReminderRepository span'ами
+type reminderRepositoryWithTracing struct {
+	next ReminderRepository
+}
+
+// NewReminderRepositoryWithTracing оборачивает ReminderRepository трассировкой
+func NewReminderRepositoryWithTracing(next ReminderRepository) ReminderRepository {
+	return &reminderRepositoryWithTracing{next: next}
+}
+
+func (r *reminderRepositoryWithTracing) GetDue(ctx context.Context, until time.Time, limit int) ([]*models.Task, error) {
+	ctx, span := tracing.StartChild(ctx, "ReminderRepository.GetDue")
+	span.SetAttribute("limit", limit)
+	tasks, err := r.next.GetDue(ctx, until, limit)
+	span.SetAttribute("rows", len(tasks))
+	span.Finish(err)
+	return tasks, err
+}
+
+func (r *reminderRepositoryWithTracing) CountDue(ctx context.Context, until time.Time) (int, error) {
+	ctx, span := tracing.StartChild(ctx, "ReminderRepository.CountDue")
+	count, err := r.next.CountDue(ctx, until)
+	span.SetAttribute("count", count)
+	span.Finish(err)
+	return count, err
+}
+
+func (r *reminderRepositoryWithTracing) MarkSent(ctx context.Context, taskID int, dueDate time.Time, sentAt time.Time) error {
+	ctx, span := tracing.StartChild(ctx, "ReminderRepository.MarkSent")
+	span.SetAttribute("task.id", taskID)
+	err := r.next.MarkSent(ctx, taskID, dueDate, sentAt)
+	span.Finish(err)
+	return err
+}
+
+// timeEntryRepositoryWithTracing оборачивает вызовы TimeEntryRepository span'ами
+type timeEntryRepositoryWithTracing struct {
+	next TimeEntryRepository
+}
+
+// NewTimeEntryRepositoryWithTracing оборачивает TimeEntryRepository трассировкой
+func NewTimeEntryRepositoryWithTracing(next TimeEntryRepository) TimeEntryRepository {
+	return &timeEntryRepositoryWithTracing{next: next}
+}
+
+func (r *timeEntryRepositoryWithTracing) Create(ctx context.Context, entry *models.TimeEntry) (*models.TimeEntry, error) {
+	ctx, span := tracing.StartChild(ctx, "TimeEntryRepository.Create")
+	span.SetAttributes(map[string]interface{}{"task.id": entry.TaskID, "manual": entry.Manual})
+	created, err := r.next.Create(ctx, entry)
+	span.Finish(err)
+	return created, err
+}
+
+func (r *timeEntryRepositoryWithTracing) GetActive(ctx context.Context) (*models.TimeEntry, error) {
+	ctx, span := tracing.StartChild(ctx, "TimeEntryRepository.GetActive")
+	entry, err := r.next.GetActive(ctx)
+	span.SetAttribute("running", entry != nil)
+	span.Finish(err)
+	return entry, err
+}
+
+func (r *timeEntryRepositoryWithTracing) Stop(ctx context.Context, endedAt time.Time) (*models.TimeEntry, error) {
+	ctx, span := tracing.StartChild(ctx, "TimeEntryRepository.Stop")
+	entry, err := r.next.Stop(ctx, endedAt)
+	span.Finish(err)
+	return entry, err
+}
+
+func (r *timeEntryRepositoryWithTracing) Delete(ctx context.Context, id int64) error {
+	ctx, span := tracing.StartChild(ctx, "TimeEntryRepository.Delete")
+	span.SetAttribute("time_entry.id", id)
+	err := r.next.Delete(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (r *timeEntryRepositoryWithTracing) GetEntries(ctx context.Context, filter models.TimeEntryFilter) ([]*models.TimeEntry, error) {
+	ctx, span := tracing.StartChild(ctx, "TimeEntryRepository.GetEntries")
+	span.SetAttribute("task.id", filter.TaskID)
+	entries, err := r.next.GetEntries(ctx, filter)
+	span.SetAttribute("rows", len(entries))
+	span.Finish(err)
+	return entries, err
+}
+
+func (r *timeEntryRepositoryWithTracing) GetTrackedByTask(ctx context.Context, taskIDs []int, now time.Time) (map[int]int64, error) {
+	ctx, span := tracing.StartChild(ctx, "TimeEntryRepository.GetTrackedByTask")
+	span.SetAttribute("tasks", len(taskIDs))
+	tracked, err := r.next.GetTrackedByTask(ctx, taskIDs, now)
+	span.SetAttribute("rows", len(tracked))
+	span.Finish(err)
+	return tracked, err
+}
+
+// webhookRepositoryWithTracing оборачивает вызовы WebhookRepository span'ами
+type webhookRepositoryWithTracing struct {
+	next WebhookRepository
+}
+
+// NewWebhookRepositoryWithTracing оборачивает WebhookRepository трассировкой
+func NewWebhookRepositoryWithTracing(next WebhookRepository) WebhookRepository {
+	return &webhookRepositoryWithTracing{next: next}
+}
+
+func (r *webhookRepositoryWithTracing) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	ctx, span := tracing.StartChild(ctx, "WebhookRepository.CreateSubscription")
+	created, err := r.next.CreateSubscription(ctx, subscription)
+	if created != nil {
+		span.SetAttribute("webhook.id", created.ID)
+	}
+	span.Finish(err)
+	return created, err
+}
+
+func (r *webhookRepositoryWithTracing) GetSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	ctx, span := tracing.StartChild(ctx, "WebhookRepository.GetSubscriptions")
+	subscriptions, err := r.next.GetSubscriptions(ctx)
+	span.SetAttribute("rows", len(subscriptions))
+	span.Finish(err)
+	return subscriptions, err
+}
+
+func (r *webhookRepositoryWithTracing) GetActiveSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	ctx, span := tracing.StartChild(ctx, "WebhookRepository.GetActiveSubscriptions")
+	subscriptions, err := r.next.GetActiveSubscriptions(ctx)
+	span.SetAttribute("rows", len(subscriptions))
+	span.Finish(err)
+	return subscriptions, err
+}
+
+func (r *webhookRepositoryWithTracing) GetSubscriptionByID(ctx context.Context, id int) (*models.WebhookSubscription, error) {
+	ctx, span := tracing.StartChild(ctx, "WebhookRepository.GetSubscriptionByID")
+	span.SetAttribute("webhook.id", id)
+	subscription, err := r.next.GetSubscriptionByID(ctx, id)
+	span.Finish(err)
+	return subscription, err
+}
+
+func (r *webhookRepositoryWithTracing) SetSubscriptionActive(ctx context.Context, id int, active bool) error {
+	ctx, span := tracing.StartChild(ctx, "WebhookRepository.SetSubscriptionActive")
+	span.SetAttributes(map[string]interface{}{"webhook.id": id, "active": active})
+	err := r.next.SetSubscriptionActive(ctx, id, active)
+	span.Finish(err)
+	return err
+}
+
+func (r *webhookRepositoryWithTracing) DeleteSubscription(ctx context.Context, id int) error {
+	ctx, span := tracing.StartChild(ctx, "WebhookRepository.DeleteSubscription")
+	span.SetAttribute("webhook.id", id)
+	err := r.next.DeleteSubscription(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (r *webhookRepositoryWithTracing) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) (bool, error) {
+	ctx, span := tracing.StartChild(ctx, "WebhookRepository.CreateDelivery")
+	created, err := r.next.CreateDelivery(ctx, delivery)
+	span.SetAttribute("created", created)
+	span.Finish(err)
+	return created, err
+}
+
+func (r *webhookRepositoryWithTracing) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	ctx, span := tracing.StartChild(ctx, "WebhookRepository.GetDueDeliveries")
+	span.SetAttribute("limit", limit)
+	deliveries, err := r.next.GetDueDeliveries(ctx, now, limit)
+	span.SetAttribute("rows", len(deliveries))
+	span.Finish(err)
+	return deliveries, err
+}
+
+func (r *webhookRepositoryWithTracing) GetDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*models.WebhookDelivery, error) {
+	ctx, span := tracing.StartChild(ctx, "WebhookRepository.GetDeliveries")
+	span.SetAttributes(map[string]interface{}{"webhook.id": subscriptionID, "limit": limit})
+	deliveries, err := r.next.GetDeliveries(ctx, subscriptionID, limit)
+	span.SetAttribute("rows", len(deliveries))
+	span.Finish(err)
+	return deliveries, err
+}
+
+func (r *webhookRepositoryWithTracing) GetDeliveryAttempts(ctx context.Context, deliveryID int64) ([]*models.WebhookAttempt, error) {
+	ctx, span := tracing.StartChild(ctx, "WebhookRepository.GetDeliveryAttempts")
+	span.SetAttribute("delivery.id", deliveryID)
+	attempts, err := r.next.GetDeliveryAttempts(ctx, deliveryID)
+	span.SetAttribute("rows", len(attempts))
+	span.Finish(err)
+	return attempts, err
+}
+
+func (r *webhookRepositoryWithTracing) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error {
+	ctx, span := tracing.StartChild(ctx, "WebhookRepository.RecordAttempt")
+	if delivery != nil {
+		span.SetAttribute("delivery.id", delivery.ID)
+	}
+	err := r.next.RecordAttempt(ctx, delivery, attempt)
+	span.Finish(err)
+	return err
+}
+
+func (r *webhookRepositoryWithTracing) RequeueDelivery(ctx context.Context, id int64, at time.Time) error {
+	ctx, span := tracing.StartChild(ctx, "WebhookRepository.RequeueDelivery")
+	span.SetAttribute("delivery.id", id)
+	err := r.next.RequeueDelivery(ctx, id, at)
+	span.Finish(err)
+	return err
+}
+
+func (r *webhookRepositoryWithTracing) CountDeliveries(ctx context.Context, status models.WebhookDeliveryStatus) (int, error) {
+	ctx, span := tracing.StartChild(ctx, "WebhookRepository.CountDeliveries")
+	span.SetAttribute("status", string(status))
+	count, err := r.next.CountDeliveries(ctx, status)
+	span.SetAttribute("count", count)
+	span.Finish(err)
+	return count, err
+}
+
+// settingsRepositoryWithTracing оборачивает вызовы SettingsRepository span'ами
+type settingsRepositoryWithTracing struct {
+	next SettingsRepository
+}
+
+// NewSettingsRepositoryWithTracing оборачивает SettingsRepository трассировкой
+func NewSettingsRepositoryWithTracing(next SettingsRepository) SettingsRepository {
+	return &settingsRepositoryWithTracing{next: next}
+}
+
+func (r *settingsRepositoryWithTracing) GetSettings(ctx context.Context) (*models.AppSettings, error) {
+	ctx, span := tracing.StartChild(ctx, "SettingsRepository.GetSettings")
+	settings, err := r.next.GetSettings(ctx)
+	span.Finish(err)
+	return settings, err
+}
+
+func (r *settingsRepositoryWithTracing) UpdateSettings(ctx context.Context, settings *models.AppSettings) error {
+	ctx, span := tracing.StartChild(ctx, "SettingsRepository.UpdateSettings")
+	err := r.next.UpdateSettings(ctx, settings)
+	span.Finish(err)
+	return err
+}
+
+// commentRepositoryWithTracing оборачивает вызовы CommentRepository span'ами
+type commentRepositoryWithTracing struct {
+	next CommentRepository
+}
+
+// NewCommentRepositoryWithTracing оборачивает CommentRepository трассировкой
+func NewCommentRepositoryWithTracing(next CommentRepository) CommentRepository {
+	return &commentRepositoryWithTracing{next: next}
+}
+
+func (r *commentRepositoryWithTracing) Create(ctx context.Context, comment *models.Comment) (*models.Comment, error) {
+	ctx, span := tracing.StartChild(ctx, "CommentRepository.Create")
+	span.SetAttribute("task.id", comment.TaskID)
+	created, err := r.next.Create(ctx, comment)
+	span.Finish(err)
+	return created, err
+}
+
+func (r *commentRepositoryWithTracing) GetByID(ctx context.Context, id int64) (*models.Comment, error) {
+	ctx, span := tracing.StartChild(ctx, "CommentRepository.GetByID")
+	span.SetAttribute("comment.id", id)
+	comment, err := r.next.GetByID(ctx, id)
+	span.Finish(err)
+	return comment, err
+}
+
+func (r *commentRepositoryWithTracing) Update(ctx context.Context, id int64, body string, updatedAt time.Time) (*models.Comment, error) {
+	ctx, span := tracing.StartChild(ctx, "CommentRepository.Update")
+	span.SetAttribute("comment.id", id)
+	comment, err := r.next.Update(ctx, id, body, updatedAt)
+	span.Finish(err)
+	return comment, err
+}
+
+func (r *commentRepositoryWithTracing) Delete(ctx context.Context, id int64) error {
+	ctx, span := tracing.StartChild(ctx, "CommentRepository.Delete")
+	span.SetAttribute("comment.id", id)
+	err := r.next.Delete(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (r *commentRepositoryWithTracing) GetByTask(ctx context.Context, taskID int, order models.SortOrder) ([]*models.Comment, error) {
+	ctx, span := tracing.StartChild(ctx, "CommentRepository.GetByTask")
+	span.SetAttributes(map[string]interface{}{"task.id": taskID, "order": string(order)})
+	comments, err := r.next.GetByTask(ctx, taskID, order)
+	span.SetAttribute("rows", len(comments))
+	span.Finish(err)
+	return comments, err
+}
+
+func (r *commentRepositoryWithTracing) GetByTasks(ctx context.Context, taskIDs []int) (map[int][]*models.Comment, error) {
+	ctx, span := tracing.StartChild(ctx, "CommentRepository.GetByTasks")
+	span.SetAttribute("tasks", len(taskIDs))
+	comments, err := r.next.GetByTasks(ctx, taskIDs)
+	span.Finish(err)
+	return comments, err
+}
+
+func (r *commentRepositoryWithTracing) GetRevisions(ctx context.Context, commentID int64) ([]*models.CommentRevision, error) {
+	ctx, span := tracing.StartChild(ctx, "CommentRepository.GetRevisions")
+	span.SetAttribute("comment.id", commentID)
+	revisions, err := r.next.GetRevisions(ctx, commentID)
+	span.SetAttribute("rows", len(revisions))
+	span.Finish(err)
+	return revisions, err
+}
+
+// attachmentRepositoryWithTracing оборачивает вызовы AttachmentRepository span'ами
+type attachmentRepositoryWithTracing struct {
+	next AttachmentRepository
+}
+
+// NewAttachmentRepositoryWithTracing оборачивает AttachmentRepository трассировкой
+func NewAttachmentRepositoryWithTracing(next AttachmentRepository) AttachmentRepository {
+	return &attachmentRepositoryWithTracing{next: next}
+}
+
+func (r *attachmentRepositoryWithTracing) Create(ctx context.Context, attachment *models.Attachment) (*models.Attachment, error) {
+	ctx, span := tracing.StartChild(ctx, "AttachmentRepository.Create")
+	span.SetAttributes(map[string]interface{}{"task.id": attachment.TaskID, "size": attachment.Size})
+	created, err := r.next.Create(ctx, attachment)
+	span.Finish(err)
+	return created, err
+}
+
+func (r *attachmentRepositoryWithTracing) GetByID(ctx context.Context, id int64) (*models.Attachment, error) {
+	ctx, span := tracing.StartChild(ctx, "AttachmentRepository.GetByID")
+	span.SetAttribute("attachment.id", id)
+	attachment, err := r.next.GetByID(ctx, id)
+	span.Finish(err)
+	return attachment, err
+}
+
+func (r *attachmentRepositoryWithTracing) Delete(ctx context.Context, id int64) error {
+	ctx, span := tracing.StartChild(ctx, "AttachmentRepository.Delete")
+	span.SetAttribute("attachment.id", id)
+	err := r.next.Delete(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (r *attachmentRepositoryWithTracing) GetByTask(ctx context.Context, taskID int) ([]*models.Attachment, error) {
+	ctx, span := tracing.StartChild(ctx, "AttachmentRepository.GetByTask")
+	span.SetAttribute("task.id", taskID)
+	attachments, err := r.next.GetByTask(ctx, taskID)
+	span.SetAttribute("rows", len(attachments))
+	span.Finish(err)
+	return attachments, err
+}
+
+func (r *attachmentRepositoryWithTracing) GetReferencedHashes(ctx context.Context) (map[string]struct{}, error) {
+	ctx, span := tracing.StartChild(ctx, "AttachmentRepository.GetReferencedHashes")
+	hashes, err := r.next.GetReferencedHashes(ctx)
+	span.SetAttribute("rows", len(hashes))
+	span.Finish(err)
+	return hashes, err
+}
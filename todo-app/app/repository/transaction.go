@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"todo-app/internal/utils"
+)
+
+// TxManager определяет интерфейс для выполнения операций репозиториев в одной транзакции
+type TxManager interface {
+	// WithinTransaction выполняет fn в транзакции. Репозитории, вызванные с переданным
+	// в fn контекстом, используют эту транзакцию. Вложенные вызовы переиспользуют внешнюю транзакцию
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// dbExecutor объединяет методы *sql.DB и *sql.Tx, используемые репозиториями
+type dbExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// txContextKey ключ контекста для текущей транзакции
+type txContextKey struct{}
+
+// executor возвращает транзакцию из контекста или подключение к БД
+func executor(ctx context.Context, db *sql.DB) dbExecutor {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// postgresTxManager реализует TxManager для PostgreSQL
+type postgresTxManager struct {
+	db *sql.DB
+}
+
+// NewTxManager создает новый менеджер транзакций
+func NewTxManager(db *sql.DB) TxManager {
+	return &postgresTxManager{db: db}
+}
+
+// WithinTransaction выполняет fn в транзакции
+func (m *postgresTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return dbError(err, "failed to begin transaction")
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			utils.ErrorContext(ctx, "Transaction rollback failed", map[string]interface{}{
+				"error":          err.Error(),
+				"rollback_error": rbErr.Error(),
+			})
+			return fmt.Errorf("transaction error: %v, rollback error: %w", err, rbErr)
+		}
+		utils.DebugContext(ctx, "Transaction rolled back", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dbError(err, "failed to commit transaction")
+	}
+
+	return nil
+}
@@ -0,0 +1,437 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"todo-app/app/models"
+)
+
+// postgresWebhookRepository реализует WebhookRepository для PostgreSQL
+type postgresWebhookRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresWebhookRepository создает новый PostgreSQL репозиторий webhooks
+func NewPostgresWebhookRepository(db *sql.DB) WebhookRepository {
+	return &postgresWebhookRepository{db: db}
+}
+
+const webhookSubscriptionColumns = `id, url, secret, event_types, filter, description, active, created_at, updated_at`
+
+const webhookDeliveryColumns = `id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+        COALESCE(last_status_code, 0), COALESCE(last_error, ''), created_at, delivered_at`
+
+// CreateSubscription создает подписку
+func (r *postgresWebhookRepository) CreateSubscription(ctx context.Context, subscription *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	query := `
+        INSERT INTO webhook_subscriptions (url, secret, event_types, filter, description, active, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id`
+
+	eventTypes, err := json.Marshal(subscription.EventTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event types: %w", err)
+	}
+
+	var filter []byte
+	if subscription.Filter != nil {
+		if filter, err = json.Marshal(subscription.Filter); err != nil {
+			return nil, fmt.Errorf("failed to marshal filter: %w", err)
+		}
+	}
+
+	now := time.Now()
+	subscription.CreatedAt = now
+	subscription.UpdatedAt = now
+
+	err = executor(ctx, r.db).QueryRowContext(ctx, query,
+		subscription.URL,
+		subscription.Secret,
+		eventTypes,
+		filter,
+		subscription.Description,
+		subscription.Active,
+		subscription.CreatedAt,
+		subscription.UpdatedAt,
+	).Scan(&subscription.ID)
+
+	if err != nil {
+		return nil, dbError(err, "failed to create webhook subscription")
+	}
+
+	return subscription, nil
+}
+
+// GetSubscriptions получает все подписки
+func (r *postgresWebhookRepository) GetSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions ORDER BY id ASC`
+	return r.querySubscriptions(ctx, query)
+}
+
+// GetActiveSubscriptions получает включенные подписки
+func (r *postgresWebhookRepository) GetActiveSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE active = TRUE ORDER BY id ASC`
+	return r.querySubscriptions(ctx, query)
+}
+
+// GetSubscriptionByID получает подписку по ID
+func (r *postgresWebhookRepository) GetSubscriptionByID(ctx context.Context, id int) (*models.WebhookSubscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+
+	subscriptions, err := r.querySubscriptions(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil, webhookNotFound(id)
+	}
+
+	return subscriptions[0], nil
+}
+
+// SetSubscriptionActive включает или отключает подписку
+func (r *postgresWebhookRepository) SetSubscriptionActive(ctx context.Context, id int, active bool) error {
+	query := `UPDATE webhook_subscriptions SET active = $2, updated_at = $3 WHERE id = $1`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id, active, time.Now())
+	if err != nil {
+		return dbError(err, "failed to update webhook subscription")
+	}
+
+	return expectAffected(result, webhookNotFound(id))
+}
+
+// DeleteSubscription удаляет подписку
+func (r *postgresWebhookRepository) DeleteSubscription(ctx context.Context, id int) error {
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return dbError(err, "failed to delete webhook subscription")
+	}
+
+	return expectAffected(result, webhookNotFound(id))
+}
+
+// CreateDelivery ставит доставку в очередь
+func (r *postgresWebhookRepository) CreateDelivery(ctx context.Context, delivery *models.WebhookDelivery) (bool, error) {
+	query := `
+        INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+        ON CONFLICT (subscription_id, event_id) DO NOTHING
+        RETURNING id`
+
+	if delivery.Status == "" {
+		delivery.Status = models.WebhookDeliveryPending
+	}
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = time.Now()
+	}
+	if delivery.NextAttemptAt.IsZero() {
+		delivery.NextAttemptAt = delivery.CreatedAt
+	}
+
+	err := executor(ctx, r.db).QueryRowContext(ctx, query,
+		delivery.SubscriptionID,
+		delivery.EventID,
+		delivery.EventType,
+		[]byte(delivery.Payload),
+		delivery.Status,
+		delivery.NextAttemptAt,
+		delivery.CreatedAt,
+	).Scan(&delivery.ID)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, dbError(err, "failed to create webhook delivery")
+	}
+
+	return true, nil
+}
+
+// GetDueDeliveries получает доставки, готовые к отправке
+func (r *postgresWebhookRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	query := `
+        SELECT ` + webhookDeliveryColumns + `
+        FROM webhook_deliveries
+        WHERE status = 'pending' AND next_attempt_at <= $1
+        ORDER BY next_attempt_at ASC, id ASC
+        LIMIT $2`
+
+	return r.queryDeliveries(ctx, query, now, limit)
+}
+
+// GetDeliveries получает последние доставки подписки
+func (r *postgresWebhookRepository) GetDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*models.WebhookDelivery, error) {
+	query := `
+        SELECT ` + webhookDeliveryColumns + `
+        FROM webhook_deliveries
+        WHERE subscription_id = $1
+        ORDER BY id DESC
+        LIMIT $2`
+
+	return r.queryDeliveries(ctx, query, subscriptionID, limit)
+}
+
+// GetDeliveryAttempts получает журнал попыток доставки
+func (r *postgresWebhookRepository) GetDeliveryAttempts(ctx context.Context, deliveryID int64) ([]*models.WebhookAttempt, error) {
+	query := `
+        SELECT id, delivery_id, attempt, COALESCE(status_code, 0), COALESCE(error, ''), duration_ms, created_at
+        FROM webhook_delivery_attempts
+        WHERE delivery_id = $1
+        ORDER BY attempt ASC`
+
+	rows, err := executor(ctx, r.db).QueryContext(ctx, query, deliveryID)
+	if err != nil {
+		return nil, dbError(err, "failed to get webhook delivery attempts")
+	}
+	defer rows.Close()
+
+	var attempts []*models.WebhookAttempt
+	for rows.Next() {
+		attempt := &models.WebhookAttempt{}
+		err := rows.Scan(
+			&attempt.ID,
+			&attempt.DeliveryID,
+			&attempt.Attempt,
+			&attempt.StatusCode,
+			&attempt.Error,
+			&attempt.DurationMs,
+			&attempt.CreatedAt,
+		)
+		if err != nil {
+			return nil, dbError(err, "failed to scan webhook delivery attempt")
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dbError(err, "rows iteration error")
+	}
+
+	return attempts, nil
+}
+
+// RecordAttempt сохраняет попытку и новое состояние доставки в одной транзакции
+func (r *postgresWebhookRepository) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) error {
+	return NewTxManager(r.db).WithinTransaction(ctx, func(ctx context.Context) error {
+		insertQuery := `
+            INSERT INTO webhook_delivery_attempts (delivery_id, attempt, status_code, error, duration_ms, created_at)
+            VALUES ($1, $2, $3, $4, $5, $6)
+            RETURNING id`
+
+		if attempt.CreatedAt.IsZero() {
+			attempt.CreatedAt = time.Now()
+		}
+		attempt.DeliveryID = delivery.ID
+
+		err := executor(ctx, r.db).QueryRowContext(ctx, insertQuery,
+			attempt.DeliveryID,
+			attempt.Attempt,
+			nullInt(attempt.StatusCode),
+			nullString(attempt.Error),
+			attempt.DurationMs,
+			attempt.CreatedAt,
+		).Scan(&attempt.ID)
+		if err != nil {
+			return dbError(err, "failed to save webhook delivery attempt")
+		}
+
+		updateQuery := `
+            UPDATE webhook_deliveries
+            SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5, last_error = $6, delivered_at = $7
+            WHERE id = $1`
+
+		_, err = executor(ctx, r.db).ExecContext(ctx, updateQuery,
+			delivery.ID,
+			delivery.Status,
+			delivery.Attempts,
+			delivery.NextAttemptAt,
+			nullInt(delivery.LastStatusCode),
+			nullString(delivery.LastError),
+			delivery.DeliveredAt,
+		)
+		if err != nil {
+			return dbError(err, "failed to update webhook delivery")
+		}
+
+		return nil
+	})
+}
+
+// RequeueDelivery возвращает доставку в очередь
+func (r *postgresWebhookRepository) RequeueDelivery(ctx context.Context, id int64, at time.Time) error {
+	query := `
+        UPDATE webhook_deliveries
+        SET status = 'pending', next_attempt_at = $2
+        WHERE id = $1 AND status <> 'delivered'`
+
+	result, err := executor(ctx, r.db).ExecContext(ctx, query, id, at)
+	if err != nil {
+		return dbError(err, "failed to requeue webhook delivery")
+	}
+
+	return expectAffected(result, deliveryNotFound(id))
+}
+
+// CountDeliveries возвращает количество доставок в указанном состоянии
+func (r *postgresWebhookRepository) CountDeliveries(ctx context.Context, status models.WebhookDeliveryStatus) (int, error) {
+	query := `SELECT COUNT(*) FROM webhook_deliveries WHERE status = $1`
+
+	var count int
+	err := retryRead(ctx, func() error {
+		return executor(ctx, r.db).QueryRowContext(ctx, query, status).Scan(&count)
+	})
+	if err != nil {
+		return 0, dbError(err, "failed to count webhook deliveries")
+	}
+
+	return count, nil
+}
+
+// querySubscriptions выполняет запрос и сканирует подписки
+func (r *postgresWebhookRepository) querySubscriptions(ctx context.Context, query string, args ...interface{}) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+		if err != nil {
+			return dbError(err, "failed to get webhook subscriptions")
+		}
+		defer rows.Close()
+
+		subscriptions, err = scanSubscriptions(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// scanSubscriptions сканирует подписки из результата запроса
+func scanSubscriptions(rows *sql.Rows) ([]*models.WebhookSubscription, error) {
+	var subscriptions []*models.WebhookSubscription
+	for rows.Next() {
+		subscription := &models.WebhookSubscription{}
+		var eventTypes, filter []byte
+		err := rows.Scan(
+			&subscription.ID,
+			&subscription.URL,
+			&subscription.Secret,
+			&eventTypes,
+			&filter,
+			&subscription.Description,
+			&subscription.Active,
+			&subscription.CreatedAt,
+			&subscription.UpdatedAt,
+		)
+		if err != nil {
+			return nil, dbError(err, "failed to scan webhook subscription")
+		}
+
+		if err := json.Unmarshal(eventTypes, &subscription.EventTypes); err != nil {
+			return nil, fmt.Errorf("failed to decode webhook event types: %w", err)
+		}
+
+		if len(filter) > 0 {
+			subscription.Filter = &models.TaskFilter{}
+			if err := json.Unmarshal(filter, subscription.Filter); err != nil {
+				return nil, fmt.Errorf("failed to decode webhook filter: %w", err)
+			}
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError(err, "rows iteration error")
+	}
+
+	return subscriptions, nil
+}
+
+// queryDeliveries выполняет запрос и сканирует доставки
+func (r *postgresWebhookRepository) queryDeliveries(ctx context.Context, query string, args ...interface{}) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	err := retryRead(ctx, func() error {
+		rows, err := executor(ctx, r.db).QueryContext(ctx, query, args...)
+		if err != nil {
+			return dbError(err, "failed to get webhook deliveries")
+		}
+		defer rows.Close()
+
+		deliveries, err = scanDeliveries(rows)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// scanDeliveries сканирует доставки из результата запроса
+func scanDeliveries(rows *sql.Rows) ([]*models.WebhookDelivery, error) {
+	var deliveries []*models.WebhookDelivery
+	for rows.Next() {
+		delivery := &models.WebhookDelivery{}
+		var payload []byte
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.EventID,
+			&delivery.EventType,
+			&payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastStatusCode,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&delivery.DeliveredAt,
+		)
+		if err != nil {
+			return nil, dbError(err, "failed to scan webhook delivery")
+		}
+		delivery.Payload = payload
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, dbError(err, "rows iteration error")
+	}
+
+	return deliveries, nil
+}
+
+// expectAffected возвращает ошибку notFound, если запрос не затронул ни одной строки
+func expectAffected(result sql.Result, notFound error) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return dbError(err, "failed to get affected rows")
+	}
+
+	if rowsAffected == 0 {
+		return notFound
+	}
+
+	return nil
+}
+
+// nullInt преобразует нулевое значение в NULL
+func nullInt(value int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(value), Valid: value != 0}
+}
+
+// nullString преобразует пустую строку в NULL
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,162 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+	"todo-app/app/realtime"
+	"todo-app/internal/middleware"
+	"todo-app/internal/utils"
+)
+
+// WebSocketPath путь WebSocket подписки на изменения задач
+const WebSocketPath = "/ws/tasks"
+
+// APIKeyHeader заголовок с API ключом клиента
+const APIKeyHeader = "X-API-Key"
+
+// Пути проверок состояния и диагностики
+const (
+	HealthzPath     = "/healthz"     // liveness, без API ключа
+	ReadyzPath      = "/readyz"      // readiness, без API ключа
+	DiagnosticsPath = "/diagnostics" // полный отчет, требует API ключ
+)
+
+// Config содержит настройки HTTP сервера
+type Config struct {
+	Address        string   // адрес прослушивания, например 127.0.0.1:8787
+	APIKeys        []string // допустимые API ключи клиентов
+	AllowedOrigins []string // Origin браузеров, которым разрешен WebSocket
+	RateLimit      middleware.RateLimiterConfig
+}
+
+// Server обслуживает внешних клиентов по HTTP: WebSocket подписки на задачи
+// и проверки состояния
+type Server struct {
+	config Config
+	logger *utils.Logger
+	mux    *http.ServeMux
+	http   *http.Server
+	limit  *middleware.RateLimit
+
+	websocket *realtime.WebSocketHandler
+	listener  net.Listener
+	done      chan struct{}
+}
+
+// NewServer создает HTTP сервер. Обработчики регистрируются до вызова Start
+func NewServer(config Config, logger *utils.Logger) *Server {
+	if logger == nil {
+		logger = utils.DefaultLogger()
+	}
+
+	mux := http.NewServeMux()
+	limit := middleware.NewRateLimit(config.RateLimit)
+
+	return &Server{
+		config: config,
+		logger: logger,
+		mux:    mux,
+		limit:  limit,
+		http: &http.Server{
+			Handler:           middleware.RequestIDMiddleware()(middleware.Tracing()(limit.Middleware()(mux))),
+			ReadHeaderTimeout: 10 * time.Second,
+		},
+	}
+}
+
+// SetRateLimit меняет ограничение частоты запросов без перезапуска сервера
+func (s *Server) SetRateLimit(config middleware.RateLimiterConfig) {
+	s.limit.Update(config)
+}
+
+// Handle регистрирует обработчик, требующий API ключ
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, s.authenticate(handler))
+}
+
+// HandlePublic регистрирует обработчик без проверки API ключа. Только для
+// проверок состояния (/healthz, /readyz), не раскрывающих данных пользователя
+func (s *Server) HandlePublic(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+// HandleWebSocket регистрирует WebSocket подписку на изменения задач.
+// Origin проверяется до upgrade, API ключ передается в заголовке X-API-Key
+func (s *Server) HandleWebSocket(handler *realtime.WebSocketHandler) {
+	s.websocket = handler
+	s.mux.Handle(WebSocketPath, middleware.WebSocketCORS(s.config.AllowedOrigins)(s.authenticate(handler)))
+}
+
+// authenticate оборачивает обработчик проверкой API ключа
+func (s *Server) authenticate(handler http.Handler) http.Handler {
+	return middleware.APIKeyValidator(s.config.APIKeys, APIKeyHeader)(handler)
+}
+
+// Handler возвращает корневой обработчик сервера (используется в тестах)
+func (s *Server) Handler() http.Handler {
+	return s.http.Handler
+}
+
+// Start начинает прослушивание адреса и обслуживает запросы в фоне
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.config.Address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.config.Address, err)
+	}
+
+	s.listener = listener
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		if err := s.http.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("HTTP server stopped unexpectedly", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	s.logger.Info("HTTP server started", map[string]interface{}{
+		"address": listener.Addr().String(),
+	})
+
+	return nil
+}
+
+// Addr возвращает фактический адрес прослушивания
+func (s *Server) Addr() string {
+	if s.listener == nil {
+		return s.config.Address
+	}
+	return s.listener.Addr().String()
+}
+
+// Stop останавливает прием запросов и закрывает WebSocket соединения
+func (s *Server) Stop() {
+	if s.listener == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Shutdown не затрагивает соединения после upgrade - закрываем их сами
+	if s.websocket != nil {
+		s.websocket.Close()
+	}
+
+	if err := s.http.Shutdown(ctx); err != nil {
+		s.logger.Warn("HTTP server shutdown timed out", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
+	<-s.done
+	s.listener = nil
+
+	s.logger.Info("HTTP server stopped")
+}
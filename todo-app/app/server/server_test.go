@@ -0,0 +1,142 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"todo-app/app/models"
+	"todo-app/app/realtime"
+	"todo-app/internal/middleware"
+	"todo-app/internal/tracing"
+
+	"github.com/gorilla/websocket"
+)
+
+// emptyTasks источник задач без данных
+type emptyTasks struct{}
+
+func (emptyTasks) GetByID(ctx context.Context, id int) (*models.Task, error) {
+	return &models.Task{ID: id}, nil
+}
+
+func (emptyTasks) GetAll(ctx context.Context, filter models.TaskFilter, sort models.TaskSort) ([]*models.Task, error) {
+	return nil, nil
+}
+
+func TestServer_WebSocketRequiresAPIKeyAndAllowedOrigin(t *testing.T) {
+	hub := realtime.NewHub(emptyTasks{}, realtime.Config{}, nil)
+
+	srv := NewServer(Config{
+		Address:        "127.0.0.1:0",
+		APIKeys:        []string{"secret-key"},
+		AllowedOrigins: []string{"https://dashboard.example.com"},
+	}, nil)
+	srv.HandleWebSocket(realtime.NewWebSocketHandler(hub, emptyTasks{}, realtime.WebSocketConfig{}, nil))
+
+	if err := srv.Start(); err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	defer srv.Stop()
+
+	url := "ws://" + srv.Addr() + WebSocketPath
+
+	cases := []struct {
+		name   string
+		header http.Header
+		status int
+	}{
+		{"missing key", http.Header{}, http.StatusUnauthorized},
+		{"invalid key", http.Header{APIKeyHeader: {"wrong"}}, http.StatusUnauthorized},
+		{"foreign origin", http.Header{APIKeyHeader: {"secret-key"}, "Origin": {"https://evil.example.org"}}, http.StatusForbidden},
+	}
+
+	for _, tc := range cases {
+		_, resp, err := websocket.DefaultDialer.Dial(url, tc.header)
+		if err == nil {
+			t.Errorf("%s: expected handshake to fail", tc.name)
+			continue
+		}
+		if resp == nil || resp.StatusCode != tc.status {
+			t.Errorf("%s: expected status %d, got %+v", tc.name, tc.status, resp)
+		}
+	}
+
+	// Браузер с разрешенным Origin и клиент без Origin подключаются
+	for _, header := range []http.Header{
+		{APIKeyHeader: {"secret-key"}, "Origin": {"https://dashboard.example.com"}},
+		{APIKeyHeader: {"secret-key"}},
+	} {
+		conn, _, err := websocket.DefaultDialer.Dial(url, header)
+		if err != nil {
+			t.Fatalf("Expected handshake to succeed: %v", err)
+		}
+
+		if err := conn.WriteJSON(realtime.ClientMessage{Type: realtime.MessageSubscribe}); err != nil {
+			t.Fatalf("Failed to subscribe: %v", err)
+		}
+
+		var msg realtime.ServerMessage
+		conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		if err := conn.ReadJSON(&msg); err != nil || msg.Type != realtime.MessageSnapshot {
+			t.Errorf("Expected snapshot, got %+v (%v)", msg, err)
+		}
+		conn.Close()
+	}
+
+	// Остановка сервера закрывает открытые соединения
+	conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{APIKeyHeader: {"secret-key"}})
+	if err != nil {
+		t.Fatalf("Expected handshake to succeed: %v", err)
+	}
+	defer conn.Close()
+
+	// Снимок подтверждает, что соединение зарегистрировано обработчиком
+	conn.WriteJSON(realtime.ClientMessage{Type: realtime.MessageSubscribe})
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, _, err := conn.ReadMessage(); err != nil {
+		t.Fatalf("Failed to read snapshot: %v", err)
+	}
+
+	srv.Stop()
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, _, err := conn.ReadMessage(); err == nil || !strings.Contains(err.Error(), "1001") {
+		t.Errorf("Expected going away close, got %v", err)
+	}
+}
+
+func TestServer_TraceParentPropagation(t *testing.T) {
+	var buf bytes.Buffer
+	tracing.SetTracer(tracing.NewTracer(tracing.Config{Exporter: tracing.NewWriterExporter(&buf), SampleRatio: 1}))
+	defer tracing.SetTracer(nil)
+
+	srv := NewServer(Config{Address: "127.0.0.1:0"}, nil)
+
+	var handlerTrace tracing.SpanContext
+	srv.HandlePublic(HealthzPath, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerTrace = tracing.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, HealthzPath, nil)
+	req.Header.Set(tracing.TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	if handlerTrace.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Handler must continue the client trace, got %s", handlerTrace.TraceID)
+	}
+
+	response, ok := tracing.ParseTraceParent(rec.Header().Get(middleware.TraceResponseHeader))
+	if !ok || response.SpanID != handlerTrace.SpanID {
+		t.Errorf("Unexpected traceresponse %q", rec.Header().Get(middleware.TraceResponseHeader))
+	}
+
+	if !strings.Contains(buf.String(), `"parent_id":"00f067aa0ba902b7"`) || !strings.Contains(buf.String(), `"http.status_code":200`) {
+		t.Errorf("Expected exported server span, got %s", buf.String())
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"todo-app/app/attachments"
+	"todo-app/app/events"
+	"todo-app/app/models"
+	"todo-app/app/repository"
+	"todo-app/internal/validation"
+)
+
+// AttachmentServiceImpl реализует интерфейс AttachmentService
+type AttachmentServiceImpl struct {
+	repo      repository.AttachmentRepository
+	taskRepo  repository.TaskRepository
+	store     *attachments.Store
+	limits    attachments.Limits
+	validator *validation.AttachmentValidator
+	txManager repository.TxManager
+	recorder  events.Recorder
+	now       func() time.Time
+}
+
+// NewAttachmentService создает сервис вложений. Содержимое хранится в store,
+// метаданные - в репозитории; содержимое без ссылок удаляет attachments.Collector
+func NewAttachmentService(repo repository.AttachmentRepository, taskRepo repository.TaskRepository, store *attachments.Store, limits attachments.Limits, txManager repository.TxManager, recorder events.Recorder) AttachmentService {
+	return &AttachmentServiceImpl{
+		repo:      repo,
+		taskRepo:  taskRepo,
+		store:     store,
+		limits:    limits,
+		validator: validation.NewAttachmentValidator(),
+		txManager: txManager,
+		recorder:  recorder,
+		now:       time.Now,
+	}
+}
+
+// withinTransaction выполняет fn в транзакции и после фиксации уведомляет о новых событиях
+func (s *AttachmentServiceImpl) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return runInTransaction(ctx, s.txManager, s.recorder, fn)
+}
+
+// AddAttachment прикрепляет к задаче файл с содержимым content. MIME тип
+// определяется по первым байтам содержимого и проверяется до записи в хранилище,
+// размер - во время записи
+func (s *AttachmentServiceImpl) AddAttachment(ctx context.Context, req models.CreateAttachmentRequest, content io.Reader) (*models.Attachment, error) {
+	req.FileName = strings.TrimSpace(req.FileName)
+	if err := s.validator.ValidateCreateAttachmentRequest(req); err != nil {
+		return nil, fmt.Errorf("invalid create attachment request: %w", err)
+	}
+
+	// Проверка задачи до записи, чтобы не сохранять содержимое впустую
+	if _, err := s.taskRepo.GetByID(ctx, req.TaskID); err != nil {
+		return nil, fmt.Errorf("failed to find task for attachment: %w", err)
+	}
+
+	reader := bufio.NewReaderSize(content, attachments.SniffLength)
+	head, err := reader.Peek(attachments.SniffLength)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+
+	contentType := attachments.DetectContentType(req.FileName, head)
+	if !s.limits.Allows(contentType) {
+		return nil, fmt.Errorf("attachment type not allowed: %w", validation.FileTypeNotAllowed(contentType))
+	}
+
+	blob, err := s.store.Put(reader, s.limits.MaxSize)
+	if err != nil {
+		if errors.Is(err, attachments.ErrTooLarge) {
+			return nil, fmt.Errorf("attachment too large: %w", validation.FileTooLarge(s.limits.MaxSize))
+		}
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+
+	// При ошибке ниже содержимое остается без ссылки и удаляется сборкой мусора
+	var attachment *models.Attachment
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		task, err := s.taskRepo.GetByID(ctx, req.TaskID)
+		if err != nil {
+			return fmt.Errorf("failed to find task for attachment: %w", err)
+		}
+
+		attachment, err = s.repo.Create(ctx, &models.Attachment{
+			TaskID:      task.ID,
+			FileName:    req.FileName,
+			ContentType: contentType,
+			Size:        blob.Size,
+			SHA256:      blob.Hash,
+			CreatedAt:   s.now(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create attachment: %w", err)
+		}
+
+		return recordEvent(ctx, s.recorder, events.NewAttachmentAdded(task, attachment))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// DeleteAttachment удаляет вложение. Содержимое удаляется сборкой мусора,
+// если на него больше нет ссылок
+func (s *AttachmentServiceImpl) DeleteAttachment(ctx context.Context, id int64) error {
+	if err := validation.PositiveID("id", id); err != nil {
+		return fmt.Errorf("invalid attachment ID: %w", err)
+	}
+
+	return s.withinTransaction(ctx, func(ctx context.Context) error {
+		attachment, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to find attachment: %w", err)
+		}
+
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete attachment: %w", err)
+		}
+
+		task, err := s.taskRepo.GetByID(ctx, attachment.TaskID)
+		if err != nil {
+			return fmt.Errorf("failed to find task for attachment: %w", err)
+		}
+
+		return recordEvent(ctx, s.recorder, events.NewAttachmentDeleted(task, attachment))
+	})
+}
+
+// GetAttachment получает метаданные вложения
+func (s *AttachmentServiceImpl) GetAttachment(ctx context.Context, id int64) (*models.Attachment, error) {
+	if err := validation.PositiveID("id", id); err != nil {
+		return nil, fmt.Errorf("invalid attachment ID: %w", err)
+	}
+
+	attachment, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// GetAttachmentContent получает вложение вместе с содержимым
+func (s *AttachmentServiceImpl) GetAttachmentContent(ctx context.Context, id int64) (*models.AttachmentContent, error) {
+	attachment, err := s.GetAttachment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.store.ReadAll(attachment.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment content: %w", err)
+	}
+
+	return &models.AttachmentContent{Attachment: attachment, Data: data}, nil
+}
+
+// GetTaskAttachments получает вложения задачи по времени добавления
+func (s *AttachmentServiceImpl) GetTaskAttachments(ctx context.Context, taskID int) ([]*models.Attachment, error) {
+	if err := validation.PositiveID("task_id", int64(taskID)); err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+
+	list, err := s.repo.GetByTask(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"todo-app/app/events"
+	"todo-app/app/models"
+	"todo-app/app/repository"
+	"todo-app/internal/validation"
+)
+
+// Ограничения выборки истории задачи
+const (
+	defaultHistoryLimit = 100
+	maxHistoryLimit     = 500
+)
+
+// CommentServiceImpl реализует интерфейс CommentService
+type CommentServiceImpl struct {
+	repo      repository.CommentRepository
+	taskRepo  repository.TaskRepository
+	outbox    repository.OutboxRepository
+	validator *validation.CommentValidator
+	txManager repository.TxManager
+	recorder  events.Recorder
+	now       func() time.Time
+}
+
+// NewCommentService создает сервис комментариев. Изменения записываются
+// событиями в outbox, из которого читается история задачи (outbox может быть nil -
+// тогда история пуста)
+func NewCommentService(repo repository.CommentRepository, taskRepo repository.TaskRepository, outbox repository.OutboxRepository, txManager repository.TxManager, recorder events.Recorder) CommentService {
+	return &CommentServiceImpl{
+		repo:      repo,
+		taskRepo:  taskRepo,
+		outbox:    outbox,
+		validator: validation.NewCommentValidator(),
+		txManager: txManager,
+		recorder:  recorder,
+		now:       time.Now,
+	}
+}
+
+// withinTransaction выполняет fn в транзакции и после фиксации уведомляет о новых событиях
+func (s *CommentServiceImpl) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return runInTransaction(ctx, s.txManager, s.recorder, fn)
+}
+
+// AddComment добавляет комментарий к задаче
+func (s *CommentServiceImpl) AddComment(ctx context.Context, req models.CreateCommentRequest) (*models.Comment, error) {
+	if err := s.validator.ValidateCreateCommentRequest(req); err != nil {
+		return nil, fmt.Errorf("invalid create comment request: %w", err)
+	}
+
+	var comment *models.Comment
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		task, err := s.taskRepo.GetByID(ctx, req.TaskID)
+		if err != nil {
+			return fmt.Errorf("failed to find task for comment: %w", err)
+		}
+
+		comment, err = s.repo.Create(ctx, &models.Comment{
+			TaskID:    task.ID,
+			Body:      models.NormalizeCommentBody(req.Body),
+			CreatedAt: s.now(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
+
+		return recordEvent(ctx, s.recorder, events.NewCommentAdded(task, comment))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// UpdateComment заменяет тело комментария. Предыдущая версия попадает в ревизии;
+// сохранение без изменений ревизию не создает
+func (s *CommentServiceImpl) UpdateComment(ctx context.Context, req models.UpdateCommentRequest) (*models.Comment, error) {
+	if err := s.validator.ValidateUpdateCommentRequest(req); err != nil {
+		return nil, fmt.Errorf("invalid update comment request: %w", err)
+	}
+
+	body := models.NormalizeCommentBody(req.Body)
+
+	var comment *models.Comment
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.repo.GetByID(ctx, req.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find comment: %w", err)
+		}
+
+		if existing.Body == body {
+			comment = existing
+			return nil
+		}
+
+		comment, err = s.repo.Update(ctx, req.ID, body, s.now())
+		if err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+
+		task, err := s.taskRepo.GetByID(ctx, comment.TaskID)
+		if err != nil {
+			return fmt.Errorf("failed to find task for comment: %w", err)
+		}
+
+		return recordEvent(ctx, s.recorder, events.NewCommentEdited(task, comment, existing.Body))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// DeleteComment удаляет комментарий вместе с ревизиями
+func (s *CommentServiceImpl) DeleteComment(ctx context.Context, id int64) error {
+	if err := validation.PositiveID("id", id); err != nil {
+		return fmt.Errorf("invalid comment ID: %w", err)
+	}
+
+	return s.withinTransaction(ctx, func(ctx context.Context) error {
+		comment, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to find comment: %w", err)
+		}
+
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete comment: %w", err)
+		}
+
+		task, err := s.taskRepo.GetByID(ctx, comment.TaskID)
+		if err != nil {
+			return fmt.Errorf("failed to find task for comment: %w", err)
+		}
+
+		return recordEvent(ctx, s.recorder, events.NewCommentDeleted(task, comment))
+	})
+}
+
+// GetTaskComments получает комментарии задачи: asc - от старых к новым, desc - наоборот
+func (s *CommentServiceImpl) GetTaskComments(ctx context.Context, taskID int, order models.SortOrder) ([]*models.Comment, error) {
+	if err := validation.PositiveID("task_id", int64(taskID)); err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+
+	if order == "" {
+		order = models.SortOrderAsc
+	}
+	if !models.IsValidSortOrder(string(order)) {
+		return nil, fmt.Errorf("invalid comment order: %w", validation.InvalidValue("order", string(order)))
+	}
+
+	comments, err := s.repo.GetByTask(ctx, taskID, order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// GetCommentsByTasks получает комментарии нескольких задач от старых к новым
+func (s *CommentServiceImpl) GetCommentsByTasks(ctx context.Context, taskIDs []int) (map[int][]*models.Comment, error) {
+	comments, err := s.repo.GetByTasks(ctx, taskIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// GetCommentRevisions получает предыдущие версии комментария от ранних к поздним
+func (s *CommentServiceImpl) GetCommentRevisions(ctx context.Context, id int64) ([]*models.CommentRevision, error) {
+	if err := validation.PositiveID("id", id); err != nil {
+		return nil, fmt.Errorf("invalid comment ID: %w", err)
+	}
+
+	// Ошибка NOT_FOUND для удаленного комментария вместо пустого списка
+	if _, err := s.repo.GetByID(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to find comment: %w", err)
+	}
+
+	revisions, err := s.repo.GetRevisions(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+// GetTaskHistory получает последние limit событий задачи (изменения, переходы,
+// комментарии) в порядке их записи. limit <= 0 - значение по умолчанию
+func (s *CommentServiceImpl) GetTaskHistory(ctx context.Context, taskID int, limit int) ([]*models.TaskHistoryEntry, error) {
+	if err := validation.PositiveID("task_id", int64(taskID)); err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+
+	history := []*models.TaskHistoryEntry{}
+	if s.outbox == nil {
+		return history, nil
+	}
+
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+
+	records, err := s.outbox.GetByTask(ctx, taskID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task history: %w", err)
+	}
+
+	for _, record := range records {
+		history = append(history, &models.TaskHistoryEntry{
+			ID:         record.ID,
+			Type:       record.EventType,
+			OccurredAt: record.CreatedAt,
+			Data:       record.Payload,
+		})
+	}
+
+	return history, nil
+}
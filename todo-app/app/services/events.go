@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"todo-app/app/events"
+	"todo-app/app/repository"
+)
+
+// runInTransaction выполняет fn в транзакции (без txManager - напрямую)
+// и после фиксации уведомляет recorder о новых событиях
+func runInTransaction(ctx context.Context, txManager repository.TxManager, recorder events.Recorder, fn func(ctx context.Context) error) error {
+	if txManager == nil {
+		return fn(ctx)
+	}
+
+	if err := txManager.WithinTransaction(ctx, fn); err != nil {
+		return err
+	}
+
+	if recorder != nil {
+		recorder.Notify()
+	}
+
+	return nil
+}
+
+// recordEvent записывает доменное событие в текущей транзакции, если recorder задан
+func recordEvent(ctx context.Context, recorder events.Recorder, event events.Event) error {
+	if recorder == nil {
+		return nil
+	}
+
+	if err := recorder.Record(ctx, event); err != nil {
+		return fmt.Errorf("failed to record event: %w", err)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"io"
 	"todo-app/app/models"
 )
 
@@ -13,6 +14,9 @@ type TaskService interface {
 	// GetAllTasks получает список всех задач с применением фильтров и сортировки
 	GetAllTasks(ctx context.Context, filter models.TaskFilter, sort models.TaskSort) ([]*models.Task, error)
 
+	// GetTasksPage получает страницу задач по непрозрачному курсору (пустой курсор - первая страница)
+	GetTasksPage(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, cursor string, limit int) (*models.TaskPage, error)
+
 	// GetTaskByID получает задачу по ID
 	GetTaskByID(ctx context.Context, id int) (*models.Task, error)
 
@@ -22,14 +26,139 @@ type TaskService interface {
 	// DeleteTask удаляет задачу
 	DeleteTask(ctx context.Context, id int) error
 
-	// ToggleTaskStatus переключает статус задачи (active/completed)
+	// ToggleTaskStatus выполняет незакрытую задачу или возвращает закрытую в todo
 	ToggleTaskStatus(ctx context.Context, id int) (*models.Task, error)
 
-	// GetDashboardStats получает статистику для дашборда
-	GetDashboardStats(ctx context.Context) (*models.DashboardStats, error)
+	// ChangeStatus переводит задачу в статус, если переход разрешен workflow
+	ChangeStatus(ctx context.Context, id int, status models.TaskStatus) (*models.Task, error)
+
+	// GetWorkflow возвращает используемый workflow статусов
+	GetWorkflow(ctx context.Context) *models.Workflow
+
+	// MoveTask ставит задачу между соседями в общем списке (пустая колонка) или в колонке доски
+	MoveTask(ctx context.Context, id, beforeID, afterID int, column models.TaskStatus) (*models.Task, error)
+
+	// GetBoard получает задачи, сгруппированные по колонкам статусов
+	GetBoard(ctx context.Context, filter models.TaskFilter) (*models.Board, error)
+
+	// SetEstimate изменяет оценку задачи в минутах; nil снимает оценку
+	SetEstimate(ctx context.Context, req models.SetEstimateRequest) (*models.Task, error)
+
+	// ArchiveTask переносит задачу в архив
+	ArchiveTask(ctx context.Context, id int) error
+
+	// GetDashboardStats получает статистику для дашборда в часовом поясе timezone
+	GetDashboardStats(ctx context.Context, timezone string) (*models.DashboardStats, error)
+}
+
+// WebhookService определяет интерфейс для сервиса управления webhooks
+type WebhookService interface {
+	// CreateWebhook создает подписку; секрет подписи возвращается только здесь
+	CreateWebhook(ctx context.Context, req models.CreateWebhookRequest) (*models.CreateWebhookResponse, error)
+
+	// GetWebhooks получает все подписки
+	GetWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error)
+
+	// SetWebhookActive включает или отключает подписку
+	SetWebhookActive(ctx context.Context, id int, active bool) error
+
+	// DeleteWebhook удаляет подписку вместе с журналом доставок
+	DeleteWebhook(ctx context.Context, id int) error
+
+	// GetWebhookDeliveries получает последние доставки подписки
+	GetWebhookDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*models.WebhookDelivery, error)
+
+	// GetDeliveryAttempts получает журнал попыток доставки
+	GetDeliveryAttempts(ctx context.Context, deliveryID int64) ([]*models.WebhookAttempt, error)
+
+	// RetryDelivery возвращает недоставленное событие (в том числе из dead-letter) в очередь
+	RetryDelivery(ctx context.Context, deliveryID int64) error
+}
+
+// SettingsService определяет интерфейс для сервиса настроек приложения
+type SettingsService interface {
+	// GetSettings получает настройки приложения
+	GetSettings(ctx context.Context) (*models.AppSettings, error)
+
+	// UpdateSettings сохраняет настройки и записывает событие об изменении
+	UpdateSettings(ctx context.Context, settings models.AppSettings) (*models.AppSettings, error)
+}
+
+// TimeTrackingService определяет интерфейс для сервиса учета времени
+type TimeTrackingService interface {
+	// StartTimer запускает таймер по задаче; одновременно может идти только один таймер
+	StartTimer(ctx context.Context, req models.StartTimerRequest) (*models.TimeEntry, error)
+
+	// StopTimer останавливает запущенный таймер
+	StopTimer(ctx context.Context) (*models.TimeEntry, error)
+
+	// GetActiveTimer получает запущенный таймер; nil - таймер не запущен
+	GetActiveTimer(ctx context.Context) (*models.TimeEntry, error)
+
+	// AddTimeEntry добавляет завершенный отрезок времени вручную
+	AddTimeEntry(ctx context.Context, req models.CreateTimeEntryRequest) (*models.TimeEntry, error)
+
+	// DeleteTimeEntry удаляет запись времени
+	DeleteTimeEntry(ctx context.Context, id int64) error
+
+	// GetTimeEntries получает записи времени по фильтру
+	GetTimeEntries(ctx context.Context, filter models.TimeEntryFilter) ([]*models.TimeEntry, error)
+
+	// GetTimeReport считает итоги по задачам, проектам или дням в часовом поясе timezone
+	GetTimeReport(ctx context.Context, req models.TimeReportRequest, timezone string) (*models.TimeReport, error)
+
+	// GetTrackedByTask возвращает затраченное время в секундах по задачам
+	GetTrackedByTask(ctx context.Context, taskIDs []int) (map[int]int64, error)
+}
+
+// CommentService определяет интерфейс комментариев и истории задач
+type CommentService interface {
+	// AddComment добавляет комментарий к задаче
+	AddComment(ctx context.Context, req models.CreateCommentRequest) (*models.Comment, error)
+
+	// UpdateComment заменяет тело комментария, сохраняя предыдущую версию
+	UpdateComment(ctx context.Context, req models.UpdateCommentRequest) (*models.Comment, error)
+
+	// DeleteComment удаляет комментарий вместе с ревизиями
+	DeleteComment(ctx context.Context, id int64) error
+
+	// GetTaskComments получает комментарии задачи по времени создания в направлении order
+	GetTaskComments(ctx context.Context, taskID int, order models.SortOrder) ([]*models.Comment, error)
+
+	// GetCommentsByTasks получает комментарии нескольких задач от старых к новым
+	GetCommentsByTasks(ctx context.Context, taskIDs []int) (map[int][]*models.Comment, error)
+
+	// GetCommentRevisions получает предыдущие версии комментария
+	GetCommentRevisions(ctx context.Context, id int64) ([]*models.CommentRevision, error)
+
+	// GetTaskHistory получает последние limit событий задачи в порядке их записи
+	GetTaskHistory(ctx context.Context, taskID int, limit int) ([]*models.TaskHistoryEntry, error)
+}
+
+// AttachmentService определяет интерфейс вложений задач
+type AttachmentService interface {
+	// AddAttachment прикрепляет к задаче файл с содержимым content
+	AddAttachment(ctx context.Context, req models.CreateAttachmentRequest, content io.Reader) (*models.Attachment, error)
+
+	// DeleteAttachment удаляет вложение
+	DeleteAttachment(ctx context.Context, id int64) error
+
+	// GetAttachment получает метаданные вложения
+	GetAttachment(ctx context.Context, id int64) (*models.Attachment, error)
+
+	// GetAttachmentContent получает вложение вместе с содержимым
+	GetAttachmentContent(ctx context.Context, id int64) (*models.AttachmentContent, error)
+
+	// GetTaskAttachments получает вложения задачи по времени добавления
+	GetTaskAttachments(ctx context.Context, taskID int) ([]*models.Attachment, error)
 }
 
 // AppServices объединяет все сервисы приложения
 type AppServices struct {
-	TaskService TaskService
+	TaskService     TaskService
+	WebhookService  WebhookService
+	SettingsService SettingsService
+	TimeTracking    TimeTrackingService
+	Comments        CommentService
+	Attachments     AttachmentService
 }
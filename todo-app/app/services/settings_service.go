@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"todo-app/app/events"
+	"todo-app/app/models"
+	"todo-app/app/repository"
+	"todo-app/internal/validation"
+)
+
+// SettingsServiceImpl реализует интерфейс SettingsService
+type SettingsServiceImpl struct {
+	repo      repository.SettingsRepository
+	validator *validation.SettingsValidator
+	txManager repository.TxManager
+	recorder  events.Recorder
+}
+
+// NewSettingsService создает сервис настроек. txManager и recorder могут быть nil,
+// тогда изменение сохраняется без события settings.updated
+func NewSettingsService(repo repository.SettingsRepository, txManager repository.TxManager, recorder events.Recorder) SettingsService {
+	return &SettingsServiceImpl{
+		repo:      repo,
+		validator: validation.NewSettingsValidator(),
+		txManager: txManager,
+		recorder:  recorder,
+	}
+}
+
+// GetSettings получает настройки приложения
+func (s *SettingsServiceImpl) GetSettings(ctx context.Context) (*models.AppSettings, error) {
+	settings, err := s.repo.GetSettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// UpdateSettings сохраняет настройки. Версия схемы задается хранилищем, а не
+// клиентом; если ни одно значение не изменилось, запись и событие пропускаются
+func (s *SettingsServiceImpl) UpdateSettings(ctx context.Context, settings models.AppSettings) (*models.AppSettings, error) {
+	if err := s.validator.ValidateSettings(settings); err != nil {
+		return nil, fmt.Errorf("invalid settings: %w", err)
+	}
+
+	var result *models.AppSettings
+	err := runInTransaction(ctx, s.txManager, s.recorder, func(ctx context.Context) error {
+		previous, err := s.repo.GetSettings(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get settings: %w", err)
+		}
+
+		settings.SchemaVersion = previous.SchemaVersion
+		if len(previous.ChangedKeys(&settings)) == 0 {
+			result = previous
+			return nil
+		}
+
+		if err := s.repo.UpdateSettings(ctx, &settings); err != nil {
+			return fmt.Errorf("failed to update settings: %w", err)
+		}
+		result = &settings
+
+		return recordEvent(ctx, s.recorder, events.NewSettingsUpdated(&settings, previous))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"time"
+	"todo-app/app/events"
 	"todo-app/app/models"
 	"todo-app/app/repository"
 	"todo-app/internal/validation"
@@ -13,6 +14,9 @@ import (
 type TaskServiceImpl struct {
 	repo      repository.TaskRepository
 	validator *validation.TaskValidator
+	txManager repository.TxManager
+	recorder  events.Recorder
+	workflow  *models.Workflow
 }
 
 // NewTaskService создает новый экземпляр сервиса задач
@@ -20,9 +24,36 @@ func NewTaskService(repo repository.TaskRepository) TaskService {
 	return &TaskServiceImpl{
 		repo:      repo,
 		validator: validation.NewTaskValidator(),
+		workflow:  models.DefaultWorkflow(),
 	}
 }
 
+// NewTaskServiceWithEvents создает сервис задач, записывающий доменные события
+// в той же транзакции, что и изменение задачи. Смена статусов проверяется по workflow,
+// nil - workflow по умолчанию
+func NewTaskServiceWithEvents(repo repository.TaskRepository, txManager repository.TxManager, recorder events.Recorder, workflow *models.Workflow) TaskService {
+	if workflow == nil {
+		workflow = models.DefaultWorkflow()
+	}
+	return &TaskServiceImpl{
+		repo:      repo,
+		validator: validation.NewTaskValidator(),
+		txManager: txManager,
+		recorder:  recorder,
+		workflow:  workflow,
+	}
+}
+
+// withinTransaction выполняет fn в транзакции и после фиксации уведомляет о новых событиях
+func (s *TaskServiceImpl) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return runInTransaction(ctx, s.txManager, s.recorder, fn)
+}
+
+// record записывает доменное событие, если сервис создан с поддержкой событий
+func (s *TaskServiceImpl) record(ctx context.Context, event events.Event) error {
+	return recordEvent(ctx, s.recorder, event)
+}
+
 // CreateTask создает новую задачу
 func (s *TaskServiceImpl) CreateTask(ctx context.Context, req models.CreateTaskRequest) (*models.Task, error) {
 	// Валидация запроса
@@ -36,11 +67,16 @@ func (s *TaskServiceImpl) CreateTask(ctx context.Context, req models.CreateTaskR
 	task := &models.Task{
 		Title:       req.Title,
 		Description: req.Description,
-		Status:      models.TaskStatusActive, // Новая задача всегда активна
+		Status:      models.TaskStatusTodo, // Новая задача всегда начинается с todo
 		Priority:    req.Priority,
-		DueDate:     req.DueDate,
+		DueDate:     models.NormalizeDueDate(req.DueDate, req.DueAllDay),
+		DueAllDay:   req.DueAllDay && req.DueDate != nil,
+		Project:     req.Project,
+		Tags:        req.Tags,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+
+		EstimateMinutes: req.EstimateMinutes,
 	}
 
 	// Если приоритет не указан, устанавливаем средний
@@ -48,10 +84,19 @@ func (s *TaskServiceImpl) CreateTask(ctx context.Context, req models.CreateTaskR
 		task.Priority = models.PriorityMedium
 	}
 
-	// Сохранение в репозитории
-	createdTask, err := s.repo.Create(ctx, task)
+	// Сохранение в репозитории вместе с событием
+	var createdTask *models.Task
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		createdTask, err = s.repo.Create(ctx, task)
+		if err != nil {
+			return fmt.Errorf("failed to create task: %w", err)
+		}
+
+		return s.record(ctx, events.NewTaskCreated(createdTask))
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create task: %w", err)
+		return nil, err
 	}
 
 	return createdTask, nil
@@ -77,6 +122,35 @@ func (s *TaskServiceImpl) GetAllTasks(ctx context.Context, filter models.TaskFil
 	return tasks, nil
 }
 
+// GetTasksPage получает страницу задач по курсору
+func (s *TaskServiceImpl) GetTasksPage(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, cursor string, limit int) (*models.TaskPage, error) {
+	// Валидация фильтра и сортировки
+	if err := s.validator.ValidateTaskFilter(filter); err != nil {
+		return nil, fmt.Errorf("invalid task filter: %w", err)
+	}
+
+	if err := s.validator.ValidateTaskSort(sort); err != nil {
+		return nil, fmt.Errorf("invalid task sort: %w", err)
+	}
+
+	// Декодируем курсор и проверяем, что он выдан для той же сортировки
+	var after *models.TaskCursor
+	if cursor != "" {
+		decoded, err := s.validator.ValidateCursor(cursor, sort)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor: %w", err)
+		}
+		after = decoded
+	}
+
+	page, err := s.repo.GetPage(ctx, filter, sort, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks page: %w", err)
+	}
+
+	return page, nil
+}
+
 // GetTaskByID получает задачу по ID
 func (s *TaskServiceImpl) GetTaskByID(ctx context.Context, id int) (*models.Task, error) {
 	// Валидация ID
@@ -100,23 +174,34 @@ func (s *TaskServiceImpl) UpdateTask(ctx context.Context, req models.UpdateTaskR
 		return nil, fmt.Errorf("invalid update task request: %w", err)
 	}
 
-	// Проверка существования задачи
-	existingTask, err := s.repo.GetByID(ctx, req.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find task for update: %w", err)
-	}
+	var updatedTask *models.Task
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		// Проверка существования задачи
+		existingTask, err := s.repo.GetByID(ctx, req.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find task for update: %w", err)
+		}
+
+		previous := *existingTask
 
-	// Обновляем только измененные поля, сохраняя значения системных полей
-	existingTask.Title = req.Title
-	existingTask.Description = req.Description
-	existingTask.Priority = req.Priority
-	existingTask.DueDate = req.DueDate
-	existingTask.UpdatedAt = time.Now()
+		// Обновляем только измененные поля, сохраняя значения системных полей
+		existingTask.Title = req.Title
+		existingTask.Description = req.Description
+		existingTask.Priority = req.Priority
+		existingTask.DueDate = models.NormalizeDueDate(req.DueDate, req.DueAllDay)
+		existingTask.DueAllDay = req.DueAllDay && req.DueDate != nil
+		existingTask.UpdatedAt = time.Now()
+
+		// Сохранение изменений в репозитории
+		updatedTask, err = s.repo.Update(ctx, existingTask)
+		if err != nil {
+			return fmt.Errorf("failed to update task: %w", err)
+		}
 
-	// Сохранение изменений в репозитории
-	updatedTask, err := s.repo.Update(ctx, existingTask)
+		return s.record(ctx, events.NewTaskUpdated(updatedTask, &previous))
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update task: %w", err)
+		return nil, err
 	}
 
 	return updatedTask, nil
@@ -129,71 +214,325 @@ func (s *TaskServiceImpl) DeleteTask(ctx context.Context, id int) error {
 		return fmt.Errorf("invalid task ID: %w", err)
 	}
 
-	// Проверка существования задачи
-	_, err := s.repo.GetByID(ctx, id)
+	return s.withinTransaction(ctx, func(ctx context.Context) error {
+		// Проверка существования задачи
+		task, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to find task for deletion: %w", err)
+		}
+
+		// Удаление задачи
+		if err := s.repo.Delete(ctx, id); err != nil {
+			return fmt.Errorf("failed to delete task: %w", err)
+		}
+
+		return s.record(ctx, events.NewTaskDeleted(task))
+	})
+}
+
+// ToggleTaskStatus выполняет незакрытую задачу или возвращает закрытую в todo
+func (s *TaskServiceImpl) ToggleTaskStatus(ctx context.Context, id int) (*models.Task, error) {
+	return s.changeStatus(ctx, id, s.workflow.ToggleTarget)
+}
+
+// ChangeStatus переводит задачу в статус, если переход разрешен workflow
+func (s *TaskServiceImpl) ChangeStatus(ctx context.Context, id int, status models.TaskStatus) (*models.Task, error) {
+	return s.changeStatus(ctx, id, func(models.TaskStatus) models.TaskStatus { return status })
+}
+
+// changeStatus переводит задачу в статус, выбранный target по текущему статусу,
+// и записывает событие перехода
+func (s *TaskServiceImpl) changeStatus(ctx context.Context, id int, target func(models.TaskStatus) models.TaskStatus) (*models.Task, error) {
+	// Валидация ID
+	if err := s.validator.ValidateID(id); err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+
+	var updatedTask *models.Task
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		// Получение текущей задачи
+		task, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to find task for status change: %w", err)
+		}
+
+		updatedTask, err = s.transition(ctx, task, target(task.Status))
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("failed to find task for deletion: %w", err)
+		return nil, err
 	}
 
-	// Удаление задачи
-	err = s.repo.Delete(ctx, id)
+	return updatedTask, nil
+}
+
+// transition переводит загруженную задачу в статус to в текущей транзакции
+// и записывает событие перехода; тот же статус оставляет задачу без изменений
+func (s *TaskServiceImpl) transition(ctx context.Context, task *models.Task, to models.TaskStatus) (*models.Task, error) {
+	from := task.Status
+	if from == to {
+		return task, nil
+	}
+
+	if err := s.validator.ValidateStatusTransition(s.workflow, from, to); err != nil {
+		return nil, fmt.Errorf("invalid status transition: %w", err)
+	}
+
+	task.ApplyStatus(to, time.Now())
+	if err := s.repo.UpdateStatus(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to change task status: %w", err)
+	}
+
+	// Получаем обновленную задачу из репозитория
+	updatedTask, err := s.repo.GetByID(ctx, task.ID)
 	if err != nil {
-		return fmt.Errorf("failed to delete task: %w", err)
+		return nil, fmt.Errorf("failed to get updated task: %w", err)
+	}
+
+	switch {
+	case to == models.TaskStatusDone:
+		err = s.record(ctx, events.NewTaskCompleted(updatedTask))
+	case from.IsClosed() && to.IsOpen():
+		err = s.record(ctx, events.NewTaskReopened(updatedTask))
+	default:
+		err = s.record(ctx, events.NewTaskStatusChanged(updatedTask, from))
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return updatedTask, nil
 }
 
-// ToggleTaskStatus переключает статус задачи между активным и выполненным
-func (s *TaskServiceImpl) ToggleTaskStatus(ctx context.Context, id int) (*models.Task, error) {
+// MoveTask ставит задачу между соседями beforeID и afterID (0 - нет соседа) в общем
+// списке (пустая колонка) или в колонке статуса на доске. Перенос в другую колонку
+// меняет статус по правилам workflow. Без соседей задача ставится в конец
+func (s *TaskServiceImpl) MoveTask(ctx context.Context, id, beforeID, afterID int, column models.TaskStatus) (*models.Task, error) {
 	// Валидация ID
 	if err := s.validator.ValidateID(id); err != nil {
 		return nil, fmt.Errorf("invalid task ID: %w", err)
 	}
 
-	// Получение текущей задачи
+	var movedTask *models.Task
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		task, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to find task for move: %w", err)
+		}
+
+		// Перенос между колонками доски - смена статуса
+		if column != "" {
+			if task, err = s.transition(ctx, task, column); err != nil {
+				return err
+			}
+		}
+
+		before, err := s.neighbor(ctx, beforeID)
+		if err != nil {
+			return err
+		}
+		after, err := s.neighbor(ctx, afterID)
+		if err != nil {
+			return err
+		}
+		if err := s.validator.ValidateMove(task, before, after, column); err != nil {
+			return fmt.Errorf("invalid task move: %w", err)
+		}
+
+		position, err := s.positionBetween(ctx, before, after, column)
+		if err != nil {
+			return err
+		}
+		if err := s.repo.UpdatePosition(ctx, id, column, position); err != nil {
+			return fmt.Errorf("failed to move task: %w", err)
+		}
+
+		movedTask, err = s.repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get moved task: %w", err)
+		}
+
+		return s.record(ctx, events.NewTaskMoved(movedTask, column))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return movedTask, nil
+}
+
+// neighbor загружает соседнюю задачу для переноса; 0 - соседа нет
+func (s *TaskServiceImpl) neighbor(ctx context.Context, id int) (*models.Task, error) {
+	if id == 0 {
+		return nil, nil
+	}
 	task, err := s.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find task for status toggle: %w", err)
+		return nil, fmt.Errorf("failed to find neighbor task: %w", err)
 	}
+	return task, nil
+}
 
-	// Переключение статуса
-	now := time.Now()
-	task.UpdatedAt = now
+// positionBetween вычисляет позицию между соседями в области column. Если зазор
+// исчерпан, позиции области перенумеровываются и соседи перечитываются
+func (s *TaskServiceImpl) positionBetween(ctx context.Context, before, after *models.Task, column models.TaskStatus) (float64, error) {
+	positionOf := func(task *models.Task) *float64 {
+		if task == nil {
+			return nil
+		}
+		position := task.PositionIn(column)
+		return &position
+	}
+
+	// Без соседей - в конец области
+	if before == nil && after == nil {
+		last, ok, err := s.repo.LastPosition(ctx, column)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get last position: %w", err)
+		}
+		if ok {
+			return last + models.PositionStep, nil
+		}
+		return models.PositionStep, nil
+	}
+
+	if position, ok := models.PositionBetween(positionOf(before), positionOf(after)); ok {
+		return position, nil
+	}
 
-	if task.Status == models.TaskStatusActive {
-		// Если задача была активной, делаем её выполненной
-		task.Status = models.TaskStatusCompleted
-		task.CompletedAt = &now
+	if err := s.repo.RebalancePositions(ctx, column); err != nil {
+		return 0, fmt.Errorf("failed to rebalance positions: %w", err)
+	}
+	before, err := s.repo.GetByID(ctx, before.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reload neighbor task: %w", err)
+	}
+	after, err = s.repo.GetByID(ctx, after.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reload neighbor task: %w", err)
+	}
 
-		// Обновление в репозитории
-		err = s.repo.MarkAsCompleted(ctx, id)
-	} else {
-		// Если задача была выполненной, делаем её активной
-		task.Status = models.TaskStatusActive
-		task.CompletedAt = nil
+	position, _ := models.PositionBetween(positionOf(before), positionOf(after))
+	return position, nil
+}
 
-		// Обновление в репозитории
-		err = s.repo.MarkAsActive(ctx, id)
+// GetBoard получает задачи, сгруппированные по колонкам статусов workflow.
+// Задачи в статусах вне workflow выводятся в дополнительных колонках после основных
+func (s *TaskServiceImpl) GetBoard(ctx context.Context, filter models.TaskFilter) (*models.Board, error) {
+	if err := s.validator.ValidateTaskFilter(filter); err != nil {
+		return nil, fmt.Errorf("invalid task filter: %w", err)
 	}
 
+	tasks, err := s.repo.GetBoardTasks(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to toggle task status: %w", err)
+		return nil, fmt.Errorf("failed to get board tasks: %w", err)
 	}
 
-	// Получаем обновленную задачу из репозитория
-	updatedTask, err := s.repo.GetByID(ctx, id)
+	board := &models.Board{Columns: make([]*models.BoardColumn, 0, len(s.workflow.Statuses))}
+	columns := make(map[models.TaskStatus]*models.BoardColumn, len(s.workflow.Statuses))
+	column := func(status models.TaskStatus) *models.BoardColumn {
+		if c, ok := columns[status]; ok {
+			return c
+		}
+		c := &models.BoardColumn{Status: status, Tasks: []*models.TaskResponse{}}
+		columns[status] = c
+		board.Columns = append(board.Columns, c)
+		return c
+	}
+	for _, status := range s.workflow.Statuses {
+		column(status)
+	}
+
+	window := models.NewDueWindow(time.Now(), filter.Timezone)
+	for _, task := range tasks {
+		c := column(task.Status)
+		c.Tasks = append(c.Tasks, &models.TaskResponse{
+			ID:              task.ID,
+			Title:           task.Title,
+			Description:     task.Description,
+			Status:          task.Status,
+			Priority:        task.Priority,
+			DueDate:         task.DueDate,
+			DueAllDay:       task.DueAllDay,
+			Project:         task.Project,
+			Tags:            task.Tags,
+			CreatedAt:       task.CreatedAt,
+			UpdatedAt:       task.UpdatedAt,
+			CompletedAt:     task.CompletedAt,
+			StartedAt:       task.StartedAt,
+			CancelledAt:     task.CancelledAt,
+			IsOverdue:       window.IsOverdue(task),
+			Position:        task.Position,
+			BoardPosition:   task.BoardPosition,
+			EstimateMinutes: task.EstimateMinutes,
+		})
+		c.Count++
+	}
+
+	return board, nil
+}
+
+// GetWorkflow возвращает используемый workflow статусов
+func (s *TaskServiceImpl) GetWorkflow(ctx context.Context) *models.Workflow {
+	return s.workflow
+}
+
+// SetEstimate изменяет оценку задачи; nil снимает оценку
+func (s *TaskServiceImpl) SetEstimate(ctx context.Context, req models.SetEstimateRequest) (*models.Task, error) {
+	if err := s.validator.ValidateSetEstimateRequest(req); err != nil {
+		return nil, fmt.Errorf("invalid set estimate request: %w", err)
+	}
+
+	var updatedTask *models.Task
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		existingTask, err := s.repo.GetByID(ctx, req.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find task for estimate: %w", err)
+		}
+
+		previous := *existingTask
+		existingTask.EstimateMinutes = req.EstimateMinutes
+
+		updatedTask, err = s.repo.Update(ctx, existingTask)
+		if err != nil {
+			return fmt.Errorf("failed to update task estimate: %w", err)
+		}
+
+		return s.record(ctx, events.NewTaskUpdated(updatedTask, &previous))
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get updated task: %w", err)
+		return nil, err
 	}
 
 	return updatedTask, nil
 }
 
-// GetDashboardStats получает статистику для дашборда
-func (s *TaskServiceImpl) GetDashboardStats(ctx context.Context) (*models.DashboardStats, error) {
+// ArchiveTask переносит задачу в архив
+func (s *TaskServiceImpl) ArchiveTask(ctx context.Context, id int) error {
+	// Валидация ID
+	if err := s.validator.ValidateID(id); err != nil {
+		return fmt.Errorf("invalid task ID: %w", err)
+	}
+
+	return s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.Archive(ctx, id); err != nil {
+			return fmt.Errorf("failed to archive task: %w", err)
+		}
+
+		task, err := s.repo.GetByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to get archived task: %w", err)
+		}
+
+		return s.record(ctx, events.NewTaskArchived(task))
+	})
+}
+
+// GetDashboardStats получает статистику для дашборда; сроки оцениваются
+// в часовом поясе timezone
+func (s *TaskServiceImpl) GetDashboardStats(ctx context.Context, timezone string) (*models.DashboardStats, error) {
 	// Получение статистики по задачам
-	stats, err := s.repo.GetTasksStats(ctx)
+	stats, err := s.repo.GetTasksStats(ctx, timezone)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get task stats: %w", err)
 	}
@@ -205,18 +544,29 @@ func (s *TaskServiceImpl) GetDashboardStats(ctx context.Context) (*models.Dashbo
 	}
 
 	// Получение предстоящих задач
-	upcomingTasks, err := s.repo.GetUpcomingTasks(ctx, 5) // Получаем 5 ближайших задач
+	upcomingTasks, err := s.repo.GetUpcomingTasks(ctx, timezone, 5) // Получаем 5 ближайших задач
 	if err != nil {
 		return nil, fmt.Errorf("failed to get upcoming tasks: %w", err)
 	}
 
+	// Количество задач по статусам
+	statusCounts, err := s.repo.GetStatusCounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status counts: %w", err)
+	}
+
 	// Подготовка статистики по приоритетам и статусам
 	priorityBreakdown := make(map[models.Priority]int)
 	statusBreakdown := make(map[models.TaskStatus]int)
 
-	// Базовые статусы всегда должны быть в результате
-	statusBreakdown[models.TaskStatusActive] = 0
-	statusBreakdown[models.TaskStatusCompleted] = 0
+	// Все статусы workflow всегда должны быть в результате; задачи в статусах
+	// вне workflow (например, после его сужения) тоже учитываются
+	for _, status := range s.workflow.Statuses {
+		statusBreakdown[status] = 0
+	}
+	for status, count := range statusCounts {
+		statusBreakdown[status] = count
+	}
 
 	// Базовые приоритеты всегда должны быть в результате
 	priorityBreakdown[models.PriorityLow] = 0
@@ -224,48 +574,59 @@ func (s *TaskServiceImpl) GetDashboardStats(ctx context.Context) (*models.Dashbo
 	priorityBreakdown[models.PriorityHigh] = 0
 
 	// Подготовка моделей ответа
+	window := models.NewDueWindow(time.Now(), timezone)
 	recentTasksResponse := make([]*models.TaskResponse, 0, len(recentTasks))
 	upcomingTasksResponse := make([]*models.TaskResponse, 0, len(upcomingTasks))
 
 	// Конвертация моделей Task в TaskResponse для недавних задач
 	for _, task := range recentTasks {
-		isOverdue := false
-		if task.DueDate != nil && task.Status == models.TaskStatusActive && task.DueDate.Before(time.Now()) {
-			isOverdue = true
-		}
+		isOverdue := window.IsOverdue(task)
 
 		recentTasksResponse = append(recentTasksResponse, &models.TaskResponse{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      task.Status,
-			Priority:    task.Priority,
-			DueDate:     task.DueDate,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   task.UpdatedAt,
-			CompletedAt: task.CompletedAt,
-			IsOverdue:   isOverdue,
+			ID:              task.ID,
+			Title:           task.Title,
+			Description:     task.Description,
+			Status:          task.Status,
+			Priority:        task.Priority,
+			DueDate:         task.DueDate,
+			DueAllDay:       task.DueAllDay,
+			Project:         task.Project,
+			Tags:            task.Tags,
+			CreatedAt:       task.CreatedAt,
+			UpdatedAt:       task.UpdatedAt,
+			CompletedAt:     task.CompletedAt,
+			StartedAt:       task.StartedAt,
+			CancelledAt:     task.CancelledAt,
+			IsOverdue:       isOverdue,
+			Position:        task.Position,
+			BoardPosition:   task.BoardPosition,
+			EstimateMinutes: task.EstimateMinutes,
 		})
 	}
 
 	// Конвертация моделей Task в TaskResponse для предстоящих задач
 	for _, task := range upcomingTasks {
-		isOverdue := false
-		if task.DueDate != nil && task.Status == models.TaskStatusActive && task.DueDate.Before(time.Now()) {
-			isOverdue = true
-		}
+		isOverdue := window.IsOverdue(task)
 
 		upcomingTasksResponse = append(upcomingTasksResponse, &models.TaskResponse{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      task.Status,
-			Priority:    task.Priority,
-			DueDate:     task.DueDate,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   task.UpdatedAt,
-			CompletedAt: task.CompletedAt,
-			IsOverdue:   isOverdue,
+			ID:              task.ID,
+			Title:           task.Title,
+			Description:     task.Description,
+			Status:          task.Status,
+			Priority:        task.Priority,
+			DueDate:         task.DueDate,
+			DueAllDay:       task.DueAllDay,
+			Project:         task.Project,
+			Tags:            task.Tags,
+			CreatedAt:       task.CreatedAt,
+			UpdatedAt:       task.UpdatedAt,
+			CompletedAt:     task.CompletedAt,
+			StartedAt:       task.StartedAt,
+			CancelledAt:     task.CancelledAt,
+			IsOverdue:       isOverdue,
+			Position:        task.Position,
+			BoardPosition:   task.BoardPosition,
+			EstimateMinutes: task.EstimateMinutes,
 		})
 	}
 
@@ -274,6 +635,7 @@ func (s *TaskServiceImpl) GetDashboardStats(ctx context.Context) (*models.Dashbo
 		TaskStats:         *stats,
 		PriorityBreakdown: priorityBreakdown,
 		StatusBreakdown:   statusBreakdown,
+		Workflow:          s.workflow,
 		RecentTasks:       recentTasksResponse,
 		UpcomingTasks:     upcomingTasksResponse,
 	}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"todo-app/app/models"
+	"todo-app/app/repository"
+	"todo-app/internal/validation"
+)
+
+// TimeTrackingServiceImpl реализует интерфейс TimeTrackingService
+type TimeTrackingServiceImpl struct {
+	repo      repository.TimeEntryRepository
+	taskRepo  repository.TaskRepository
+	validator *validation.TimeEntryValidator
+	now       func() time.Time
+}
+
+// NewTimeTrackingService создает сервис учета времени
+func NewTimeTrackingService(repo repository.TimeEntryRepository, taskRepo repository.TaskRepository) TimeTrackingService {
+	return &TimeTrackingServiceImpl{
+		repo:      repo,
+		taskRepo:  taskRepo,
+		validator: validation.NewTimeEntryValidator(),
+		now:       time.Now,
+	}
+}
+
+// StartTimer запускает таймер по задаче. Второй таймер отклоняется
+// уникальным индексом БД, поэтому проверка не зависит от гонок между окнами
+func (s *TimeTrackingServiceImpl) StartTimer(ctx context.Context, req models.StartTimerRequest) (*models.TimeEntry, error) {
+	if err := s.validator.ValidateStartTimer(req); err != nil {
+		return nil, fmt.Errorf("invalid start timer request: %w", err)
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, req.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task for timer: %w", err)
+	}
+
+	entry, err := s.repo.Create(ctx, &models.TimeEntry{
+		TaskID:    task.ID,
+		StartedAt: s.now(),
+		Note:      req.Note,
+		TaskTitle: task.Title,
+		Project:   task.Project,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start timer: %w", err)
+	}
+
+	return entry, nil
+}
+
+// StopTimer останавливает запущенный таймер
+func (s *TimeTrackingServiceImpl) StopTimer(ctx context.Context) (*models.TimeEntry, error) {
+	entry, err := s.repo.Stop(ctx, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop timer: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetActiveTimer получает запущенный таймер; nil - таймер не запущен
+func (s *TimeTrackingServiceImpl) GetActiveTimer(ctx context.Context) (*models.TimeEntry, error) {
+	entry, err := s.repo.GetActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running timer: %w", err)
+	}
+
+	return entry, nil
+}
+
+// AddTimeEntry добавляет завершенный отрезок времени вручную
+func (s *TimeTrackingServiceImpl) AddTimeEntry(ctx context.Context, req models.CreateTimeEntryRequest) (*models.TimeEntry, error) {
+	if err := s.validator.ValidateCreateTimeEntry(req, s.now()); err != nil {
+		return nil, fmt.Errorf("invalid time entry: %w", err)
+	}
+
+	task, err := s.taskRepo.GetByID(ctx, req.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find task for time entry: %w", err)
+	}
+
+	endedAt := req.EndedAt
+	entry, err := s.repo.Create(ctx, &models.TimeEntry{
+		TaskID:    task.ID,
+		StartedAt: req.StartedAt,
+		EndedAt:   &endedAt,
+		Note:      req.Note,
+		Manual:    true,
+		TaskTitle: task.Title,
+		Project:   task.Project,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create time entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// DeleteTimeEntry удаляет запись времени, в том числе запущенный таймер
+func (s *TimeTrackingServiceImpl) DeleteTimeEntry(ctx context.Context, id int64) error {
+	if err := validation.PositiveID("id", id); err != nil {
+		return fmt.Errorf("invalid time entry ID: %w", err)
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete time entry: %w", err)
+	}
+
+	return nil
+}
+
+// GetTimeEntries получает записи времени по фильтру
+func (s *TimeTrackingServiceImpl) GetTimeEntries(ctx context.Context, filter models.TimeEntryFilter) ([]*models.TimeEntry, error) {
+	entries, err := s.repo.GetEntries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// GetTimeReport считает итоги по задачам, проектам или дням. Дни считаются
+// в часовом поясе timezone; пустой или неизвестный пояс - локальный пояс системы
+func (s *TimeTrackingServiceImpl) GetTimeReport(ctx context.Context, req models.TimeReportRequest, timezone string) (*models.TimeReport, error) {
+	if req.GroupBy == "" {
+		req.GroupBy = models.TimeGroupTask
+	}
+	if err := s.validator.ValidateTimeReport(req); err != nil {
+		return nil, fmt.Errorf("invalid time report request: %w", err)
+	}
+
+	entries, err := s.repo.GetEntries(ctx, models.TimeEntryFilter{From: req.From, To: req.To})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time entries for report: %w", err)
+	}
+
+	loc := time.Local
+	if timezone != "" {
+		if userLoc, err := time.LoadLocation(timezone); err == nil {
+			loc = userLoc
+		}
+	}
+
+	return models.SummarizeTime(entries, req.GroupBy, req.From, req.To, loc, s.now()), nil
+}
+
+// GetTrackedByTask возвращает затраченное время в секундах по задачам
+func (s *TimeTrackingServiceImpl) GetTrackedByTask(ctx context.Context, taskIDs []int) (map[int]int64, error) {
+	tracked, err := s.repo.GetTrackedByTask(ctx, taskIDs, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tracked time: %w", err)
+	}
+
+	return tracked, nil
+}
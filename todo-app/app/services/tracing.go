@@ -0,0 +1,436 @@
+package services
+
+import (
+	"context"
+	"io"
+	"todo-app/app/models"
+	"todo-app/internal/tracing"
+)
+
+// taskServiceWithTracing оборачивает вызовы TaskService span'ами
+type taskServiceWithTracing struct {
+	next TaskService
+}
+
+// NewTaskServiceWithTracing оборачивает TaskService трассировкой
+func NewTaskServiceWithTracing(next TaskService) TaskService {
+	return &taskServiceWithTracing{next: next}
+}
+
+func (s *taskServiceWithTracing) CreateTask(ctx context.Context, req models.CreateTaskRequest) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.CreateTask")
+	task, err := s.next.CreateTask(ctx, req)
+	if task != nil {
+		span.SetAttribute("task.id", task.ID)
+	}
+	span.Finish(err)
+	return task, err
+}
+
+func (s *taskServiceWithTracing) GetAllTasks(ctx context.Context, filter models.TaskFilter, sort models.TaskSort) ([]*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetAllTasks")
+	span.SetAttributes(filter.LogFields())
+	span.SetAttributes(sort.LogFields())
+	tasks, err := s.next.GetAllTasks(ctx, filter, sort)
+	span.SetAttribute("rows", len(tasks))
+	span.Finish(err)
+	return tasks, err
+}
+
+func (s *taskServiceWithTracing) GetTasksPage(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, cursor string, limit int) (*models.TaskPage, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetTasksPage")
+	span.SetAttributes(filter.LogFields())
+	span.SetAttributes(sort.LogFields())
+	span.SetAttributes(map[string]interface{}{"cursor": cursor != "", "limit": limit})
+	page, err := s.next.GetTasksPage(ctx, filter, sort, cursor, limit)
+	if page != nil {
+		span.SetAttributes(map[string]interface{}{"rows": len(page.Tasks), "has_next": page.HasNext})
+	}
+	span.Finish(err)
+	return page, err
+}
+
+func (s *taskServiceWithTracing) GetTaskByID(ctx context.Context, id int) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetTaskByID")
+	span.SetAttribute("task.id", id)
+	task, err := s.next.GetTaskByID(ctx, id)
+	span.Finish(err)
+	return task, err
+}
+
+func (s *taskServiceWithTracing) UpdateTask(ctx context.Context, req models.UpdateTaskRequest) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.UpdateTask")
+	span.SetAttribute("task.id", req.ID)
+	task, err := s.next.UpdateTask(ctx, req)
+	span.Finish(err)
+	return task, err
+}
+
+func (s *taskServiceWithTracing) DeleteTask(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "TaskService.DeleteTask")
+	span.SetAttribute("task.id", id)
+	err := s.next.DeleteTask(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (s *taskServiceWithTracing) ToggleTaskStatus(ctx context.Context, id int) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.ToggleTaskStatus")
+	span.SetAttribute("task.id", id)
+	task, err := s.next.ToggleTaskStatus(ctx, id)
+	if task != nil {
+		span.SetAttribute("task.status", string(task.Status))
+	}
+	span.Finish(err)
+	return task, err
+}
+
+func (s *taskServiceWithTracing) ChangeStatus(ctx context.Context, id int, status models.TaskStatus) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.ChangeStatus")
+	span.SetAttribute("task.id", id)
+	span.SetAttribute("task.status", string(status))
+	task, err := s.next.ChangeStatus(ctx, id, status)
+	span.Finish(err)
+	return task, err
+}
+
+// GetWorkflow не трассируется: workflow хранится в памяти
+func (s *taskServiceWithTracing) GetWorkflow(ctx context.Context) *models.Workflow {
+	return s.next.GetWorkflow(ctx)
+}
+
+func (s *taskServiceWithTracing) MoveTask(ctx context.Context, id, beforeID, afterID int, column models.TaskStatus) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.MoveTask")
+	span.SetAttribute("task.id", id)
+	span.SetAttribute("column", string(column))
+	task, err := s.next.MoveTask(ctx, id, beforeID, afterID, column)
+	span.Finish(err)
+	return task, err
+}
+
+func (s *taskServiceWithTracing) GetBoard(ctx context.Context, filter models.TaskFilter) (*models.Board, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetBoard")
+	span.SetAttributes(filter.LogFields())
+	board, err := s.next.GetBoard(ctx, filter)
+	span.Finish(err)
+	return board, err
+}
+
+func (s *taskServiceWithTracing) SetEstimate(ctx context.Context, req models.SetEstimateRequest) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.SetEstimate")
+	span.SetAttribute("task.id", req.ID)
+	task, err := s.next.SetEstimate(ctx, req)
+	span.Finish(err)
+	return task, err
+}
+
+func (s *taskServiceWithTracing) ArchiveTask(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "TaskService.ArchiveTask")
+	span.SetAttribute("task.id", id)
+	err := s.next.ArchiveTask(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (s *taskServiceWithTracing) GetDashboardStats(ctx context.Context, timezone string) (*models.DashboardStats, error) {
+	ctx, span := tracing.Start(ctx, "TaskService.GetDashboardStats")
+	stats, err := s.next.GetDashboardStats(ctx, timezone)
+	span.Finish(err)
+	return stats, err
+}
+
+// webhookServiceWithTracing оборачивает вызовы WebhookService span'ами
+type webhookServiceWithTracing struct {
+	next WebhookService
+}
+
+// NewWebhookServiceWithTracing оборачивает WebhookService трассировкой
+func NewWebhookServiceWithTracing(next WebhookService) WebhookService {
+	return &webhookServiceWithTracing{next: next}
+}
+
+func (s *webhookServiceWithTracing) CreateWebhook(ctx context.Context, req models.CreateWebhookRequest) (*models.CreateWebhookResponse, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.CreateWebhook")
+	response, err := s.next.CreateWebhook(ctx, req)
+	span.Finish(err)
+	return response, err
+}
+
+func (s *webhookServiceWithTracing) GetWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.GetWebhooks")
+	subscriptions, err := s.next.GetWebhooks(ctx)
+	span.SetAttribute("rows", len(subscriptions))
+	span.Finish(err)
+	return subscriptions, err
+}
+
+func (s *webhookServiceWithTracing) SetWebhookActive(ctx context.Context, id int, active bool) error {
+	ctx, span := tracing.Start(ctx, "WebhookService.SetWebhookActive")
+	span.SetAttributes(map[string]interface{}{"webhook.id": id, "active": active})
+	err := s.next.SetWebhookActive(ctx, id, active)
+	span.Finish(err)
+	return err
+}
+
+func (s *webhookServiceWithTracing) DeleteWebhook(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "WebhookService.DeleteWebhook")
+	span.SetAttribute("webhook.id", id)
+	err := s.next.DeleteWebhook(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (s *webhookServiceWithTracing) GetWebhookDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*models.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.GetWebhookDeliveries")
+	span.SetAttributes(map[string]interface{}{"webhook.id": subscriptionID, "limit": limit})
+	deliveries, err := s.next.GetWebhookDeliveries(ctx, subscriptionID, limit)
+	span.SetAttribute("rows", len(deliveries))
+	span.Finish(err)
+	return deliveries, err
+}
+
+func (s *webhookServiceWithTracing) GetDeliveryAttempts(ctx context.Context, deliveryID int64) ([]*models.WebhookAttempt, error) {
+	ctx, span := tracing.Start(ctx, "WebhookService.GetDeliveryAttempts")
+	span.SetAttribute("delivery.id", deliveryID)
+	attempts, err := s.next.GetDeliveryAttempts(ctx, deliveryID)
+	span.SetAttribute("rows", len(attempts))
+	span.Finish(err)
+	return attempts, err
+}
+
+func (s *webhookServiceWithTracing) RetryDelivery(ctx context.Context, deliveryID int64) error {
+	ctx, span := tracing.Start(ctx, "WebhookService.RetryDelivery")
+	span.SetAttribute("delivery.id", deliveryID)
+	err := s.next.RetryDelivery(ctx, deliveryID)
+	span.Finish(err)
+	return err
+}
+
+// settingsServiceWithTracing оборачивает вызовы SettingsService span'ами
+type settingsServiceWithTracing struct {
+	next SettingsService
+}
+
+// NewSettingsServiceWithTracing оборачивает SettingsService трассировкой
+func NewSettingsServiceWithTracing(next SettingsService) SettingsService {
+	return &settingsServiceWithTracing{next: next}
+}
+
+func (s *settingsServiceWithTracing) GetSettings(ctx context.Context) (*models.AppSettings, error) {
+	ctx, span := tracing.Start(ctx, "SettingsService.GetSettings")
+	settings, err := s.next.GetSettings(ctx)
+	span.Finish(err)
+	return settings, err
+}
+
+func (s *settingsServiceWithTracing) UpdateSettings(ctx context.Context, settings models.AppSettings) (*models.AppSettings, error) {
+	ctx, span := tracing.Start(ctx, "SettingsService.UpdateSettings")
+	updated, err := s.next.UpdateSettings(ctx, settings)
+	span.Finish(err)
+	return updated, err
+}
+
+// timeTrackingServiceWithTracing оборачивает вызовы TimeTrackingService span'ами
+type timeTrackingServiceWithTracing struct {
+	next TimeTrackingService
+}
+
+// NewTimeTrackingServiceWithTracing оборачивает TimeTrackingService трассировкой
+func NewTimeTrackingServiceWithTracing(next TimeTrackingService) TimeTrackingService {
+	return &timeTrackingServiceWithTracing{next: next}
+}
+
+func (s *timeTrackingServiceWithTracing) StartTimer(ctx context.Context, req models.StartTimerRequest) (*models.TimeEntry, error) {
+	ctx, span := tracing.Start(ctx, "TimeTrackingService.StartTimer")
+	span.SetAttribute("task.id", req.TaskID)
+	entry, err := s.next.StartTimer(ctx, req)
+	span.Finish(err)
+	return entry, err
+}
+
+func (s *timeTrackingServiceWithTracing) StopTimer(ctx context.Context) (*models.TimeEntry, error) {
+	ctx, span := tracing.Start(ctx, "TimeTrackingService.StopTimer")
+	entry, err := s.next.StopTimer(ctx)
+	if entry != nil {
+		span.SetAttribute("task.id", entry.TaskID)
+	}
+	span.Finish(err)
+	return entry, err
+}
+
+func (s *timeTrackingServiceWithTracing) GetActiveTimer(ctx context.Context) (*models.TimeEntry, error) {
+	ctx, span := tracing.Start(ctx, "TimeTrackingService.GetActiveTimer")
+	entry, err := s.next.GetActiveTimer(ctx)
+	span.Finish(err)
+	return entry, err
+}
+
+func (s *timeTrackingServiceWithTracing) AddTimeEntry(ctx context.Context, req models.CreateTimeEntryRequest) (*models.TimeEntry, error) {
+	ctx, span := tracing.Start(ctx, "TimeTrackingService.AddTimeEntry")
+	span.SetAttribute("task.id", req.TaskID)
+	entry, err := s.next.AddTimeEntry(ctx, req)
+	span.Finish(err)
+	return entry, err
+}
+
+func (s *timeTrackingServiceWithTracing) DeleteTimeEntry(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "TimeTrackingService.DeleteTimeEntry")
+	span.SetAttribute("time_entry.id", id)
+	err := s.next.DeleteTimeEntry(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (s *timeTrackingServiceWithTracing) GetTimeEntries(ctx context.Context, filter models.TimeEntryFilter) ([]*models.TimeEntry, error) {
+	ctx, span := tracing.Start(ctx, "TimeTrackingService.GetTimeEntries")
+	entries, err := s.next.GetTimeEntries(ctx, filter)
+	span.SetAttribute("rows", len(entries))
+	span.Finish(err)
+	return entries, err
+}
+
+func (s *timeTrackingServiceWithTracing) GetTimeReport(ctx context.Context, req models.TimeReportRequest, timezone string) (*models.TimeReport, error) {
+	ctx, span := tracing.Start(ctx, "TimeTrackingService.GetTimeReport")
+	span.SetAttribute("group_by", string(req.GroupBy))
+	report, err := s.next.GetTimeReport(ctx, req, timezone)
+	span.Finish(err)
+	return report, err
+}
+
+func (s *timeTrackingServiceWithTracing) GetTrackedByTask(ctx context.Context, taskIDs []int) (map[int]int64, error) {
+	ctx, span := tracing.Start(ctx, "TimeTrackingService.GetTrackedByTask")
+	span.SetAttribute("tasks", len(taskIDs))
+	tracked, err := s.next.GetTrackedByTask(ctx, taskIDs)
+	span.Finish(err)
+	return tracked, err
+}
+
+// commentServiceWithTracing оборачивает вызовы CommentService span'ами
+type commentServiceWithTracing struct {
+	next CommentService
+}
+
+// NewCommentServiceWithTracing оборачивает CommentService трассировкой
+func NewCommentServiceWithTracing(next CommentService) CommentService {
+	return &commentServiceWithTracing{next: next}
+}
+
+func (s *commentServiceWithTracing) AddComment(ctx context.Context, req models.CreateCommentRequest) (*models.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.AddComment")
+	span.SetAttributes(map[string]interface{}{"task.id": req.TaskID, "body_len": len(req.Body)})
+	comment, err := s.next.AddComment(ctx, req)
+	span.Finish(err)
+	return comment, err
+}
+
+func (s *commentServiceWithTracing) UpdateComment(ctx context.Context, req models.UpdateCommentRequest) (*models.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.UpdateComment")
+	span.SetAttributes(map[string]interface{}{"comment.id": req.ID, "body_len": len(req.Body)})
+	comment, err := s.next.UpdateComment(ctx, req)
+	span.Finish(err)
+	return comment, err
+}
+
+func (s *commentServiceWithTracing) DeleteComment(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "CommentService.DeleteComment")
+	span.SetAttribute("comment.id", id)
+	err := s.next.DeleteComment(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (s *commentServiceWithTracing) GetTaskComments(ctx context.Context, taskID int, order models.SortOrder) ([]*models.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.GetTaskComments")
+	span.SetAttributes(map[string]interface{}{"task.id": taskID, "order": string(order)})
+	comments, err := s.next.GetTaskComments(ctx, taskID, order)
+	span.SetAttribute("rows", len(comments))
+	span.Finish(err)
+	return comments, err
+}
+
+func (s *commentServiceWithTracing) GetCommentsByTasks(ctx context.Context, taskIDs []int) (map[int][]*models.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.GetCommentsByTasks")
+	span.SetAttribute("tasks", len(taskIDs))
+	comments, err := s.next.GetCommentsByTasks(ctx, taskIDs)
+	span.Finish(err)
+	return comments, err
+}
+
+func (s *commentServiceWithTracing) GetCommentRevisions(ctx context.Context, id int64) ([]*models.CommentRevision, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.GetCommentRevisions")
+	span.SetAttribute("comment.id", id)
+	revisions, err := s.next.GetCommentRevisions(ctx, id)
+	span.SetAttribute("rows", len(revisions))
+	span.Finish(err)
+	return revisions, err
+}
+
+func (s *commentServiceWithTracing) GetTaskHistory(ctx context.Context, taskID int, limit int) ([]*models.TaskHistoryEntry, error) {
+	ctx, span := tracing.Start(ctx, "CommentService.GetTaskHistory")
+	span.SetAttributes(map[string]interface{}{"task.id": taskID, "limit": limit})
+	history, err := s.next.GetTaskHistory(ctx, taskID, limit)
+	span.SetAttribute("rows", len(history))
+	span.Finish(err)
+	return history, err
+}
+
+// attachmentServiceWithTracing оборачивает вызовы AttachmentService span'ами
+type attachmentServiceWithTracing struct {
+	next AttachmentService
+}
+
+// NewAttachmentServiceWithTracing оборачивает AttachmentService трассировкой
+func NewAttachmentServiceWithTracing(next AttachmentService) AttachmentService {
+	return &attachmentServiceWithTracing{next: next}
+}
+
+func (s *attachmentServiceWithTracing) AddAttachment(ctx context.Context, req models.CreateAttachmentRequest, content io.Reader) (*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.AddAttachment")
+	span.SetAttribute("task.id", req.TaskID)
+	attachment, err := s.next.AddAttachment(ctx, req, content)
+	if attachment != nil {
+		span.SetAttributes(map[string]interface{}{
+			"attachment.id": attachment.ID,
+			"content_type":  attachment.ContentType,
+			"size":          attachment.Size,
+		})
+	}
+	span.Finish(err)
+	return attachment, err
+}
+
+func (s *attachmentServiceWithTracing) DeleteAttachment(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "AttachmentService.DeleteAttachment")
+	span.SetAttribute("attachment.id", id)
+	err := s.next.DeleteAttachment(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (s *attachmentServiceWithTracing) GetAttachment(ctx context.Context, id int64) (*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.GetAttachment")
+	span.SetAttribute("attachment.id", id)
+	attachment, err := s.next.GetAttachment(ctx, id)
+	span.Finish(err)
+	return attachment, err
+}
+
+func (s *attachmentServiceWithTracing) GetAttachmentContent(ctx context.Context, id int64) (*models.AttachmentContent, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.GetAttachmentContent")
+	span.SetAttribute("attachment.id", id)
+	content, err := s.next.GetAttachmentContent(ctx, id)
+	if content != nil {
+		span.SetAttribute("size", len(content.Data))
+	}
+	span.Finish(err)
+	return content, err
+}
+
+func (s *attachmentServiceWithTracing) GetTaskAttachments(ctx context.Context, taskID int) ([]*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentService.GetTaskAttachments")
+	span.SetAttribute("task.id", taskID)
+	attachments, err := s.next.GetTaskAttachments(ctx, taskID)
+	span.SetAttribute("rows", len(attachments))
+	span.Finish(err)
+	return attachments, err
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"todo-app/app/events"
+	"todo-app/app/models"
+	"todo-app/app/repository"
+	"todo-app/app/webhooks"
+	"todo-app/internal/validation"
+)
+
+// WebhookServiceImpl реализует интерфейс WebhookService
+type WebhookServiceImpl struct {
+	repo       repository.WebhookRepository
+	validator  *validation.WebhookValidator
+	dispatcher *webhooks.Dispatcher
+}
+
+// NewWebhookService создает новый экземпляр сервиса webhooks. dispatcher может быть nil,
+// тогда повторная доставка выполнится при следующем опросе очереди. allowPrivateTargets
+// разрешает подписки на адреса localhost и частных сетей
+func NewWebhookService(repo repository.WebhookRepository, dispatcher *webhooks.Dispatcher, allowPrivateTargets bool) WebhookService {
+	return &WebhookServiceImpl{
+		repo:       repo,
+		validator:  validation.NewWebhookValidator().AllowPrivateTargets(allowPrivateTargets),
+		dispatcher: dispatcher,
+	}
+}
+
+// knownEventTypes содержит типы событий, на которые можно подписаться
+var knownEventTypes = map[string]bool{
+	string(events.AllEvents):         true,
+	string(events.TypeTaskCreated):   true,
+	string(events.TypeTaskUpdated):   true,
+	string(events.TypeTaskCompleted): true,
+	string(events.TypeTaskReopened):  true,
+	string(events.TypeTaskStatus):    true,
+	string(events.TypeTaskMoved):     true,
+	string(events.TypeTaskDeleted):   true,
+	string(events.TypeTaskArchived):  true,
+
+	string(events.TypeCommentAdded):   true,
+	string(events.TypeCommentEdited):  true,
+	string(events.TypeCommentDeleted): true,
+
+	string(events.TypeAttachmentAdded):   true,
+	string(events.TypeAttachmentDeleted): true,
+
+	string(events.TypeSettingsUpdated): true,
+}
+
+// CreateWebhook создает подписку
+func (s *WebhookServiceImpl) CreateWebhook(ctx context.Context, req models.CreateWebhookRequest) (*models.CreateWebhookResponse, error) {
+	// Валидация запроса
+	if err := s.validator.ValidateCreateWebhookRequest(req); err != nil {
+		return nil, fmt.Errorf("invalid create webhook request: %w", err)
+	}
+
+	for i, eventType := range req.EventTypes {
+		if !knownEventTypes[eventType] {
+			return nil, fmt.Errorf("invalid create webhook request: %w",
+				validation.InvalidValue(fmt.Sprintf("event_types[%d]", i), eventType))
+		}
+	}
+
+	// Генерируем секрет, если он не задан
+	secret := req.Secret
+	if secret == "" {
+		generated, err := webhooks.GenerateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	subscription := &models.WebhookSubscription{
+		URL:         req.URL,
+		Secret:      secret,
+		EventTypes:  req.EventTypes,
+		Filter:      req.Filter,
+		Description: req.Description,
+		Active:      true,
+	}
+
+	created, err := s.repo.CreateSubscription(ctx, subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return &models.CreateWebhookResponse{
+		Webhook: created,
+		Secret:  secret,
+	}, nil
+}
+
+// GetWebhooks получает все подписки
+func (s *WebhookServiceImpl) GetWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	subscriptions, err := s.repo.GetSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// SetWebhookActive включает или отключает подписку
+func (s *WebhookServiceImpl) SetWebhookActive(ctx context.Context, id int, active bool) error {
+	if err := validation.PositiveID("id", int64(id)); err != nil {
+		return fmt.Errorf("invalid webhook ID: %w", err)
+	}
+
+	if err := s.repo.SetSubscriptionActive(ctx, id, active); err != nil {
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteWebhook удаляет подписку
+func (s *WebhookServiceImpl) DeleteWebhook(ctx context.Context, id int) error {
+	if err := validation.PositiveID("id", int64(id)); err != nil {
+		return fmt.Errorf("invalid webhook ID: %w", err)
+	}
+
+	if err := s.repo.DeleteSubscription(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries получает журнал доставок подписки
+func (s *WebhookServiceImpl) GetWebhookDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*models.WebhookDelivery, error) {
+	if err := validation.PositiveID("id", int64(subscriptionID)); err != nil {
+		return nil, fmt.Errorf("invalid webhook ID: %w", err)
+	}
+
+	deliveries, err := s.repo.GetDeliveries(ctx, subscriptionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// GetDeliveryAttempts получает журнал попыток доставки
+func (s *WebhookServiceImpl) GetDeliveryAttempts(ctx context.Context, deliveryID int64) ([]*models.WebhookAttempt, error) {
+	if err := validation.PositiveID("delivery_id", deliveryID); err != nil {
+		return nil, fmt.Errorf("invalid delivery ID: %w", err)
+	}
+
+	attempts, err := s.repo.GetDeliveryAttempts(ctx, deliveryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// RetryDelivery возвращает доставку из dead-letter в очередь
+func (s *WebhookServiceImpl) RetryDelivery(ctx context.Context, deliveryID int64) error {
+	if err := validation.PositiveID("delivery_id", deliveryID); err != nil {
+		return fmt.Errorf("invalid delivery ID: %w", err)
+	}
+
+	if err := s.repo.RequeueDelivery(ctx, deliveryID, time.Now()); err != nil {
+		return fmt.Errorf("failed to retry webhook delivery: %w", err)
+	}
+
+	if s.dispatcher != nil {
+		s.dispatcher.Notify()
+	}
+
+	return nil
+}
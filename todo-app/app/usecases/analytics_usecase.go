@@ -10,7 +10,9 @@ import (
 
 // AnalyticsUseCaseImpl реализует интерфейс AnalyticsUseCase
 type AnalyticsUseCaseImpl struct {
-	taskService services.TaskService
+	taskService  services.TaskService
+	settings     services.SettingsService
+	timeTracking services.TimeTrackingService
 }
 
 // NewAnalyticsUseCase создает новый экземпляр AnalyticsUseCase
@@ -20,10 +22,29 @@ func NewAnalyticsUseCase(taskService services.TaskService) AnalyticsUseCase {
 	}
 }
 
+// NewAnalyticsUseCaseWithSettings создает AnalyticsUseCase, считающий сроки
+// в часовом поясе пользователя из настроек
+func NewAnalyticsUseCaseWithSettings(taskService services.TaskService, settings services.SettingsService) AnalyticsUseCase {
+	return &AnalyticsUseCaseImpl{
+		taskService: taskService,
+		settings:    settings,
+	}
+}
+
+// NewAnalyticsUseCaseWithTimeTracking создает AnalyticsUseCase с отчетом
+// оценок против затраченного времени
+func NewAnalyticsUseCaseWithTimeTracking(taskService services.TaskService, settings services.SettingsService, timeTracking services.TimeTrackingService) AnalyticsUseCase {
+	return &AnalyticsUseCaseImpl{
+		taskService:  taskService,
+		settings:     settings,
+		timeTracking: timeTracking,
+	}
+}
+
 // GetTasksStats получает общую статистику по задачам
 func (uc *AnalyticsUseCaseImpl) GetTasksStats(ctx context.Context) (*models.TaskStats, error) {
 	// Получаем дашборд статистику и извлекаем из неё TaskStats
-	dashboardStats, err := uc.taskService.GetDashboardStats(ctx)
+	dashboardStats, err := uc.taskService.GetDashboardStats(ctx, userTimezone(ctx, uc.settings))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dashboard stats: %w", err)
 	}
@@ -34,7 +55,7 @@ func (uc *AnalyticsUseCaseImpl) GetTasksStats(ctx context.Context) (*models.Task
 // GetDashboardStats получает полную статистику для дашборда
 func (uc *AnalyticsUseCaseImpl) GetDashboardStats(ctx context.Context) (*models.DashboardStats, error) {
 	// Вызов сервисного слоя
-	stats, err := uc.taskService.GetDashboardStats(ctx)
+	stats, err := uc.taskService.GetDashboardStats(ctx, userTimezone(ctx, uc.settings))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dashboard stats: %w", err)
 	}
@@ -49,8 +70,9 @@ func (uc *AnalyticsUseCaseImpl) GetDashboardStats(ctx context.Context) (*models.
 func (uc *AnalyticsUseCaseImpl) GetOverdueTasks(ctx context.Context) ([]*models.Task, error) {
 	// Создаем фильтр для получения активных задач
 	filter := models.TaskFilter{
-		Status:   models.TaskStatusActive,
+		Status:   models.StatusFilterActive,
 		DateType: models.DateFilterOverdue,
+		Timezone: userTimezone(ctx, uc.settings),
 	}
 
 	// Сортировка по дате выполнения (просроченные раньше идут первыми)
@@ -67,10 +89,10 @@ func (uc *AnalyticsUseCaseImpl) GetOverdueTasks(ctx context.Context) ([]*models.
 
 	// Дополнительная фильтрация на уровне бизнес-логики
 	overdueTasks := make([]*models.Task, 0)
-	now := time.Now()
+	window := models.NewDueWindow(time.Now(), filter.Timezone)
 
 	for _, task := range tasks {
-		if task.DueDate != nil && task.DueDate.Before(now) && task.Status == models.TaskStatusActive {
+		if window.IsOverdue(task) {
 			overdueTasks = append(overdueTasks, task)
 		}
 	}
@@ -82,7 +104,7 @@ func (uc *AnalyticsUseCaseImpl) GetOverdueTasks(ctx context.Context) ([]*models.
 func (uc *AnalyticsUseCaseImpl) GetHighPriorityTasks(ctx context.Context) ([]*models.Task, error) {
 	// Создаем фильтр для задач с высоким приоритетом
 	filter := models.TaskFilter{
-		Status:   models.TaskStatusActive, // Только активные задачи
+		Status:   models.StatusFilterActive, // Только незакрытые задачи
 		Priority: models.PriorityHigh,
 	}
 
@@ -133,7 +155,7 @@ func (uc *AnalyticsUseCaseImpl) GetCompletionRates(ctx context.Context, period s
 
 	// Получаем завершенные задачи за период
 	completedFilter := models.TaskFilter{
-		Status:  models.TaskStatusCompleted,
+		Status:  models.StatusFilterCompleted,
 		DueFrom: &fromDate,
 		DueTo:   &now,
 	}
@@ -173,7 +195,7 @@ func (uc *AnalyticsUseCaseImpl) GetCompletionRates(ctx context.Context, period s
 func (uc *AnalyticsUseCaseImpl) GetTasksByPriority(ctx context.Context) (map[models.Priority][]*models.Task, error) {
 	// Получаем все активные задачи
 	filter := models.TaskFilter{
-		Status: models.TaskStatusActive,
+		Status: models.StatusFilterActive,
 	}
 
 	sort := models.TaskSort{
@@ -201,3 +223,34 @@ func (uc *AnalyticsUseCaseImpl) GetTasksByPriority(ctx context.Context) (map[mod
 
 	return priorityGroups, nil
 }
+
+// GetEstimateReport сравнивает оценки задач с затраченным временем.
+// В отчет попадают задачи фильтра, у которых есть оценка или учтенное время
+func (uc *AnalyticsUseCaseImpl) GetEstimateReport(ctx context.Context, filter models.TaskFilter) (*models.EstimateReport, error) {
+	if filter.Status == "" {
+		filter.Status = models.StatusFilterAll
+	}
+	if filter.Timezone == "" {
+		filter.Timezone = userTimezone(ctx, uc.settings)
+	}
+
+	tasks, err := uc.taskService.GetAllTasks(ctx, filter, models.GetDefaultSort())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks for estimate report: %w", err)
+	}
+
+	tracked := map[int]int64{}
+	if uc.timeTracking != nil {
+		taskIDs := make([]int, len(tasks))
+		for i, task := range tasks {
+			taskIDs[i] = task.ID
+		}
+
+		tracked, err = uc.timeTracking.GetTrackedByTask(ctx, taskIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tracked time for estimate report: %w", err)
+		}
+	}
+
+	return models.NewEstimateReport(tasks, tracked), nil
+}
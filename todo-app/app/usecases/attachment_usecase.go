@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"todo-app/app/models"
+	"todo-app/app/services"
+	"todo-app/internal/validation"
+)
+
+// AttachmentUseCaseImpl реализует интерфейс AttachmentUseCase
+type AttachmentUseCaseImpl struct {
+	attachments services.AttachmentService
+}
+
+// NewAttachmentUseCase создает новый экземпляр AttachmentUseCase
+func NewAttachmentUseCase(attachments services.AttachmentService) AttachmentUseCase {
+	return &AttachmentUseCaseImpl{
+		attachments: attachments,
+	}
+}
+
+// AddAttachment прикрепляет к задаче файл, переданный содержимым
+func (uc *AttachmentUseCaseImpl) AddAttachment(ctx context.Context, req models.CreateAttachmentRequest, data []byte) (*models.Attachment, error) {
+	attachment, err := uc.attachments.AddAttachment(ctx, req, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to add attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// AddAttachmentFromFile прикрепляет к задаче локальный файл (например, выбранный
+// в диалоге открытия). Файл читается потоком, имя вложения - имя файла
+func (uc *AttachmentUseCaseImpl) AddAttachmentFromFile(ctx context.Context, taskID int, path string) (*models.Attachment, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open attachment file: %w", validation.InvalidValue("path", path))
+	}
+	defer file.Close()
+
+	if info, err := file.Stat(); err != nil || !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("attachment is not a regular file: %w", validation.InvalidValue("path", path))
+	}
+
+	req := models.CreateAttachmentRequest{TaskID: taskID, FileName: filepath.Base(path)}
+	attachment, err := uc.attachments.AddAttachment(ctx, req, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add attachment: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// DeleteAttachment удаляет вложение
+func (uc *AttachmentUseCaseImpl) DeleteAttachment(ctx context.Context, id int64) error {
+	if err := uc.attachments.DeleteAttachment(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	return nil
+}
+
+// GetTaskAttachments получает вложения задачи
+func (uc *AttachmentUseCaseImpl) GetTaskAttachments(ctx context.Context, taskID int) ([]*models.Attachment, error) {
+	return uc.attachments.GetTaskAttachments(ctx, taskID)
+}
+
+// GetAttachmentContent получает вложение вместе с содержимым
+func (uc *AttachmentUseCaseImpl) GetAttachmentContent(ctx context.Context, id int64) (*models.AttachmentContent, error) {
+	return uc.attachments.GetAttachmentContent(ctx, id)
+}
@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"todo-app/app/models"
+	"todo-app/app/services"
+)
+
+// CommentUseCaseImpl реализует интерфейс CommentUseCase
+type CommentUseCaseImpl struct {
+	comments services.CommentService
+}
+
+// NewCommentUseCase создает новый экземпляр CommentUseCase
+func NewCommentUseCase(comments services.CommentService) CommentUseCase {
+	return &CommentUseCaseImpl{
+		comments: comments,
+	}
+}
+
+// AddComment добавляет комментарий с телом в Markdown
+func (uc *CommentUseCaseImpl) AddComment(ctx context.Context, req models.CreateCommentRequest) (*models.Comment, error) {
+	comment, err := uc.comments.AddComment(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// UpdateComment изменяет комментарий
+func (uc *CommentUseCaseImpl) UpdateComment(ctx context.Context, req models.UpdateCommentRequest) (*models.Comment, error) {
+	comment, err := uc.comments.UpdateComment(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+// DeleteComment удаляет комментарий
+func (uc *CommentUseCaseImpl) DeleteComment(ctx context.Context, id int64) error {
+	if err := uc.comments.DeleteComment(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	return nil
+}
+
+// GetTaskComments получает комментарии задачи в заданном порядке
+func (uc *CommentUseCaseImpl) GetTaskComments(ctx context.Context, taskID int, order models.SortOrder) ([]*models.Comment, error) {
+	return uc.comments.GetTaskComments(ctx, taskID, order)
+}
+
+// GetCommentRevisions получает предыдущие версии комментария
+func (uc *CommentUseCaseImpl) GetCommentRevisions(ctx context.Context, id int64) ([]*models.CommentRevision, error) {
+	return uc.comments.GetCommentRevisions(ctx, id)
+}
+
+// GetTaskHistory получает последние события задачи, включая комментарии
+func (uc *CommentUseCaseImpl) GetTaskHistory(ctx context.Context, taskID int, limit int) ([]*models.TaskHistoryEntry, error) {
+	return uc.comments.GetTaskHistory(ctx, taskID, limit)
+}
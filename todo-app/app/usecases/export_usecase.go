@@ -5,6 +5,7 @@ import (
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 	"todo-app/app/models"
@@ -13,7 +14,9 @@ import (
 
 // ExportUseCaseImpl реализует интерфейс ExportUseCase
 type ExportUseCaseImpl struct {
-	taskService services.TaskService
+	taskService  services.TaskService
+	timeTracking services.TimeTrackingService
+	comments     services.CommentService
 }
 
 // NewExportUseCase создает новый экземпляр ExportUseCase
@@ -23,6 +26,31 @@ func NewExportUseCase(taskService services.TaskService) ExportUseCase {
 	}
 }
 
+// NewExportUseCaseWithTimeTracking создает ExportUseCase, выгружающий
+// в CSV затраченное на задачи время
+func NewExportUseCaseWithTimeTracking(taskService services.TaskService, timeTracking services.TimeTrackingService) ExportUseCase {
+	return &ExportUseCaseImpl{
+		taskService:  taskService,
+		timeTracking: timeTracking,
+	}
+}
+
+// NewExportUseCaseWithComments создает ExportUseCase, выгружающий в CSV
+// затраченное время, а в JSON - комментарии задач
+func NewExportUseCaseWithComments(taskService services.TaskService, timeTracking services.TimeTrackingService, comments services.CommentService) ExportUseCase {
+	return &ExportUseCaseImpl{
+		taskService:  taskService,
+		timeTracking: timeTracking,
+		comments:     comments,
+	}
+}
+
+// exportedTask представляет задачу в JSON экспорте вместе с ее комментариями
+type exportedTask struct {
+	*models.Task
+	Comments []*models.Comment `json:"comments"`
+}
+
 // ExportTasksToCSV экспортирует задачи в формат CSV
 func (uc *ExportUseCaseImpl) ExportTasksToCSV(ctx context.Context, filter models.TaskFilter) ([]byte, error) {
 	// Получаем задачи с учетом фильтра
@@ -36,6 +64,11 @@ func (uc *ExportUseCaseImpl) ExportTasksToCSV(ctx context.Context, filter models
 		return nil, fmt.Errorf("failed to get tasks for CSV export: %w", err)
 	}
 
+	tracked, err := uc.trackedTime(ctx, tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tracked time for CSV export: %w", err)
+	}
+
 	// Создаем CSV буфер
 	var csvContent strings.Builder
 	writer := csv.NewWriter(&csvContent)
@@ -52,6 +85,8 @@ func (uc *ExportUseCaseImpl) ExportTasksToCSV(ctx context.Context, filter models
 		"Updated At",
 		"Completed At",
 		"Is Overdue",
+		"Estimate Hours",
+		"Tracked Hours",
 	}
 
 	if err := writer.Write(headers); err != nil {
@@ -60,7 +95,7 @@ func (uc *ExportUseCaseImpl) ExportTasksToCSV(ctx context.Context, filter models
 
 	// Записываем данные задач
 	for _, task := range tasks {
-		record := uc.taskToCSVRecord(task)
+		record := uc.taskToCSVRecord(task, tracked[task.ID])
 		if err := writer.Write(record); err != nil {
 			return nil, fmt.Errorf("failed to write CSV record: %w", err)
 		}
@@ -88,17 +123,30 @@ func (uc *ExportUseCaseImpl) ExportTasksToJSON(ctx context.Context, filter model
 		return nil, fmt.Errorf("failed to get tasks for JSON export: %w", err)
 	}
 
+	comments, err := uc.taskComments(ctx, tasks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments for JSON export: %w", err)
+	}
+
+	exported := make([]exportedTask, len(tasks))
+	for i, task := range tasks {
+		exported[i] = exportedTask{Task: task, Comments: comments[task.ID]}
+		if exported[i].Comments == nil {
+			exported[i].Comments = []*models.Comment{}
+		}
+	}
+
 	// Конвертируем в структуру для экспорта
 	exportData := struct {
 		ExportedAt time.Time         `json:"exported_at"`
 		Filter     models.TaskFilter `json:"filter"`
 		Count      int               `json:"count"`
-		Tasks      []*models.Task    `json:"tasks"`
+		Tasks      []exportedTask    `json:"tasks"`
 	}{
 		ExportedAt: time.Now(),
 		Filter:     filter,
 		Count:      len(tasks),
-		Tasks:      tasks,
+		Tasks:      exported,
 	}
 
 	// Сериализуем в JSON
@@ -145,14 +193,51 @@ func (uc *ExportUseCaseImpl) GetExportableFields() []string {
 		"updated_at",
 		"completed_at",
 		"is_overdue",
+		"estimate_hours",
+		"tracked_hours",
 	}
 }
 
-// taskToCSVRecord конвертирует задачу в запись CSV
-func (uc *ExportUseCaseImpl) taskToCSVRecord(task *models.Task) []string {
+// trackedTime возвращает затраченное время в секундах по задачам;
+// без сервиса учета времени - пустой результат
+func (uc *ExportUseCaseImpl) trackedTime(ctx context.Context, tasks []*models.Task) (map[int]int64, error) {
+	if uc.timeTracking == nil {
+		return map[int]int64{}, nil
+	}
+
+	taskIDs := make([]int, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+
+	return uc.timeTracking.GetTrackedByTask(ctx, taskIDs)
+}
+
+// taskComments возвращает комментарии задач от старых к новым;
+// без сервиса комментариев - пустой результат
+func (uc *ExportUseCaseImpl) taskComments(ctx context.Context, tasks []*models.Task) (map[int][]*models.Comment, error) {
+	if uc.comments == nil {
+		return map[int][]*models.Comment{}, nil
+	}
+
+	taskIDs := make([]int, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+
+	return uc.comments.GetCommentsByTasks(ctx, taskIDs)
+}
+
+// formatHours форматирует секунды в часы с двумя знаками для расчетов в таблицах
+func formatHours(seconds int64) string {
+	return strconv.FormatFloat(float64(seconds)/3600, 'f', 2, 64)
+}
+
+// taskToCSVRecord конвертирует задачу в запись CSV; tracked - затраченное время в секундах
+func (uc *ExportUseCaseImpl) taskToCSVRecord(task *models.Task, tracked int64) []string {
 	// Определяем, просрочена ли задача
 	isOverdue := "false"
-	if task.DueDate != nil && task.Status == models.TaskStatusActive && task.DueDate.Before(time.Now()) {
+	if models.NewDueWindow(time.Now(), "").IsOverdue(task) {
 		isOverdue = "true"
 	}
 
@@ -167,6 +252,11 @@ func (uc *ExportUseCaseImpl) taskToCSVRecord(task *models.Task) []string {
 		completedAtStr = task.CompletedAt.Format("2006-01-02 15:04:05")
 	}
 
+	estimateStr := ""
+	if task.EstimateMinutes != nil {
+		estimateStr = formatHours(task.EstimateSeconds())
+	}
+
 	return []string{
 		fmt.Sprintf("%d", task.ID),
 		task.Title,
@@ -178,6 +268,8 @@ func (uc *ExportUseCaseImpl) taskToCSVRecord(task *models.Task) []string {
 		task.UpdatedAt.Format("2006-01-02 15:04:05"),
 		completedAtStr,
 		isOverdue,
+		estimateStr,
+		formatHours(tracked),
 	}
 }
 
@@ -245,12 +337,13 @@ func (uc *ExportUseCaseImpl) generateHTMLReport(tasks []*models.Task, filter mod
         </thead>
         <tbody>`)
 
+	window := models.NewDueWindow(time.Now(), "")
 	for _, task := range tasks {
 		statusClass := "status-" + strings.ToLower(string(task.Status))
 		priorityClass := "priority-" + strings.ToLower(string(task.Priority))
 
 		rowClass := ""
-		if task.DueDate != nil && task.Status == models.TaskStatusActive && task.DueDate.Before(time.Now()) {
+		if window.IsOverdue(task) {
 			rowClass = "overdue"
 		}
 
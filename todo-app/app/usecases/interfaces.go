@@ -10,15 +10,39 @@ type TaskUseCase interface {
 	// CreateTask создает новую задачу с валидацией и бизнес-правилами
 	CreateTask(ctx context.Context, req models.CreateTaskRequest) (*models.Task, error)
 
+	// ParseQuickAdd разбирает строку быстрого добавления для предпросмотра
+	ParseQuickAdd(ctx context.Context, input string) (*models.QuickAddResult, error)
+
+	// QuickAdd создает задачу из строки быстрого добавления
+	QuickAdd(ctx context.Context, input string) (*models.Task, error)
+
 	// UpdateTask обновляет существующую задачу с проверкой существования
 	UpdateTask(ctx context.Context, req models.UpdateTaskRequest) (*models.Task, error)
 
 	// DeleteTask удаляет задачу с проверкой прав доступа
 	DeleteTask(ctx context.Context, id int) error
 
-	// ToggleTaskStatus переключает статус задачи (active/completed)
+	// ToggleTaskStatus выполняет незакрытую задачу или возвращает закрытую в todo
 	ToggleTaskStatus(ctx context.Context, id int) (*models.Task, error)
 
+	// ChangeTaskStatus переводит задачу в статус по правилам workflow
+	ChangeTaskStatus(ctx context.Context, id int, status models.TaskStatus) (*models.Task, error)
+
+	// GetWorkflow возвращает статусы задач и разрешенные переходы
+	GetWorkflow(ctx context.Context) *models.Workflow
+
+	// MoveTask ставит задачу между соседями в общем списке (пустая колонка) или в колонке доски
+	MoveTask(ctx context.Context, id, beforeID, afterID int, column models.TaskStatus) (*models.Task, error)
+
+	// GetBoard получает задачи, сгруппированные по колонкам статусов, в ручном порядке
+	GetBoard(ctx context.Context, filter models.TaskFilter) (*models.Board, error)
+
+	// SetTaskEstimate изменяет оценку задачи в минутах; nil снимает оценку
+	SetTaskEstimate(ctx context.Context, req models.SetEstimateRequest) (*models.Task, error)
+
+	// ArchiveTask переносит выполненную или отмененную задачу в архив
+	ArchiveTask(ctx context.Context, id int) (*models.Task, error)
+
 	// GetTasks получает список задач с применением фильтров и сортировки
 	GetTasks(ctx context.Context, filter models.TaskFilter, sort models.TaskSort) ([]*models.Task, error)
 
@@ -27,6 +51,9 @@ type TaskUseCase interface {
 
 	// GetTasksWithPagination получает задачи с пагинацией
 	GetTasksWithPagination(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, page, limit int) (*models.TaskListResponse, error)
+
+	// GetTasksWithCursor получает задачи с keyset-пагинацией по непрозрачному курсору
+	GetTasksWithCursor(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, cursor string, limit int) (*models.TaskListResponse, error)
 }
 
 // AnalyticsUseCase определяет интерфейс для аналитики и статистики задач
@@ -48,6 +75,9 @@ type AnalyticsUseCase interface {
 
 	// GetTasksByPriority группирует задачи по приоритетам
 	GetTasksByPriority(ctx context.Context) (map[models.Priority][]*models.Task, error)
+
+	// GetEstimateReport сравнивает оценки задач фильтра с затраченным временем
+	GetEstimateReport(ctx context.Context, filter models.TaskFilter) (*models.EstimateReport, error)
 }
 
 // ExportUseCase определяет интерфейс для экспорта данных
@@ -65,9 +95,107 @@ type ExportUseCase interface {
 	GetExportableFields() []string
 }
 
+// TimeTrackingUseCase определяет интерфейс для учета времени по задачам
+type TimeTrackingUseCase interface {
+	// StartTimer запускает таймер по задаче; одновременно может идти только один таймер
+	StartTimer(ctx context.Context, req models.StartTimerRequest) (*models.TimeEntry, error)
+
+	// StopTimer останавливает запущенный таймер
+	StopTimer(ctx context.Context) (*models.TimeEntry, error)
+
+	// GetActiveTimer получает запущенный таймер; nil - таймер не запущен
+	GetActiveTimer(ctx context.Context) (*models.TimeEntry, error)
+
+	// AddTimeEntry добавляет время вручную
+	AddTimeEntry(ctx context.Context, req models.CreateTimeEntryRequest) (*models.TimeEntry, error)
+
+	// DeleteTimeEntry удаляет запись времени
+	DeleteTimeEntry(ctx context.Context, id int64) error
+
+	// GetTaskTimeEntries получает записи времени задачи
+	GetTaskTimeEntries(ctx context.Context, taskID int) ([]*models.TimeEntry, error)
+
+	// GetTimeReport получает итоги времени по задачам, проектам или дням за период
+	GetTimeReport(ctx context.Context, req models.TimeReportRequest) (*models.TimeReport, error)
+}
+
+// CommentUseCase определяет интерфейс комментариев и истории задач
+type CommentUseCase interface {
+	// AddComment добавляет комментарий с телом в Markdown
+	AddComment(ctx context.Context, req models.CreateCommentRequest) (*models.Comment, error)
+
+	// UpdateComment изменяет комментарий; предыдущая версия сохраняется в ревизиях
+	UpdateComment(ctx context.Context, req models.UpdateCommentRequest) (*models.Comment, error)
+
+	// DeleteComment удаляет комментарий
+	DeleteComment(ctx context.Context, id int64) error
+
+	// GetTaskComments получает комментарии задачи: asc - от старых к новым, desc - наоборот
+	GetTaskComments(ctx context.Context, taskID int, order models.SortOrder) ([]*models.Comment, error)
+
+	// GetCommentRevisions получает предыдущие версии комментария
+	GetCommentRevisions(ctx context.Context, id int64) ([]*models.CommentRevision, error)
+
+	// GetTaskHistory получает последние события задачи, включая комментарии
+	GetTaskHistory(ctx context.Context, taskID int, limit int) ([]*models.TaskHistoryEntry, error)
+}
+
+// AttachmentUseCase определяет интерфейс вложений задач
+type AttachmentUseCase interface {
+	// AddAttachment прикрепляет к задаче файл, переданный содержимым
+	AddAttachment(ctx context.Context, req models.CreateAttachmentRequest, data []byte) (*models.Attachment, error)
+
+	// AddAttachmentFromFile прикрепляет к задаче локальный файл по пути
+	AddAttachmentFromFile(ctx context.Context, taskID int, path string) (*models.Attachment, error)
+
+	// DeleteAttachment удаляет вложение; содержимое удаляется сборкой мусора
+	DeleteAttachment(ctx context.Context, id int64) error
+
+	// GetTaskAttachments получает вложения задачи по времени добавления
+	GetTaskAttachments(ctx context.Context, taskID int) ([]*models.Attachment, error)
+
+	// GetAttachmentContent получает вложение вместе с содержимым
+	GetAttachmentContent(ctx context.Context, id int64) (*models.AttachmentContent, error)
+}
+
+// WebhookUseCase определяет интерфейс для управления исходящими webhooks
+type WebhookUseCase interface {
+	// CreateWebhook создает подписку на события задач
+	CreateWebhook(ctx context.Context, req models.CreateWebhookRequest) (*models.CreateWebhookResponse, error)
+
+	// GetWebhooks получает все подписки
+	GetWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error)
+
+	// SetWebhookActive включает или отключает подписку
+	SetWebhookActive(ctx context.Context, id int, active bool) error
+
+	// DeleteWebhook удаляет подписку
+	DeleteWebhook(ctx context.Context, id int) error
+
+	// GetWebhookDeliveries получает журнал доставок подписки
+	GetWebhookDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*models.WebhookDelivery, error)
+
+	// GetDeliveryAttempts получает журнал попыток доставки
+	GetDeliveryAttempts(ctx context.Context, deliveryID int64) ([]*models.WebhookAttempt, error)
+
+	// RetryDelivery повторяет недоставленное событие
+	RetryDelivery(ctx context.Context, deliveryID int64) error
+}
+
+// SettingsUseCase определяет интерфейс для работы с настройками приложения
+type SettingsUseCase interface {
+	// GetSettings получает настройки приложения
+	GetSettings(ctx context.Context) (*models.AppSettings, error)
+
+	// UpdateSettings проверяет и сохраняет настройки, возвращает сохраненные значения
+	UpdateSettings(ctx context.Context, settings models.AppSettings) (*models.AppSettings, error)
+}
+
 // UseCases объединяет все use case интерфейсы
 type UseCases struct {
 	Task      TaskUseCase
 	Analytics AnalyticsUseCase
 	Export    ExportUseCase
+	Webhook   WebhookUseCase
+	Settings  SettingsUseCase
 }
@@ -0,0 +1,400 @@
+package usecases
+
+import (
+	"context"
+	"time"
+	"todo-app/app/metrics"
+	"todo-app/app/models"
+)
+
+// Имена use cases в метке usecase
+const (
+	metricsTaskUseCase       = "task"
+	metricsAnalyticsUseCase  = "analytics"
+	metricsExportUseCase     = "export"
+	metricsWebhookUseCase    = "webhook"
+	metricsSettingsUseCase   = "settings"
+	metricsTimeUseCase       = "time_tracking"
+	metricsCommentUseCase    = "comment"
+	metricsAttachmentUseCase = "attachment"
+)
+
+// taskUseCaseWithMetrics учитывает операции над задачами и длительность вызовов
+type taskUseCaseWithMetrics struct {
+	next    TaskUseCase
+	metrics *metrics.Metrics
+}
+
+// NewTaskUseCaseWithMetrics оборачивает TaskUseCase сбором метрик
+func NewTaskUseCaseWithMetrics(next TaskUseCase, m *metrics.Metrics) TaskUseCase {
+	return &taskUseCaseWithMetrics{next: next, metrics: m}
+}
+
+// operation учитывает изменяющую операцию: счетчик по результату и длительность
+func (uc *taskUseCaseWithMetrics) operation(name, method string, start time.Time, err error) {
+	uc.metrics.ObserveTaskOperation(name, err)
+	uc.metrics.ObserveUseCase(metricsTaskUseCase, method, start)
+}
+
+func (uc *taskUseCaseWithMetrics) CreateTask(ctx context.Context, req models.CreateTaskRequest) (*models.Task, error) {
+	start := time.Now()
+	task, err := uc.next.CreateTask(ctx, req)
+	uc.operation("create", "CreateTask", start, err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithMetrics) ParseQuickAdd(ctx context.Context, input string) (*models.QuickAddResult, error) {
+	defer uc.metrics.ObserveUseCase(metricsTaskUseCase, "ParseQuickAdd", time.Now())
+	return uc.next.ParseQuickAdd(ctx, input)
+}
+
+func (uc *taskUseCaseWithMetrics) QuickAdd(ctx context.Context, input string) (*models.Task, error) {
+	start := time.Now()
+	task, err := uc.next.QuickAdd(ctx, input)
+	uc.operation("create", "QuickAdd", start, err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithMetrics) UpdateTask(ctx context.Context, req models.UpdateTaskRequest) (*models.Task, error) {
+	start := time.Now()
+	task, err := uc.next.UpdateTask(ctx, req)
+	uc.operation("update", "UpdateTask", start, err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithMetrics) DeleteTask(ctx context.Context, id int) error {
+	start := time.Now()
+	err := uc.next.DeleteTask(ctx, id)
+	uc.operation("delete", "DeleteTask", start, err)
+	return err
+}
+
+func (uc *taskUseCaseWithMetrics) ToggleTaskStatus(ctx context.Context, id int) (*models.Task, error) {
+	start := time.Now()
+	task, err := uc.next.ToggleTaskStatus(ctx, id)
+	uc.operation("toggle", "ToggleTaskStatus", start, err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithMetrics) ChangeTaskStatus(ctx context.Context, id int, status models.TaskStatus) (*models.Task, error) {
+	start := time.Now()
+	task, err := uc.next.ChangeTaskStatus(ctx, id, status)
+	uc.operation("status", "ChangeTaskStatus", start, err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithMetrics) GetWorkflow(ctx context.Context) *models.Workflow {
+	return uc.next.GetWorkflow(ctx)
+}
+
+func (uc *taskUseCaseWithMetrics) MoveTask(ctx context.Context, id, beforeID, afterID int, column models.TaskStatus) (*models.Task, error) {
+	start := time.Now()
+	task, err := uc.next.MoveTask(ctx, id, beforeID, afterID, column)
+	uc.operation("move", "MoveTask", start, err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithMetrics) GetBoard(ctx context.Context, filter models.TaskFilter) (*models.Board, error) {
+	defer uc.metrics.ObserveUseCase(metricsTaskUseCase, "GetBoard", time.Now())
+	return uc.next.GetBoard(ctx, filter)
+}
+
+func (uc *taskUseCaseWithMetrics) SetTaskEstimate(ctx context.Context, req models.SetEstimateRequest) (*models.Task, error) {
+	start := time.Now()
+	task, err := uc.next.SetTaskEstimate(ctx, req)
+	uc.operation("update", "SetTaskEstimate", start, err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithMetrics) ArchiveTask(ctx context.Context, id int) (*models.Task, error) {
+	start := time.Now()
+	task, err := uc.next.ArchiveTask(ctx, id)
+	uc.operation("archive", "ArchiveTask", start, err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithMetrics) GetTasks(ctx context.Context, filter models.TaskFilter, sort models.TaskSort) ([]*models.Task, error) {
+	defer uc.metrics.ObserveUseCase(metricsTaskUseCase, "GetTasks", time.Now())
+	return uc.next.GetTasks(ctx, filter, sort)
+}
+
+func (uc *taskUseCaseWithMetrics) GetTaskByID(ctx context.Context, id int) (*models.Task, error) {
+	defer uc.metrics.ObserveUseCase(metricsTaskUseCase, "GetTaskByID", time.Now())
+	return uc.next.GetTaskByID(ctx, id)
+}
+
+func (uc *taskUseCaseWithMetrics) GetTasksWithPagination(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, page, limit int) (*models.TaskListResponse, error) {
+	defer uc.metrics.ObserveUseCase(metricsTaskUseCase, "GetTasksWithPagination", time.Now())
+	return uc.next.GetTasksWithPagination(ctx, filter, sort, page, limit)
+}
+
+func (uc *taskUseCaseWithMetrics) GetTasksWithCursor(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, cursor string, limit int) (*models.TaskListResponse, error) {
+	defer uc.metrics.ObserveUseCase(metricsTaskUseCase, "GetTasksWithCursor", time.Now())
+	return uc.next.GetTasksWithCursor(ctx, filter, sort, cursor, limit)
+}
+
+// analyticsUseCaseWithMetrics учитывает длительность вызовов аналитики
+type analyticsUseCaseWithMetrics struct {
+	next    AnalyticsUseCase
+	metrics *metrics.Metrics
+}
+
+// NewAnalyticsUseCaseWithMetrics оборачивает AnalyticsUseCase сбором метрик
+func NewAnalyticsUseCaseWithMetrics(next AnalyticsUseCase, m *metrics.Metrics) AnalyticsUseCase {
+	return &analyticsUseCaseWithMetrics{next: next, metrics: m}
+}
+
+func (uc *analyticsUseCaseWithMetrics) GetTasksStats(ctx context.Context) (*models.TaskStats, error) {
+	defer uc.metrics.ObserveUseCase(metricsAnalyticsUseCase, "GetTasksStats", time.Now())
+	return uc.next.GetTasksStats(ctx)
+}
+
+func (uc *analyticsUseCaseWithMetrics) GetDashboardStats(ctx context.Context) (*models.DashboardStats, error) {
+	defer uc.metrics.ObserveUseCase(metricsAnalyticsUseCase, "GetDashboardStats", time.Now())
+	return uc.next.GetDashboardStats(ctx)
+}
+
+func (uc *analyticsUseCaseWithMetrics) GetOverdueTasks(ctx context.Context) ([]*models.Task, error) {
+	defer uc.metrics.ObserveUseCase(metricsAnalyticsUseCase, "GetOverdueTasks", time.Now())
+	return uc.next.GetOverdueTasks(ctx)
+}
+
+func (uc *analyticsUseCaseWithMetrics) GetHighPriorityTasks(ctx context.Context) ([]*models.Task, error) {
+	defer uc.metrics.ObserveUseCase(metricsAnalyticsUseCase, "GetHighPriorityTasks", time.Now())
+	return uc.next.GetHighPriorityTasks(ctx)
+}
+
+func (uc *analyticsUseCaseWithMetrics) GetCompletionRates(ctx context.Context, period string) (map[string]float64, error) {
+	defer uc.metrics.ObserveUseCase(metricsAnalyticsUseCase, "GetCompletionRates", time.Now())
+	return uc.next.GetCompletionRates(ctx, period)
+}
+
+func (uc *analyticsUseCaseWithMetrics) GetTasksByPriority(ctx context.Context) (map[models.Priority][]*models.Task, error) {
+	defer uc.metrics.ObserveUseCase(metricsAnalyticsUseCase, "GetTasksByPriority", time.Now())
+	return uc.next.GetTasksByPriority(ctx)
+}
+
+func (uc *analyticsUseCaseWithMetrics) GetEstimateReport(ctx context.Context, filter models.TaskFilter) (*models.EstimateReport, error) {
+	defer uc.metrics.ObserveUseCase(metricsAnalyticsUseCase, "GetEstimateReport", time.Now())
+	return uc.next.GetEstimateReport(ctx, filter)
+}
+
+// exportUseCaseWithMetrics учитывает длительность экспорта
+type exportUseCaseWithMetrics struct {
+	next    ExportUseCase
+	metrics *metrics.Metrics
+}
+
+// NewExportUseCaseWithMetrics оборачивает ExportUseCase сбором метрик
+func NewExportUseCaseWithMetrics(next ExportUseCase, m *metrics.Metrics) ExportUseCase {
+	return &exportUseCaseWithMetrics{next: next, metrics: m}
+}
+
+func (uc *exportUseCaseWithMetrics) ExportTasksToCSV(ctx context.Context, filter models.TaskFilter) ([]byte, error) {
+	defer uc.metrics.ObserveUseCase(metricsExportUseCase, "ExportTasksToCSV", time.Now())
+	return uc.next.ExportTasksToCSV(ctx, filter)
+}
+
+func (uc *exportUseCaseWithMetrics) ExportTasksToJSON(ctx context.Context, filter models.TaskFilter) ([]byte, error) {
+	defer uc.metrics.ObserveUseCase(metricsExportUseCase, "ExportTasksToJSON", time.Now())
+	return uc.next.ExportTasksToJSON(ctx, filter)
+}
+
+func (uc *exportUseCaseWithMetrics) ExportTasksToPDF(ctx context.Context, filter models.TaskFilter) ([]byte, error) {
+	defer uc.metrics.ObserveUseCase(metricsExportUseCase, "ExportTasksToPDF", time.Now())
+	return uc.next.ExportTasksToPDF(ctx, filter)
+}
+
+func (uc *exportUseCaseWithMetrics) GetExportableFields() []string {
+	return uc.next.GetExportableFields()
+}
+
+// webhookUseCaseWithMetrics учитывает длительность вызовов управления webhooks
+type webhookUseCaseWithMetrics struct {
+	next    WebhookUseCase
+	metrics *metrics.Metrics
+}
+
+// NewWebhookUseCaseWithMetrics оборачивает WebhookUseCase сбором метрик
+func NewWebhookUseCaseWithMetrics(next WebhookUseCase, m *metrics.Metrics) WebhookUseCase {
+	return &webhookUseCaseWithMetrics{next: next, metrics: m}
+}
+
+func (uc *webhookUseCaseWithMetrics) CreateWebhook(ctx context.Context, req models.CreateWebhookRequest) (*models.CreateWebhookResponse, error) {
+	defer uc.metrics.ObserveUseCase(metricsWebhookUseCase, "CreateWebhook", time.Now())
+	return uc.next.CreateWebhook(ctx, req)
+}
+
+func (uc *webhookUseCaseWithMetrics) GetWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	defer uc.metrics.ObserveUseCase(metricsWebhookUseCase, "GetWebhooks", time.Now())
+	return uc.next.GetWebhooks(ctx)
+}
+
+func (uc *webhookUseCaseWithMetrics) SetWebhookActive(ctx context.Context, id int, active bool) error {
+	defer uc.metrics.ObserveUseCase(metricsWebhookUseCase, "SetWebhookActive", time.Now())
+	return uc.next.SetWebhookActive(ctx, id, active)
+}
+
+func (uc *webhookUseCaseWithMetrics) DeleteWebhook(ctx context.Context, id int) error {
+	defer uc.metrics.ObserveUseCase(metricsWebhookUseCase, "DeleteWebhook", time.Now())
+	return uc.next.DeleteWebhook(ctx, id)
+}
+
+func (uc *webhookUseCaseWithMetrics) GetWebhookDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*models.WebhookDelivery, error) {
+	defer uc.metrics.ObserveUseCase(metricsWebhookUseCase, "GetWebhookDeliveries", time.Now())
+	return uc.next.GetWebhookDeliveries(ctx, subscriptionID, limit)
+}
+
+func (uc *webhookUseCaseWithMetrics) GetDeliveryAttempts(ctx context.Context, deliveryID int64) ([]*models.WebhookAttempt, error) {
+	defer uc.metrics.ObserveUseCase(metricsWebhookUseCase, "GetDeliveryAttempts", time.Now())
+	return uc.next.GetDeliveryAttempts(ctx, deliveryID)
+}
+
+func (uc *webhookUseCaseWithMetrics) RetryDelivery(ctx context.Context, deliveryID int64) error {
+	defer uc.metrics.ObserveUseCase(metricsWebhookUseCase, "RetryDelivery", time.Now())
+	return uc.next.RetryDelivery(ctx, deliveryID)
+}
+
+// settingsUseCaseWithMetrics учитывает длительность вызовов настроек
+type settingsUseCaseWithMetrics struct {
+	next    SettingsUseCase
+	metrics *metrics.Metrics
+}
+
+// NewSettingsUseCaseWithMetrics оборачивает SettingsUseCase сбором метрик
+func NewSettingsUseCaseWithMetrics(next SettingsUseCase, m *metrics.Metrics) SettingsUseCase {
+	return &settingsUseCaseWithMetrics{next: next, metrics: m}
+}
+
+func (uc *settingsUseCaseWithMetrics) GetSettings(ctx context.Context) (*models.AppSettings, error) {
+	defer uc.metrics.ObserveUseCase(metricsSettingsUseCase, "GetSettings", time.Now())
+	return uc.next.GetSettings(ctx)
+}
+
+func (uc *settingsUseCaseWithMetrics) UpdateSettings(ctx context.Context, settings models.AppSettings) (*models.AppSettings, error) {
+	defer uc.metrics.ObserveUseCase(metricsSettingsUseCase, "UpdateSettings", time.Now())
+	return uc.next.UpdateSettings(ctx, settings)
+}
+
+// timeTrackingUseCaseWithMetrics учитывает длительность вызовов учета времени
+type timeTrackingUseCaseWithMetrics struct {
+	next    TimeTrackingUseCase
+	metrics *metrics.Metrics
+}
+
+// NewTimeTrackingUseCaseWithMetrics оборачивает TimeTrackingUseCase сбором метрик
+func NewTimeTrackingUseCaseWithMetrics(next TimeTrackingUseCase, m *metrics.Metrics) TimeTrackingUseCase {
+	return &timeTrackingUseCaseWithMetrics{next: next, metrics: m}
+}
+
+func (uc *timeTrackingUseCaseWithMetrics) StartTimer(ctx context.Context, req models.StartTimerRequest) (*models.TimeEntry, error) {
+	defer uc.metrics.ObserveUseCase(metricsTimeUseCase, "StartTimer", time.Now())
+	return uc.next.StartTimer(ctx, req)
+}
+
+func (uc *timeTrackingUseCaseWithMetrics) StopTimer(ctx context.Context) (*models.TimeEntry, error) {
+	defer uc.metrics.ObserveUseCase(metricsTimeUseCase, "StopTimer", time.Now())
+	return uc.next.StopTimer(ctx)
+}
+
+func (uc *timeTrackingUseCaseWithMetrics) GetActiveTimer(ctx context.Context) (*models.TimeEntry, error) {
+	defer uc.metrics.ObserveUseCase(metricsTimeUseCase, "GetActiveTimer", time.Now())
+	return uc.next.GetActiveTimer(ctx)
+}
+
+func (uc *timeTrackingUseCaseWithMetrics) AddTimeEntry(ctx context.Context, req models.CreateTimeEntryRequest) (*models.TimeEntry, error) {
+	defer uc.metrics.ObserveUseCase(metricsTimeUseCase, "AddTimeEntry", time.Now())
+	return uc.next.AddTimeEntry(ctx, req)
+}
+
+func (uc *timeTrackingUseCaseWithMetrics) DeleteTimeEntry(ctx context.Context, id int64) error {
+	defer uc.metrics.ObserveUseCase(metricsTimeUseCase, "DeleteTimeEntry", time.Now())
+	return uc.next.DeleteTimeEntry(ctx, id)
+}
+
+func (uc *timeTrackingUseCaseWithMetrics) GetTaskTimeEntries(ctx context.Context, taskID int) ([]*models.TimeEntry, error) {
+	defer uc.metrics.ObserveUseCase(metricsTimeUseCase, "GetTaskTimeEntries", time.Now())
+	return uc.next.GetTaskTimeEntries(ctx, taskID)
+}
+
+func (uc *timeTrackingUseCaseWithMetrics) GetTimeReport(ctx context.Context, req models.TimeReportRequest) (*models.TimeReport, error) {
+	defer uc.metrics.ObserveUseCase(metricsTimeUseCase, "GetTimeReport", time.Now())
+	return uc.next.GetTimeReport(ctx, req)
+}
+
+// commentUseCaseWithMetrics учитывает длительность вызовов комментариев
+type commentUseCaseWithMetrics struct {
+	next    CommentUseCase
+	metrics *metrics.Metrics
+}
+
+// NewCommentUseCaseWithMetrics оборачивает CommentUseCase сбором метрик
+func NewCommentUseCaseWithMetrics(next CommentUseCase, m *metrics.Metrics) CommentUseCase {
+	return &commentUseCaseWithMetrics{next: next, metrics: m}
+}
+
+func (uc *commentUseCaseWithMetrics) AddComment(ctx context.Context, req models.CreateCommentRequest) (*models.Comment, error) {
+	defer uc.metrics.ObserveUseCase(metricsCommentUseCase, "AddComment", time.Now())
+	return uc.next.AddComment(ctx, req)
+}
+
+func (uc *commentUseCaseWithMetrics) UpdateComment(ctx context.Context, req models.UpdateCommentRequest) (*models.Comment, error) {
+	defer uc.metrics.ObserveUseCase(metricsCommentUseCase, "UpdateComment", time.Now())
+	return uc.next.UpdateComment(ctx, req)
+}
+
+func (uc *commentUseCaseWithMetrics) DeleteComment(ctx context.Context, id int64) error {
+	defer uc.metrics.ObserveUseCase(metricsCommentUseCase, "DeleteComment", time.Now())
+	return uc.next.DeleteComment(ctx, id)
+}
+
+func (uc *commentUseCaseWithMetrics) GetTaskComments(ctx context.Context, taskID int, order models.SortOrder) ([]*models.Comment, error) {
+	defer uc.metrics.ObserveUseCase(metricsCommentUseCase, "GetTaskComments", time.Now())
+	return uc.next.GetTaskComments(ctx, taskID, order)
+}
+
+func (uc *commentUseCaseWithMetrics) GetCommentRevisions(ctx context.Context, id int64) ([]*models.CommentRevision, error) {
+	defer uc.metrics.ObserveUseCase(metricsCommentUseCase, "GetCommentRevisions", time.Now())
+	return uc.next.GetCommentRevisions(ctx, id)
+}
+
+func (uc *commentUseCaseWithMetrics) GetTaskHistory(ctx context.Context, taskID int, limit int) ([]*models.TaskHistoryEntry, error) {
+	defer uc.metrics.ObserveUseCase(metricsCommentUseCase, "GetTaskHistory", time.Now())
+	return uc.next.GetTaskHistory(ctx, taskID, limit)
+}
+
+// attachmentUseCaseWithMetrics учитывает длительность операций с вложениями
+type attachmentUseCaseWithMetrics struct {
+	next    AttachmentUseCase
+	metrics *metrics.Metrics
+}
+
+// NewAttachmentUseCaseWithMetrics оборачивает AttachmentUseCase сбором метрик
+func NewAttachmentUseCaseWithMetrics(next AttachmentUseCase, m *metrics.Metrics) AttachmentUseCase {
+	return &attachmentUseCaseWithMetrics{next: next, metrics: m}
+}
+
+func (uc *attachmentUseCaseWithMetrics) AddAttachment(ctx context.Context, req models.CreateAttachmentRequest, data []byte) (*models.Attachment, error) {
+	defer uc.metrics.ObserveUseCase(metricsAttachmentUseCase, "AddAttachment", time.Now())
+	return uc.next.AddAttachment(ctx, req, data)
+}
+
+func (uc *attachmentUseCaseWithMetrics) AddAttachmentFromFile(ctx context.Context, taskID int, path string) (*models.Attachment, error) {
+	defer uc.metrics.ObserveUseCase(metricsAttachmentUseCase, "AddAttachmentFromFile", time.Now())
+	return uc.next.AddAttachmentFromFile(ctx, taskID, path)
+}
+
+func (uc *attachmentUseCaseWithMetrics) DeleteAttachment(ctx context.Context, id int64) error {
+	defer uc.metrics.ObserveUseCase(metricsAttachmentUseCase, "DeleteAttachment", time.Now())
+	return uc.next.DeleteAttachment(ctx, id)
+}
+
+func (uc *attachmentUseCaseWithMetrics) GetTaskAttachments(ctx context.Context, taskID int) ([]*models.Attachment, error) {
+	defer uc.metrics.ObserveUseCase(metricsAttachmentUseCase, "GetTaskAttachments", time.Now())
+	return uc.next.GetTaskAttachments(ctx, taskID)
+}
+
+func (uc *attachmentUseCaseWithMetrics) GetAttachmentContent(ctx context.Context, id int64) (*models.AttachmentContent, error) {
+	defer uc.metrics.ObserveUseCase(metricsAttachmentUseCase, "GetAttachmentContent", time.Now())
+	return uc.next.GetAttachmentContent(ctx, id)
+}
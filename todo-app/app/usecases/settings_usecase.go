@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"todo-app/app/models"
+	"todo-app/app/services"
+	"todo-app/internal/utils"
+)
+
+// SettingsUseCaseImpl реализует интерфейс SettingsUseCase
+type SettingsUseCaseImpl struct {
+	settingsService services.SettingsService
+}
+
+// NewSettingsUseCase создает новый экземпляр SettingsUseCase
+func NewSettingsUseCase(settingsService services.SettingsService) SettingsUseCase {
+	return &SettingsUseCaseImpl{
+		settingsService: settingsService,
+	}
+}
+
+// GetSettings получает настройки приложения
+func (uc *SettingsUseCaseImpl) GetSettings(ctx context.Context) (*models.AppSettings, error) {
+	return uc.settingsService.GetSettings(ctx)
+}
+
+// UpdateSettings проверяет и сохраняет настройки
+func (uc *SettingsUseCaseImpl) UpdateSettings(ctx context.Context, settings models.AppSettings) (*models.AppSettings, error) {
+	updated, err := uc.settingsService.UpdateSettings(ctx, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update settings: %w", err)
+	}
+
+	return updated, nil
+}
+
+// userTimezone возвращает часовой пояс пользователя из настроек. Пустая строка
+// означает локальный пояс процесса: недоступные настройки не мешают запросу
+func userTimezone(ctx context.Context, settings services.SettingsService) string {
+	if settings == nil {
+		return ""
+	}
+
+	current, err := settings.GetSettings(ctx)
+	if err != nil {
+		utils.WarnContext(ctx, "Failed to load timezone from settings", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return ""
+	}
+
+	return current.Timezone
+}
@@ -5,14 +5,24 @@ import (
 	"fmt"
 	"time"
 	"todo-app/app/models"
+	"todo-app/app/quickadd"
 	"todo-app/app/services"
+	"todo-app/internal/utils"
 	"todo-app/internal/validation"
 )
 
+// Причины нарушений бизнес-правил задач; совпадают с utils.ErrConflict
+var (
+	ErrCompletedTaskLocked = fmt.Errorf("completed task is locked: %w", utils.ErrConflict)
+	ErrTaskNotCompleted    = fmt.Errorf("task is not completed: %w", utils.ErrConflict)
+)
+
 // TaskUseCaseImpl реализует интерфейс TaskUseCase
 type TaskUseCaseImpl struct {
 	taskService services.TaskService
+	settings    services.SettingsService
 	validator   *validation.TaskValidator
+	now         func() time.Time // часы для разбора относительных сроков
 }
 
 // NewTaskUseCase создает новый экземпляр TaskUseCase
@@ -20,11 +30,54 @@ func NewTaskUseCase(taskService services.TaskService) TaskUseCase {
 	return &TaskUseCaseImpl{
 		taskService: taskService,
 		validator:   validation.NewTaskValidator(),
+		now:         time.Now,
+	}
+}
+
+// NewTaskUseCaseWithSettings создает TaskUseCase, берущий значения по умолчанию
+// (приоритет новой задачи) из настроек пользователя
+func NewTaskUseCaseWithSettings(taskService services.TaskService, settings services.SettingsService) TaskUseCase {
+	return &TaskUseCaseImpl{
+		taskService: taskService,
+		settings:    settings,
+		validator:   validation.NewTaskValidator(),
+		now:         time.Now,
 	}
 }
 
+// defaultPriority возвращает приоритет новой задачи из настроек.
+// Недоступные настройки не мешают созданию задачи
+func (uc *TaskUseCaseImpl) defaultPriority(ctx context.Context) models.Priority {
+	if uc.settings == nil {
+		return models.PriorityMedium
+	}
+
+	settings, err := uc.settings.GetSettings(ctx)
+	if err != nil {
+		utils.WarnContext(ctx, "Failed to load default priority from settings", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return models.PriorityMedium
+	}
+
+	return settings.DefaultPriority
+}
+
+// withTimezone подставляет в фильтр часовой пояс пользователя, если он не задан явно
+func (uc *TaskUseCaseImpl) withTimezone(ctx context.Context, filter models.TaskFilter) models.TaskFilter {
+	if filter.Timezone == "" {
+		filter.Timezone = userTimezone(ctx, uc.settings)
+	}
+	return filter
+}
+
 // CreateTask создает новую задачу с применением бизнес-правил
 func (uc *TaskUseCaseImpl) CreateTask(ctx context.Context, req models.CreateTaskRequest) (*models.Task, error) {
+	// Если приоритет не указан, берем приоритет по умолчанию из настроек
+	if req.Priority == "" {
+		req.Priority = uc.defaultPriority(ctx)
+	}
+
 	// Дополнительная валидация на уровне use case
 	if err := uc.validator.ValidateCreateTaskRequest(req); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
@@ -32,12 +85,7 @@ func (uc *TaskUseCaseImpl) CreateTask(ctx context.Context, req models.CreateTask
 
 	// Бизнес-правила для создания задачи
 	if req.DueDate != nil && req.DueDate.Before(time.Now().AddDate(0, 0, -1)) {
-		return nil, fmt.Errorf("due date cannot be in the past")
-	}
-
-	// Если приоритет не указан, устанавливаем средний по умолчанию
-	if req.Priority == "" {
-		req.Priority = models.PriorityMedium
+		return nil, validation.DueDateInPast()
 	}
 
 	// Вызов сервисного слоя
@@ -49,6 +97,24 @@ func (uc *TaskUseCaseImpl) CreateTask(ctx context.Context, req models.CreateTask
 	return task, nil
 }
 
+// ParseQuickAdd разбирает строку быстрого добавления без создания задачи.
+// Относительные сроки считаются от текущего момента в часовом поясе пользователя
+func (uc *TaskUseCaseImpl) ParseQuickAdd(ctx context.Context, input string) (*models.QuickAddResult, error) {
+	now := models.NewDueWindow(uc.now(), userTimezone(ctx, uc.settings)).Now
+	result := quickadd.Parse(input, now)
+	return &result, nil
+}
+
+// QuickAdd создает задачу из строки быстрого добавления
+func (uc *TaskUseCaseImpl) QuickAdd(ctx context.Context, input string) (*models.Task, error) {
+	result, err := uc.ParseQuickAdd(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.CreateTask(ctx, result.CreateTaskRequest())
+}
+
 // UpdateTask обновляет существующую задачу с проверками
 func (uc *TaskUseCaseImpl) UpdateTask(ctx context.Context, req models.UpdateTaskRequest) (*models.Task, error) {
 	// Валидация запроса
@@ -59,19 +125,21 @@ func (uc *TaskUseCaseImpl) UpdateTask(ctx context.Context, req models.UpdateTask
 	// Проверяем, что задача существует
 	existingTask, err := uc.taskService.GetTaskByID(ctx, req.ID)
 	if err != nil {
-		return nil, fmt.Errorf("task not found: %w", err)
+		return nil, fmt.Errorf("failed to load task: %w", err)
 	}
 
 	// Бизнес-правила для обновления
 	if req.DueDate != nil && req.DueDate.Before(time.Now().AddDate(0, 0, -1)) {
-		return nil, fmt.Errorf("due date cannot be in the past")
+		return nil, validation.DueDateInPast()
 	}
 
-	// Если задача уже выполнена, не разрешаем изменять некоторые поля
-	if existingTask.Status == models.TaskStatusCompleted {
-		// Можно изменить только описание у выполненной задачи
+	// Если задача уже закрыта, не разрешаем изменять некоторые поля
+	if existingTask.Status.IsClosed() {
+		// Можно изменить только описание у выполненной или отмененной задачи
 		if req.Title != existingTask.Title || req.Priority != existingTask.Priority {
-			return nil, fmt.Errorf("cannot modify title or priority of completed task")
+			return nil, utils.NewConflictError("cannot modify title or priority of closed task").
+				WithCause(ErrCompletedTaskLocked).
+				WithMessageKey("error.task_completed_locked", nil)
 		}
 	}
 
@@ -94,7 +162,7 @@ func (uc *TaskUseCaseImpl) DeleteTask(ctx context.Context, id int) error {
 	// Проверяем существование задачи
 	task, err := uc.taskService.GetTaskByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("task not found: %w", err)
+		return fmt.Errorf("failed to load task: %w", err)
 	}
 
 	// Бизнес-правило: можно удалять только свои задачи
@@ -120,18 +188,18 @@ func (uc *TaskUseCaseImpl) ToggleTaskStatus(ctx context.Context, id int) (*model
 	// Получаем текущую задачу
 	task, err := uc.taskService.GetTaskByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("task not found: %w", err)
+		return nil, fmt.Errorf("failed to load task: %w", err)
 	}
 
 	// Бизнес-логика переключения статуса
-	if task.Status == models.TaskStatusActive {
+	if task.Status.IsOpen() {
 		// При завершении задачи проверяем, не просрочена ли она
-		if task.DueDate != nil && task.DueDate.Before(time.Now()) {
+		if models.NewDueWindow(time.Now(), "").IsOverdue(task) {
 			// Можно добавить специальную логику для просроченных задач
 			// Например, отметить как "completed late"
 		}
-	} else if task.Status == models.TaskStatusCompleted {
-		// При возврате в активное состояние очищаем completed_at
+	} else {
+		// При возврате в todo отметки времени переходов очищаются
 		// Это будет обработано в сервисном слое
 	}
 
@@ -144,6 +212,105 @@ func (uc *TaskUseCaseImpl) ToggleTaskStatus(ctx context.Context, id int) (*model
 	return updatedTask, nil
 }
 
+// ChangeTaskStatus переводит задачу в статус; допустимость перехода
+// проверяет сервисный слой по workflow
+func (uc *TaskUseCaseImpl) ChangeTaskStatus(ctx context.Context, id int, status models.TaskStatus) (*models.Task, error) {
+	// Валидация ID
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+
+	updatedTask, err := uc.taskService.ChangeStatus(ctx, id, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to change task status: %w", err)
+	}
+
+	return updatedTask, nil
+}
+
+// GetWorkflow возвращает статусы задач и разрешенные переходы
+func (uc *TaskUseCaseImpl) GetWorkflow(ctx context.Context) *models.Workflow {
+	return uc.taskService.GetWorkflow(ctx)
+}
+
+// MoveTask переносит задачу в ручном порядке; перенос в другую колонку доски
+// меняет статус по правилам workflow
+func (uc *TaskUseCaseImpl) MoveTask(ctx context.Context, id, beforeID, afterID int, column models.TaskStatus) (*models.Task, error) {
+	// Валидация ID
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+
+	// Соседей может не быть (0), но не отрицательные ID
+	if beforeID < 0 {
+		return nil, validation.PositiveID("before_id", int64(beforeID))
+	}
+	if afterID < 0 {
+		return nil, validation.PositiveID("after_id", int64(afterID))
+	}
+
+	task, err := uc.taskService.MoveTask(ctx, id, beforeID, afterID, column)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move task: %w", err)
+	}
+
+	return task, nil
+}
+
+// GetBoard получает задачи доски; сроки оцениваются в часовом поясе пользователя
+func (uc *TaskUseCaseImpl) GetBoard(ctx context.Context, filter models.TaskFilter) (*models.Board, error) {
+	if filter.DateType == "" {
+		filter.DateType = models.DateFilterAll
+	}
+	filter = uc.withTimezone(ctx, filter)
+
+	board, err := uc.taskService.GetBoard(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", err)
+	}
+
+	return board, nil
+}
+
+// SetTaskEstimate изменяет оценку задачи
+func (uc *TaskUseCaseImpl) SetTaskEstimate(ctx context.Context, req models.SetEstimateRequest) (*models.Task, error) {
+	task, err := uc.taskService.SetEstimate(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set task estimate: %w", err)
+	}
+
+	return task, nil
+}
+
+// ArchiveTask переносит задачу в архив
+func (uc *TaskUseCaseImpl) ArchiveTask(ctx context.Context, id int) (*models.Task, error) {
+	// Валидация ID
+	if err := uc.validator.ValidateID(id); err != nil {
+		return nil, fmt.Errorf("invalid task ID: %w", err)
+	}
+
+	// Получаем текущую задачу
+	task, err := uc.taskService.GetTaskByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load task: %w", err)
+	}
+
+	// Бизнес-правило: архивировать можно только выполненные или отмененные задачи
+	if !task.Status.IsClosed() {
+		return nil, utils.NewConflictError("task must be completed or cancelled before archiving").
+			WithCause(ErrTaskNotCompleted).
+			WithMessageKey("error.task_not_completed", nil)
+	}
+
+	// Вызов сервисного слоя
+	if err := uc.taskService.ArchiveTask(ctx, id); err != nil {
+		return nil, fmt.Errorf("failed to archive task: %w", err)
+	}
+
+	task.Archived = true
+	return task, nil
+}
+
 // GetTasks получает список задач с применением бизнес-правил фильтрации
 func (uc *TaskUseCaseImpl) GetTasks(ctx context.Context, filter models.TaskFilter, sort models.TaskSort) ([]*models.Task, error) {
 	// Валидация фильтра и сортировки
@@ -154,6 +321,7 @@ func (uc *TaskUseCaseImpl) GetTasks(ctx context.Context, filter models.TaskFilte
 	if err := uc.validator.ValidateTaskSort(sort); err != nil {
 		return nil, fmt.Errorf("invalid sort: %w", err)
 	}
+	filter = uc.withTimezone(ctx, filter)
 
 	// Применение бизнес-правил к фильтрам
 	// Например, пользователь может видеть только свои задачи
@@ -178,7 +346,7 @@ func (uc *TaskUseCaseImpl) GetTaskByID(ctx context.Context, id int) (*models.Tas
 	// Вызов сервисного слоя
 	task, err := uc.taskService.GetTaskByID(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("task not found: %w", err)
+		return nil, fmt.Errorf("failed to load task: %w", err)
 	}
 
 	// Бизнес-правило: проверка прав доступа к задаче
@@ -205,6 +373,7 @@ func (uc *TaskUseCaseImpl) GetTasksWithPagination(ctx context.Context, filter mo
 	if err := uc.validator.ValidateTaskSort(sort); err != nil {
 		return nil, fmt.Errorf("invalid sort: %w", err)
 	}
+	filter = uc.withTimezone(ctx, filter)
 
 	// Получение всех задач (в будущем можно оптимизировать с пагинацией на уровне БД)
 	allTasks, err := uc.taskService.GetAllTasks(ctx, filter, sort)
@@ -234,24 +403,30 @@ func (uc *TaskUseCaseImpl) GetTasksWithPagination(ctx context.Context, filter mo
 	pagedTasks := allTasks[start:end]
 
 	// Конвертация в TaskResponse
+	window := models.NewDueWindow(time.Now(), filter.Timezone)
 	taskResponses := make([]*models.TaskResponse, 0, len(pagedTasks))
 	for _, task := range pagedTasks {
-		isOverdue := false
-		if task.DueDate != nil && task.Status == models.TaskStatusActive && task.DueDate.Before(time.Now()) {
-			isOverdue = true
-		}
+		isOverdue := window.IsOverdue(task)
 
 		taskResponses = append(taskResponses, &models.TaskResponse{
-			ID:          task.ID,
-			Title:       task.Title,
-			Description: task.Description,
-			Status:      task.Status,
-			Priority:    task.Priority,
-			DueDate:     task.DueDate,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   task.UpdatedAt,
-			CompletedAt: task.CompletedAt,
-			IsOverdue:   isOverdue,
+			ID:              task.ID,
+			Title:           task.Title,
+			Description:     task.Description,
+			Status:          task.Status,
+			Priority:        task.Priority,
+			DueDate:         task.DueDate,
+			DueAllDay:       task.DueAllDay,
+			Project:         task.Project,
+			Tags:            task.Tags,
+			CreatedAt:       task.CreatedAt,
+			UpdatedAt:       task.UpdatedAt,
+			CompletedAt:     task.CompletedAt,
+			StartedAt:       task.StartedAt,
+			CancelledAt:     task.CancelledAt,
+			IsOverdue:       isOverdue,
+			Position:        task.Position,
+			BoardPosition:   task.BoardPosition,
+			EstimateMinutes: task.EstimateMinutes,
 		})
 	}
 
@@ -262,3 +437,65 @@ func (uc *TaskUseCaseImpl) GetTasksWithPagination(ctx context.Context, filter mo
 		Sort:       sort,
 	}, nil
 }
+
+// GetTasksWithCursor получает задачи с keyset-пагинацией
+func (uc *TaskUseCaseImpl) GetTasksWithCursor(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, cursor string, limit int) (*models.TaskListResponse, error) {
+	// Валидация параметров пагинации
+	if limit < 1 || limit > 100 {
+		limit = 20 // Значение по умолчанию
+	}
+
+	// Валидация фильтра и сортировки
+	if err := uc.validator.ValidateTaskFilter(filter); err != nil {
+		return nil, fmt.Errorf("invalid filter: %w", err)
+	}
+
+	if err := uc.validator.ValidateTaskSort(sort); err != nil {
+		return nil, fmt.Errorf("invalid sort: %w", err)
+	}
+	filter = uc.withTimezone(ctx, filter)
+
+	// Вызов сервисного слоя
+	page, err := uc.taskService.GetTasksPage(ctx, filter, sort, cursor, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tasks page: %w", err)
+	}
+
+	// Конвертация в TaskResponse
+	window := models.NewDueWindow(time.Now(), filter.Timezone)
+	taskResponses := make([]*models.TaskResponse, 0, len(page.Tasks))
+	for _, task := range page.Tasks {
+		isOverdue := window.IsOverdue(task)
+
+		taskResponses = append(taskResponses, &models.TaskResponse{
+			ID:              task.ID,
+			Title:           task.Title,
+			Description:     task.Description,
+			Status:          task.Status,
+			Priority:        task.Priority,
+			DueDate:         task.DueDate,
+			DueAllDay:       task.DueAllDay,
+			Project:         task.Project,
+			Tags:            task.Tags,
+			CreatedAt:       task.CreatedAt,
+			UpdatedAt:       task.UpdatedAt,
+			CompletedAt:     task.CompletedAt,
+			StartedAt:       task.StartedAt,
+			CancelledAt:     task.CancelledAt,
+			IsOverdue:       isOverdue,
+			Position:        task.Position,
+			BoardPosition:   task.BoardPosition,
+			EstimateMinutes: task.EstimateMinutes,
+		})
+	}
+
+	meta := utils.CalculateCursorPaginationMeta(limit, page.HasNext, cursor != "", page.NextCursor)
+
+	return &models.TaskListResponse{
+		Tasks:      taskResponses,
+		Filter:     filter,
+		Sort:       sort,
+		NextCursor: page.NextCursor,
+		Pagination: &meta,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"todo-app/app/models"
+	"todo-app/app/services"
+	"todo-app/internal/validation"
+)
+
+// TimeTrackingUseCaseImpl реализует интерфейс TimeTrackingUseCase
+type TimeTrackingUseCaseImpl struct {
+	timeTracking services.TimeTrackingService
+	settings     services.SettingsService
+}
+
+// NewTimeTrackingUseCase создает TimeTrackingUseCase, считающий итоги по дням
+// в часовом поясе пользователя из настроек (settings может быть nil)
+func NewTimeTrackingUseCase(timeTracking services.TimeTrackingService, settings services.SettingsService) TimeTrackingUseCase {
+	return &TimeTrackingUseCaseImpl{
+		timeTracking: timeTracking,
+		settings:     settings,
+	}
+}
+
+// StartTimer запускает таймер по задаче
+func (uc *TimeTrackingUseCaseImpl) StartTimer(ctx context.Context, req models.StartTimerRequest) (*models.TimeEntry, error) {
+	entry, err := uc.timeTracking.StartTimer(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start timer: %w", err)
+	}
+
+	return entry, nil
+}
+
+// StopTimer останавливает запущенный таймер
+func (uc *TimeTrackingUseCaseImpl) StopTimer(ctx context.Context) (*models.TimeEntry, error) {
+	entry, err := uc.timeTracking.StopTimer(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stop timer: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetActiveTimer получает запущенный таймер, в том числе оставшийся с прошлого запуска приложения
+func (uc *TimeTrackingUseCaseImpl) GetActiveTimer(ctx context.Context) (*models.TimeEntry, error) {
+	return uc.timeTracking.GetActiveTimer(ctx)
+}
+
+// AddTimeEntry добавляет время вручную
+func (uc *TimeTrackingUseCaseImpl) AddTimeEntry(ctx context.Context, req models.CreateTimeEntryRequest) (*models.TimeEntry, error) {
+	entry, err := uc.timeTracking.AddTimeEntry(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add time entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// DeleteTimeEntry удаляет запись времени
+func (uc *TimeTrackingUseCaseImpl) DeleteTimeEntry(ctx context.Context, id int64) error {
+	return uc.timeTracking.DeleteTimeEntry(ctx, id)
+}
+
+// GetTaskTimeEntries получает записи времени задачи в порядке начала
+func (uc *TimeTrackingUseCaseImpl) GetTaskTimeEntries(ctx context.Context, taskID int) ([]*models.TimeEntry, error) {
+	if err := validation.PositiveID("task_id", int64(taskID)); err != nil {
+		return nil, err
+	}
+
+	return uc.timeTracking.GetTimeEntries(ctx, models.TimeEntryFilter{TaskID: taskID})
+}
+
+// GetTimeReport получает итоги времени по задачам, проектам или дням за период
+func (uc *TimeTrackingUseCaseImpl) GetTimeReport(ctx context.Context, req models.TimeReportRequest) (*models.TimeReport, error) {
+	report, err := uc.timeTracking.GetTimeReport(ctx, req, userTimezone(ctx, uc.settings))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time report: %w", err)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,555 @@
+package usecases
+
+import (
+	"context"
+	"todo-app/app/models"
+	"todo-app/internal/tracing"
+)
+
+// taskUseCaseWithTracing оборачивает вызовы TaskUseCase span'ами
+type taskUseCaseWithTracing struct {
+	next TaskUseCase
+}
+
+// NewTaskUseCaseWithTracing оборачивает TaskUseCase трассировкой
+func NewTaskUseCaseWithTracing(next TaskUseCase) TaskUseCase {
+	return &taskUseCaseWithTracing{next: next}
+}
+
+func (uc *taskUseCaseWithTracing) CreateTask(ctx context.Context, req models.CreateTaskRequest) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.CreateTask")
+	span.SetAttribute("task.priority", string(req.Priority))
+	task, err := uc.next.CreateTask(ctx, req)
+	if task != nil {
+		span.SetAttribute("task.id", task.ID)
+	}
+	span.Finish(err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithTracing) ParseQuickAdd(ctx context.Context, input string) (*models.QuickAddResult, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.ParseQuickAdd")
+	result, err := uc.next.ParseQuickAdd(ctx, input)
+	if result != nil {
+		span.SetAttribute("quickadd.has_due", result.DueDate != nil)
+	}
+	span.Finish(err)
+	return result, err
+}
+
+func (uc *taskUseCaseWithTracing) QuickAdd(ctx context.Context, input string) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.QuickAdd")
+	task, err := uc.next.QuickAdd(ctx, input)
+	if task != nil {
+		span.SetAttribute("task.id", task.ID)
+	}
+	span.Finish(err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithTracing) UpdateTask(ctx context.Context, req models.UpdateTaskRequest) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.UpdateTask")
+	span.SetAttribute("task.id", req.ID)
+	task, err := uc.next.UpdateTask(ctx, req)
+	span.Finish(err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithTracing) DeleteTask(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.DeleteTask")
+	span.SetAttribute("task.id", id)
+	err := uc.next.DeleteTask(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (uc *taskUseCaseWithTracing) ToggleTaskStatus(ctx context.Context, id int) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.ToggleTaskStatus")
+	span.SetAttribute("task.id", id)
+	task, err := uc.next.ToggleTaskStatus(ctx, id)
+	span.Finish(err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithTracing) ChangeTaskStatus(ctx context.Context, id int, status models.TaskStatus) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.ChangeTaskStatus")
+	span.SetAttribute("task.id", id)
+	span.SetAttribute("task.status", string(status))
+	task, err := uc.next.ChangeTaskStatus(ctx, id, status)
+	span.Finish(err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithTracing) GetWorkflow(ctx context.Context) *models.Workflow {
+	return uc.next.GetWorkflow(ctx)
+}
+
+func (uc *taskUseCaseWithTracing) MoveTask(ctx context.Context, id, beforeID, afterID int, column models.TaskStatus) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.MoveTask")
+	span.SetAttribute("task.id", id)
+	span.SetAttribute("column", string(column))
+	task, err := uc.next.MoveTask(ctx, id, beforeID, afterID, column)
+	span.Finish(err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithTracing) GetBoard(ctx context.Context, filter models.TaskFilter) (*models.Board, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.GetBoard")
+	span.SetAttributes(filter.LogFields())
+	board, err := uc.next.GetBoard(ctx, filter)
+	span.Finish(err)
+	return board, err
+}
+
+func (uc *taskUseCaseWithTracing) SetTaskEstimate(ctx context.Context, req models.SetEstimateRequest) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.SetTaskEstimate")
+	span.SetAttribute("task.id", req.ID)
+	task, err := uc.next.SetTaskEstimate(ctx, req)
+	span.Finish(err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithTracing) ArchiveTask(ctx context.Context, id int) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.ArchiveTask")
+	span.SetAttribute("task.id", id)
+	task, err := uc.next.ArchiveTask(ctx, id)
+	span.Finish(err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithTracing) GetTasks(ctx context.Context, filter models.TaskFilter, sort models.TaskSort) ([]*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.GetTasks")
+	span.SetAttributes(filter.LogFields())
+	span.SetAttributes(sort.LogFields())
+	tasks, err := uc.next.GetTasks(ctx, filter, sort)
+	span.SetAttribute("rows", len(tasks))
+	span.Finish(err)
+	return tasks, err
+}
+
+func (uc *taskUseCaseWithTracing) GetTaskByID(ctx context.Context, id int) (*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.GetTaskByID")
+	span.SetAttribute("task.id", id)
+	task, err := uc.next.GetTaskByID(ctx, id)
+	span.Finish(err)
+	return task, err
+}
+
+func (uc *taskUseCaseWithTracing) GetTasksWithPagination(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, page, limit int) (*models.TaskListResponse, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.GetTasksWithPagination")
+	span.SetAttributes(filter.LogFields())
+	span.SetAttributes(sort.LogFields())
+	span.SetAttributes(map[string]interface{}{"page": page, "limit": limit})
+	response, err := uc.next.GetTasksWithPagination(ctx, filter, sort, page, limit)
+	if response != nil {
+		span.SetAttributes(map[string]interface{}{"rows": len(response.Tasks), "total": response.TotalCount})
+	}
+	span.Finish(err)
+	return response, err
+}
+
+func (uc *taskUseCaseWithTracing) GetTasksWithCursor(ctx context.Context, filter models.TaskFilter, sort models.TaskSort, cursor string, limit int) (*models.TaskListResponse, error) {
+	ctx, span := tracing.Start(ctx, "TaskUseCase.GetTasksWithCursor")
+	span.SetAttributes(filter.LogFields())
+	span.SetAttributes(sort.LogFields())
+	span.SetAttributes(map[string]interface{}{"cursor": cursor != "", "limit": limit})
+	response, err := uc.next.GetTasksWithCursor(ctx, filter, sort, cursor, limit)
+	if response != nil {
+		span.SetAttribute("rows", len(response.Tasks))
+	}
+	span.Finish(err)
+	return response, err
+}
+
+// analyticsUseCaseWithTracing оборачивает вызовы AnalyticsUseCase span'ами
+type analyticsUseCaseWithTracing struct {
+	next AnalyticsUseCase
+}
+
+// NewAnalyticsUseCaseWithTracing оборачивает AnalyticsUseCase трассировкой
+func NewAnalyticsUseCaseWithTracing(next AnalyticsUseCase) AnalyticsUseCase {
+	return &analyticsUseCaseWithTracing{next: next}
+}
+
+func (uc *analyticsUseCaseWithTracing) GetTasksStats(ctx context.Context) (*models.TaskStats, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsUseCase.GetTasksStats")
+	stats, err := uc.next.GetTasksStats(ctx)
+	span.Finish(err)
+	return stats, err
+}
+
+func (uc *analyticsUseCaseWithTracing) GetDashboardStats(ctx context.Context) (*models.DashboardStats, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsUseCase.GetDashboardStats")
+	stats, err := uc.next.GetDashboardStats(ctx)
+	span.Finish(err)
+	return stats, err
+}
+
+func (uc *analyticsUseCaseWithTracing) GetOverdueTasks(ctx context.Context) ([]*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsUseCase.GetOverdueTasks")
+	tasks, err := uc.next.GetOverdueTasks(ctx)
+	span.SetAttribute("rows", len(tasks))
+	span.Finish(err)
+	return tasks, err
+}
+
+func (uc *analyticsUseCaseWithTracing) GetHighPriorityTasks(ctx context.Context) ([]*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsUseCase.GetHighPriorityTasks")
+	tasks, err := uc.next.GetHighPriorityTasks(ctx)
+	span.SetAttribute("rows", len(tasks))
+	span.Finish(err)
+	return tasks, err
+}
+
+func (uc *analyticsUseCaseWithTracing) GetCompletionRates(ctx context.Context, period string) (map[string]float64, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsUseCase.GetCompletionRates")
+	span.SetAttribute("period", period)
+	rates, err := uc.next.GetCompletionRates(ctx, period)
+	span.Finish(err)
+	return rates, err
+}
+
+func (uc *analyticsUseCaseWithTracing) GetTasksByPriority(ctx context.Context) (map[models.Priority][]*models.Task, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsUseCase.GetTasksByPriority")
+	groups, err := uc.next.GetTasksByPriority(ctx)
+	span.Finish(err)
+	return groups, err
+}
+
+func (uc *analyticsUseCaseWithTracing) GetEstimateReport(ctx context.Context, filter models.TaskFilter) (*models.EstimateReport, error) {
+	ctx, span := tracing.Start(ctx, "AnalyticsUseCase.GetEstimateReport")
+	report, err := uc.next.GetEstimateReport(ctx, filter)
+	if report != nil {
+		span.SetAttribute("rows", len(report.Tasks))
+	}
+	span.Finish(err)
+	return report, err
+}
+
+// exportUseCaseWithTracing оборачивает экспорт span'ами
+type exportUseCaseWithTracing struct {
+	next ExportUseCase
+}
+
+// NewExportUseCaseWithTracing оборачивает ExportUseCase трассировкой
+func NewExportUseCaseWithTracing(next ExportUseCase) ExportUseCase {
+	return &exportUseCaseWithTracing{next: next}
+}
+
+func (uc *exportUseCaseWithTracing) export(ctx context.Context, name string, filter models.TaskFilter, fn func(context.Context) ([]byte, error)) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "ExportUseCase."+name)
+	span.SetAttributes(filter.LogFields())
+	data, err := fn(ctx)
+	span.SetAttribute("bytes", len(data))
+	span.Finish(err)
+	return data, err
+}
+
+func (uc *exportUseCaseWithTracing) ExportTasksToCSV(ctx context.Context, filter models.TaskFilter) ([]byte, error) {
+	return uc.export(ctx, "ExportTasksToCSV", filter, func(ctx context.Context) ([]byte, error) {
+		return uc.next.ExportTasksToCSV(ctx, filter)
+	})
+}
+
+func (uc *exportUseCaseWithTracing) ExportTasksToJSON(ctx context.Context, filter models.TaskFilter) ([]byte, error) {
+	return uc.export(ctx, "ExportTasksToJSON", filter, func(ctx context.Context) ([]byte, error) {
+		return uc.next.ExportTasksToJSON(ctx, filter)
+	})
+}
+
+func (uc *exportUseCaseWithTracing) ExportTasksToPDF(ctx context.Context, filter models.TaskFilter) ([]byte, error) {
+	return uc.export(ctx, "ExportTasksToPDF", filter, func(ctx context.Context) ([]byte, error) {
+		return uc.next.ExportTasksToPDF(ctx, filter)
+	})
+}
+
+func (uc *exportUseCaseWithTracing) GetExportableFields() []string {
+	return uc.next.GetExportableFields()
+}
+
+// webhookUseCaseWithTracing оборачивает управление webhooks span'ами
+type webhookUseCaseWithTracing struct {
+	next WebhookUseCase
+}
+
+// NewWebhookUseCaseWithTracing оборачивает WebhookUseCase трассировкой
+func NewWebhookUseCaseWithTracing(next WebhookUseCase) WebhookUseCase {
+	return &webhookUseCaseWithTracing{next: next}
+}
+
+func (uc *webhookUseCaseWithTracing) CreateWebhook(ctx context.Context, req models.CreateWebhookRequest) (*models.CreateWebhookResponse, error) {
+	ctx, span := tracing.Start(ctx, "WebhookUseCase.CreateWebhook")
+	response, err := uc.next.CreateWebhook(ctx, req)
+	span.Finish(err)
+	return response, err
+}
+
+func (uc *webhookUseCaseWithTracing) GetWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	ctx, span := tracing.Start(ctx, "WebhookUseCase.GetWebhooks")
+	subscriptions, err := uc.next.GetWebhooks(ctx)
+	span.SetAttribute("rows", len(subscriptions))
+	span.Finish(err)
+	return subscriptions, err
+}
+
+func (uc *webhookUseCaseWithTracing) SetWebhookActive(ctx context.Context, id int, active bool) error {
+	ctx, span := tracing.Start(ctx, "WebhookUseCase.SetWebhookActive")
+	span.SetAttributes(map[string]interface{}{"webhook.id": id, "active": active})
+	err := uc.next.SetWebhookActive(ctx, id, active)
+	span.Finish(err)
+	return err
+}
+
+func (uc *webhookUseCaseWithTracing) DeleteWebhook(ctx context.Context, id int) error {
+	ctx, span := tracing.Start(ctx, "WebhookUseCase.DeleteWebhook")
+	span.SetAttribute("webhook.id", id)
+	err := uc.next.DeleteWebhook(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (uc *webhookUseCaseWithTracing) GetWebhookDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*models.WebhookDelivery, error) {
+	ctx, span := tracing.Start(ctx, "WebhookUseCase.GetWebhookDeliveries")
+	span.SetAttributes(map[string]interface{}{"webhook.id": subscriptionID, "limit": limit})
+	deliveries, err := uc.next.GetWebhookDeliveries(ctx, subscriptionID, limit)
+	span.SetAttribute("rows", len(deliveries))
+	span.Finish(err)
+	return deliveries, err
+}
+
+func (uc *webhookUseCaseWithTracing) GetDeliveryAttempts(ctx context.Context, deliveryID int64) ([]*models.WebhookAttempt, error) {
+	ctx, span := tracing.Start(ctx, "WebhookUseCase.GetDeliveryAttempts")
+	span.SetAttribute("delivery.id", deliveryID)
+	attempts, err := uc.next.GetDeliveryAttempts(ctx, deliveryID)
+	span.SetAttribute("rows", len(attempts))
+	span.Finish(err)
+	return attempts, err
+}
+
+func (uc *webhookUseCaseWithTracing) RetryDelivery(ctx context.Context, deliveryID int64) error {
+	ctx, span := tracing.Start(ctx, "WebhookUseCase.RetryDelivery")
+	span.SetAttribute("delivery.id", deliveryID)
+	err := uc.next.RetryDelivery(ctx, deliveryID)
+	span.Finish(err)
+	return err
+}
+
+// settingsUseCaseWithTracing оборачивает вызовы SettingsUseCase span'ами
+type settingsUseCaseWithTracing struct {
+	next SettingsUseCase
+}
+
+// NewSettingsUseCaseWithTracing оборачивает SettingsUseCase трассировкой
+func NewSettingsUseCaseWithTracing(next SettingsUseCase) SettingsUseCase {
+	return &settingsUseCaseWithTracing{next: next}
+}
+
+func (uc *settingsUseCaseWithTracing) GetSettings(ctx context.Context) (*models.AppSettings, error) {
+	ctx, span := tracing.Start(ctx, "SettingsUseCase.GetSettings")
+	settings, err := uc.next.GetSettings(ctx)
+	span.Finish(err)
+	return settings, err
+}
+
+func (uc *settingsUseCaseWithTracing) UpdateSettings(ctx context.Context, settings models.AppSettings) (*models.AppSettings, error) {
+	ctx, span := tracing.Start(ctx, "SettingsUseCase.UpdateSettings")
+	updated, err := uc.next.UpdateSettings(ctx, settings)
+	if updated != nil {
+		span.SetAttribute("settings.schema_version", updated.SchemaVersion)
+	}
+	span.Finish(err)
+	return updated, err
+}
+
+// timeTrackingUseCaseWithTracing оборачивает вызовы TimeTrackingUseCase span'ами
+type timeTrackingUseCaseWithTracing struct {
+	next TimeTrackingUseCase
+}
+
+// NewTimeTrackingUseCaseWithTracing оборачивает TimeTrackingUseCase трассировкой
+func NewTimeTrackingUseCaseWithTracing(next TimeTrackingUseCase) TimeTrackingUseCase {
+	return &timeTrackingUseCaseWithTracing{next: next}
+}
+
+func (uc *timeTrackingUseCaseWithTracing) StartTimer(ctx context.Context, req models.StartTimerRequest) (*models.TimeEntry, error) {
+	ctx, span := tracing.Start(ctx, "TimeTrackingUseCase.StartTimer")
+	span.SetAttribute("task.id", req.TaskID)
+	entry, err := uc.next.StartTimer(ctx, req)
+	span.Finish(err)
+	return entry, err
+}
+
+func (uc *timeTrackingUseCaseWithTracing) StopTimer(ctx context.Context) (*models.TimeEntry, error) {
+	ctx, span := tracing.Start(ctx, "TimeTrackingUseCase.StopTimer")
+	entry, err := uc.next.StopTimer(ctx)
+	if entry != nil {
+		span.SetAttributes(map[string]interface{}{"task.id": entry.TaskID, "duration_seconds": entry.DurationSeconds})
+	}
+	span.Finish(err)
+	return entry, err
+}
+
+func (uc *timeTrackingUseCaseWithTracing) GetActiveTimer(ctx context.Context) (*models.TimeEntry, error) {
+	ctx, span := tracing.Start(ctx, "TimeTrackingUseCase.GetActiveTimer")
+	entry, err := uc.next.GetActiveTimer(ctx)
+	span.SetAttribute("running", entry != nil)
+	span.Finish(err)
+	return entry, err
+}
+
+func (uc *timeTrackingUseCaseWithTracing) AddTimeEntry(ctx context.Context, req models.CreateTimeEntryRequest) (*models.TimeEntry, error) {
+	ctx, span := tracing.Start(ctx, "TimeTrackingUseCase.AddTimeEntry")
+	span.SetAttribute("task.id", req.TaskID)
+	entry, err := uc.next.AddTimeEntry(ctx, req)
+	span.Finish(err)
+	return entry, err
+}
+
+func (uc *timeTrackingUseCaseWithTracing) DeleteTimeEntry(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "TimeTrackingUseCase.DeleteTimeEntry")
+	span.SetAttribute("time_entry.id", id)
+	err := uc.next.DeleteTimeEntry(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (uc *timeTrackingUseCaseWithTracing) GetTaskTimeEntries(ctx context.Context, taskID int) ([]*models.TimeEntry, error) {
+	ctx, span := tracing.Start(ctx, "TimeTrackingUseCase.GetTaskTimeEntries")
+	span.SetAttribute("task.id", taskID)
+	entries, err := uc.next.GetTaskTimeEntries(ctx, taskID)
+	span.SetAttribute("rows", len(entries))
+	span.Finish(err)
+	return entries, err
+}
+
+func (uc *timeTrackingUseCaseWithTracing) GetTimeReport(ctx context.Context, req models.TimeReportRequest) (*models.TimeReport, error) {
+	ctx, span := tracing.Start(ctx, "TimeTrackingUseCase.GetTimeReport")
+	span.SetAttribute("group_by", string(req.GroupBy))
+	report, err := uc.next.GetTimeReport(ctx, req)
+	if report != nil {
+		span.SetAttribute("rows", len(report.Totals))
+	}
+	span.Finish(err)
+	return report, err
+}
+
+// commentUseCaseWithTracing оборачивает вызовы CommentUseCase span'ами
+type commentUseCaseWithTracing struct {
+	next CommentUseCase
+}
+
+// NewCommentUseCaseWithTracing оборачивает CommentUseCase трассировкой
+func NewCommentUseCaseWithTracing(next CommentUseCase) CommentUseCase {
+	return &commentUseCaseWithTracing{next: next}
+}
+
+func (uc *commentUseCaseWithTracing) AddComment(ctx context.Context, req models.CreateCommentRequest) (*models.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentUseCase.AddComment")
+	span.SetAttribute("task.id", req.TaskID)
+	comment, err := uc.next.AddComment(ctx, req)
+	if comment != nil {
+		span.SetAttribute("comment.id", comment.ID)
+	}
+	span.Finish(err)
+	return comment, err
+}
+
+func (uc *commentUseCaseWithTracing) UpdateComment(ctx context.Context, req models.UpdateCommentRequest) (*models.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentUseCase.UpdateComment")
+	span.SetAttribute("comment.id", req.ID)
+	comment, err := uc.next.UpdateComment(ctx, req)
+	span.Finish(err)
+	return comment, err
+}
+
+func (uc *commentUseCaseWithTracing) DeleteComment(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "CommentUseCase.DeleteComment")
+	span.SetAttribute("comment.id", id)
+	err := uc.next.DeleteComment(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (uc *commentUseCaseWithTracing) GetTaskComments(ctx context.Context, taskID int, order models.SortOrder) ([]*models.Comment, error) {
+	ctx, span := tracing.Start(ctx, "CommentUseCase.GetTaskComments")
+	span.SetAttributes(map[string]interface{}{"task.id": taskID, "order": string(order)})
+	comments, err := uc.next.GetTaskComments(ctx, taskID, order)
+	span.SetAttribute("rows", len(comments))
+	span.Finish(err)
+	return comments, err
+}
+
+func (uc *commentUseCaseWithTracing) GetCommentRevisions(ctx context.Context, id int64) ([]*models.CommentRevision, error) {
+	ctx, span := tracing.Start(ctx, "CommentUseCase.GetCommentRevisions")
+	span.SetAttribute("comment.id", id)
+	revisions, err := uc.next.GetCommentRevisions(ctx, id)
+	span.SetAttribute("rows", len(revisions))
+	span.Finish(err)
+	return revisions, err
+}
+
+func (uc *commentUseCaseWithTracing) GetTaskHistory(ctx context.Context, taskID int, limit int) ([]*models.TaskHistoryEntry, error) {
+	ctx, span := tracing.Start(ctx, "CommentUseCase.GetTaskHistory")
+	span.SetAttributes(map[string]interface{}{"task.id": taskID, "limit": limit})
+	history, err := uc.next.GetTaskHistory(ctx, taskID, limit)
+	span.SetAttribute("rows", len(history))
+	span.Finish(err)
+	return history, err
+}
+
+// attachmentUseCaseWithTracing оборачивает вызовы AttachmentUseCase span'ами
+type attachmentUseCaseWithTracing struct {
+	next AttachmentUseCase
+}
+
+// NewAttachmentUseCaseWithTracing оборачивает AttachmentUseCase трассировкой
+func NewAttachmentUseCaseWithTracing(next AttachmentUseCase) AttachmentUseCase {
+	return &attachmentUseCaseWithTracing{next: next}
+}
+
+func (uc *attachmentUseCaseWithTracing) AddAttachment(ctx context.Context, req models.CreateAttachmentRequest, data []byte) (*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUseCase.AddAttachment")
+	span.SetAttributes(map[string]interface{}{"task.id": req.TaskID, "size": len(data)})
+	attachment, err := uc.next.AddAttachment(ctx, req, data)
+	if attachment != nil {
+		span.SetAttribute("attachment.id", attachment.ID)
+	}
+	span.Finish(err)
+	return attachment, err
+}
+
+func (uc *attachmentUseCaseWithTracing) AddAttachmentFromFile(ctx context.Context, taskID int, path string) (*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUseCase.AddAttachmentFromFile")
+	span.SetAttribute("task.id", taskID)
+	attachment, err := uc.next.AddAttachmentFromFile(ctx, taskID, path)
+	if attachment != nil {
+		span.SetAttributes(map[string]interface{}{"attachment.id": attachment.ID, "size": attachment.Size})
+	}
+	span.Finish(err)
+	return attachment, err
+}
+
+func (uc *attachmentUseCaseWithTracing) DeleteAttachment(ctx context.Context, id int64) error {
+	ctx, span := tracing.Start(ctx, "AttachmentUseCase.DeleteAttachment")
+	span.SetAttribute("attachment.id", id)
+	err := uc.next.DeleteAttachment(ctx, id)
+	span.Finish(err)
+	return err
+}
+
+func (uc *attachmentUseCaseWithTracing) GetTaskAttachments(ctx context.Context, taskID int) ([]*models.Attachment, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUseCase.GetTaskAttachments")
+	span.SetAttribute("task.id", taskID)
+	attachments, err := uc.next.GetTaskAttachments(ctx, taskID)
+	span.SetAttribute("rows", len(attachments))
+	span.Finish(err)
+	return attachments, err
+}
+
+func (uc *attachmentUseCaseWithTracing) GetAttachmentContent(ctx context.Context, id int64) (*models.AttachmentContent, error) {
+	ctx, span := tracing.Start(ctx, "AttachmentUseCase.GetAttachmentContent")
+	span.SetAttribute("attachment.id", id)
+	content, err := uc.next.GetAttachmentContent(ctx, id)
+	span.Finish(err)
+	return content, err
+}
@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"todo-app/app/models"
+	"todo-app/app/services"
+)
+
+// WebhookUseCaseImpl реализует интерфейс WebhookUseCase
+type WebhookUseCaseImpl struct {
+	webhookService services.WebhookService
+}
+
+// NewWebhookUseCase создает новый экземпляр WebhookUseCase
+func NewWebhookUseCase(webhookService services.WebhookService) WebhookUseCase {
+	return &WebhookUseCaseImpl{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhook создает подписку на события задач
+func (uc *WebhookUseCaseImpl) CreateWebhook(ctx context.Context, req models.CreateWebhookRequest) (*models.CreateWebhookResponse, error) {
+	response, err := uc.webhookService.CreateWebhook(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return response, nil
+}
+
+// GetWebhooks получает все подписки
+func (uc *WebhookUseCaseImpl) GetWebhooks(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	return uc.webhookService.GetWebhooks(ctx)
+}
+
+// SetWebhookActive включает или отключает подписку
+func (uc *WebhookUseCaseImpl) SetWebhookActive(ctx context.Context, id int, active bool) error {
+	return uc.webhookService.SetWebhookActive(ctx, id, active)
+}
+
+// DeleteWebhook удаляет подписку
+func (uc *WebhookUseCaseImpl) DeleteWebhook(ctx context.Context, id int) error {
+	return uc.webhookService.DeleteWebhook(ctx, id)
+}
+
+// GetWebhookDeliveries получает журнал доставок с ограничением размера
+func (uc *WebhookUseCaseImpl) GetWebhookDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*models.WebhookDelivery, error) {
+	// Бизнес-правило: журнал отдается порциями не более 100 записей
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	return uc.webhookService.GetWebhookDeliveries(ctx, subscriptionID, limit)
+}
+
+// GetDeliveryAttempts получает журнал попыток доставки
+func (uc *WebhookUseCaseImpl) GetDeliveryAttempts(ctx context.Context, deliveryID int64) ([]*models.WebhookAttempt, error) {
+	return uc.webhookService.GetDeliveryAttempts(ctx, deliveryID)
+}
+
+// RetryDelivery повторяет недоставленное событие
+func (uc *WebhookUseCaseImpl) RetryDelivery(ctx context.Context, deliveryID int64) error {
+	return uc.webhookService.RetryDelivery(ctx, deliveryID)
+}
@@ -0,0 +1,420 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+	"todo-app/app/events"
+	"todo-app/app/models"
+	"todo-app/app/repository"
+	"todo-app/internal/utils"
+)
+
+// ErrPrivateTarget возвращается при попытке доставки на localhost или в частную сеть
+var ErrPrivateTarget = errors.New("webhook target resolves to a private address")
+
+// Config содержит настройки доставки webhooks
+type Config struct {
+	MaxAttempts    int           // количество попыток до перевода в dead-letter
+	InitialBackoff time.Duration // задержка перед первым повтором
+	MaxBackoff     time.Duration // максимальная задержка между повторами
+	Timeout        time.Duration // таймаут одного HTTP запроса
+	PollInterval   time.Duration // интервал проверки очереди повторов
+	BatchSize      int           // максимальное количество доставок за один проход
+
+	AllowPrivateTargets bool // разрешить подключение к localhost и частным сетям
+}
+
+// Envelope представляет тело запроса webhook
+type Envelope struct {
+	ID         int64           `json:"id"` // ID события, стабилен между повторами
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Dispatcher ставит события в очередь доставки и отправляет их подписчикам
+type Dispatcher struct {
+	repo   repository.WebhookRepository
+	client *http.Client
+	config Config
+	logger *utils.Logger
+	now    func() time.Time
+
+	notify  chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	startMu sync.Mutex
+	running bool
+}
+
+// NewDispatcher создает диспетчер webhooks. Если client равен nil,
+// используется http.Client с таймаутом из конфигурации, который без
+// AllowPrivateTargets не подключается к localhost и частным сетям
+func NewDispatcher(repo repository.WebhookRepository, client *http.Client, config Config, logger *utils.Logger) *Dispatcher {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 8
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = 10 * time.Second
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = time.Hour
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 50
+	}
+	if client == nil {
+		client = newClient(config)
+	}
+	if logger == nil {
+		logger = utils.DefaultLogger()
+	}
+
+	return &Dispatcher{
+		repo:   repo,
+		client: client,
+		config: config,
+		logger: logger,
+		now:    time.Now,
+		notify: make(chan struct{}, 1),
+	}
+}
+
+// newClient создает HTTP клиент доставки. Адрес проверяется после резолвинга
+// имени при каждом подключении, в том числе после редиректа, поэтому имя,
+// указывающее на локальный адрес, не обходит проверку подписки
+func newClient(config Config) *http.Client {
+	dialer := &net.Dialer{Timeout: config.Timeout}
+	if !config.AllowPrivateTargets {
+		dialer.Control = func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || utils.IsPrivateIP(ip) {
+				return fmt.Errorf("%w: %s", ErrPrivateTarget, address)
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: config.Timeout, Transport: transport}
+}
+
+// Attach подписывает диспетчер на все события шины. Подписка синхронная:
+// доставки ставятся в очередь до того, как outbox отметит событие опубликованным,
+// а ошибка HandleEvent оставляет событие в outbox для повторной публикации
+func (d *Dispatcher) Attach(bus *events.Bus) func() {
+	return bus.Subscribe(events.AllEvents, d.HandleEvent)
+}
+
+// HandleEvent создает доставки события для подходящих подписок. Повторный вызов
+// для того же события безопасен: CreateDelivery не дублирует доставки
+func (d *Dispatcher) HandleEvent(ctx context.Context, event events.Event) error {
+	subscriptions, err := d.repo.GetActiveSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load webhook subscriptions: %w", err)
+	}
+
+	var payload []byte
+	queued := 0
+
+	for _, subscription := range subscriptions {
+		if !d.matches(subscription, event) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = buildEnvelope(event); err != nil {
+				return err
+			}
+		}
+
+		created, err := d.repo.CreateDelivery(ctx, &models.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        event.Meta().ID,
+			EventType:      string(event.Type()),
+			Payload:        payload,
+			NextAttemptAt:  d.now(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to queue webhook delivery: %w", err)
+		}
+		if created {
+			queued++
+		}
+	}
+
+	if queued > 0 {
+		d.Notify()
+	}
+
+	return nil
+}
+
+// matches проверяет тип события и предикат подписки
+func (d *Dispatcher) matches(subscription *models.WebhookSubscription, event events.Event) bool {
+	if !subscription.HandlesEvent(string(event.Type())) {
+		return false
+	}
+
+	if subscription.Filter == nil {
+		return true
+	}
+
+	taskEvent, ok := event.(events.TaskEvent)
+	if !ok {
+		return false
+	}
+
+	_, task := taskEvent.Subject()
+	return subscription.Filter.Matches(task, d.now())
+}
+
+// buildEnvelope сериализует событие в тело запроса
+func buildEnvelope(event events.Event) ([]byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s event: %w", event.Type(), err)
+	}
+
+	return json.Marshal(Envelope{
+		ID:         event.Meta().ID,
+		Type:       string(event.Type()),
+		OccurredAt: event.Meta().OccurredAt,
+		Data:       data,
+	})
+}
+
+// Notify запускает внеочередную обработку очереди
+func (d *Dispatcher) Notify() {
+	select {
+	case d.notify <- struct{}{}:
+	default:
+	}
+}
+
+// Start запускает фоновую доставку
+func (d *Dispatcher) Start() {
+	d.startMu.Lock()
+	defer d.startMu.Unlock()
+
+	if d.running {
+		return
+	}
+	d.running = true
+	d.stop = make(chan struct{})
+	d.done = make(chan struct{})
+
+	go d.run()
+	d.Notify()
+}
+
+// Stop останавливает доставку, дожидаясь завершения текущего прохода
+func (d *Dispatcher) Stop() {
+	d.startMu.Lock()
+	defer d.startMu.Unlock()
+
+	if !d.running {
+		return
+	}
+	d.running = false
+
+	close(d.stop)
+	<-d.done
+}
+
+// Running сообщает, запущена ли фоновая доставка
+func (d *Dispatcher) Running() bool {
+	d.startMu.Lock()
+	defer d.startMu.Unlock()
+	return d.running
+}
+
+// run основной цикл доставки
+func (d *Dispatcher) run() {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		case <-d.notify:
+		case <-ticker.C:
+		}
+
+		if err := d.ProcessDue(context.Background()); err != nil {
+			d.logger.Error("Failed to process webhook deliveries", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
+}
+
+// ProcessDue отправляет все доставки, время попытки которых наступило
+func (d *Dispatcher) ProcessDue(ctx context.Context) error {
+	deliveries, err := d.repo.GetDueDeliveries(ctx, d.now(), d.config.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	subscriptions := make(map[int]*models.WebhookSubscription)
+
+	// Ошибка одной доставки не останавливает обработку остальных
+	var errs []error
+	for _, delivery := range deliveries {
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = d.repo.GetSubscriptionByID(ctx, delivery.SubscriptionID)
+			switch {
+			case errors.Is(err, utils.ErrNotFound) || (err == nil && subscription == nil):
+				errs = append(errs, d.deadLetter(ctx, delivery, "subscription not found"))
+				continue
+			case err != nil:
+				errs = append(errs, fmt.Errorf("failed to load webhook subscription %d: %w", delivery.SubscriptionID, err))
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		if err := d.Deliver(ctx, subscription, delivery); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deadLetter переводит доставку, которую невозможно выполнить, в dead-letter
+func (d *Dispatcher) deadLetter(ctx context.Context, delivery *models.WebhookDelivery, reason string) error {
+	attempt := &models.WebhookAttempt{
+		Attempt:   delivery.Attempts + 1,
+		CreatedAt: d.now(),
+		Error:     reason,
+	}
+
+	delivery.Attempts = attempt.Attempt
+	delivery.LastStatusCode = 0
+	delivery.LastError = reason
+	delivery.Status = models.WebhookDeliveryDead
+
+	d.logger.Warn("Webhook delivery moved to dead-letter", map[string]interface{}{
+		"delivery_id":     delivery.ID,
+		"subscription_id": delivery.SubscriptionID,
+		"error":           reason,
+	})
+
+	return d.repo.RecordAttempt(ctx, delivery, attempt)
+}
+
+// Deliver выполняет одну попытку доставки и сохраняет ее результат
+func (d *Dispatcher) Deliver(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery) error {
+	attempt := &models.WebhookAttempt{
+		Attempt:   delivery.Attempts + 1,
+		CreatedAt: d.now(),
+	}
+
+	if subscription.Active {
+		d.send(ctx, subscription, delivery, attempt)
+	} else {
+		attempt.Error = "subscription is disabled"
+	}
+
+	delivery.Attempts = attempt.Attempt
+	delivery.LastStatusCode = attempt.StatusCode
+	delivery.LastError = attempt.Error
+
+	switch {
+	case attempt.Succeeded():
+		deliveredAt := d.now()
+		delivery.Status = models.WebhookDeliveryDelivered
+		delivery.DeliveredAt = &deliveredAt
+	case !subscription.Active || delivery.Attempts >= d.config.MaxAttempts:
+		delivery.Status = models.WebhookDeliveryDead
+		d.logger.Warn("Webhook delivery moved to dead-letter", map[string]interface{}{
+			"delivery_id":     delivery.ID,
+			"subscription_id": subscription.ID,
+			"attempts":        delivery.Attempts,
+			"error":           attempt.Error,
+		})
+	default:
+		delivery.Status = models.WebhookDeliveryPending
+		delivery.NextAttemptAt = d.now().Add(d.Backoff(delivery.Attempts))
+	}
+
+	return d.repo.RecordAttempt(ctx, delivery, attempt)
+}
+
+// send отправляет подписанный запрос и заполняет результат попытки
+func (d *Dispatcher) send(ctx context.Context, subscription *models.WebhookSubscription, delivery *models.WebhookDelivery, attempt *models.WebhookAttempt) {
+	ctx, cancel := context.WithTimeout(ctx, d.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Error = err.Error()
+		return
+	}
+
+	timestamp := d.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "todo-app-webhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, delivery.Payload))
+
+	start := time.Now()
+	resp, err := d.client.Do(req)
+	attempt.DurationMs = time.Since(start).Milliseconds()
+
+	if err != nil {
+		attempt.Error = err.Error()
+		d.logger.LogAPICall("webhook", subscription.URL, http.MethodPost, 0, time.Since(start), err)
+		return
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	attempt.StatusCode = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		attempt.Error = fmt.Sprintf("unexpected status code %d", resp.StatusCode)
+	}
+
+	d.logger.LogAPICall("webhook", subscription.URL, http.MethodPost, resp.StatusCode, time.Since(start), nil)
+}
+
+// Backoff возвращает задержку перед следующей попыткой: InitialBackoff * 2^(attempts-1),
+// но не более MaxBackoff
+func (d *Dispatcher) Backoff(attempts int) time.Duration {
+	delay := d.config.InitialBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= d.config.MaxBackoff {
+			return d.config.MaxBackoff
+		}
+	}
+	return delay
+}
@@ -0,0 +1,402 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+	"todo-app/app/events"
+	"todo-app/app/models"
+)
+
+// memoryRepository хранит подписки и доставки в памяти для тестов
+type memoryRepository struct {
+	mu            sync.Mutex
+	subscriptions map[int]*models.WebhookSubscription
+	deliveries    map[int64]*models.WebhookDelivery
+	attempts      []*models.WebhookAttempt
+	nextID        int64
+}
+
+func newMemoryRepository(subscriptions ...*models.WebhookSubscription) *memoryRepository {
+	repo := &memoryRepository{
+		subscriptions: make(map[int]*models.WebhookSubscription),
+		deliveries:    make(map[int64]*models.WebhookDelivery),
+	}
+	for _, s := range subscriptions {
+		repo.subscriptions[s.ID] = s
+	}
+	return repo
+}
+
+func (r *memoryRepository) CreateSubscription(ctx context.Context, s *models.WebhookSubscription) (*models.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s.ID = len(r.subscriptions) + 1
+	r.subscriptions[s.ID] = s
+	return s, nil
+}
+
+func (r *memoryRepository) GetSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	return r.GetActiveSubscriptions(ctx)
+}
+
+func (r *memoryRepository) GetActiveSubscriptions(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*models.WebhookSubscription
+	for _, s := range r.subscriptions {
+		if s.Active {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryRepository) GetSubscriptionByID(ctx context.Context, id int) (*models.WebhookSubscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.subscriptions[id], nil
+}
+
+func (r *memoryRepository) SetSubscriptionActive(ctx context.Context, id int, active bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subscriptions[id].Active = active
+	return nil
+}
+
+func (r *memoryRepository) DeleteSubscription(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.subscriptions, id)
+	return nil
+}
+
+func (r *memoryRepository) CreateDelivery(ctx context.Context, d *models.WebhookDelivery) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.deliveries {
+		if existing.SubscriptionID == d.SubscriptionID && existing.EventID == d.EventID {
+			return false, nil
+		}
+	}
+	r.nextID++
+	d.ID = r.nextID
+	d.Status = models.WebhookDeliveryPending
+	r.deliveries[d.ID] = d
+	return true, nil
+}
+
+func (r *memoryRepository) GetDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var result []*models.WebhookDelivery
+	for _, d := range r.deliveries {
+		if d.Status == models.WebhookDeliveryPending && !d.NextAttemptAt.After(now) {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryRepository) GetDeliveries(ctx context.Context, subscriptionID int, limit int) ([]*models.WebhookDelivery, error) {
+	return nil, nil
+}
+
+func (r *memoryRepository) GetDeliveryAttempts(ctx context.Context, deliveryID int64) ([]*models.WebhookAttempt, error) {
+	return nil, nil
+}
+
+func (r *memoryRepository) RecordAttempt(ctx context.Context, d *models.WebhookDelivery, a *models.WebhookAttempt) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts = append(r.attempts, a)
+	r.deliveries[d.ID] = d
+	return nil
+}
+
+func (r *memoryRepository) RequeueDelivery(ctx context.Context, id int64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries[id].Status = models.WebhookDeliveryPending
+	r.deliveries[id].NextAttemptAt = at
+	return nil
+}
+
+func (r *memoryRepository) CountDeliveries(ctx context.Context, status models.WebhookDeliveryStatus) (int, error) {
+	return 0, nil
+}
+
+// outboxEvent имитирует событие, доставленное из outbox с присвоенным ID
+func outboxEvent(t *testing.T, event events.Event, id int64) events.Event {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("Failed to marshal event: %v", err)
+	}
+	decoded, err := events.Decode(event.Type(), id, payload)
+	if err != nil {
+		t.Fatalf("Failed to decode event: %v", err)
+	}
+	return decoded
+}
+
+func TestDispatcher_DeliversSignedRequest(t *testing.T) {
+	const secret = "test-secret-0123456789"
+
+	received := make(chan *http.Request, 1)
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		received <- r
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	repo := newMemoryRepository(&models.WebhookSubscription{
+		ID:         1,
+		URL:        server.URL,
+		Secret:     secret,
+		EventTypes: []string{string(events.TypeTaskCreated)},
+		Filter:     &models.TaskFilter{Priority: models.PriorityHigh},
+		Active:     true,
+	})
+	dispatcher := NewDispatcher(repo, server.Client(), Config{}, nil)
+	ctx := context.Background()
+
+	// Задача со средним приоритетом не проходит фильтр подписки
+	low := outboxEvent(t, events.NewTaskCreated(&models.Task{ID: 1, Priority: models.PriorityMedium}), 10)
+	if err := dispatcher.HandleEvent(ctx, low); err != nil {
+		t.Fatalf("HandleEvent failed: %v", err)
+	}
+
+	// Событие другого типа не входит в подписку
+	completed := outboxEvent(t, events.NewTaskCompleted(&models.Task{ID: 2, Priority: models.PriorityHigh}), 11)
+	if err := dispatcher.HandleEvent(ctx, completed); err != nil {
+		t.Fatalf("HandleEvent failed: %v", err)
+	}
+
+	high := outboxEvent(t, events.NewTaskCreated(&models.Task{ID: 3, Title: "Urgent", Priority: models.PriorityHigh}), 12)
+	if err := dispatcher.HandleEvent(ctx, high); err != nil {
+		t.Fatalf("HandleEvent failed: %v", err)
+	}
+	// Повторная доставка того же события из outbox не создает дубликат
+	if err := dispatcher.HandleEvent(ctx, high); err != nil {
+		t.Fatalf("HandleEvent failed: %v", err)
+	}
+
+	if len(repo.deliveries) != 1 {
+		t.Fatalf("Expected 1 queued delivery, got %d", len(repo.deliveries))
+	}
+
+	if err := dispatcher.ProcessDue(ctx); err != nil {
+		t.Fatalf("ProcessDue failed: %v", err)
+	}
+
+	req := <-received
+	if req.Header.Get(HeaderEvent) != string(events.TypeTaskCreated) {
+		t.Errorf("Unexpected event header: %s", req.Header.Get(HeaderEvent))
+	}
+
+	err := Verify(secret, req.Header.Get(HeaderTimestamp), req.Header.Get(HeaderSignature), body, time.Minute, time.Now())
+	if err != nil {
+		t.Errorf("Signature verification failed: %v", err)
+	}
+
+	if err := Verify("wrong-secret", req.Header.Get(HeaderTimestamp), req.Header.Get(HeaderSignature), body, 0, time.Now()); err == nil {
+		t.Error("Expected verification with wrong secret to fail")
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		t.Fatalf("Failed to decode envelope: %v", err)
+	}
+	if envelope.ID != 12 || envelope.Type != string(events.TypeTaskCreated) {
+		t.Errorf("Unexpected envelope: %+v", envelope)
+	}
+
+	delivery := repo.deliveries[1]
+	if delivery.Status != models.WebhookDeliveryDelivered || delivery.Attempts != 1 || delivery.DeliveredAt == nil {
+		t.Errorf("Expected delivered status after 1 attempt, got %+v", delivery)
+	}
+}
+
+func TestDispatcher_RetriesWithBackoffAndDeadLetters(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	repo := newMemoryRepository(&models.WebhookSubscription{
+		ID:         1,
+		URL:        server.URL,
+		Secret:     "secret",
+		EventTypes: []string{"*"},
+		Active:     true,
+	})
+	dispatcher := NewDispatcher(repo, server.Client(), Config{
+		MaxAttempts:    3,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+	}, nil)
+
+	// Управляемые часы, чтобы не ждать реальных задержек
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	dispatcher.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	event := outboxEvent(t, events.NewTaskDeleted(&models.Task{ID: 1}), 1)
+	if err := dispatcher.HandleEvent(ctx, event); err != nil {
+		t.Fatalf("HandleEvent failed: %v", err)
+	}
+
+	expectedDelays := []time.Duration{time.Second, 2 * time.Second}
+	for i, delay := range expectedDelays {
+		if err := dispatcher.ProcessDue(ctx); err != nil {
+			t.Fatalf("ProcessDue failed: %v", err)
+		}
+
+		delivery := repo.deliveries[1]
+		if delivery.Status != models.WebhookDeliveryPending || delivery.Attempts != i+1 {
+			t.Fatalf("Expected pending delivery after attempt %d, got %+v", i+1, delivery)
+		}
+		if got := delivery.NextAttemptAt.Sub(now); got != delay {
+			t.Errorf("Expected backoff %s after attempt %d, got %s", delay, i+1, got)
+		}
+
+		// До наступления времени повтора доставка не выполняется
+		if err := dispatcher.ProcessDue(ctx); err != nil {
+			t.Fatalf("ProcessDue failed: %v", err)
+		}
+		if calls != i+1 {
+			t.Errorf("Expected %d calls before backoff elapsed, got %d", i+1, calls)
+		}
+
+		now = delivery.NextAttemptAt
+	}
+
+	if err := dispatcher.ProcessDue(ctx); err != nil {
+		t.Fatalf("ProcessDue failed: %v", err)
+	}
+
+	delivery := repo.deliveries[1]
+	if delivery.Status != models.WebhookDeliveryDead || delivery.Attempts != 3 || delivery.LastStatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected dead-letter after 3 attempts, got %+v", delivery)
+	}
+
+	if len(repo.attempts) != 3 {
+		t.Errorf("Expected 3 logged attempts, got %d", len(repo.attempts))
+	}
+}
+
+func TestDispatcher_BackoffIsCapped(t *testing.T) {
+	dispatcher := NewDispatcher(newMemoryRepository(), nil, Config{
+		InitialBackoff: time.Second,
+		MaxBackoff:     10 * time.Second,
+	}, nil)
+
+	cases := map[int]time.Duration{
+		1:  time.Second,
+		2:  2 * time.Second,
+		4:  8 * time.Second,
+		5:  10 * time.Second,
+		30: 10 * time.Second,
+	}
+
+	for attempts, expected := range cases {
+		if got := dispatcher.Backoff(attempts); got != expected {
+			t.Errorf("Backoff(%d) = %s, expected %s", attempts, got, expected)
+		}
+	}
+}
+
+// failingRepository не может поставить доставку в очередь
+type failingRepository struct {
+	*memoryRepository
+}
+
+func (r *failingRepository) CreateDelivery(ctx context.Context, d *models.WebhookDelivery) (bool, error) {
+	return false, errors.New("connection reset")
+}
+
+func TestDispatcher_EnqueueErrorReachesPublisher(t *testing.T) {
+	repo := &failingRepository{newMemoryRepository(&models.WebhookSubscription{
+		ID:         1,
+		URL:        "https://hooks.example.com",
+		EventTypes: []string{string(events.AllEvents)},
+		Active:     true,
+	})}
+
+	bus := events.NewBus(events.BusConfig{}, nil)
+	defer bus.Close()
+	NewDispatcher(repo, nil, Config{}, nil).Attach(bus)
+
+	// Ошибка возвращается outbox, и событие остается неопубликованным
+	event := outboxEvent(t, events.NewTaskCreated(&models.Task{ID: 1}), 1)
+	if err := bus.Publish(context.Background(), event); err == nil {
+		t.Fatal("Expected enqueue error from Publish")
+	}
+}
+
+func TestDispatcher_DefaultClientRejectsPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	// httptest слушает 127.0.0.1
+	if _, err := newClient(Config{Timeout: time.Second}).Get(server.URL); !errors.Is(err, ErrPrivateTarget) {
+		t.Errorf("Expected ErrPrivateTarget, got %v", err)
+	}
+
+	resp, err := newClient(Config{Timeout: time.Second, AllowPrivateTargets: true}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected request with AllowPrivateTargets, got %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestDispatcher_ProcessDueSkipsDeletedSubscription(t *testing.T) {
+	delivered := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		delivered <- struct{}{}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	repo := newMemoryRepository(&models.WebhookSubscription{ID: 2, URL: server.URL, Active: true})
+	dispatcher := NewDispatcher(repo, server.Client(), Config{}, nil)
+	ctx := context.Background()
+
+	// Доставка удаленной подписки стоит в очереди первой
+	for _, subscriptionID := range []int{1, 2} {
+		if _, err := repo.CreateDelivery(ctx, &models.WebhookDelivery{
+			SubscriptionID: subscriptionID,
+			EventID:        int64(subscriptionID),
+			Payload:        []byte(`{}`),
+			NextAttemptAt:  time.Now().Add(-time.Duration(3-subscriptionID) * time.Minute),
+		}); err != nil {
+			t.Fatalf("CreateDelivery failed: %v", err)
+		}
+	}
+
+	if err := dispatcher.ProcessDue(ctx); err != nil {
+		t.Fatalf("ProcessDue failed: %v", err)
+	}
+
+	select {
+	case <-delivered:
+	default:
+		t.Error("Expected delivery of the existing subscription")
+	}
+	if status := repo.deliveries[1].Status; status != models.WebhookDeliveryDead {
+		t.Errorf("Expected orphaned delivery in dead-letter, got %s", status)
+	}
+}
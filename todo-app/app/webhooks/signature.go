@@ -0,0 +1,69 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Заголовки исходящих запросов webhook
+const (
+	HeaderEvent     = "X-Todo-Event"     // тип события
+	HeaderDelivery  = "X-Todo-Delivery"  // ID доставки (одинаков для всех повторов)
+	HeaderTimestamp = "X-Todo-Timestamp" // Unix-время подписи в секундах
+	HeaderSignature = "X-Todo-Signature" // sha256=<hex HMAC-SHA256("timestamp.body")>
+)
+
+// signaturePrefix префикс значения заголовка подписи
+const signaturePrefix = "sha256="
+
+// Sign вычисляет подпись тела запроса. В подпись входит время отправки,
+// чтобы получатель мог отбрасывать повторно воспроизведенные запросы
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify проверяет подпись входящего запроса. Используется получателями
+// (и тестами) для проверки подлинности и свежести запроса; tolerance <= 0 отключает проверку времени
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration, now time.Time) error {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid signature timestamp: %w", err)
+	}
+
+	if tolerance > 0 {
+		age := now.Sub(time.Unix(ts, 0))
+		if age > tolerance || age < -tolerance {
+			return fmt.Errorf("signature timestamp is outside of tolerance")
+		}
+	}
+
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return fmt.Errorf("unsupported signature format")
+	}
+
+	expected := Sign(secret, ts, body)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return fmt.Errorf("signature mismatch")
+	}
+
+	return nil
+}
+
+// GenerateSecret создает случайный секрет подписи
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
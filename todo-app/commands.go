@@ -0,0 +1,294 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"todo-app/app/config"
+	"todo-app/app/diagnostics"
+	"todo-app/app/models"
+	"todo-app/app/reminders"
+	"todo-app/app/repository"
+	"todo-app/internal/utils"
+)
+
+// runCommand выполняет подкоманду и возвращает код завершения процесса
+func runCommand(flags *config.Flags, args []string) int {
+	switch args[0] {
+	case "config":
+		return runConfigCommand(flags, args[1:])
+	case "secret":
+		return runSecretCommand(args[1:])
+	case "doctor":
+		return runDoctorCommand(flags)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n", args[0])
+		printUsage()
+		return 2
+	}
+}
+
+// printUsage выводит список подкоманд
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: todo-app [--config FILE] [--set key=value]... [command]")
+	fmt.Fprintln(os.Stderr, "Commands:")
+	fmt.Fprintln(os.Stderr, "  config path               show config file location")
+	fmt.Fprintln(os.Stderr, "  config print              show defaults merged with config file")
+	fmt.Fprintln(os.Stderr, "  config print --effective  show config after env and flags")
+	fmt.Fprintln(os.Stderr, "  config validate           validate effective config")
+	fmt.Fprintln(os.Stderr, "  secret set NAME           store secret read from stdin (e.g. database.password)")
+	fmt.Fprintln(os.Stderr, "  secret delete NAME        remove stored secret")
+	fmt.Fprintln(os.Stderr, "  secret list               list stored secret names")
+	fmt.Fprintln(os.Stderr, "  doctor                    check configuration, database and environment")
+}
+
+// runConfigCommand обрабатывает "config path|print|validate"
+func runConfigCommand(flags *config.Flags, args []string) int {
+	if len(args) == 0 {
+		printUsage()
+		return 2
+	}
+
+	loader := config.NewLoader(flags)
+
+	switch args[0] {
+	case "path":
+		dir, err := config.DefaultConfigDir()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		if _, err := loader.LoadFile(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		fmt.Printf("Config dir: %s\n", dir)
+		if loader.File() != "" {
+			fmt.Printf("Config file: %s\n", loader.File())
+		} else {
+			fmt.Println("Config file: not found, using defaults")
+		}
+		return 0
+
+	case "print":
+		effective := len(args) > 1 && (args[1] == "--effective" || args[1] == "-effective")
+
+		var cfg *config.Config
+		var err error
+		if effective {
+			cfg, err = loader.Load()
+		} else {
+			cfg, err = loader.LoadFile()
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		if err := cfg.WriteYAML(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		if effective {
+			return reportValidation(cfg)
+		}
+		return 0
+
+	case "validate":
+		cfg, err := loader.Load()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+
+		if code := reportValidation(cfg); code != 0 {
+			return code
+		}
+		fmt.Println("Configuration is valid")
+		return 0
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown config command: %s\n", args[0])
+		printUsage()
+		return 2
+	}
+}
+
+// reportValidation выводит ошибки валидации по полям
+func reportValidation(cfg *config.Config) int {
+	err := cfg.Validate()
+	if err == nil {
+		return 0
+	}
+
+	var fieldErrs config.ValidationErrors
+	if !errors.As(err, &fieldErrs) {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	fmt.Fprintln(os.Stderr, "Invalid configuration:")
+	for _, fieldErr := range fieldErrs {
+		fmt.Fprintf(os.Stderr, "  %s\n", fieldErr)
+	}
+	return 1
+}
+
+// runSecretCommand обрабатывает "secret set|delete|list". Значение читается
+// из stdin, чтобы пароль не попадал в историю команд и список процессов
+func runSecretCommand(args []string) int {
+	if len(args) == 0 {
+		printUsage()
+		return 2
+	}
+
+	store, err := config.DefaultSecretStore()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	switch {
+	case args[0] == "set" && len(args) == 2:
+		fmt.Fprintf(os.Stderr, "Enter value for %s: ", args[1])
+		value, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && value == "" {
+			fmt.Fprintln(os.Stderr, "\nfailed to read secret value")
+			return 1
+		}
+		value = strings.TrimRight(value, "\r\n")
+		if value == "" {
+			fmt.Fprintln(os.Stderr, "\nsecret value cannot be empty")
+			return 1
+		}
+
+		if err := store.Set(args[1], value); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "\nSecret %s saved to %s\n", args[1], store.Path)
+		return 0
+
+	case args[0] == "delete" && len(args) == 2:
+		if err := store.Delete(args[1]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Printf("Secret %s deleted\n", args[1])
+		return 0
+
+	case args[0] == "list" && len(args) == 1:
+		names, err := store.Names()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return 0
+
+	default:
+		printUsage()
+		return 2
+	}
+}
+
+// runDoctorCommand проверяет конфигурацию, БД, миграции и окружение без запуска
+// приложения и выводит рекомендации по исправлению найденных проблем
+func runDoctorCommand(flags *config.Flags) int {
+	cfg, err := config.NewLoader(flags).Load()
+	if err != nil {
+		printCheck(models.DiagnosticCheck{
+			Name:    "config",
+			Status:  models.DiagnosticError,
+			Message: err.Error(),
+			Fix:     "fix the config file or flags (todo-app config path)",
+		})
+		return 1
+	}
+
+	var checks []models.DiagnosticCheck
+	var fieldErrs config.ValidationErrors
+	if err := cfg.Validate(); errors.As(err, &fieldErrs) {
+		for _, fieldErr := range fieldErrs {
+			checks = append(checks, models.DiagnosticCheck{
+				Name:    "config",
+				Status:  models.DiagnosticError,
+				Message: fieldErr.Error(),
+				Fix:     "fix " + fieldErr.Field + " in the config file, environment or --set",
+			})
+		}
+	} else {
+		checks = append(checks, models.DiagnosticCheck{Name: "config", Status: models.DiagnosticOK, Message: "valid"})
+	}
+
+	options := diagnostics.Options{Config: cfg}
+
+	conn, err := cfg.DatabaseConnection()
+	if err != nil {
+		checks = append(checks, models.DiagnosticCheck{
+			Name:    "credentials",
+			Status:  models.DiagnosticError,
+			Message: err.Error(),
+			Fix:     "check DB_PASSWORD_FILE, DATABASE_URL and the secret store (todo-app secret list)",
+		})
+	} else {
+		// Соединение проверяет отчет: ошибка ping определяет рекомендацию
+		db, err := sql.Open("postgres", conn.DSN())
+		if err == nil {
+			defer db.Close()
+			utils.ConfigurePool(db, conn)
+			options.DB = db
+			options.Reminders = reminders.NewScheduler(repository.NewPostgresReminderRepository(db), reminders.Config{
+				Enabled:   cfg.Reminders.Enabled,
+				LeadTime:  cfg.Reminders.LeadTime,
+				Interval:  cfg.Reminders.Interval,
+				BatchSize: cfg.Reminders.BatchSize,
+			}, nil)
+		}
+	}
+
+	report := diagnostics.NewDiagnostics(options).Report(context.Background())
+	checks = append(checks, report.Checks...)
+
+	build := report.Build
+	fmt.Printf("%s %s (%s), %s %s", cfg.App.Name, build.AppVersion, build.Environment, build.GoVersion, build.Platform)
+	if build.Revision != "" {
+		fmt.Printf(", revision %.12s", build.Revision)
+		if build.Modified {
+			fmt.Print(" (modified)")
+		}
+	}
+	fmt.Println()
+	fmt.Println()
+
+	failed := false
+	for _, check := range checks {
+		printCheck(check)
+		failed = failed || check.Status == models.DiagnosticError
+	}
+
+	fmt.Println()
+	if failed {
+		fmt.Println("Problems found")
+		return 1
+	}
+	fmt.Println("No problems found")
+	return 0
+}
+
+// printCheck выводит результат проверки и рекомендацию
+func printCheck(check models.DiagnosticCheck) {
+	fmt.Printf("%-9s %-20s %s\n", "["+string(check.Status)+"]", check.Name, check.Message)
+	if check.Fix != "" {
+		fmt.Printf("%-30s fix: %s\n", "", check.Fix)
+	}
+}
@@ -0,0 +1,5 @@
+-- Составные индексы для keyset-пагинации: поле сортировки + id как tie-breaker
+CREATE INDEX IF NOT EXISTS idx_tasks_created_at_id ON tasks(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_updated_at_id ON tasks(updated_at, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date_id ON tasks((COALESCE(due_date, 'infinity'::timestamp)), id);
+CREATE INDEX IF NOT EXISTS idx_tasks_title_id ON tasks(title, id);
@@ -0,0 +1,16 @@
+-- Уведомления об изменениях задач для LISTEN/NOTIFY (видны изменения любых процессов)
+CREATE OR REPLACE FUNCTION notify_task_change() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM pg_notify('task_changes', json_build_object('op', TG_OP, 'id', OLD.id)::text);
+    ELSE
+        PERFORM pg_notify('task_changes', json_build_object('op', TG_OP, 'id', NEW.id)::text);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tasks_notify_change ON tasks;
+CREATE TRIGGER tasks_notify_change
+    AFTER INSERT OR UPDATE OR DELETE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION notify_task_change();
@@ -0,0 +1,7 @@
+-- Отправленные напоминания о сроках задач. Напоминание привязано к сроку:
+-- при переносе due_date задача снова попадает в выборку
+CREATE TABLE IF NOT EXISTS task_reminders (
+    task_id INTEGER PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+    due_date TIMESTAMP NOT NULL,
+    sent_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
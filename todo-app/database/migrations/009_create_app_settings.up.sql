@@ -0,0 +1,8 @@
+-- Настройки приложения в виде пар ключ-значение: новая настройка добавляется
+-- без изменения схемы, отсутствующие ключи заменяются значениями по умолчанию.
+-- Ключ schema_version хранит версию набора ключей (models.SettingsSchemaVersion)
+CREATE TABLE IF NOT EXISTS app_settings (
+    key VARCHAR(64) PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_tasks_due_date_id;
+
+ALTER TABLE task_reminders ALTER COLUMN due_date TYPE TIMESTAMP USING due_date AT TIME ZONE 'UTC';
+ALTER TABLE tasks ALTER COLUMN due_date TYPE TIMESTAMP USING due_date AT TIME ZONE 'UTC';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date_id ON tasks((COALESCE(due_date, 'infinity'::timestamp)), id);
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_all_day;
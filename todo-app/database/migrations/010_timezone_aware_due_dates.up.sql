@@ -0,0 +1,15 @@
+-- Сроки задач с часовым поясом. До этой миграции срок из формы записывался
+-- полночью UTC в TIMESTAMP без пояса: существующие значения считаются UTC,
+-- а сроки ровно в полночь - сроками на весь день (дата без времени)
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_all_day BOOLEAN NOT NULL DEFAULT FALSE;
+
+UPDATE tasks SET due_all_day = TRUE
+WHERE due_date IS NOT NULL AND due_date = date_trunc('day', due_date);
+
+-- Индекс keyset-пагинации зависит от типа столбца и пересоздается
+DROP INDEX IF EXISTS idx_tasks_due_date_id;
+
+ALTER TABLE tasks ALTER COLUMN due_date TYPE TIMESTAMPTZ USING due_date AT TIME ZONE 'UTC';
+ALTER TABLE task_reminders ALTER COLUMN due_date TYPE TIMESTAMPTZ USING due_date AT TIME ZONE 'UTC';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due_date_id ON tasks((COALESCE(due_date, 'infinity'::timestamptz)), id);
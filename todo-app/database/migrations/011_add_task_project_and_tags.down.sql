@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tasks_tags;
+DROP INDEX IF EXISTS idx_tasks_project;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS tags;
+ALTER TABLE tasks DROP COLUMN IF EXISTS project;
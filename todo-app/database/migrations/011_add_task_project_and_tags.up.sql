@@ -0,0 +1,9 @@
+-- Проект и метки задачи (заполняются, например, быстрым добавлением)
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- Индекс для выборок по проекту
+CREATE INDEX IF NOT EXISTS idx_tasks_project ON tasks(project) WHERE project <> '';
+
+-- GIN индекс для поиска задач по метке
+CREATE INDEX IF NOT EXISTS idx_tasks_tags ON tasks USING GIN(tags);
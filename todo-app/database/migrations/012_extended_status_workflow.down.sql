@@ -0,0 +1,11 @@
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_status_check;
+
+UPDATE tasks SET status = 'completed', completed_at = COALESCE(completed_at, cancelled_at)
+WHERE status IN ('done', 'cancelled');
+UPDATE tasks SET status = 'active' WHERE status IN ('todo', 'in_progress', 'waiting');
+
+ALTER TABLE tasks ALTER COLUMN status SET DEFAULT 'active';
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_check CHECK (status IN ('active', 'completed'));
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS cancelled_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS started_at;
@@ -0,0 +1,13 @@
+-- Расширенный workflow статусов: todo, in_progress, waiting, done, cancelled.
+-- Существующие активные задачи переходят в todo, выполненные - в done
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_status_check;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS started_at TIMESTAMPTZ NULL;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ NULL;
+
+UPDATE tasks SET status = 'todo' WHERE status = 'active';
+UPDATE tasks SET status = 'done' WHERE status = 'completed';
+
+ALTER TABLE tasks ALTER COLUMN status SET DEFAULT 'todo';
+ALTER TABLE tasks ADD CONSTRAINT tasks_status_check
+    CHECK (status IN ('todo', 'in_progress', 'waiting', 'done', 'cancelled'));
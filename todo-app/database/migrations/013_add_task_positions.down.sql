@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_tasks_board_position;
+DROP INDEX IF EXISTS idx_tasks_position;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS board_position;
+ALTER TABLE tasks DROP COLUMN IF EXISTS position;
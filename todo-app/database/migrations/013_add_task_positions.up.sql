@@ -0,0 +1,18 @@
+-- Ручной порядок задач: position - в общем списке, board_position - в колонке
+-- статуса на доске. Позиции разреженные: между соседями вставляется середина,
+-- при исчерпании зазора позиции перенумеровываются с шагом 1024
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS position DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS board_position DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+-- Начальный порядок совпадает с сортировкой по умолчанию: новые задачи сверху
+UPDATE tasks t SET position = r.rn * 1024, board_position = r.board_rn * 1024
+FROM (
+    SELECT id,
+           ROW_NUMBER() OVER (ORDER BY created_at DESC, id DESC) AS rn,
+           ROW_NUMBER() OVER (PARTITION BY status ORDER BY created_at DESC, id DESC) AS board_rn
+    FROM tasks
+) r
+WHERE t.id = r.id;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_position ON tasks(position, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_board_position ON tasks(status, board_position, id);
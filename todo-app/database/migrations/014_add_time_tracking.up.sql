@@ -0,0 +1,20 @@
+-- Учет времени: оценка задачи в минутах и отрезки затраченного времени.
+-- Запущенный таймер - запись без ended_at; он хранится в БД и переживает
+-- перезапуск приложения. Частичный уникальный индекс допускает только один таймер
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimate_minutes INTEGER
+    CHECK (estimate_minutes IS NULL OR estimate_minutes > 0);
+
+CREATE TABLE IF NOT EXISTS time_entries (
+    id BIGSERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ,
+    note TEXT NOT NULL DEFAULT '',
+    manual BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT time_entries_range_check CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_active ON time_entries ((TRUE)) WHERE ended_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_time_entries_task ON time_entries(task_id, started_at);
+CREATE INDEX IF NOT EXISTS idx_time_entries_started_at ON time_entries(started_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_event_outbox_task;
+DROP TABLE IF EXISTS task_comment_revisions;
+DROP TABLE IF EXISTS task_comments;
@@ -0,0 +1,26 @@
+-- Комментарии (заметки) к задаче с телом в Markdown. Предыдущие версии
+-- тела при редактировании сохраняются в task_comment_revisions
+CREATE TABLE IF NOT EXISTS task_comments (
+    id BIGSERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    body TEXT NOT NULL CHECK (length(btrim(body)) > 0),
+    edit_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_comments_task ON task_comments(task_id, created_at, id);
+
+-- created_at ревизии - момент, с которого действовала эта версия тела
+CREATE TABLE IF NOT EXISTS task_comment_revisions (
+    id BIGSERIAL PRIMARY KEY,
+    comment_id BIGINT NOT NULL REFERENCES task_comments(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    replaced_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_comment_revisions_comment ON task_comment_revisions(comment_id, id);
+
+-- История задачи читается из outbox по task_id события
+CREATE INDEX IF NOT EXISTS idx_event_outbox_task ON event_outbox ((payload->>'task_id'), id);
@@ -0,0 +1,15 @@
+-- Вложения задач. Содержимое хранится в каталоге вложений по SHA-256
+-- (одинаковые файлы - один раз), здесь только метаданные и ссылка на хэш.
+-- Содержимое без ссылок удаляет сборщик мусора приложения
+CREATE TABLE IF NOT EXISTS attachments (
+    id BIGSERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    file_name TEXT NOT NULL CHECK (length(btrim(file_name)) > 0),
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    sha256 CHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_task ON attachments(task_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_attachments_sha256 ON attachments(sha256);
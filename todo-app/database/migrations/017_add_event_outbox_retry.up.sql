@@ -0,0 +1,3 @@
+-- Повтор публикации событий, подписчик которых завершился с ошибкой.
+-- NULL - событие можно публиковать сразу
+ALTER TABLE event_outbox ADD COLUMN IF NOT EXISTS next_attempt_at TIMESTAMP NULL;
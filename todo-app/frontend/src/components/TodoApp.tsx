@@ -1,4 +1,4 @@
-import React, { useState, useEffect } from 'react';
+import React, { useState, useEffect, useRef } from 'react';
 import { Plus, Search, Filter, SortAsc, List, Calendar as CalendarIcon } from 'lucide-react';
 import { Button } from '@/components/ui/button';
 import { Input } from '@/components/ui/input';
@@ -11,8 +11,13 @@ import { TaskItem } from './TaskItem';
 import { ThemeToggle } from './ThemeToggle';
 import { WeekView } from './WeekView';
 import { cn } from '@/lib/utils';
-import { CreateTask, GetAllTasks, DeleteTask, ToggleTaskStatus, UpdateTask } from '../../wailsjs/go/main/App';
+import { isApiError, unwrap } from '@/lib/api';
+import { CreateTask, GetTaskSync, DeleteTask, ToggleTaskStatus, UpdateTask } from '../../wailsjs/go/main/App';
 import { models } from '../../wailsjs/go/models';
+import { EventsOn } from '../../wailsjs/runtime/runtime';
+
+// Wails event carrying a models.TaskChangeBatch (see TaskChangesEvent in app.go)
+const TASK_CHANGES_EVENT = 'tasks:changed';
 
 export interface Task {
   id: string;
@@ -48,35 +53,84 @@ export const TodoApp: React.FC = () => {
   const mapBackendTask = (backendTask: models.Task): Task => ({
     id: backendTask.id.toString(),
     title: backendTask.title,
-    completed: backendTask.status === 'completed',
+    completed: backendTask.status === 'done' || backendTask.status === 'cancelled',
     createdAt: new Date(backendTask.created_at),
     deadline: backendTask.due_date ? new Date(backendTask.due_date) : new Date(),
     priority: backendTask.priority as 'low' | 'medium' | 'high',
     archived: backendTask.archived || false,
   });
 
-  // Load tasks from backend on component mount
+  // Sequence number of the last change batch applied to the task list
+  const lastSeqRef = useRef(0);
+
+  // Load tasks from backend on mount and keep them in sync with live updates
   useEffect(() => {
-    const loadTasks = async () => {
+    let cancelled = false;
+
+    // Full resync: replace the list with a snapshot and remember its sequence number
+    const resync = async () => {
       try {
-        const backendTasks = await GetAllTasks();
-        const mappedTasks = backendTasks.map(mapBackendTask);
-        setTasks(mappedTasks);
+        const snapshot = await unwrap<models.TaskSyncSnapshot>(GetTaskSync());
+        if (cancelled) return;
+        lastSeqRef.current = snapshot.seq;
+        setTasks((snapshot.tasks || []).map(mapBackendTask));
       } catch (error) {
         console.error('Failed to load tasks from backend:', error);
-        // Set empty array if backend fails
-        setTasks([]);
+        if (!cancelled) setTasks([]);
       }
     };
-    
-    loadTasks();
+
+    const applyBatch = (batch: models.TaskChangeBatch) => {
+      // Batches up to the snapshot sequence are already reflected in the list
+      if (batch.seq <= lastSeqRef.current) return;
+
+      // A gap means we missed a batch; the backend asks for resync when it may have missed changes
+      if (batch.resync || batch.seq !== lastSeqRef.current + 1) {
+        resync();
+        return;
+      }
+
+      lastSeqRef.current = batch.seq;
+      setTasks(prev => {
+        let next = prev;
+        for (const change of batch.changes || []) {
+          const id = change.task_id.toString();
+          if (change.kind === 'removed' || !change.task) {
+            next = next.filter(task => task.id !== id);
+            continue;
+          }
+          const mapped = mapBackendTask(change.task);
+          next = next.some(task => task.id === id)
+            ? next.map(task => (task.id === id ? mapped : task))
+            : [mapped, ...next];
+        }
+        return next;
+      });
+    };
+
+    // Resync when the window comes back, since events may have been missed meanwhile
+    const handleReconnect = () => {
+      if (document.visibilityState === 'visible') resync();
+    };
+
+    const unsubscribe = EventsOn(TASK_CHANGES_EVENT, applyBatch);
+    window.addEventListener('online', handleReconnect);
+    document.addEventListener('visibilitychange', handleReconnect);
+    resync();
+
+    return () => {
+      cancelled = true;
+      unsubscribe();
+      window.removeEventListener('online', handleReconnect);
+      document.removeEventListener('visibilitychange', handleReconnect);
+    };
   }, []);
 
     const addTask = async () => {
     if (newTaskTitle.trim()) {
       try {
         const deadlineStr = newTaskDeadline.toISOString().split('T')[0]; // Format as YYYY-MM-DD
-        const createdTask = await CreateTask(newTaskTitle.trim(), '', newTaskPriority, deadlineStr);
+        const createdTask = await unwrap<models.Task>(CreateTask(newTaskTitle.trim(), '', newTaskPriority, deadlineStr));
         const newTask = mapBackendTask(createdTask);
         setTasks(prev => [newTask, ...prev]);
         setNewTaskTitle('');
@@ -84,7 +138,9 @@ export const TodoApp: React.FC = () => {
         setNewTaskPriority('medium'); // Reset to default
       } catch (error) {
         console.error('Failed to create task:', error);
-        // Fallback to local creation if backend fails
+        // The backend rejected the task (validation, conflict): keep the form as is
+        if (isApiError(error)) return;
+        // Fallback to local creation if backend is unreachable
         const newTask: Task = {
           id: Date.now().toString(),
           title: newTaskTitle.trim(),
@@ -104,7 +160,7 @@ export const TodoApp: React.FC = () => {
 
   const toggleTask = async (id: string) => {
     try {
-      const updatedTask = await ToggleTaskStatus(parseInt(id));
+      const updatedTask = await unwrap<models.Task>(ToggleTaskStatus(parseInt(id)));
       const mappedTask = mapBackendTask(updatedTask);
       setTasks(prev =>
         prev.map(task =>
@@ -113,7 +169,9 @@ export const TodoApp: React.FC = () => {
       );
     } catch (error) {
       console.error('Failed to toggle task status:', error);
-      // Fallback to local update if backend fails
+      // A typed error means the backend answered; the task may no longer exist
+      if (isApiError(error)) return;
+      // Fallback to local update if backend is unreachable
       setTasks(prev =>
         prev.map(task =>
           task.id === id ? { ...task, completed: !task.completed } : task
@@ -136,7 +194,7 @@ export const TodoApp: React.FC = () => {
     if (!taskToDelete) return;
     
     try {
-      await DeleteTask(parseInt(taskToDelete.id));
+      await unwrap<void>(DeleteTask(parseInt(taskToDelete.id)));
       setTasks(prev => prev.filter(task => task.id !== taskToDelete.id));
     } catch (error) {
       console.error('Failed to delete task:', error);
@@ -0,0 +1,64 @@
+// Every Wails binding resolves with a utils.StandardResponse envelope instead of
+// rejecting the promise. unwrap turns it back into data or a typed ApiError.
+
+export type ApiErrorType =
+  | 'VALIDATION_ERROR'
+  | 'NOT_FOUND'
+  | 'UNAUTHORIZED'
+  | 'FORBIDDEN'
+  | 'CONFLICT'
+  | 'INTERNAL_ERROR'
+  | 'DATABASE_ERROR'
+  | 'EXTERNAL_SERVICE_ERROR'
+  | 'BAD_REQUEST'
+  | 'TIMEOUT'
+  | 'RATE_LIMITED';
+
+export interface FieldError {
+  field: string;
+  tag: string;
+  param?: string;
+  message: string;
+}
+
+export interface ApiResponse<T> {
+  success: boolean;
+  data?: T;
+  error?: string;
+  message?: string;
+  code?: number;
+  type?: ApiErrorType;
+  fields?: FieldError[];
+}
+
+export class ApiError extends Error {
+  readonly type: ApiErrorType;
+  readonly code: number;
+  readonly fields: FieldError[];
+
+  constructor(response: ApiResponse<unknown>) {
+    super(response.error || 'Unexpected error');
+    this.name = 'ApiError';
+    this.type = response.type || 'INTERNAL_ERROR';
+    this.code = response.code || 500;
+    this.fields = response.fields || [];
+  }
+
+  // Message for a single form field, if the backend rejected it
+  fieldMessage(field: string): string | undefined {
+    return this.fields.find(f => f.field === field)?.message;
+  }
+}
+
+export function isApiError(error: unknown, type?: ApiErrorType): error is ApiError {
+  return error instanceof ApiError && (type === undefined || error.type === type);
+}
+
+// unwrap resolves with the envelope data or throws ApiError with its stable type
+export async function unwrap<T>(call: Promise<ApiResponse<T>>): Promise<T> {
+  const response = await call;
+  if (!response || !response.success) {
+    throw new ApiError(response || { success: false });
+  }
+  return response.data as T;
+}
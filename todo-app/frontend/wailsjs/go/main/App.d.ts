@@ -2,32 +2,108 @@
 // This file is automatically generated. DO NOT EDIT
 import {models} from '../models';
 
-export function ArchiveTask(arg1:number):Promise<models.Task>;
+export function AddAttachment(arg1:number,arg2:string):Promise<any>;
 
-export function CreateTask(arg1:string,arg2:string,arg3:string,arg4:string):Promise<models.Task>;
+export function AddAttachmentData(arg1:number,arg2:string,arg3:string):Promise<any>;
 
-export function DeleteTask(arg1:number):Promise<void>;
+export function AddTaskComment(arg1:number,arg2:string):Promise<any>;
 
-export function GetAllTasks():Promise<Array<models.Task>>;
+export function AddTimeEntry(arg1:models.CreateTimeEntryRequest):Promise<any>;
 
-export function GetAppInfo():Promise<Record<string, any>>;
+export function ArchiveTask(arg1:number):Promise<any>;
 
-export function GetArchivedTasks():Promise<Array<models.Task>>;
+export function ChangeTaskStatus(arg1:number,arg2:string):Promise<any>;
+
+export function CreateTask(arg1:string,arg2:string,arg3:string,arg4:string):Promise<any>;
+
+export function CreateWebhook(arg1:models.CreateWebhookRequest):Promise<any>;
+
+export function DeleteAttachment(arg1:number):Promise<any>;
+
+export function DeleteTask(arg1:number):Promise<any>;
+
+export function DeleteTaskComment(arg1:number):Promise<any>;
+
+export function DeleteTimeEntry(arg1:number):Promise<any>;
+
+export function DeleteWebhook(arg1:number):Promise<any>;
+
+export function GetActiveTimer():Promise<any>;
+
+export function GetAllTasks():Promise<any>;
+
+export function GetAppInfo():Promise<any>;
+
+export function GetArchivedTasks():Promise<any>;
+
+export function GetAttachmentData(arg1:number):Promise<any>;
+
+export function GetBoard(arg1:models.TaskFilter):Promise<any>;
+
+export function GetCommentRevisions(arg1:number):Promise<any>;
 
 export function GetDashboardStats():Promise<any>;
 
-export function GetTaskByID(arg1:number):Promise<models.Task>;
+export function GetDiagnostics():Promise<any>;
+
+export function GetEstimateReport(arg1:models.TaskFilter):Promise<any>;
+
+export function GetSettings():Promise<any>;
+
+export function GetTaskAttachments(arg1:number):Promise<any>;
+
+export function GetTaskByID(arg1:number):Promise<any>;
+
+export function GetTaskComments(arg1:number,arg2:string):Promise<any>;
 
-export function GetTasksByPriority(arg1:string):Promise<Array<models.Task>>;
+export function GetTaskHistory(arg1:number,arg2:number):Promise<any>;
 
-export function GetTasksByStatus(arg1:string):Promise<Array<models.Task>>;
+export function GetTaskSync():Promise<any>;
+
+export function GetTaskTimeEntries(arg1:number):Promise<any>;
+
+export function GetTasksByPriority(arg1:string):Promise<any>;
+
+export function GetTasksByStatus(arg1:string):Promise<any>;
+
+export function GetTasksPage(arg1:models.TaskFilter,arg2:models.TaskSort,arg3:string,arg4:number):Promise<any>;
 
 export function GetTasksStats():Promise<any>;
 
+export function GetTimeReport(arg1:models.TimeReportRequest):Promise<any>;
+
+export function GetWebhookDeliveries(arg1:number,arg2:number):Promise<any>;
+
+export function GetWebhookDeliveryAttempts(arg1:number):Promise<any>;
+
+export function GetWebhooks():Promise<any>;
+
+export function GetWorkflow():Promise<any>;
+
 export function Greet(arg1:string):Promise<string>;
 
-export function HealthCheck():Promise<Record<string, any>>;
+export function HealthCheck():Promise<any>;
+
+export function MoveTask(arg1:number,arg2:number,arg3:number,arg4:string):Promise<any>;
+
+export function ParseQuickAdd(arg1:string):Promise<any>;
+
+export function QuickAdd(arg1:string):Promise<any>;
+
+export function RetryWebhookDelivery(arg1:number):Promise<any>;
+
+export function SetTaskEstimate(arg1:number,arg2:number):Promise<any>;
+
+export function SetWebhookActive(arg1:number,arg2:boolean):Promise<any>;
+
+export function StartTimer(arg1:number,arg2:string):Promise<any>;
+
+export function StopTimer():Promise<any>;
+
+export function ToggleTaskStatus(arg1:number):Promise<any>;
+
+export function UpdateSettings(arg1:models.AppSettings):Promise<any>;
 
-export function ToggleTaskStatus(arg1:number):Promise<models.Task>;
+export function UpdateTask(arg1:number,arg2:string,arg3:string,arg4:string,arg5:string):Promise<any>;
 
-export function UpdateTask(arg1:number,arg2:string,arg3:string,arg4:string,arg5:string):Promise<models.Task>;
+export function UpdateTaskComment(arg1:number,arg2:string):Promise<any>;
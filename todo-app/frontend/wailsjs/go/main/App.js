@@ -2,18 +2,62 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AddAttachment(arg1, arg2) {
+  return window['go']['main']['App']['AddAttachment'](arg1, arg2);
+}
+
+export function AddAttachmentData(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AddAttachmentData'](arg1, arg2, arg3);
+}
+
+export function AddTaskComment(arg1, arg2) {
+  return window['go']['main']['App']['AddTaskComment'](arg1, arg2);
+}
+
+export function AddTimeEntry(arg1) {
+  return window['go']['main']['App']['AddTimeEntry'](arg1);
+}
+
 export function ArchiveTask(arg1) {
   return window['go']['main']['App']['ArchiveTask'](arg1);
 }
 
+export function ChangeTaskStatus(arg1, arg2) {
+  return window['go']['main']['App']['ChangeTaskStatus'](arg1, arg2);
+}
+
 export function CreateTask(arg1, arg2, arg3, arg4) {
   return window['go']['main']['App']['CreateTask'](arg1, arg2, arg3, arg4);
 }
 
+export function CreateWebhook(arg1) {
+  return window['go']['main']['App']['CreateWebhook'](arg1);
+}
+
+export function DeleteAttachment(arg1) {
+  return window['go']['main']['App']['DeleteAttachment'](arg1);
+}
+
 export function DeleteTask(arg1) {
   return window['go']['main']['App']['DeleteTask'](arg1);
 }
 
+export function DeleteTaskComment(arg1) {
+  return window['go']['main']['App']['DeleteTaskComment'](arg1);
+}
+
+export function DeleteTimeEntry(arg1) {
+  return window['go']['main']['App']['DeleteTimeEntry'](arg1);
+}
+
+export function DeleteWebhook(arg1) {
+  return window['go']['main']['App']['DeleteWebhook'](arg1);
+}
+
+export function GetActiveTimer() {
+  return window['go']['main']['App']['GetActiveTimer']();
+}
+
 export function GetAllTasks() {
   return window['go']['main']['App']['GetAllTasks']();
 }
@@ -26,14 +70,58 @@ export function GetArchivedTasks() {
   return window['go']['main']['App']['GetArchivedTasks']();
 }
 
+export function GetAttachmentData(arg1) {
+  return window['go']['main']['App']['GetAttachmentData'](arg1);
+}
+
+export function GetBoard(arg1) {
+  return window['go']['main']['App']['GetBoard'](arg1);
+}
+
+export function GetCommentRevisions(arg1) {
+  return window['go']['main']['App']['GetCommentRevisions'](arg1);
+}
+
 export function GetDashboardStats() {
   return window['go']['main']['App']['GetDashboardStats']();
 }
 
+export function GetDiagnostics() {
+  return window['go']['main']['App']['GetDiagnostics']();
+}
+
+export function GetEstimateReport(arg1) {
+  return window['go']['main']['App']['GetEstimateReport'](arg1);
+}
+
+export function GetSettings() {
+  return window['go']['main']['App']['GetSettings']();
+}
+
+export function GetTaskAttachments(arg1) {
+  return window['go']['main']['App']['GetTaskAttachments'](arg1);
+}
+
 export function GetTaskByID(arg1) {
   return window['go']['main']['App']['GetTaskByID'](arg1);
 }
 
+export function GetTaskComments(arg1, arg2) {
+  return window['go']['main']['App']['GetTaskComments'](arg1, arg2);
+}
+
+export function GetTaskHistory(arg1, arg2) {
+  return window['go']['main']['App']['GetTaskHistory'](arg1, arg2);
+}
+
+export function GetTaskSync() {
+  return window['go']['main']['App']['GetTaskSync']();
+}
+
+export function GetTaskTimeEntries(arg1) {
+  return window['go']['main']['App']['GetTaskTimeEntries'](arg1);
+}
+
 export function GetTasksByPriority(arg1) {
   return window['go']['main']['App']['GetTasksByPriority'](arg1);
 }
@@ -42,10 +130,34 @@ export function GetTasksByStatus(arg1) {
   return window['go']['main']['App']['GetTasksByStatus'](arg1);
 }
 
+export function GetTasksPage(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetTasksPage'](arg1, arg2, arg3, arg4);
+}
+
 export function GetTasksStats() {
   return window['go']['main']['App']['GetTasksStats']();
 }
 
+export function GetTimeReport(arg1) {
+  return window['go']['main']['App']['GetTimeReport'](arg1);
+}
+
+export function GetWebhookDeliveries(arg1, arg2) {
+  return window['go']['main']['App']['GetWebhookDeliveries'](arg1, arg2);
+}
+
+export function GetWebhookDeliveryAttempts(arg1) {
+  return window['go']['main']['App']['GetWebhookDeliveryAttempts'](arg1);
+}
+
+export function GetWebhooks() {
+  return window['go']['main']['App']['GetWebhooks']();
+}
+
+export function GetWorkflow() {
+  return window['go']['main']['App']['GetWorkflow']();
+}
+
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
@@ -54,10 +166,50 @@ export function HealthCheck() {
   return window['go']['main']['App']['HealthCheck']();
 }
 
+export function MoveTask(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['MoveTask'](arg1, arg2, arg3, arg4);
+}
+
+export function ParseQuickAdd(arg1) {
+  return window['go']['main']['App']['ParseQuickAdd'](arg1);
+}
+
+export function QuickAdd(arg1) {
+  return window['go']['main']['App']['QuickAdd'](arg1);
+}
+
+export function RetryWebhookDelivery(arg1) {
+  return window['go']['main']['App']['RetryWebhookDelivery'](arg1);
+}
+
+export function SetTaskEstimate(arg1, arg2) {
+  return window['go']['main']['App']['SetTaskEstimate'](arg1, arg2);
+}
+
+export function SetWebhookActive(arg1, arg2) {
+  return window['go']['main']['App']['SetWebhookActive'](arg1, arg2);
+}
+
+export function StartTimer(arg1, arg2) {
+  return window['go']['main']['App']['StartTimer'](arg1, arg2);
+}
+
+export function StopTimer() {
+  return window['go']['main']['App']['StopTimer']();
+}
+
 export function ToggleTaskStatus(arg1) {
   return window['go']['main']['App']['ToggleTaskStatus'](arg1);
 }
 
+export function UpdateSettings(arg1) {
+  return window['go']['main']['App']['UpdateSettings'](arg1);
+}
+
 export function UpdateTask(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['main']['App']['UpdateTask'](arg1, arg2, arg3, arg4, arg5);
 }
+
+export function UpdateTaskComment(arg1, arg2) {
+  return window['go']['main']['App']['UpdateTaskComment'](arg1, arg2);
+}
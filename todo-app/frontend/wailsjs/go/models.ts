@@ -1,5 +1,406 @@
 export namespace models {
 	
+	export class AppSettings {
+	    schema_version: number;
+	    theme: string;
+	    language: string;
+	    notifications_on: boolean;
+	    auto_save: boolean;
+	    default_priority: string;
+	    week_start: string;
+	    date_format: string;
+	    timezone: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new AppSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.schema_version = source["schema_version"];
+	        this.theme = source["theme"];
+	        this.language = source["language"];
+	        this.notifications_on = source["notifications_on"];
+	        this.auto_save = source["auto_save"];
+	        this.default_priority = source["default_priority"];
+	        this.week_start = source["week_start"];
+	        this.date_format = source["date_format"];
+	        this.timezone = source["timezone"];
+	    }
+	}
+	
+	export class BuildInfo {
+	    app_version: string;
+	    environment: string;
+	    go_version: string;
+	    module: string;
+	    revision?: string;
+	    revision_time?: string;
+	    modified: boolean;
+	    platform: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BuildInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.app_version = source["app_version"];
+	        this.environment = source["environment"];
+	        this.go_version = source["go_version"];
+	        this.module = source["module"];
+	        this.revision = source["revision"];
+	        this.revision_time = source["revision_time"];
+	        this.modified = source["modified"];
+	        this.platform = source["platform"];
+	    }
+	}
+	
+	export class CreateTimeEntryRequest {
+	    task_id: number;
+	    // Go type: time
+	    started_at: any;
+	    // Go type: time
+	    ended_at: any;
+	    note: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CreateTimeEntryRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.task_id = source["task_id"];
+	        this.started_at = this.convertValues(source["started_at"], null);
+	        this.ended_at = this.convertValues(source["ended_at"], null);
+	        this.note = source["note"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CreateWebhookRequest {
+	    url: string;
+	    event_types: string[];
+	    filter?: TaskFilter;
+	    secret: string;
+	    description: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CreateWebhookRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.url = source["url"];
+	        this.event_types = source["event_types"];
+	        this.filter = this.convertValues(source["filter"], TaskFilter);
+	        this.secret = source["secret"];
+	        this.description = source["description"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CreateWebhookResponse {
+	    webhook?: WebhookSubscription;
+	    secret: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CreateWebhookResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.webhook = this.convertValues(source["webhook"], WebhookSubscription);
+	        this.secret = source["secret"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class DatabaseDiagnostics {
+	    status: string;
+	    latency_ms: number;
+	    error?: string;
+	    degraded_reasons?: string[];
+	    max_open: number;
+	    open: number;
+	    in_use: number;
+	    idle: number;
+	    wait_count: number;
+	    wait_ms: number;
+	    max_idle_closed: number;
+	    max_lifetime_closed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseDiagnostics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.status = source["status"];
+	        this.latency_ms = source["latency_ms"];
+	        this.error = source["error"];
+	        this.degraded_reasons = source["degraded_reasons"];
+	        this.max_open = source["max_open"];
+	        this.open = source["open"];
+	        this.in_use = source["in_use"];
+	        this.idle = source["idle"];
+	        this.wait_count = source["wait_count"];
+	        this.wait_ms = source["wait_ms"];
+	        this.max_idle_closed = source["max_idle_closed"];
+	        this.max_lifetime_closed = source["max_lifetime_closed"];
+	    }
+	}
+	
+	export class DiagnosticCheck {
+	    name: string;
+	    status: string;
+	    message: string;
+	    fix?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiagnosticCheck(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.status = source["status"];
+	        this.message = source["message"];
+	        this.fix = source["fix"];
+	    }
+	}
+	
+	export class DiagnosticsReport {
+	    // Go type: time
+	    generated_at: any;
+	    status: string;
+	    ready: boolean;
+	    build: BuildInfo;
+	    database: DatabaseDiagnostics;
+	    migrations: MigrationDiagnostics;
+	    pending_reminders: number;
+	    jobs: JobDiagnostics[];
+	    log_disk?: DiskDiagnostics;
+	    queries?: QueryDiagnostics;
+	    checks: DiagnosticCheck[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DiagnosticsReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.generated_at = this.convertValues(source["generated_at"], null);
+	        this.status = source["status"];
+	        this.ready = source["ready"];
+	        this.build = this.convertValues(source["build"], BuildInfo);
+	        this.database = this.convertValues(source["database"], DatabaseDiagnostics);
+	        this.migrations = this.convertValues(source["migrations"], MigrationDiagnostics);
+	        this.pending_reminders = source["pending_reminders"];
+	        this.jobs = this.convertValues(source["jobs"], JobDiagnostics);
+	        this.log_disk = this.convertValues(source["log_disk"], DiskDiagnostics);
+	        this.queries = this.convertValues(source["queries"], QueryDiagnostics);
+	        this.checks = this.convertValues(source["checks"], DiagnosticCheck);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class DiskDiagnostics {
+	    path: string;
+	    free_bytes: number;
+	    total_bytes: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DiskDiagnostics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.free_bytes = source["free_bytes"];
+	        this.total_bytes = source["total_bytes"];
+	        this.error = source["error"];
+	    }
+	}
+	
+	export class JobDiagnostics {
+	    name: string;
+	    enabled: boolean;
+	    running: boolean;
+	    queue: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new JobDiagnostics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.enabled = source["enabled"];
+	        this.running = source["running"];
+	        this.queue = source["queue"];
+	        this.error = source["error"];
+	    }
+	}
+	
+	export class MigrationDiagnostics {
+	    available: string[];
+	    applied: string[];
+	    pending: string[];
+	    unknown: string[];
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new MigrationDiagnostics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.available = source["available"];
+	        this.applied = source["applied"];
+	        this.pending = source["pending"];
+	        this.unknown = source["unknown"];
+	        this.error = source["error"];
+	    }
+	}
+	
+	export class QueryDiagnostics {
+	    // Go type: time
+	    since: any;
+	    slow_threshold_ms: number;
+	    total_queries: number;
+	    top: QueryStats[];
+	
+	    static createFrom(source: any = {}) {
+	        return new QueryDiagnostics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.since = this.convertValues(source["since"], null);
+	        this.slow_threshold_ms = source["slow_threshold_ms"];
+	        this.total_queries = source["total_queries"];
+	        this.top = this.convertValues(source["top"], QueryStats);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class QueryStats {
+	    query: string;
+	    calls: number;
+	    errors: number;
+	    slow_calls: number;
+	    rows: number;
+	    total_ms: number;
+	    avg_ms: number;
+	    max_ms: number;
+	    last_error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new QueryStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.query = source["query"];
+	        this.calls = source["calls"];
+	        this.errors = source["errors"];
+	        this.slow_calls = source["slow_calls"];
+	        this.rows = source["rows"];
+	        this.total_ms = source["total_ms"];
+	        this.avg_ms = source["avg_ms"];
+	        this.max_ms = source["max_ms"];
+	        this.last_error = source["last_error"];
+	    }
+	}
+	
 	export class Task {
 	    id: number;
 	    title: string;
@@ -8,6 +409,9 @@ export namespace models {
 	    priority: string;
 	    // Go type: time
 	    due_date?: any;
+	    due_all_day: boolean;
+	    project: string;
+	    tags: string[];
 	    archived: boolean;
 	    // Go type: time
 	    created_at: any;
@@ -15,6 +419,13 @@ export namespace models {
 	    updated_at: any;
 	    // Go type: time
 	    completed_at?: any;
+	    // Go type: time
+	    started_at?: any;
+	    // Go type: time
+	    cancelled_at?: any;
+	    position: number;
+	    board_position: number;
+	    estimate_minutes?: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new Task(source);
@@ -28,10 +439,367 @@ export namespace models {
 	        this.status = source["status"];
 	        this.priority = source["priority"];
 	        this.due_date = this.convertValues(source["due_date"], null);
+	        this.due_all_day = source["due_all_day"];
+	        this.project = source["project"];
+	        this.tags = source["tags"];
 	        this.archived = source["archived"];
 	        this.created_at = this.convertValues(source["created_at"], null);
 	        this.updated_at = this.convertValues(source["updated_at"], null);
 	        this.completed_at = this.convertValues(source["completed_at"], null);
+	        this.started_at = this.convertValues(source["started_at"], null);
+	        this.cancelled_at = this.convertValues(source["cancelled_at"], null);
+	        this.position = source["position"];
+	        this.board_position = source["board_position"];
+	        this.estimate_minutes = source["estimate_minutes"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TaskChange {
+	    kind: string;
+	    task_id: number;
+	    task?: Task;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskChange(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.task_id = source["task_id"];
+	        this.task = this.convertValues(source["task"], Task);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TaskChangeBatch {
+	    seq: number;
+	    changes: TaskChange[];
+	    resync: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskChangeBatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.seq = source["seq"];
+	        this.changes = this.convertValues(source["changes"], TaskChange);
+	        this.resync = source["resync"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TaskFilter {
+	    status: string;
+	    priority: string;
+	    date_type: string;
+	    search: string;
+	    // Go type: time
+	    due_from?: any;
+	    // Go type: time
+	    due_to?: any;
+	    archived: boolean;
+	    timezone: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.status = source["status"];
+	        this.priority = source["priority"];
+	        this.date_type = source["date_type"];
+	        this.search = source["search"];
+	        this.due_from = this.convertValues(source["due_from"], null);
+	        this.due_to = this.convertValues(source["due_to"], null);
+	        this.archived = source["archived"];
+	        this.timezone = source["timezone"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TaskSort {
+	    field: string;
+	    order: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskSort(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.field = source["field"];
+	        this.order = source["order"];
+	    }
+	}
+	
+	export class TaskSyncSnapshot {
+	    seq: number;
+	    tasks: Task[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskSyncSnapshot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.seq = source["seq"];
+	        this.tasks = this.convertValues(source["tasks"], Task);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class TimeReportRequest {
+	    group_by: string;
+	    // Go type: time
+	    from?: any;
+	    // Go type: time
+	    to?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new TimeReportRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.group_by = source["group_by"];
+	        this.from = this.convertValues(source["from"], null);
+	        this.to = this.convertValues(source["to"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class WebhookAttempt {
+	    id: number;
+	    delivery_id: number;
+	    attempt: number;
+	    status_code: number;
+	    error: string;
+	    duration_ms: number;
+	    // Go type: time
+	    created_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new WebhookAttempt(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.delivery_id = source["delivery_id"];
+	        this.attempt = source["attempt"];
+	        this.status_code = source["status_code"];
+	        this.error = source["error"];
+	        this.duration_ms = source["duration_ms"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class WebhookDelivery {
+	    id: number;
+	    subscription_id: number;
+	    event_id: number;
+	    event_type: string;
+	    payload: number[];
+	    status: string;
+	    attempts: number;
+	    // Go type: time
+	    next_attempt_at: any;
+	    last_status_code: number;
+	    last_error: string;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    delivered_at?: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new WebhookDelivery(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.subscription_id = source["subscription_id"];
+	        this.event_id = source["event_id"];
+	        this.event_type = source["event_type"];
+	        this.payload = source["payload"];
+	        this.status = source["status"];
+	        this.attempts = source["attempts"];
+	        this.next_attempt_at = this.convertValues(source["next_attempt_at"], null);
+	        this.last_status_code = source["last_status_code"];
+	        this.last_error = source["last_error"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.delivered_at = this.convertValues(source["delivered_at"], null);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class WebhookSubscription {
+	    id: number;
+	    url: string;
+	    event_types: string[];
+	    filter?: TaskFilter;
+	    description: string;
+	    active: boolean;
+	    // Go type: time
+	    created_at: any;
+	    // Go type: time
+	    updated_at: any;
+	
+	    static createFrom(source: any = {}) {
+	        return new WebhookSubscription(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.url = source["url"];
+	        this.event_types = source["event_types"];
+	        this.filter = this.convertValues(source["filter"], TaskFilter);
+	        this.description = source["description"];
+	        this.active = source["active"];
+	        this.created_at = this.convertValues(source["created_at"], null);
+	        this.updated_at = this.convertValues(source["updated_at"], null);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
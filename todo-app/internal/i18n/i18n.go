@@ -0,0 +1,246 @@
+// Package i18n содержит каталог сообщений пользователю на поддерживаемых языках
+// с подстановкой параметров и формами множественного числа
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Locale язык сообщений
+type Locale string
+
+const (
+	EN Locale = "en"
+	RU Locale = "ru"
+)
+
+// DefaultLocale язык по умолчанию и запасной язык каталога
+const DefaultLocale = EN
+
+// Locales возвращает поддерживаемые языки
+func Locales() []Locale {
+	return []Locale{EN, RU}
+}
+
+// ParseLocale разбирает код языка: "ru", "ru-RU", "ru_RU" -> RU
+func ParseLocale(value string) (Locale, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if i := strings.IndexAny(value, "-_"); i > 0 {
+		value = value[:i]
+	}
+
+	for _, locale := range Locales() {
+		if Locale(value) == locale {
+			return locale, true
+		}
+	}
+	return DefaultLocale, false
+}
+
+// Params параметры шаблона сообщения. Параметр "count" выбирает форму множественного числа
+type Params map[string]interface{}
+
+// Message шаблон сообщения с формами множественного числа. Для языков без
+// формы (например, Few в английском) используется Other. Плейсхолдеры: {name}
+type Message struct {
+	One   string
+	Few   string
+	Many  string
+	Other string
+}
+
+// Text создает сообщение без форм множественного числа
+func Text(text string) Message {
+	return Message{Other: text}
+}
+
+// form выбирает шаблон по категории множественного числа
+func (m Message) form(category pluralCategory) string {
+	var text string
+	switch category {
+	case pluralOne:
+		text = m.One
+	case pluralFew:
+		text = m.Few
+	case pluralMany:
+		text = m.Many
+	}
+	if text == "" {
+		text = m.Other
+	}
+	return text
+}
+
+// Catalog хранит сообщения по языкам и ключам
+type Catalog struct {
+	mu       sync.RWMutex
+	messages map[Locale]map[string]Message
+}
+
+// NewCatalog создает пустой каталог
+func NewCatalog() *Catalog {
+	return &Catalog{messages: make(map[Locale]map[string]Message)}
+}
+
+// Add добавляет (или заменяет) сообщения языка
+func (c *Catalog) Add(locale Locale, messages map[string]Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.messages[locale] == nil {
+		c.messages[locale] = make(map[string]Message, len(messages))
+	}
+	for key, message := range messages {
+		c.messages[locale][key] = message
+	}
+}
+
+// Has проверяет наличие ключа для языка (без запасного языка)
+func (c *Catalog) Has(locale Locale, key string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, ok := c.messages[locale][key]
+	return ok
+}
+
+// Keys возвращает ключи сообщений языка
+func (c *Catalog) Keys(locale Locale) []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]string, 0, len(c.messages[locale]))
+	for key := range c.messages[locale] {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Lookup ищет сообщение для языка, затем для DefaultLocale
+func (c *Catalog) Lookup(locale Locale, key string) (Message, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if message, ok := c.messages[locale][key]; ok {
+		return message, true
+	}
+	message, ok := c.messages[DefaultLocale][key]
+	return message, ok
+}
+
+// Translate возвращает сообщение с подставленными параметрами.
+// Неизвестный ключ возвращается как есть, чтобы пропуск в каталоге был заметен
+func (c *Catalog) Translate(locale Locale, key string, params Params) string {
+	message, ok := c.Lookup(locale, key)
+	if !ok {
+		return key
+	}
+
+	category := pluralOther
+	if count, ok := countParam(params); ok {
+		category = pluralRule(locale, count)
+	}
+
+	return substitute(message.form(category), params)
+}
+
+// substitute заменяет плейсхолдеры {name} значениями параметров
+func substitute(text string, params Params) string {
+	if len(params) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// countParam извлекает параметр count как целое число
+func countParam(params Params) (int64, bool) {
+	switch v := params["count"].(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// pluralCategory категория множественного числа (CLDR)
+type pluralCategory int
+
+const (
+	pluralOther pluralCategory = iota
+	pluralOne
+	pluralFew
+	pluralMany
+)
+
+// pluralRule возвращает категорию для целого числа по правилам языка
+func pluralRule(locale Locale, n int64) pluralCategory {
+	if n < 0 {
+		n = -n
+	}
+
+	switch locale {
+	case RU:
+		mod10, mod100 := n%10, n%100
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return pluralOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return pluralFew
+		default:
+			return pluralMany
+		}
+	default:
+		if n == 1 {
+			return pluralOne
+		}
+		return pluralOther
+	}
+}
+
+// defaultCatalog каталог встроенных сообщений приложения
+var defaultCatalog = newDefaultCatalog()
+
+// Default возвращает каталог встроенных сообщений
+func Default() *Catalog {
+	return defaultCatalog
+}
+
+// T переводит ключ встроенным каталогом
+func T(locale Locale, key string, params Params) string {
+	return defaultCatalog.Translate(locale, key, params)
+}
+
+type localeKey struct{}
+
+// WithLocale сохраняет язык пользователя в контексте вызова
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// LocaleFromContext возвращает язык вызова или DefaultLocale
+func LocaleFromContext(ctx context.Context) Locale {
+	if ctx != nil {
+		if locale, ok := ctx.Value(localeKey{}).(Locale); ok {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// TC переводит ключ на язык из контекста
+func TC(ctx context.Context, key string, params Params) string {
+	return T(LocaleFromContext(ctx), key, params)
+}
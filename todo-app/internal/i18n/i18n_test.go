@@ -0,0 +1,95 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTranslate_Plural(t *testing.T) {
+	tests := []struct {
+		locale Locale
+		count  int
+		want   string
+	}{
+		{EN, 1, "Please correct 1 field"},
+		{EN, 2, "Please correct 2 fields"},
+		{EN, 0, "Please correct 0 fields"},
+		{RU, 1, "Исправьте 1 поле"},
+		{RU, 3, "Исправьте 3 поля"},
+		{RU, 5, "Исправьте 5 полей"},
+		{RU, 11, "Исправьте 11 полей"},
+		{RU, 21, "Исправьте 21 поле"},
+		{RU, 112, "Исправьте 112 полей"},
+		{RU, 124, "Исправьте 124 поля"},
+	}
+
+	for _, tt := range tests {
+		if got := T(tt.locale, "validation.summary", Params{"count": tt.count}); got != tt.want {
+			t.Errorf("%s/%d: expected %q, got %q", tt.locale, tt.count, tt.want, got)
+		}
+	}
+
+	// Параметр count из тега validator приходит строкой
+	if got := T(RU, "validation.min.string", Params{"field": "Название", "count": "2"}); got != "Поле «Название» должно содержать минимум 2 символа" {
+		t.Errorf("Unexpected message: %q", got)
+	}
+}
+
+func TestCatalog_FallbackAndCoverage(t *testing.T) {
+	c := NewCatalog()
+	c.Add(EN, map[string]Message{"greeting": Text("Hello, {name}")})
+
+	if got := c.Translate(RU, "greeting", Params{"name": "Ann"}); got != "Hello, Ann" {
+		t.Errorf("Expected fallback to %s, got %q", DefaultLocale, got)
+	}
+	if got := c.Translate(EN, "missing", nil); got != "missing" {
+		t.Errorf("Unknown key must be returned as is, got %q", got)
+	}
+
+	// Каждый язык встроенного каталога переводит все ключи
+	for _, locale := range Locales() {
+		for _, key := range Default().Keys(DefaultLocale) {
+			if !Default().Has(locale, key) {
+				t.Errorf("Locale %s misses key %s", locale, key)
+			}
+		}
+	}
+}
+
+func TestResolver(t *testing.T) {
+	calls := 0
+	language, fail := "ru-RU", false
+	r := NewResolver(func(ctx context.Context) (string, error) {
+		calls++
+		if fail {
+			return "", errors.New("no settings table")
+		}
+		return language, nil
+	}, time.Minute)
+
+	if got := r.Locale(context.Background()); got != RU {
+		t.Fatalf("Expected ru, got %s", got)
+	}
+	language = "en"
+	if got := r.Locale(context.Background()); got != RU || calls != 1 {
+		t.Errorf("Expected cached ru after 1 call, got %s after %d", got, calls)
+	}
+
+	r.Invalidate()
+	if got := r.Locale(context.Background()); got != EN {
+		t.Errorf("Expected en after Invalidate, got %s", got)
+	}
+
+	r.Invalidate()
+	fail = true
+	if got := r.Locale(context.Background()); got != DefaultLocale {
+		t.Errorf("Expected default locale on error, got %s", got)
+	}
+
+	var nilResolver *Resolver
+	if got := nilResolver.Locale(context.Background()); got != DefaultLocale {
+		t.Errorf("Expected default locale for nil resolver, got %s", got)
+	}
+}
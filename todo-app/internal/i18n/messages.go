@@ -0,0 +1,258 @@
+package i18n
+
+// Ключи встроенного каталога:
+//   validation.<tag>[.<kind>] - сообщения тегов validator ({field}, {param}, {count})
+//   validation.<rule>         - проверки вне тегов (due_date_past, date_range, ...)
+//   field.<json name>         - названия полей форм
+//   error.<ErrorType>         - сообщения пользователю по типу utils.AppError
+
+// newDefaultCatalog создает каталог встроенных сообщений
+func newDefaultCatalog() *Catalog {
+	c := NewCatalog()
+	c.Add(EN, messagesEN)
+	c.Add(RU, messagesRU)
+	return c
+}
+
+var messagesEN = map[string]Message{
+	// Теги validator
+	"validation.required": Text("{field} is required"),
+	"validation.min.string": {
+		One:   "{field} must be at least {count} character long",
+		Other: "{field} must be at least {count} characters long",
+	},
+	"validation.max.string": {
+		One:   "{field} must be at most {count} character long",
+		Other: "{field} must be at most {count} characters long",
+	},
+	"validation.min.items": {
+		One:   "{field} must contain at least {count} item",
+		Other: "{field} must contain at least {count} items",
+	},
+	"validation.max.items": {
+		One:   "{field} must contain at most {count} item",
+		Other: "{field} must contain at most {count} items",
+	},
+	"validation.min.number":  Text("{field} must be at least {param}"),
+	"validation.max.number":  Text("{field} must be at most {param}"),
+	"validation.gt":          Text("{field} must be greater than {param}"),
+	"validation.gte":         Text("{field} must be greater than or equal to {param}"),
+	"validation.lt":          Text("{field} must be less than {param}"),
+	"validation.lte":         Text("{field} must be less than or equal to {param}"),
+	"validation.oneof":       Text("{field} must be one of: {param}"),
+	"validation.priority":    Text("{field} has an invalid priority"),
+	"validation.status":      Text("{field} has an invalid status"),
+	"validation.url":         Text("{field} must be a valid URL"),
+	"validation.future_date": Text("{field} must be in the future"),
+	"validation.timezone":    Text("{field} must be an IANA time zone, e.g. Europe/Berlin"),
+	"validation.default":     Text("{field} is invalid ({tag})"),
+
+	// Проверки вне тегов
+	"validation.due_date_past":     Text("{field} cannot be in the past"),
+	"validation.date_range":        Text("{field} cannot be later than {other}"),
+	"validation.invalid_value":     Text("{field} has an unsupported value \"{value}\""),
+	"validation.status_transition": Text("Cannot change status from \"{from}\" to \"{to}\""),
+	"validation.move_neighbor":     Text("{field} must be another task in the target list, placed in order"),
+	"validation.positive_id":       Text("{field} must be a positive number"),
+	"validation.webhook_url":       Text("{field} must be an absolute http(s) URL"),
+	"validation.webhook_private":   Text("{field} must not point to localhost or a private network"),
+	"validation.invalid_cursor":    Text("{field} is invalid or expired, reload the list"),
+	"validation.time_in_future":    Text("{field} cannot be in the future, use the timer for ongoing work"),
+	"validation.file_too_large":    Text("{field} must not be larger than {max}"),
+	"validation.file_type":         Text("{field} type \"{type}\" is not allowed"),
+	"validation.summary": {
+		One:   "Please correct {count} field",
+		Other: "Please correct {count} fields",
+	},
+
+	// Поля форм
+	"field.id":               Text("ID"),
+	"field.delivery_id":      Text("Delivery ID"),
+	"field.title":            Text("Title"),
+	"field.description":      Text("Description"),
+	"field.priority":         Text("Priority"),
+	"field.due_date":         Text("Due date"),
+	"field.status":           Text("Status"),
+	"field.date_type":        Text("Date filter"),
+	"field.due_from":         Text("Due from"),
+	"field.due_to":           Text("Due to"),
+	"field.sort_field":       Text("Sort field"),
+	"field.sort_order":       Text("Sort order"),
+	"field.cursor":           Text("Page cursor"),
+	"field.url":              Text("URL"),
+	"field.event_types":      Text("Event types"),
+	"field.secret":           Text("Secret"),
+	"field.theme":            Text("Theme"),
+	"field.language":         Text("Language"),
+	"field.notifications_on": Text("Notifications"),
+	"field.auto_save":        Text("Auto save"),
+	"field.default_priority": Text("Default priority"),
+	"field.week_start":       Text("First day of week"),
+	"field.date_format":      Text("Date format"),
+	"field.timezone":         Text("Time zone"),
+	"field.before_id":        Text("Previous task"),
+	"field.after_id":         Text("Next task"),
+	"field.task_id":          Text("Task"),
+	"field.estimate_minutes": Text("Estimate (minutes)"),
+	"field.note":             Text("Note"),
+	"field.started_at":       Text("Start"),
+	"field.ended_at":         Text("End"),
+	"field.group_by":         Text("Group by"),
+	"field.from":             Text("From"),
+	"field.to":               Text("To"),
+	"field.body":             Text("Comment"),
+	"field.order":            Text("Order"),
+	"field.file":             Text("File"),
+	"field.file_name":        Text("File name"),
+	"field.path":             Text("File path"),
+
+	// Типы ошибок
+	"error.VALIDATION_ERROR":       Text("Please check your input and try again"),
+	"error.NOT_FOUND":              Text("The requested resource was not found"),
+	"error.UNAUTHORIZED":           Text("Please log in to continue"),
+	"error.FORBIDDEN":              Text("You don't have permission to perform this action"),
+	"error.CONFLICT":               Text("This action conflicts with existing data"),
+	"error.INTERNAL_ERROR":         Text("Something went wrong. Please try again later"),
+	"error.DATABASE_ERROR":         Text("The database is unavailable. Please try again later"),
+	"error.EXTERNAL_SERVICE_ERROR": Text("A third-party service is currently unavailable"),
+	"error.BAD_REQUEST":            Text("The request is invalid"),
+	"error.TIMEOUT":                Text("The operation took too long to complete. Please try again"),
+	"error.RATE_LIMITED":           Text("Too many requests. Please wait a moment"),
+	"error.unknown":                Text("An unexpected error occurred"),
+
+	// Ошибки предметной области (AppError.WithMessageKey)
+	"error.task_not_found":        Text("Task #{id} was not found"),
+	"error.task_completed_locked": Text("The title and priority of a completed or cancelled task cannot be changed"),
+	"error.task_not_completed":    Text("Only completed or cancelled tasks can be archived"),
+	"error.webhook_not_found":     Text("Webhook #{id} was not found"),
+	"error.delivery_not_found":    Text("Undelivered webhook delivery #{id} was not found"),
+	"error.time_entry_not_found":  Text("Time entry #{id} was not found"),
+	"error.timer_running":         Text("Another timer is already running. Stop it before starting a new one"),
+	"error.timer_not_running":     Text("No timer is running"),
+	"error.comment_not_found":     Text("Comment #{id} was not found"),
+	"error.attachment_not_found":  Text("Attachment #{id} was not found"),
+}
+
+var messagesRU = map[string]Message{
+	// Теги validator
+	"validation.required": Text("Поле «{field}» обязательно для заполнения"),
+	"validation.min.string": {
+		One:  "Поле «{field}» должно содержать минимум {count} символ",
+		Few:  "Поле «{field}» должно содержать минимум {count} символа",
+		Many: "Поле «{field}» должно содержать минимум {count} символов",
+	},
+	"validation.max.string": {
+		One:  "Поле «{field}» должно содержать максимум {count} символ",
+		Few:  "Поле «{field}» должно содержать максимум {count} символа",
+		Many: "Поле «{field}» должно содержать максимум {count} символов",
+	},
+	"validation.min.items": {
+		One:  "Поле «{field}» должно содержать минимум {count} элемент",
+		Few:  "Поле «{field}» должно содержать минимум {count} элемента",
+		Many: "Поле «{field}» должно содержать минимум {count} элементов",
+	},
+	"validation.max.items": {
+		One:  "Поле «{field}» должно содержать максимум {count} элемент",
+		Few:  "Поле «{field}» должно содержать максимум {count} элемента",
+		Many: "Поле «{field}» должно содержать максимум {count} элементов",
+	},
+	"validation.min.number":  Text("Поле «{field}» должно быть не меньше {param}"),
+	"validation.max.number":  Text("Поле «{field}» должно быть не больше {param}"),
+	"validation.gt":          Text("Поле «{field}» должно быть больше {param}"),
+	"validation.gte":         Text("Поле «{field}» должно быть не меньше {param}"),
+	"validation.lt":          Text("Поле «{field}» должно быть меньше {param}"),
+	"validation.lte":         Text("Поле «{field}» должно быть не больше {param}"),
+	"validation.oneof":       Text("Поле «{field}» должно быть одним из: {param}"),
+	"validation.priority":    Text("Некорректный приоритет в поле «{field}»"),
+	"validation.status":      Text("Некорректный статус в поле «{field}»"),
+	"validation.url":         Text("Поле «{field}» должно быть корректным URL"),
+	"validation.future_date": Text("Дата в поле «{field}» должна быть в будущем"),
+	"validation.timezone":    Text("Поле «{field}» должно быть часовым поясом IANA, например Europe/Moscow"),
+	"validation.default":     Text("Ошибка валидации поля «{field}» ({tag})"),
+
+	// Проверки вне тегов
+	"validation.due_date_past":     Text("Поле «{field}» не может быть в прошлом"),
+	"validation.date_range":        Text("Поле «{field}» не может быть позже поля «{other}»"),
+	"validation.invalid_value":     Text("Недопустимое значение «{value}» в поле «{field}»"),
+	"validation.status_transition": Text("Нельзя перевести задачу из статуса «{from}» в «{to}»"),
+	"validation.move_neighbor":     Text("«{field}» должна быть другой задачей целевого списка в правильном порядке"),
+	"validation.positive_id":       Text("Поле «{field}» должно быть положительным числом"),
+	"validation.webhook_url":       Text("Поле «{field}» должно быть абсолютным http(s) URL"),
+	"validation.webhook_private":   Text("Поле «{field}» не должно указывать на localhost или частную сеть"),
+	"validation.invalid_cursor":    Text("Поле «{field}» некорректно или устарело, обновите список"),
+	"validation.time_in_future":    Text("Поле «{field}» не может быть в будущем, для текущей работы используйте таймер"),
+	"validation.file_too_large":    Text("Файл в поле «{field}» не должен быть больше {max}"),
+	"validation.file_type":         Text("Тип файла «{type}» в поле «{field}» не разрешен"),
+	"validation.summary": {
+		One:  "Исправьте {count} поле",
+		Few:  "Исправьте {count} поля",
+		Many: "Исправьте {count} полей",
+	},
+
+	// Поля форм
+	"field.id":               Text("ID"),
+	"field.delivery_id":      Text("ID доставки"),
+	"field.title":            Text("Название"),
+	"field.description":      Text("Описание"),
+	"field.priority":         Text("Приоритет"),
+	"field.due_date":         Text("Срок"),
+	"field.status":           Text("Статус"),
+	"field.date_type":        Text("Фильтр по дате"),
+	"field.due_from":         Text("Срок с"),
+	"field.due_to":           Text("Срок по"),
+	"field.sort_field":       Text("Поле сортировки"),
+	"field.sort_order":       Text("Порядок сортировки"),
+	"field.cursor":           Text("Курсор страницы"),
+	"field.url":              Text("Адрес"),
+	"field.event_types":      Text("Типы событий"),
+	"field.secret":           Text("Секрет"),
+	"field.theme":            Text("Тема"),
+	"field.language":         Text("Язык"),
+	"field.notifications_on": Text("Уведомления"),
+	"field.auto_save":        Text("Автосохранение"),
+	"field.default_priority": Text("Приоритет по умолчанию"),
+	"field.week_start":       Text("Первый день недели"),
+	"field.date_format":      Text("Формат даты"),
+	"field.timezone":         Text("Часовой пояс"),
+	"field.before_id":        Text("Предыдущая задача"),
+	"field.after_id":         Text("Следующая задача"),
+	"field.task_id":          Text("Задача"),
+	"field.estimate_minutes": Text("Оценка (минуты)"),
+	"field.note":             Text("Заметка"),
+	"field.started_at":       Text("Начало"),
+	"field.ended_at":         Text("Окончание"),
+	"field.group_by":         Text("Группировка"),
+	"field.from":             Text("С"),
+	"field.to":               Text("По"),
+	"field.body":             Text("Комментарий"),
+	"field.order":            Text("Порядок"),
+	"field.file":             Text("Файл"),
+	"field.file_name":        Text("Имя файла"),
+	"field.path":             Text("Путь к файлу"),
+
+	// Типы ошибок
+	"error.VALIDATION_ERROR":       Text("Проверьте введенные данные и попробуйте снова"),
+	"error.NOT_FOUND":              Text("Запрошенные данные не найдены"),
+	"error.UNAUTHORIZED":           Text("Войдите, чтобы продолжить"),
+	"error.FORBIDDEN":              Text("Недостаточно прав для этого действия"),
+	"error.CONFLICT":               Text("Действие конфликтует с существующими данными"),
+	"error.INTERNAL_ERROR":         Text("Что-то пошло не так. Попробуйте позже"),
+	"error.DATABASE_ERROR":         Text("База данных недоступна. Попробуйте позже"),
+	"error.EXTERNAL_SERVICE_ERROR": Text("Внешний сервис временно недоступен"),
+	"error.BAD_REQUEST":            Text("Некорректный запрос"),
+	"error.TIMEOUT":                Text("Операция выполнялась слишком долго. Попробуйте снова"),
+	"error.RATE_LIMITED":           Text("Слишком много запросов. Подождите немного"),
+	"error.unknown":                Text("Произошла непредвиденная ошибка"),
+
+	// Ошибки предметной области (AppError.WithMessageKey)
+	"error.task_not_found":        Text("Задача #{id} не найдена"),
+	"error.task_completed_locked": Text("У выполненной или отмененной задачи нельзя изменить название и приоритет"),
+	"error.task_not_completed":    Text("В архив можно перенести только выполненную или отмененную задачу"),
+	"error.webhook_not_found":     Text("Webhook #{id} не найден"),
+	"error.delivery_not_found":    Text("Недоставленное событие webhook #{id} не найдено"),
+	"error.time_entry_not_found":  Text("Запись времени #{id} не найдена"),
+	"error.timer_running":         Text("Уже запущен другой таймер. Остановите его, прежде чем запускать новый"),
+	"error.timer_not_running":     Text("Таймер не запущен"),
+	"error.comment_not_found":     Text("Комментарий #{id} не найден"),
+	"error.attachment_not_found":  Text("Вложение #{id} не найдено"),
+}
@@ -0,0 +1,72 @@
+package i18n
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// LocaleSource возвращает код языка из настроек пользователя
+type LocaleSource func(ctx context.Context) (string, error)
+
+// Resolver определяет язык вызова по настройкам пользователя.
+// Результат кэшируется на ttl, чтобы не читать настройки на каждый binding;
+// после изменения настроек кэш сбрасывается через Invalidate
+type Resolver struct {
+	source LocaleSource
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	locale    Locale
+	expiresAt time.Time
+}
+
+// NewResolver создает Resolver. ttl <= 0 отключает кэширование
+func NewResolver(source LocaleSource, ttl time.Duration) *Resolver {
+	return &Resolver{
+		source: source,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+// Locale возвращает язык пользователя. При ошибке чтения настроек или
+// неизвестном коде используется DefaultLocale; ошибка не кэшируется
+func (r *Resolver) Locale(ctx context.Context) Locale {
+	if r == nil || r.source == nil {
+		return DefaultLocale
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if r.locale != "" && now.Before(r.expiresAt) {
+		return r.locale
+	}
+
+	value, err := r.source(ctx)
+	if err != nil {
+		return DefaultLocale
+	}
+
+	locale, _ := ParseLocale(value)
+	if r.ttl > 0 {
+		r.locale = locale
+		r.expiresAt = now.Add(r.ttl)
+	}
+	return locale
+}
+
+// Invalidate сбрасывает закэшированный язык
+func (r *Resolver) Invalidate() {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	r.locale = ""
+	r.expiresAt = time.Time{}
+	r.mu.Unlock()
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+
+	"todo-app/internal/utils"
 )
 
 // CORSConfig содержит настройки CORS
@@ -189,8 +191,10 @@ func WebSocketCORS(allowedOrigins []string) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			origin := r.Header.Get("Origin")
 
-			if !isOriginAllowed(origin, allowedOrigins) {
-				http.Error(w, "Origin not allowed", http.StatusForbidden)
+			// Браузер всегда передает Origin; клиенты без него не браузерные
+			// и аутентифицируются отдельно (например, через APIKeyValidator)
+			if origin != "" && !isOriginAllowed(origin, allowedOrigins) {
+				utils.WriteErrorJSON(w, utils.NewForbiddenError("Origin not allowed"))
 				return
 			}
 
@@ -237,12 +241,12 @@ func APIKeyValidator(validKeys []string, headerName string) func(http.Handler) h
 			apiKey := r.Header.Get(headerName)
 
 			if apiKey == "" {
-				http.Error(w, "API key required", http.StatusUnauthorized)
+				utils.WriteErrorJSON(w, utils.NewUnauthorizedError("API key required"))
 				return
 			}
 
 			if !keyMap[apiKey] {
-				http.Error(w, "Invalid API key", http.StatusUnauthorized)
+				utils.WriteErrorJSON(w, utils.NewUnauthorizedError("Invalid API key"))
 				return
 			}
 
@@ -250,29 +254,3 @@ func APIKeyValidator(validKeys []string, headerName string) func(http.Handler) h
 		})
 	}
 }
-
-// RateLimiter создает простой rate limiter middleware
-type RateLimiterConfig struct {
-	RequestsPerMinute int
-	BurstSize         int
-}
-
-// Примечание: Это упрощенная реализация rate limiter'а
-// В продакшене рекомендуется использовать более сложные решения
-// с поддержкой Redis или других внешних хранилищ
-func RateLimiter(config RateLimiterConfig) func(http.Handler) http.Handler {
-	// Здесь должна быть реализация rate limiter'а
-	// Для простоты оставляем заглушку
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			// TODO: Реализовать rate limiting
-			// clientIP := r.RemoteAddr
-			// if isRateLimited(clientIP, config) {
-			//     http.Error(w, "Rate limit exceeded", http.StatusTooManyRequests)
-			//     return
-			// }
-
-			next.ServeHTTP(w, r)
-		})
-	}
-}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -144,7 +145,7 @@ func Logging(config LoggingConfig) func(http.Handler) http.Handler {
 			requestInfo := collectRequestInfo(r, config, startTime)
 
 			// Логируем входящий запрос
-			logIncomingRequest(config.Logger, requestInfo)
+			logIncomingRequest(r.Context(), config.Logger, requestInfo)
 
 			// Создаем обертку для ResponseWriter
 			rw := &responseWriter{
@@ -161,7 +162,7 @@ func Logging(config LoggingConfig) func(http.Handler) http.Handler {
 			responseInfo := collectResponseInfo(rw, config, time.Since(startTime))
 
 			// Логируем завершенный запрос
-			logCompletedRequest(config.Logger, requestInfo, responseInfo)
+			logCompletedRequest(r.Context(), config.Logger, requestInfo, responseInfo)
 		})
 	}
 }
@@ -224,7 +225,7 @@ func collectResponseInfo(rw *responseWriter, config LoggingConfig, duration time
 }
 
 // logIncomingRequest логирует входящий запрос
-func logIncomingRequest(logger *utils.Logger, info RequestInfo) {
+func logIncomingRequest(ctx context.Context, logger *utils.Logger, info RequestInfo) {
 	fields := map[string]interface{}{
 		"method":         info.Method,
 		"path":           info.Path,
@@ -243,11 +244,11 @@ func logIncomingRequest(logger *utils.Logger, info RequestInfo) {
 		fields["body"] = info.Body
 	}
 
-	logger.Info("Incoming request", fields)
+	logger.InfoContext(ctx, "Incoming request", fields)
 }
 
 // logCompletedRequest логирует завершенный запрос
-func logCompletedRequest(logger *utils.Logger, requestInfo RequestInfo, responseInfo ResponseInfo) {
+func logCompletedRequest(ctx context.Context, logger *utils.Logger, requestInfo RequestInfo, responseInfo ResponseInfo) {
 	fields := map[string]interface{}{
 		"method":        requestInfo.Method,
 		"path":          requestInfo.Path,
@@ -272,11 +273,11 @@ func logCompletedRequest(logger *utils.Logger, requestInfo RequestInfo, response
 		requestInfo.Method, requestInfo.Path, responseInfo.StatusCode, responseInfo.Duration.Milliseconds())
 
 	if responseInfo.StatusCode >= 500 {
-		logger.Error(message, fields)
+		logger.ErrorContext(ctx, message, fields)
 	} else if responseInfo.StatusCode >= 400 {
-		logger.Warn(message, fields)
+		logger.WarnContext(ctx, message, fields)
 	} else {
-		logger.Info(message, fields)
+		logger.InfoContext(ctx, message, fields)
 	}
 }
 
@@ -329,28 +330,48 @@ func getClientIP(r *http.Request) string {
 	return r.RemoteAddr
 }
 
-// RequestIDMiddleware добавляет уникальный ID к каждому запросу
+// RequestIDHeader заголовок с ID запроса
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware добавляет уникальный ID к каждому запросу. ID клиента
+// (заголовок X-Request-ID) сохраняется, если он корректен, иначе генерируется новый.
+// ID кладется в контекст запроса (utils.WithRequestID) и попадает во все записи
+// логов, сделанные с этим контекстом в сервисах и репозиториях
 func RequestIDMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			requestID := generateRequestID()
-
-			// Добавляем ID в контекст запроса
-			// В Go 1.7+ можно использовать context.WithValue
+			requestID := r.Header.Get(RequestIDHeader)
+			if !validRequestID(requestID) {
+				requestID = generateRequestID()
+			}
 
 			// Добавляем ID в заголовок ответа
-			w.Header().Set("X-Request-ID", requestID)
+			w.Header().Set(RequestIDHeader, requestID)
 
-			next.ServeHTTP(w, r)
+			next.ServeHTTP(w, r.WithContext(utils.WithRequestID(r.Context(), requestID)))
 		})
 	}
 }
 
+// validRequestID проверяет ID запроса от клиента: непустой, до 64 символов,
+// только буквы, цифры и -_. (чтобы не допустить инъекций в логи)
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 64 {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // generateRequestID генерирует уникальный ID запроса
 func generateRequestID() string {
-	// Простая реализация на основе времени
-	// В продакшене лучше использовать UUID
-	return fmt.Sprintf("%d", time.Now().UnixNano())
+	return utils.NewID()
 }
 
 // StructuredLogging создает middleware для структурированного логирования
@@ -359,9 +380,14 @@ func StructuredLogging(logger *utils.Logger) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			startTime := time.Now()
 
-			// Создаем контекстный логгер для этого запроса
+			// Создаем контекстный логгер для этого запроса; ID берется из RequestIDMiddleware
+			requestID := utils.RequestIDFromContext(r.Context())
+			if requestID == "" {
+				requestID = generateRequestID()
+				r = r.WithContext(utils.WithRequestID(r.Context(), requestID))
+			}
 			contextLogger := logger.WithFields(map[string]interface{}{
-				"request_id": generateRequestID(),
+				"request_id": requestID,
 				"method":     r.Method,
 				"path":       r.URL.Path,
 				"remote_ip":  getClientIP(r),
@@ -383,6 +409,7 @@ func StructuredLogging(logger *utils.Logger) func(http.Handler) http.Handler {
 			// Логируем завершение
 			duration := time.Since(startTime)
 			contextLogger = logger.WithFields(map[string]interface{}{
+				"request_id":  requestID,
 				"status_code": rw.statusCode,
 				"duration_ms": duration.Milliseconds(),
 				"size_bytes":  rw.contentLength,
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"todo-app/internal/utils"
+)
+
+// RateLimiterConfig содержит настройки ограничения частоты запросов
+type RateLimiterConfig struct {
+	RequestsPerMinute int // 0 отключает ограничение
+	BurstSize         int // запросов подряд сверх равномерного темпа
+}
+
+// RateLimit ограничивает частоту запросов с одного IP алгоритмом token bucket.
+// Лимиты можно менять на лету через Update (например, при перезагрузке конфигурации)
+type RateLimit struct {
+	mu      sync.Mutex
+	config  RateLimiterConfig
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+// bucket хранит токены одного клиента
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// idleBucketTTL время, после которого неактивный клиент забывается
+const idleBucketTTL = 10 * time.Minute
+
+// NewRateLimit создает ограничитель частоты запросов
+func NewRateLimit(config RateLimiterConfig) *RateLimit {
+	return &RateLimit{
+		config:  normalizeRateLimit(config),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// normalizeRateLimit подставляет размер всплеска по умолчанию
+func normalizeRateLimit(config RateLimiterConfig) RateLimiterConfig {
+	if config.BurstSize <= 0 {
+		config.BurstSize = 1
+	}
+	return config
+}
+
+// Update применяет новые лимиты; накопленные токены ограничиваются новым всплеском
+func (rl *RateLimit) Update(config RateLimiterConfig) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	rl.config = normalizeRateLimit(config)
+	for _, b := range rl.buckets {
+		b.tokens = math.Min(b.tokens, float64(rl.config.BurstSize))
+	}
+}
+
+// Config возвращает текущие лимиты
+func (rl *RateLimit) Config() RateLimiterConfig {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.config
+}
+
+// Allow расходует токен клиента. Возвращает false и время до появления
+// следующего токена, если лимит исчерпан
+func (rl *RateLimit) Allow(key string) (bool, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if rl.config.RequestsPerMinute <= 0 {
+		return true, 0
+	}
+
+	now := rl.now()
+	rate := float64(rl.config.RequestsPerMinute) / float64(time.Minute)
+	burst := float64(rl.config.BurstSize)
+
+	b, ok := rl.buckets[key]
+	if !ok {
+		rl.cleanup(now)
+		b = &bucket{tokens: burst, lastSeen: now}
+		rl.buckets[key] = b
+	}
+
+	b.tokens = math.Min(burst, b.tokens+float64(now.Sub(b.lastSeen))*rate)
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / rate)
+	}
+
+	b.tokens--
+	return true, 0
+}
+
+// cleanup удаляет неактивных клиентов
+func (rl *RateLimit) cleanup(now time.Time) {
+	for key, b := range rl.buckets {
+		if now.Sub(b.lastSeen) > idleBucketTTL {
+			delete(rl.buckets, key)
+		}
+	}
+}
+
+// Middleware возвращает HTTP middleware, отвечающий 429 при превышении лимита
+func (rl *RateLimit) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := getClientIP(r)
+			if host, _, err := net.SplitHostPort(key); err == nil {
+				key = host
+			}
+
+			allowed, retryAfter := rl.Allow(key)
+			if !allowed {
+				seconds := int(math.Ceil(retryAfter.Seconds()))
+				w.Header().Set("Retry-After", strconv.Itoa(seconds))
+				utils.WriteErrorJSON(w, utils.NewRateLimitedError())
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RateLimiter создает rate limiter middleware с фиксированными лимитами.
+// Примечание: состояние хранится в памяти процесса; для нескольких экземпляров
+// нужны внешние хранилища (например, Redis)
+func RateLimiter(config RateLimiterConfig) func(http.Handler) http.Handler {
+	return NewRateLimit(config).Middleware()
+}
@@ -0,0 +1,100 @@
+package middleware
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/http"
+	"todo-app/internal/tracing"
+)
+
+// TraceResponseHeader заголовок ответа с контекстом серверного span'а (W3C traceresponse)
+const TraceResponseHeader = "traceresponse"
+
+// Tracing начинает серверный span на каждый запрос. Корректный заголовок
+// traceparent клиента делает span продолжением его трассы; контекст запроса
+// несет span, поэтому вызовы use case и репозиториев становятся дочерними
+func Tracing() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !tracing.Enabled() {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx := r.Context()
+			if parent, ok := tracing.ParseTraceParent(r.Header.Get(tracing.TraceParentHeader)); ok {
+				ctx = tracing.ContextWithRemoteParent(ctx, parent)
+			}
+
+			ctx, span := tracing.Start(ctx, "HTTP "+r.Method+" "+r.URL.Path)
+			defer span.End()
+
+			span.SetAttributes(map[string]interface{}{
+				"http.method":    r.Method,
+				"http.path":      r.URL.Path,
+				"http.client_ip": getClientIP(r),
+			})
+			w.Header().Set(TraceResponseHeader, span.SpanContext().TraceParent())
+
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			status := rec.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			span.SetAttribute("http.status_code", status)
+			if status >= http.StatusInternalServerError {
+				span.RecordError(errors.New(http.StatusText(status)))
+			} else {
+				span.SetStatusOK()
+			}
+		})
+	}
+}
+
+// statusRecorder запоминает код ответа. В отличие от responseWriter логирования
+// пропускает Hijack и Flush, необходимые WebSocket upgrade
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+// WriteHeader захватывает код статуса
+func (rw *statusRecorder) WriteHeader(code int) {
+	if rw.status == 0 {
+		rw.status = code
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+// Write фиксирует код 200, если заголовки не отправлены явно
+func (rw *statusRecorder) Write(b []byte) (int, error) {
+	if rw.status == 0 {
+		rw.status = http.StatusOK
+	}
+	return rw.ResponseWriter.Write(b)
+}
+
+// Hijack передает соединение обработчику (WebSocket)
+func (rw *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	rw.status = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+// Flush сбрасывает буфер ответа, если writer это поддерживает
+func (rw *statusRecorder) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap возвращает исходный writer для http.ResponseController
+func (rw *statusRecorder) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
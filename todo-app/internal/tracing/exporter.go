@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"todo-app/internal/utils"
+)
+
+// Exporter принимает завершенные span'ы. Export вызывается синхронно из Span.End
+// и должен быть безопасен для конкурентного использования
+type Exporter interface {
+	// Export сохраняет или отправляет span
+	Export(span SpanData) error
+
+	// Close сбрасывает буферы и освобождает ресурсы
+	Close() error
+}
+
+// WriterExporter пишет span'ы в io.Writer по одному JSON объекту на строку
+type WriterExporter struct {
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// NewWriterExporter создает экспортер в произвольный writer (тесты, буферы)
+func NewWriterExporter(w io.Writer) *WriterExporter {
+	return &WriterExporter{w: w}
+}
+
+// NewStdoutExporter создает экспортер в стандартный вывод
+func NewStdoutExporter() *WriterExporter {
+	return NewWriterExporter(os.Stdout)
+}
+
+// NewFileExporter создает экспортер в файл JSON Lines с ротацией как у логов
+func NewFileExporter(path string, rotation utils.RotationConfig) (*WriterExporter, error) {
+	file, err := utils.OpenRotatingFile(path, rotation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %w", err)
+	}
+	return &WriterExporter{w: file, closer: file}, nil
+}
+
+// Export пишет span одной строкой JSON
+func (e *WriterExporter) Export(span SpanData) error {
+	line, err := json.Marshal(span)
+	if err != nil {
+		return fmt.Errorf("failed to marshal span: %w", err)
+	}
+	line = append(line, '\n')
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if _, err := e.w.Write(line); err != nil {
+		return fmt.Errorf("failed to write span: %w", err)
+	}
+	return nil
+}
+
+// Close закрывает файл экспортера (stdout не закрывается)
+func (e *WriterExporter) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.closer == nil {
+		return nil
+	}
+	err := e.closer.Close()
+	e.closer = nil
+	return err
+}
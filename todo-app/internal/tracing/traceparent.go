@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"encoding/hex"
+	"strings"
+)
+
+// TraceParentHeader заголовок W3C Trace Context
+const TraceParentHeader = "traceparent"
+
+// flagSampled флаг trace-flags: трасса записывается
+const flagSampled = 0x01
+
+// ParseTraceParent разбирает заголовок traceparent (W3C Trace Context):
+// "00-<trace-id 32 hex>-<parent-id 16 hex>-<flags 2 hex>". Будущие версии
+// принимаются, если начинаются с известного формата; версия ff недопустима
+func ParseTraceParent(header string) (SpanContext, bool) {
+	header = strings.TrimSpace(header)
+	if len(header) < 55 {
+		return SpanContext{}, false
+	}
+
+	version := header[0:2]
+	if !isLowerHex(version) || version == "ff" {
+		return SpanContext{}, false
+	}
+	if version == "00" && len(header) != 55 {
+		return SpanContext{}, false
+	}
+	if len(header) > 55 && header[55] != '-' {
+		return SpanContext{}, false
+	}
+	if header[2] != '-' || header[35] != '-' || header[52] != '-' {
+		return SpanContext{}, false
+	}
+
+	traceHex, spanHex, flagsHex := header[3:35], header[36:52], header[53:55]
+	if !isLowerHex(traceHex) || !isLowerHex(spanHex) || !isLowerHex(flagsHex) {
+		return SpanContext{}, false
+	}
+
+	var sc SpanContext
+	_, _ = hex.Decode(sc.TraceID[:], []byte(traceHex))
+	_, _ = hex.Decode(sc.SpanID[:], []byte(spanHex))
+
+	var flags [1]byte
+	_, _ = hex.Decode(flags[:], []byte(flagsHex))
+	sc.Sampled = flags[0]&flagSampled != 0
+
+	if !sc.IsValid() {
+		return SpanContext{}, false
+	}
+	return sc, true
+}
+
+// TraceParent форматирует контекст span'а как заголовок traceparent
+func (sc SpanContext) TraceParent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// isLowerHex проверяет, что строка состоит из hex символов в нижнем регистре
+func isLowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,416 @@
+// Package tracing реализует трассировку вызовов в духе OpenTelemetry без внешних
+// зависимостей: span'ы App, use case, сервиса и репозитория связываются через
+// context.Context, завершенные span'ы передаются экспортеру (stdout, файл)
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+	"todo-app/internal/utils"
+)
+
+// Поля логов, связывающие записи с трассировкой
+const (
+	FieldTraceID = "trace_id"
+	FieldSpanID  = "span_id"
+)
+
+// Статусы span'а
+const (
+	StatusUnset = "unset"
+	StatusOK    = "ok"
+	StatusError = "error"
+)
+
+// TraceID идентификатор трассы (16 байт)
+type TraceID [16]byte
+
+// String возвращает ID в hex
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid проверяет, что ID не нулевой
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+// SpanID идентификатор span'а (8 байт)
+type SpanID [8]byte
+
+// String возвращает ID в hex
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid проверяет, что ID не нулевой
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+// SpanContext идентифицирует span для связи с дочерними и передачи между процессами
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// IsValid проверяет, что оба ID заданы
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// SpanData завершенный span в виде для экспорта
+type SpanData struct {
+	TraceID    string                 `json:"trace_id"`
+	SpanID     string                 `json:"span_id"`
+	ParentID   string                 `json:"parent_id,omitempty"`
+	Name       string                 `json:"name"`
+	Service    string                 `json:"service,omitempty"`
+	Start      time.Time              `json:"start"`
+	End        time.Time              `json:"end"`
+	DurationMs float64                `json:"duration_ms"`
+	Status     string                 `json:"status"`
+	Error      string                 `json:"error,omitempty"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// Config содержит настройки трассировщика
+type Config struct {
+	Service     string   // имя сервиса в экспортируемых span'ах
+	Exporter    Exporter // nil - span'ы не экспортируются
+	SampleRatio float64  // доля записываемых корневых трасс (0..1); дочерние следуют решению родителя
+}
+
+// Tracer создает span'ы и передает завершенные экспортеру
+type Tracer struct {
+	service  string
+	exporter Exporter
+	ratio    float64
+	dropped  atomic.Int64
+}
+
+// NewTracer создает трассировщик
+func NewTracer(config Config) *Tracer {
+	ratio := config.SampleRatio
+	if ratio < 0 || math.IsNaN(ratio) {
+		ratio = 0
+	}
+	if ratio > 1 {
+		ratio = 1
+	}
+
+	return &Tracer{
+		service:  config.Service,
+		exporter: config.Exporter,
+		ratio:    ratio,
+	}
+}
+
+// Dropped возвращает количество span'ов, которые не удалось экспортировать
+func (t *Tracer) Dropped() int64 {
+	return t.dropped.Load()
+}
+
+// Close закрывает экспортер
+func (t *Tracer) Close() error {
+	if t.exporter == nil {
+		return nil
+	}
+	return t.exporter.Close()
+}
+
+// globalTracer трассировщик, используемый Start; nil - трассировка отключена
+var globalTracer atomic.Pointer[Tracer]
+
+// SetTracer задает глобальный трассировщик (nil отключает трассировку)
+func SetTracer(t *Tracer) {
+	globalTracer.Store(t)
+}
+
+// GlobalTracer возвращает глобальный трассировщик или nil
+func GlobalTracer() *Tracer {
+	return globalTracer.Load()
+}
+
+// Enabled проверяет, включена ли трассировка
+func Enabled() bool {
+	return globalTracer.Load() != nil
+}
+
+type spanKey struct{}
+type remoteKey struct{}
+
+// Span операция в трассе. Методы безопасно вызывать у nil span'а:
+// при отключенной трассировке Start возвращает nil
+type Span struct {
+	tracer *Tracer
+	sc     SpanContext
+	parent SpanID
+	name   string
+	start  time.Time
+
+	mu     sync.Mutex
+	attrs  map[string]interface{}
+	status string
+	err    string
+	ended  bool
+}
+
+// Start начинает span глобальным трассировщиком. Родителем становится span из
+// контекста или удаленный span (ContextWithRemoteParent)
+func Start(ctx context.Context, name string) (context.Context, *Span) {
+	t := globalTracer.Load()
+	if t == nil {
+		return ctx, nil
+	}
+	return t.Start(ctx, name)
+}
+
+// StartChild начинает span только внутри существующей трассы. Используется
+// нижними слоями (репозитории), чтобы фоновые опросы не порождали корневые трассы
+func StartChild(ctx context.Context, name string) (context.Context, *Span) {
+	if !SpanContextFromContext(ctx).IsValid() {
+		return ctx, nil
+	}
+	return Start(ctx, name)
+}
+
+// Start начинает span этим трассировщиком
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	parent := SpanContextFromContext(ctx)
+
+	span := &Span{
+		tracer: t,
+		name:   name,
+		start:  time.Now(),
+		status: StatusUnset,
+	}
+
+	if parent.IsValid() {
+		span.sc.TraceID = parent.TraceID
+		span.sc.Sampled = parent.Sampled
+		span.parent = parent.SpanID
+	} else {
+		span.sc.TraceID = newTraceID()
+		span.sc.Sampled = t.sample(span.sc.TraceID)
+	}
+	span.sc.SpanID = newSpanID()
+
+	ctx = context.WithValue(ctx, spanKey{}, span)
+	ctx = utils.ContextWithFields(ctx, map[string]interface{}{
+		FieldTraceID: span.sc.TraceID.String(),
+		FieldSpanID:  span.sc.SpanID.String(),
+	})
+	return ctx, span
+}
+
+// sample принимает решение о записи корневой трассы по ее ID,
+// чтобы решение было одинаковым в разных процессах
+func (t *Tracer) sample(id TraceID) bool {
+	switch {
+	case t.ratio >= 1:
+		return true
+	case t.ratio <= 0:
+		return false
+	}
+
+	var v uint64
+	for _, b := range id[8:] {
+		v = v<<8 | uint64(b)
+	}
+	return float64(v>>11)/float64(1<<53) < t.ratio
+}
+
+// SpanFromContext возвращает текущий span или nil
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+// SpanContextFromContext возвращает контекст текущего (или удаленного родительского) span'а
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if span := SpanFromContext(ctx); span != nil {
+		return span.sc
+	}
+	if ctx != nil {
+		if sc, ok := ctx.Value(remoteKey{}).(SpanContext); ok {
+			return sc
+		}
+	}
+	return SpanContext{}
+}
+
+// ContextWithRemoteParent задает родителя из другого процесса (traceparent)
+func ContextWithRemoteParent(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// SpanContext возвращает идентификаторы span'а
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// IsRecording проверяет, будет ли span экспортирован
+func (s *Span) IsRecording() bool {
+	return s != nil && s.sc.Sampled
+}
+
+// SetAttribute добавляет атрибут span'а
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if !s.IsRecording() {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.attrs == nil {
+		s.attrs = make(map[string]interface{})
+	}
+	s.attrs[key] = value
+}
+
+// SetAttributes добавляет атрибуты span'а
+func (s *Span) SetAttributes(attrs map[string]interface{}) {
+	for key, value := range attrs {
+		s.SetAttribute(key, value)
+	}
+}
+
+// RecordError отмечает span ошибочным; nil не меняет статус.
+// Отмена контекста тоже считается ошибкой
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status = StatusError
+	s.err = err.Error()
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		if s.attrs == nil {
+			s.attrs = make(map[string]interface{})
+		}
+		s.attrs["canceled"] = true
+	}
+}
+
+// SetStatusOK отмечает span успешным
+func (s *Span) SetStatusOK() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.status == StatusUnset {
+		s.status = StatusOK
+	}
+}
+
+// Finish отмечает результат операции (ошибка или успех) и завершает span
+func (s *Span) Finish(err error) {
+	if err != nil {
+		s.RecordError(err)
+	} else {
+		s.SetStatusOK()
+	}
+	s.End()
+}
+
+// End завершает span и передает его экспортеру. Повторный вызов игнорируется
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	end := time.Now()
+
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	data := s.data(end)
+	s.mu.Unlock()
+
+	if !s.sc.Sampled || s.tracer.exporter == nil {
+		return
+	}
+
+	if err := s.tracer.exporter.Export(data); err != nil {
+		if s.tracer.dropped.Add(1) == 1 {
+			utils.Warn("Failed to export span", map[string]interface{}{
+				"span":  s.name,
+				"error": err.Error(),
+			})
+		}
+	}
+}
+
+// data формирует SpanData; вызывается под s.mu
+func (s *Span) data(end time.Time) SpanData {
+	data := SpanData{
+		TraceID:    s.sc.TraceID.String(),
+		SpanID:     s.sc.SpanID.String(),
+		Name:       s.name,
+		Service:    s.tracer.service,
+		Start:      s.start,
+		End:        end,
+		DurationMs: float64(end.Sub(s.start).Microseconds()) / 1000,
+		Status:     s.status,
+		Error:      s.err,
+	}
+	if s.parent.IsValid() {
+		data.ParentID = s.parent.String()
+	}
+	if len(s.attrs) > 0 {
+		data.Attributes = make(map[string]interface{}, len(s.attrs))
+		for key, value := range s.attrs {
+			data.Attributes[key] = value
+		}
+	}
+	return data
+}
+
+// newTraceID генерирует случайный ID трассы
+func newTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
+
+// newSpanID генерирует случайный ID span'а
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
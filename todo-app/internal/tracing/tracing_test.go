@@ -0,0 +1,137 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"todo-app/internal/utils"
+)
+
+// readSpans разбирает вывод WriterExporter
+func readSpans(t *testing.T, buf *bytes.Buffer) []SpanData {
+	t.Helper()
+
+	var spans []SpanData
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var span SpanData
+		if err := json.Unmarshal([]byte(line), &span); err != nil {
+			t.Fatalf("Invalid span line %q: %v", line, err)
+		}
+		spans = append(spans, span)
+	}
+	return spans
+}
+
+func TestTracer_NestedSpans(t *testing.T) {
+	var buf bytes.Buffer
+	SetTracer(NewTracer(Config{Service: "test", Exporter: NewWriterExporter(&buf), SampleRatio: 1}))
+	defer SetTracer(nil)
+
+	// Репозиторий вне трассы span не создает
+	if _, span := StartChild(context.Background(), "Repository.Poll"); span != nil {
+		t.Fatal("StartChild must not start a root span")
+	}
+
+	ctx, root := Start(context.Background(), "App.GetDashboardStats")
+	childCtx, child := Start(ctx, "TaskService.GetDashboardStats")
+	_, repo := StartChild(childCtx, "TaskRepository.GetTasksStats")
+	repo.SetAttribute("rows", 4)
+	repo.Finish(errors.New("timeout"))
+	child.Finish(nil)
+	root.End()
+	root.End() // повторный End игнорируется
+
+	if got := utils.FieldsFromContext(childCtx)[FieldTraceID]; got != root.SpanContext().TraceID.String() {
+		t.Errorf("Expected trace_id in log fields, got %v", got)
+	}
+
+	spans := readSpans(t, &buf)
+	if len(spans) != 3 {
+		t.Fatalf("Expected 3 spans, got %d: %s", len(spans), buf.String())
+	}
+
+	repoData, childData, rootData := spans[0], spans[1], spans[2]
+	if repoData.TraceID != rootData.TraceID || childData.TraceID != rootData.TraceID {
+		t.Errorf("Spans must share trace id: %+v", spans)
+	}
+	if repoData.ParentID != childData.SpanID || childData.ParentID != rootData.SpanID || rootData.ParentID != "" {
+		t.Errorf("Unexpected parent chain: %+v", spans)
+	}
+	if repoData.Status != StatusError || repoData.Error != "timeout" || repoData.Attributes["rows"] != float64(4) {
+		t.Errorf("Unexpected repository span: %+v", repoData)
+	}
+	if childData.Status != StatusOK || rootData.Service != "test" {
+		t.Errorf("Unexpected spans: %+v", spans)
+	}
+}
+
+func TestTracer_DisabledAndUnsampled(t *testing.T) {
+	ctx, span := Start(context.Background(), "App.GetTasks")
+	if span != nil || ctx != context.Background() {
+		t.Fatal("Expected no span without tracer")
+	}
+	// Методы nil span'а безопасны
+	span.SetAttribute("key", 1)
+	span.Finish(errors.New("ignored"))
+
+	var buf bytes.Buffer
+	tracer := NewTracer(Config{Exporter: NewWriterExporter(&buf), SampleRatio: 0})
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, child := tracer.Start(ctx, "child")
+	child.End()
+	root.End()
+
+	if root.IsRecording() || child.IsRecording() || buf.Len() != 0 {
+		t.Errorf("Unsampled trace must not be exported: %s", buf.String())
+	}
+	if !child.SpanContext().IsValid() {
+		t.Error("Unsampled spans still propagate ids")
+	}
+}
+
+func TestParseTraceParent(t *testing.T) {
+	header := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	sc, ok := ParseTraceParent(header)
+	if !ok || !sc.Sampled || sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" {
+		t.Fatalf("Unexpected span context: %+v ok=%t", sc, ok)
+	}
+	if sc.TraceParent() != header {
+		t.Errorf("Round trip mismatch: %s", sc.TraceParent())
+	}
+
+	for _, invalid := range []string{
+		"",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",  // нулевой trace id
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",  // нулевой span id
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",  // запрещенная версия
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",  // верхний регистр
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-", // лишние данные в версии 00
+	} {
+		if _, ok := ParseTraceParent(invalid); ok {
+			t.Errorf("Expected %q to be rejected", invalid)
+		}
+	}
+
+	// Будущая версия с дополнительными полями принимается
+	if _, ok := ParseTraceParent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00-extra"); !ok {
+		t.Error("Expected future version to be accepted")
+	}
+
+	// Дочерний span продолжает удаленную трассу
+	var buf bytes.Buffer
+	tracer := NewTracer(Config{Exporter: NewWriterExporter(&buf), SampleRatio: 0})
+	_, span := tracer.Start(ContextWithRemoteParent(context.Background(), sc), "HTTP GET /diagnostics")
+	span.End()
+
+	spans := readSpans(t, &buf)
+	if len(spans) != 1 || spans[0].TraceID != sc.TraceID.String() || spans[0].ParentID != sc.SpanID.String() {
+		t.Errorf("Expected span to follow sampled remote parent: %s", buf.String())
+	}
+}
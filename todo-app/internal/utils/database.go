@@ -1,12 +1,15 @@
 package utils
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // DatabaseConfig содержит конфигурацию для подключения к БД
@@ -17,32 +20,221 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+
+	// Пул соединений; нулевые значения заменяются значениями по умолчанию
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// ConnectRetry повтор первого подключения, пока БД недоступна (например, еще запускается)
+	ConnectRetry RetryPolicy
+
+	// Queries собирает статистику запросов через обертку драйвера; nil - без инструментирования
+	Queries *QueryRecorder
 }
 
-// InitDB инициализирует подключение к PostgreSQL
-func InitDB(config DatabaseConfig) (*sql.DB, error) {
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		config.Host, config.Port, config.User, config.Password, config.DBName, config.SSLMode)
+// Значения пула по умолчанию
+const (
+	DefaultMaxOpenConns    = 25
+	DefaultMaxIdleConns    = 25
+	DefaultConnMaxLifetime = 5 * time.Minute
+)
+
+// connectPingTimeout ограничивает одну попытку подключения
+const connectPingTimeout = 5 * time.Second
+
+// DSN возвращает строку подключения в формате key=value. Содержит пароль:
+// передавайте ее только драйверу и никогда не выводите в логи
+func (c DatabaseConfig) DSN() string {
+	return c.dsn(c.Password)
+}
+
+// String возвращает строку подключения со скрытым паролем, поэтому
+// случайный вывод конфигурации через %v или %s не раскрывает секрет
+func (c DatabaseConfig) String() string {
+	if c.Password == "" {
+		return c.dsn("")
+	}
+	return c.dsn("******")
+}
+
+// GoString скрывает пароль и при выводе через %#v
+func (c DatabaseConfig) GoString() string {
+	return "utils.DatabaseConfig{" + c.String() + "}"
+}
+
+// dsn собирает строку подключения с указанным паролем
+func (c DatabaseConfig) dsn(password string) string {
+	parts := []string{
+		"host=" + quoteDSNValue(c.Host),
+		"port=" + strconv.Itoa(c.Port),
+		"user=" + quoteDSNValue(c.User),
+	}
+	if password != "" {
+		parts = append(parts, "password="+quoteDSNValue(password))
+	}
+	parts = append(parts, "dbname="+quoteDSNValue(c.DBName))
+	if c.SSLMode != "" {
+		parts = append(parts, "sslmode="+quoteDSNValue(c.SSLMode))
+	}
+	return strings.Join(parts, " ")
+}
+
+// quoteDSNValue экранирует значение по правилам libpq: пробелы,
+// кавычки и обратная косая черта требуют значения в одинарных кавычках
+func quoteDSNValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, ` '\`) {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
 
-	db, err := sql.Open("postgres", dsn)
+// InitDB инициализирует подключение к PostgreSQL. Пока БД недоступна по
+// временной причине (IsTransientDBError), подключение повторяется согласно ConnectRetry
+func InitDB(config DatabaseConfig) (*sql.DB, error) {
+	db, err := OpenDB(config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+		return nil, err
 	}
 
+	// Настройка пула соединений
+	ConfigurePool(db, config)
+
 	// Проверка подключения
-	if err := db.Ping(); err != nil {
+	err = Retry(context.Background(), config.ConnectRetry, func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), connectPingTimeout)
+		defer cancel()
+		return db.PingContext(ctx)
+	}, func(attempt int, delay time.Duration, err error) {
+		defaultLogger.Warn("Database is not available yet, retrying", map[string]interface{}{
+			"attempt":      attempt,
+			"max_attempts": config.ConnectRetry.MaxAttempts,
+			"retry_in":     delay.Round(time.Millisecond).String(),
+			"error":        err.Error(),
+		})
+	})
+	if err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// Настройка пула соединений
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(25)
-	db.SetConnMaxLifetime(5 * time.Minute)
-
 	log.Println("Database connection established successfully")
 	return db, nil
 }
 
+// OpenDB открывает пул соединений без проверки подключения. Если задан
+// config.Queries, запросы выполняются через инструментированный драйвер
+func OpenDB(config DatabaseConfig) (*sql.DB, error) {
+	if config.Queries == nil {
+		db, err := sql.Open("postgres", config.DSN())
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database: %w", err)
+		}
+		return db, nil
+	}
+
+	connector, err := pq.NewConnector(config.DSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return sql.OpenDB(InstrumentConnector(connector, config.Queries)), nil
+}
+
+// ConfigurePool применяет настройки пула соединений
+func ConfigurePool(db *sql.DB, config DatabaseConfig) {
+	maxOpen := config.MaxOpenConns
+	if maxOpen <= 0 {
+		maxOpen = DefaultMaxOpenConns
+	}
+	maxIdle := config.MaxIdleConns
+	if maxIdle <= 0 {
+		maxIdle = DefaultMaxIdleConns
+	}
+	if maxIdle > maxOpen {
+		maxIdle = maxOpen
+	}
+	lifetime := config.ConnMaxLifetime
+	if lifetime <= 0 {
+		lifetime = DefaultConnMaxLifetime
+	}
+
+	db.SetMaxOpenConns(maxOpen)
+	db.SetMaxIdleConns(maxIdle)
+	db.SetConnMaxLifetime(lifetime)
+	db.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+}
+
+// Состояния БД для health check
+const (
+	DBStatusOK          = "ok"
+	DBStatusDegraded    = "degraded"
+	DBStatusUnavailable = "unavailable"
+)
+
+// DBHealth описывает состояние подключения к БД
+type DBHealth struct {
+	Status    string        `json:"status"`
+	Latency   time.Duration `json:"latency"`
+	Error     string        `json:"error,omitempty"`
+	Reasons   []string      `json:"reasons,omitempty"` // причины деградации
+	Stats     sql.DBStats   `json:"stats"`
+	CheckedAt time.Time     `json:"checked_at"`
+}
+
+// DBHealthThresholds задает границы деградированного режима
+type DBHealthThresholds struct {
+	SlowPing        time.Duration // ping дольше - деградация
+	TransientWindow time.Duration // временные ошибки за этот период - деградация
+}
+
+// DefaultDBHealthThresholds значения по умолчанию
+var DefaultDBHealthThresholds = DBHealthThresholds{
+	SlowPing:        500 * time.Millisecond,
+	TransientWindow: time.Minute,
+}
+
+// CheckDBHealth проверяет БД. Деградированный режим - БД отвечает, но медленно,
+// пул исчерпан или недавно были временные ошибки (запросы повторялись);
+// приложение продолжает работать, но часть операций может завершаться ошибкой
+func CheckDBHealth(ctx context.Context, db *sql.DB, thresholds DBHealthThresholds) DBHealth {
+	health := DBHealth{Status: DBStatusOK, CheckedAt: time.Now()}
+	if db == nil {
+		health.Status = DBStatusUnavailable
+		health.Error = "database is not initialized"
+		return health
+	}
+
+	start := time.Now()
+	err := db.PingContext(ctx)
+	health.Latency = time.Since(start)
+	health.Stats = db.Stats()
+
+	if err != nil {
+		health.Status = DBStatusUnavailable
+		health.Error = err.Error()
+		return health
+	}
+
+	if thresholds.SlowPing > 0 && health.Latency > thresholds.SlowPing {
+		health.Reasons = append(health.Reasons, fmt.Sprintf("slow ping: %s", health.Latency.Round(time.Millisecond)))
+	}
+	if stats := health.Stats; stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections {
+		health.Reasons = append(health.Reasons, "connection pool exhausted")
+	}
+	if last, lastErr, _ := LastTransientDBError(); !last.IsZero() && time.Since(last) < thresholds.TransientWindow {
+		health.Reasons = append(health.Reasons, "recent transient error: "+lastErr)
+	}
+
+	if len(health.Reasons) > 0 {
+		health.Status = DBStatusDegraded
+	}
+
+	return health
+}
+
 // Transaction выполняет функцию в рамках транзакции
 func Transaction(db *sql.DB, fn func(*sql.Tx) error) error {
 	tx, err := db.Begin()
@@ -106,6 +298,30 @@ func (m *MigrationHelper) IsMigrationApplied(version string) (bool, error) {
 	return count > 0, nil
 }
 
+// AppliedMigrations возвращает версии примененных миграций по возрастанию
+func (m *MigrationHelper) AppliedMigrations() ([]string, error) {
+	rows, err := m.db.Query("SELECT version FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []string
+	for rows.Next() {
+		var version string
+		if err := rows.Scan(&version); err != nil {
+			return nil, fmt.Errorf("failed to scan migration version: %w", err)
+		}
+		versions = append(versions, version)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	return versions, nil
+}
+
 // MarkMigrationApplied отмечает миграцию как примененную
 func (m *MigrationHelper) MarkMigrationApplied(version string) error {
 	_, err := m.db.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", version)
@@ -115,6 +331,19 @@ func (m *MigrationHelper) MarkMigrationApplied(version string) error {
 	return nil
 }
 
+// ApplyMigration выполняет SQL миграции и отмечает ее примененной в одной транзакции
+func (m *MigrationHelper) ApplyMigration(version, upSQL string) error {
+	return Transaction(m.db, func(tx *sql.Tx) error {
+		if _, err := tx.Exec(upSQL); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", version, err)
+		}
+		if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+			return fmt.Errorf("failed to mark migration as applied: %w", err)
+		}
+		return nil
+	})
+}
+
 // CloseDB закрывает подключение к БД с логированием
 func CloseDB(db *sql.DB) {
 	if err := db.Close(); err != nil {
@@ -1,8 +1,12 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"runtime"
+	"strings"
+	"todo-app/internal/i18n"
 )
 
 // Определение кастомных типов ошибок
@@ -19,16 +23,145 @@ const (
 	ErrorTypeExternal     ErrorType = "EXTERNAL_SERVICE_ERROR"
 	ErrorTypeBadRequest   ErrorType = "BAD_REQUEST"
 	ErrorTypeTimeout      ErrorType = "TIMEOUT"
+	ErrorTypeRateLimited  ErrorType = "RATE_LIMITED"
 )
 
+// Причины ошибок по типам. errors.Is(err, ErrNotFound) истинно, если в цепочке err
+// есть AppError типа NOT_FOUND или причина, обернувшая ErrNotFound
+// (например, repository.ErrTaskNotFound)
+var (
+	ErrValidation   = errors.New("validation error")
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrConflict     = errors.New("conflict")
+	ErrInternal     = errors.New("internal error")
+	ErrDatabase     = errors.New("database error")
+	ErrExternal     = errors.New("external service error")
+	ErrBadRequest   = errors.New("bad request")
+	ErrTimeout      = errors.New("timeout")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// errorTypeSentinels сопоставляет типы ошибок и их причины
+var errorTypeSentinels = map[ErrorType]error{
+	ErrorTypeValidation:   ErrValidation,
+	ErrorTypeNotFound:     ErrNotFound,
+	ErrorTypeUnauthorized: ErrUnauthorized,
+	ErrorTypeForbidden:    ErrForbidden,
+	ErrorTypeConflict:     ErrConflict,
+	ErrorTypeInternal:     ErrInternal,
+	ErrorTypeDatabase:     ErrDatabase,
+	ErrorTypeExternal:     ErrExternal,
+	ErrorTypeBadRequest:   ErrBadRequest,
+	ErrorTypeTimeout:      ErrTimeout,
+	ErrorTypeRateLimited:  ErrRateLimited,
+}
+
+// StatusCode возвращает HTTP-код по умолчанию для типа ошибки
+func (t ErrorType) StatusCode() int {
+	switch t {
+	case ErrorTypeValidation, ErrorTypeBadRequest:
+		return 400
+	case ErrorTypeUnauthorized:
+		return 401
+	case ErrorTypeForbidden:
+		return 403
+	case ErrorTypeNotFound:
+		return 404
+	case ErrorTypeTimeout:
+		return 408
+	case ErrorTypeConflict:
+		return 409
+	case ErrorTypeRateLimited:
+		return 429
+	case ErrorTypeExternal:
+		return 502
+	default:
+		return 500
+	}
+}
+
+// Sentinel возвращает причину ошибок типа для errors.Is
+func (t ErrorType) Sentinel() error {
+	return errorTypeSentinels[t]
+}
+
 // AppError представляет кастомную ошибку приложения
 type AppError struct {
-	Type        ErrorType `json:"type"`
-	Message     string    `json:"message"`
-	Code        int       `json:"code,omitempty"`
-	Details     string    `json:"details,omitempty"`
-	StackTrace  string    `json:"stack_trace,omitempty"`
-	OriginalErr error     `json:"-"`
+	Type        ErrorType    `json:"type"`
+	Message     string       `json:"message"`
+	Code        int          `json:"code,omitempty"`
+	Details     string       `json:"details,omitempty"`
+	StackTrace  string       `json:"stack_trace,omitempty"`
+	Fields      []FieldError `json:"fields,omitempty"`
+	OriginalErr error        `json:"-"`
+
+	// key и params - сообщение каталога i18n вместо сообщения по типу
+	key    string
+	params i18n.Params
+}
+
+// FieldError описывает ошибку одного поля формы. Message формируется по ключу
+// каталога i18n и пересобирается на языке пользователя в LocalizeError
+type FieldError struct {
+	Field   string `json:"field"`
+	Tag     string `json:"tag"`
+	Param   string `json:"param,omitempty"`
+	Message string `json:"message"`
+
+	key    string
+	params i18n.Params
+}
+
+// NewFieldError создает ошибку поля с сообщением на DefaultLocale.
+// В params параметр "field" (и "other") задается json-именем поля
+func NewFieldError(field, tag, param, key string, params i18n.Params) FieldError {
+	fe := FieldError{
+		Field:  field,
+		Tag:    tag,
+		Param:  param,
+		key:    key,
+		params: params,
+	}
+	return fe.Localize(i18n.DefaultLocale)
+}
+
+// Localize возвращает копию ошибки поля с сообщением на указанном языке.
+// Имена полей в параметрах заменяются названиями из каталога (field.<name>)
+func (f FieldError) Localize(locale i18n.Locale) FieldError {
+	if f.key == "" {
+		return f
+	}
+
+	params := make(i18n.Params, len(f.params)+2)
+	for name, value := range f.params {
+		params[name] = value
+	}
+	params["tag"] = f.Tag
+	for _, name := range []string{"field", "other"} {
+		if value, ok := params[name].(string); ok {
+			params[name] = fieldLabel(locale, value)
+		}
+	}
+
+	f.Message = i18n.T(locale, f.key, params)
+	return f
+}
+
+// fieldLabel возвращает название поля; для элементов коллекций ("event_types[0]")
+// используется название самой коллекции
+func fieldLabel(locale i18n.Locale, field string) string {
+	name := field
+	if i := strings.IndexByte(name, '['); i > 0 {
+		name = name[:i]
+	}
+
+	key := "field." + name
+	if !i18n.Default().Has(locale, key) && !i18n.Default().Has(i18n.DefaultLocale, key) {
+		return field
+	}
+	return i18n.T(locale, key, nil)
 }
 
 // Error реализует интерфейс error
@@ -44,6 +177,25 @@ func (e *AppError) Unwrap() error {
 	return e.OriginalErr
 }
 
+// Is сопоставляет ошибку с причиной ее типа: errors.Is(err, ErrNotFound)
+func (e *AppError) Is(target error) bool {
+	return target != nil && errorTypeSentinels[e.Type] == target
+}
+
+// WithCause задает причину ошибки (обычно sentinel-ошибку слоя)
+func (e *AppError) WithCause(err error) *AppError {
+	e.OriginalErr = err
+	return e
+}
+
+// WithMessageKey задает сообщение пользователю из каталога i18n вместо общего
+// сообщения типа ошибки: "Задача #5 не найдена" вместо "Данные не найдены"
+func (e *AppError) WithMessageKey(key string, params i18n.Params) *AppError {
+	e.key = key
+	e.params = params
+	return e
+}
+
 // WithStackTrace добавляет stack trace к ошибке
 func (e *AppError) WithStackTrace() *AppError {
 	e.StackTrace = getStackTrace()
@@ -86,6 +238,23 @@ func NewValidationError(message string) *AppError {
 	return NewError(ErrorTypeValidation, message).WithCode(400)
 }
 
+// NewFieldValidationError создает ошибку валидации со всеми ошибками полей.
+// Message перечисляет сообщения полей через "; "
+func NewFieldValidationError(fields []FieldError) *AppError {
+	err := NewValidationError(joinFieldMessages(fields))
+	err.Fields = fields
+	return err
+}
+
+// joinFieldMessages объединяет сообщения ошибок полей
+func joinFieldMessages(fields []FieldError) string {
+	messages := make([]string, len(fields))
+	for i, field := range fields {
+		messages[i] = field.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
 // NewNotFoundError создает ошибку "не найдено"
 func NewNotFoundError(resource string) *AppError {
 	return NewError(ErrorTypeNotFound, fmt.Sprintf("%s not found", resource)).WithCode(404)
@@ -136,6 +305,11 @@ func NewBadRequestError(message string) *AppError {
 	return NewError(ErrorTypeBadRequest, message).WithCode(400)
 }
 
+// NewRateLimitedError создает ошибку превышения лимита запросов
+func NewRateLimitedError() *AppError {
+	return NewError(ErrorTypeRateLimited, "Rate limit exceeded").WithCode(429)
+}
+
 // NewTimeoutError создает ошибку таймаута
 func NewTimeoutError(operation string) *AppError {
 	message := fmt.Sprintf("Operation '%s' timed out", operation)
@@ -149,7 +323,8 @@ func WrapError(err error, errorType ErrorType, message string) *AppError {
 	}
 
 	// Если ошибка уже является AppError, возвращаем её
-	if appErr, ok := err.(*AppError); ok {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
 		return appErr
 	}
 
@@ -167,45 +342,97 @@ func WrapDatabaseError(err error, operation string) *AppError {
 	return WrapError(err, ErrorTypeDatabase, message).WithCode(500)
 }
 
-// IsErrorType проверяет, является ли ошибка определенного типа
-func IsErrorType(err error, errorType ErrorType) bool {
-	if appErr, ok := err.(*AppError); ok {
-		return appErr.Type == errorType
+// AsAppError возвращает AppError из цепочки err. Ошибки без AppError
+// классифицируются: истекший или отмененный контекст - TIMEOUT, остальное - INTERNAL_ERROR
+func AsAppError(err error) *AppError {
+	if err == nil {
+		return nil
 	}
-	return false
+
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return NewErrorWithCause(ErrorTypeTimeout, "Operation timed out", err).WithCode(408)
+	}
+	return NewErrorWithCause(ErrorTypeInternal, "Internal server error", err).WithCode(500)
 }
 
-// GetErrorCode возвращает код ошибки
+// IsErrorType проверяет, есть ли в цепочке ошибка определенного типа
+func IsErrorType(err error, errorType ErrorType) bool {
+	sentinel := errorType.Sentinel()
+	return sentinel != nil && errors.Is(err, sentinel)
+}
+
+// GetErrorCode возвращает код ошибки (для ошибок без AppError - 500)
 func GetErrorCode(err error) int {
-	if appErr, ok := err.(*AppError); ok {
-		return appErr.Code
+	if err == nil {
+		return 0
+	}
+
+	appErr := AsAppError(err)
+	if appErr.Code == 0 {
+		return appErr.Type.StatusCode()
 	}
-	return 500 // Неизвестная ошибка по умолчанию
+	return appErr.Code
 }
 
 // GetUserFriendlyMessage возвращает пользовательское сообщение об ошибке
 func GetUserFriendlyMessage(err error) string {
-	if appErr, ok := err.(*AppError); ok {
-		switch appErr.Type {
-		case ErrorTypeValidation:
-			return "Please check your input and try again"
-		case ErrorTypeNotFound:
-			return "The requested resource was not found"
-		case ErrorTypeUnauthorized:
-			return "Please log in to continue"
-		case ErrorTypeForbidden:
-			return "You don't have permission to perform this action"
-		case ErrorTypeConflict:
-			return "This action conflicts with existing data"
-		case ErrorTypeTimeout:
-			return "The operation took too long to complete. Please try again"
-		case ErrorTypeExternal:
-			return "A third-party service is currently unavailable"
-		default:
-			return "Something went wrong. Please try again later"
+	return GetLocalizedMessage(err, i18n.DefaultLocale)
+}
+
+// GetLocalizedMessage возвращает пользовательское сообщение об ошибке на указанном языке.
+// Для ошибок валидации с полями возвращается число полей, которые нужно исправить
+func GetLocalizedMessage(err error, locale i18n.Locale) string {
+	var appErr *AppError
+	if !errors.As(err, &appErr) {
+		return i18n.T(locale, "error.unknown", nil)
+	}
+
+	if appErr.Type == ErrorTypeValidation && len(appErr.Fields) > 0 {
+		return i18n.T(locale, "validation.summary", i18n.Params{"count": len(appErr.Fields)})
+	}
+
+	if appErr.key != "" {
+		return i18n.T(locale, appErr.key, appErr.params)
+	}
+
+	key := "error." + string(appErr.Type)
+	if !i18n.Default().Has(i18n.DefaultLocale, key) {
+		key = "error." + string(ErrorTypeInternal)
+	}
+	return i18n.T(locale, key, nil)
+}
+
+// LocalizeError возвращает ошибку для показа пользователю на указанном языке.
+// AppError из цепочки err заменяется копией: сообщения полей пересобираются на
+// языке пользователя, а сообщение ошибки без полей - сообщением из каталога
+// (WithMessageKey или по типу; исходное сообщение сохраняется в Details).
+// Остальные ошибки возвращаются как есть
+func LocalizeError(err error, locale i18n.Locale) error {
+	var appErr *AppError
+	if err == nil || !errors.As(err, &appErr) {
+		return err
+	}
+
+	localized := *appErr
+	if len(appErr.Fields) > 0 {
+		localized.Fields = make([]FieldError, len(appErr.Fields))
+		for i, field := range appErr.Fields {
+			localized.Fields[i] = field.Localize(locale)
 		}
+		localized.Message = joinFieldMessages(localized.Fields)
+		return &localized
+	}
+
+	if localized.Details == "" {
+		localized.Details = appErr.Message
 	}
-	return "An unexpected error occurred"
+	localized.Message = GetLocalizedMessage(appErr, locale)
+	return &localized
 }
 
 // getStackTrace получает stack trace
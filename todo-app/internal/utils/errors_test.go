@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"todo-app/internal/i18n"
+)
+
+func TestAppError_SentinelsAndEnvelope(t *testing.T) {
+	errTaskLocked := fmt.Errorf("task is locked: %w", ErrConflict)
+	err := fmt.Errorf("failed to update task: %w",
+		NewConflictError("cannot modify completed task").
+			WithCause(errTaskLocked).
+			WithMessageKey("error.task_completed_locked", nil))
+
+	if !errors.Is(err, ErrConflict) || !errors.Is(err, errTaskLocked) || errors.Is(err, ErrNotFound) {
+		t.Fatalf("Unexpected sentinel matching for %v", err)
+	}
+
+	response := ErrorResponse(LocalizeError(err, i18n.RU))
+	if response.Success || response.Type != ErrorTypeConflict || response.Code != 409 {
+		t.Errorf("Unexpected envelope: %+v", response)
+	}
+	if response.Error != "У выполненной или отмененной задачи нельзя изменить название и приоритет" {
+		t.Errorf("Unexpected localized message: %q", response.Error)
+	}
+}
+
+func TestAsAppError_ClassifiesPlainErrors(t *testing.T) {
+	tests := []struct {
+		err      error
+		typ      ErrorType
+		code     int
+		sentinel error
+	}{
+		{errors.New("boom"), ErrorTypeInternal, 500, ErrInternal},
+		{fmt.Errorf("query: %w", context.DeadlineExceeded), ErrorTypeTimeout, 408, ErrTimeout},
+		{NewError(ErrorTypeNotFound, "no code"), ErrorTypeNotFound, 404, ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		response := ErrorResponse(tt.err)
+		if response.Type != tt.typ || response.Code != tt.code {
+			t.Errorf("%v: expected %s/%d, got %+v", tt.err, tt.typ, tt.code, response)
+		}
+		if !errors.Is(AsAppError(tt.err), tt.sentinel) {
+			t.Errorf("%v: expected errors.Is %v", tt.err, tt.sentinel)
+		}
+	}
+
+	if response := WailsResponse([]int{1}, nil).(StandardResponse); !response.Success || response.Type != "" {
+		t.Errorf("Unexpected success envelope: %+v", response)
+	}
+}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// Ключи полей корреляции в записях логов
+const (
+	FieldRequestID   = "request_id"
+	FieldOperationID = "operation_id"
+	FieldOperation   = "operation"
+)
+
+type logFieldsKey struct{}
+
+type loggerKey struct{}
+
+// NewID генерирует случайный идентификатор (16 hex-символов) для запросов и операций
+func NewID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand не должен отказывать; время все равно уникально в пределах процесса
+		return fmt.Sprintf("%016x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// ContextWithFields возвращает контекст, все записи логов которого получат fields.
+// Поля родительского контекста сохраняются, одноименные перезаписываются
+func ContextWithFields(ctx context.Context, fields map[string]interface{}) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	parent := FieldsFromContext(ctx)
+	merged := make(map[string]interface{}, len(parent)+len(fields))
+	for key, value := range parent {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+
+	return context.WithValue(ctx, logFieldsKey{}, merged)
+}
+
+// FieldsFromContext возвращает поля логов из контекста (не изменять)
+func FieldsFromContext(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(logFieldsKey{}).(map[string]interface{})
+	return fields
+}
+
+// WithRequestID добавляет в контекст ID HTTP запроса
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return ContextWithFields(ctx, map[string]interface{}{FieldRequestID: requestID})
+}
+
+// RequestIDFromContext возвращает ID HTTP запроса или пустую строку
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := FieldsFromContext(ctx)[FieldRequestID].(string)
+	return id
+}
+
+// WithOperation начинает операцию: добавляет в контекст ее имя и новый operation_id
+func WithOperation(ctx context.Context, name string) context.Context {
+	return ContextWithFields(ctx, map[string]interface{}{
+		FieldOperation:   name,
+		FieldOperationID: NewID(),
+	})
+}
+
+// OperationIDFromContext возвращает ID текущей операции или пустую строку
+func OperationIDFromContext(ctx context.Context) string {
+	id, _ := FieldsFromContext(ctx)[FieldOperationID].(string)
+	return id
+}
+
+// WithLogger сохраняет логгер в контексте
+func WithLogger(ctx context.Context, logger *Logger) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// LoggerFromContext возвращает логгер из контекста или глобальный логгер
+func LoggerFromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*Logger); ok && logger != nil {
+			return logger
+		}
+	}
+	return defaultLogger
+}
+
+// mergeFields объединяет поля контекста и записи; поля записи имеют приоритет
+func mergeFields(ctxFields, fields map[string]interface{}) map[string]interface{} {
+	if len(ctxFields) == 0 {
+		return fields
+	}
+	if len(fields) == 0 {
+		return ctxFields
+	}
+
+	merged := make(map[string]interface{}, len(ctxFields)+len(fields))
+	for key, value := range ctxFields {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return merged
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogger_ContextFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLogger(LoggerConfig{Level: DEBUG, Output: &buf})
+	if err != nil {
+		t.Fatalf("NewLogger failed: %v", err)
+	}
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	ctx = WithOperation(ctx, "CreateTask")
+	ctx = WithLogger(ctx, logger)
+
+	if RequestIDFromContext(ctx) != "req-1" || len(OperationIDFromContext(ctx)) != 16 {
+		t.Fatalf("Unexpected context ids: %v", FieldsFromContext(ctx))
+	}
+
+	// Пакетная функция берет логгер из контекста, поля записи перекрывают поля контекста
+	WarnContext(ctx, "Retrying", map[string]interface{}{"attempt": 1, FieldOperation: "override"})
+
+	line := buf.String()
+	for _, want := range []string{"[WARN] Retrying", "request_id=req-1", "operation_id=" + OperationIDFromContext(ctx), "operation=override", "attempt=1"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("Expected %q in %q", want, line)
+		}
+	}
+
+	// Текстовый формат указывает место вызова, а не logger.go
+	if !strings.Contains(line, "logcontext_test.go") {
+		t.Errorf("Expected caller file in %q", line)
+	}
+}
+
+func TestLogger_DebugSampling(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := NewLogger(LoggerConfig{
+		Level:         DEBUG,
+		Output:        &buf,
+		DebugSampling: SamplingConfig{Initial: 2, Thereafter: 3, Tick: time.Hour},
+	})
+
+	for i := 0; i < 10; i++ {
+		logger.Debug("hot path")
+		logger.Info("not sampled")
+	}
+
+	// 2 первых + 5-е и 8-е
+	if got := strings.Count(buf.String(), "hot path"); got != 4 {
+		t.Errorf("Expected 4 sampled debug lines, got %d", got)
+	}
+	if got := strings.Count(buf.String(), "not sampled"); got != 10 {
+		t.Errorf("INFO must not be sampled, got %d", got)
+	}
+	if logger.SampledOut() != 6 {
+		t.Errorf("Expected 6 dropped, got %d", logger.SampledOut())
+	}
+
+	logger.SetDebugSampling(SamplingConfig{})
+	buf.Reset()
+	logger.Debug("hot path")
+	if !strings.Contains(buf.String(), "hot path") {
+		t.Error("Expected sampling to be disabled")
+	}
+}
+
+func TestLogger_SlogBackendAndBridge(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := NewLogger(LoggerConfig{
+		Level:      INFO,
+		Output:     &buf,
+		JSONFormat: true,
+		NewHandler: DefaultSlogHandler,
+	})
+
+	ctx := WithOperation(context.Background(), "GetTasks")
+	logger.InfoContext(ctx, "via logger", map[string]interface{}{"count": 3})
+	logger.Slog().With("component", "test").WithGroup("db").InfoContext(ctx, "via slog", "rows", 5)
+	logger.Slog().Debug("below level")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 JSON lines, got %q", buf.String())
+	}
+
+	var first, second map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", lines[0], err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", lines[1], err)
+	}
+
+	if first["msg"] != "via logger" || first["count"] != float64(3) || first[FieldOperation] != "GetTasks" {
+		t.Errorf("Unexpected record: %v", first)
+	}
+	source, _ := first["source"].(map[string]interface{})
+	if file, _ := source["file"].(string); !strings.HasSuffix(file, "logcontext_test.go") {
+		t.Errorf("Expected source to point to the test, got %v", first["source"])
+	}
+
+	if second["msg"] != "via slog" || second["component"] != "test" || second["db.rows"] != float64(5) || second[FieldOperationID] == nil {
+		t.Errorf("Unexpected bridged record: %v", second)
+	}
+}
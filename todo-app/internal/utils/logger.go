@@ -1,13 +1,16 @@
 package utils
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -42,14 +45,18 @@ func (l LogLevel) String() string {
 
 // Logger представляет кастомный логгер
 type Logger struct {
-	level      LogLevel
+	level      atomic.Int32 // LogLevel, меняется при перезагрузке конфигурации
 	output     io.Writer
+	file       *RotatingFile // nil, если логи пишутся только в Output
 	debugLog   *log.Logger
 	infoLog    *log.Logger
 	warnLog    *log.Logger
 	errorLog   *log.Logger
 	fatalLog   *log.Logger
-	jsonFormat bool
+	jsonFormat atomic.Bool
+	newHandler func(w io.Writer, json bool) slog.Handler
+	handler    atomic.Pointer[handlerRef] // если задан, записи передаются ему вместо встроенного форматирования
+	sampler    atomic.Pointer[sampler]    // семплирование DEBUG, nil - отключено
 }
 
 // LoggerConfig содержит конфигурацию логгера
@@ -58,6 +65,13 @@ type LoggerConfig struct {
 	Output     io.Writer
 	JSONFormat bool
 	LogFile    string
+	Rotation   RotationConfig // ротация LogFile
+	// DebugSampling ограничивает поток повторяющихся DEBUG сообщений
+	DebugSampling SamplingConfig
+	// NewHandler переводит логгер на обработчик log/slog (например DefaultSlogHandler).
+	// Функция получает вывод (Output и LogFile с ротацией) и признак JSON формата;
+	// API Logger при этом сохраняется
+	NewHandler func(w io.Writer, json bool) slog.Handler
 }
 
 // NewLogger создает новый логгер
@@ -68,39 +82,113 @@ func NewLogger(config LoggerConfig) (*Logger, error) {
 	}
 
 	// Если указан файл логов, создаем MultiWriter
+	var file *RotatingFile
 	if config.LogFile != "" {
-		// Создаем директорию для логов если она не существует
-		logDir := filepath.Dir(config.LogFile)
-		if err := os.MkdirAll(logDir, 0755); err != nil {
-			return nil, fmt.Errorf("failed to create log directory: %w", err)
-		}
-
-		file, err := os.OpenFile(config.LogFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+		var err error
+		file, err = OpenRotatingFile(config.LogFile, config.Rotation)
 		if err != nil {
-			return nil, fmt.Errorf("failed to open log file: %w", err)
+			return nil, err
 		}
-		output = io.MultiWriter(config.Output, file)
+		output = io.MultiWriter(output, file)
 	}
 
 	logger := &Logger{
-		level:      config.Level,
 		output:     output,
-		jsonFormat: config.JSONFormat,
+		file:       file,
+		newHandler: config.NewHandler,
 	}
+	logger.level.Store(int32(config.Level))
 
 	// Создаем логгеры для каждого уровня
+	logger.debugLog = log.New(output, "", 0)
+	logger.infoLog = log.New(output, "", 0)
+	logger.warnLog = log.New(output, "", 0)
+	logger.errorLog = log.New(output, "", 0)
+	logger.fatalLog = log.New(output, "", 0)
+	logger.SetJSONFormat(config.JSONFormat)
+	logger.SetDebugSampling(config.DebugSampling)
+
+	return logger, nil
+}
+
+// Rotate принудительно ротирует файл логов (если он задан)
+func (l *Logger) Rotate() error {
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Rotate()
+}
+
+// Close закрывает файл логов. Записи после закрытия снова откроют файл
+func (l *Logger) Close() error {
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Close()
+}
+
+// Level возвращает текущий уровень логирования
+func (l *Logger) Level() LogLevel {
+	return LogLevel(l.level.Load())
+}
+
+// SetLevel меняет уровень логирования без пересоздания логгера
+func (l *Logger) SetLevel(level LogLevel) {
+	l.level.Store(int32(level))
+}
+
+// SetJSONFormat переключает формат вывода без пересоздания логгера
+func (l *Logger) SetJSONFormat(enabled bool) {
+	l.jsonFormat.Store(enabled)
+
 	flags := log.LstdFlags
-	if !config.JSONFormat {
+	if !enabled {
 		flags = log.LstdFlags | log.Lshortfile
 	}
 
-	logger.debugLog = log.New(output, "", flags)
-	logger.infoLog = log.New(output, "", flags)
-	logger.warnLog = log.New(output, "", flags)
-	logger.errorLog = log.New(output, "", flags)
-	logger.fatalLog = log.New(output, "", flags)
+	for _, logger := range []*log.Logger{l.debugLog, l.infoLog, l.warnLog, l.errorLog, l.fatalLog} {
+		logger.SetFlags(flags)
+	}
 
-	return logger, nil
+	if l.newHandler != nil {
+		l.handler.Store(&handlerRef{Handler: l.newHandler(l.output, enabled)})
+	}
+}
+
+// SetDebugSampling меняет семплирование DEBUG сообщений без пересоздания логгера
+func (l *Logger) SetDebugSampling(config SamplingConfig) {
+	if !config.Enabled() {
+		l.sampler.Store(nil)
+		return
+	}
+	l.sampler.Store(newSampler(config))
+}
+
+// SampledOut возвращает количество DEBUG сообщений, отброшенных семплированием
+// с последнего изменения настроек
+func (l *Logger) SampledOut() int64 {
+	if s := l.sampler.Load(); s != nil {
+		return s.dropped.Load()
+	}
+	return 0
+}
+
+// ParseLogLevel преобразует уровень из конфигурации ("debug", "info", ...)
+func ParseLogLevel(level string) (LogLevel, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return DEBUG, nil
+	case "info":
+		return INFO, nil
+	case "warn":
+		return WARN, nil
+	case "error":
+		return ERROR, nil
+	case "fatal":
+		return FATAL, nil
+	default:
+		return INFO, fmt.Errorf("unknown log level: %s", level)
+	}
 }
 
 // DefaultLogger создает логгер с настройками по умолчанию
@@ -113,16 +201,17 @@ func DefaultLogger() *Logger {
 	return logger
 }
 
-// formatMessage форматирует сообщение в зависимости от формата
-func (l *Logger) formatMessage(level LogLevel, message string, fields map[string]interface{}) string {
-	if l.jsonFormat {
-		return l.formatJSON(level, message, fields)
+// formatMessage форматирует сообщение в зависимости от формата.
+// skip - количество кадров стека между formatMessage и кодом, вызвавшим логгер
+func (l *Logger) formatMessage(skip int, level LogLevel, message string, fields map[string]interface{}) string {
+	if l.jsonFormat.Load() {
+		return l.formatJSON(skip+1, level, message, fields)
 	}
 	return l.formatText(level, message, fields)
 }
 
 // formatJSON форматирует сообщение в JSON формате
-func (l *Logger) formatJSON(level LogLevel, message string, fields map[string]interface{}) string {
+func (l *Logger) formatJSON(skip int, level LogLevel, message string, fields map[string]interface{}) string {
 	// Простая JSON сериализация (можно заменить на encoding/json)
 	json := fmt.Sprintf(`{"timestamp":"%s","level":"%s","message":"%s"`,
 		time.Now().UTC().Format(time.RFC3339), level.String(), message)
@@ -132,7 +221,7 @@ func (l *Logger) formatJSON(level LogLevel, message string, fields map[string]in
 	}
 
 	// Добавляем caller info
-	_, file, line, ok := runtime.Caller(3)
+	_, file, line, ok := runtime.Caller(skip + 1)
 	if ok {
 		json += fmt.Sprintf(`,"caller":"%s:%d"`, filepath.Base(file), line)
 	}
@@ -158,76 +247,102 @@ func (l *Logger) formatText(level LogLevel, message string, fields map[string]in
 
 // shouldLog проверяет, нужно ли логировать сообщение данного уровня
 func (l *Logger) shouldLog(level LogLevel) bool {
-	return level >= l.level
+	return level >= l.Level()
 }
 
-// Debug логирует сообщение уровня DEBUG
-func (l *Logger) Debug(message string, fields ...map[string]interface{}) {
-	if !l.shouldLog(DEBUG) {
+// log записывает сообщение с полями контекста. skip - количество кадров стека
+// между log и кодом, вызвавшим логгер (для определения caller)
+func (l *Logger) log(ctx context.Context, skip int, level LogLevel, message string, fields map[string]interface{}) {
+	if !l.shouldLog(level) {
 		return
 	}
 
-	var fieldMap map[string]interface{}
-	if len(fields) > 0 {
-		fieldMap = fields[0]
+	if level == DEBUG {
+		if s := l.sampler.Load(); s != nil && !s.allow(message, time.Now()) {
+			return
+		}
 	}
 
-	l.debugLog.Println(l.formatMessage(DEBUG, message, fieldMap))
-}
+	fields = mergeFields(FieldsFromContext(ctx), fields)
 
-// Info логирует сообщение уровня INFO
-func (l *Logger) Info(message string, fields ...map[string]interface{}) {
-	if !l.shouldLog(INFO) {
+	if ref := l.handler.Load(); ref != nil {
+		l.logSlog(ctx, ref.Handler, skip+1, level, message, fields)
 		return
 	}
 
-	var fieldMap map[string]interface{}
-	if len(fields) > 0 {
-		fieldMap = fields[0]
-	}
-
-	l.infoLog.Println(l.formatMessage(INFO, message, fieldMap))
+	l.levelLogger(level).Output(skip+2, l.formatMessage(skip+1, level, message, fields))
 }
 
-// Warn логирует сообщение уровня WARN
-func (l *Logger) Warn(message string, fields ...map[string]interface{}) {
-	if !l.shouldLog(WARN) {
-		return
+// levelLogger возвращает log.Logger для уровня
+func (l *Logger) levelLogger(level LogLevel) *log.Logger {
+	switch level {
+	case DEBUG:
+		return l.debugLog
+	case INFO:
+		return l.infoLog
+	case WARN:
+		return l.warnLog
+	case ERROR:
+		return l.errorLog
+	default:
+		return l.fatalLog
 	}
+}
 
-	var fieldMap map[string]interface{}
+// firstFields возвращает необязательный аргумент fields
+func firstFields(fields []map[string]interface{}) map[string]interface{} {
 	if len(fields) > 0 {
-		fieldMap = fields[0]
+		return fields[0]
 	}
+	return nil
+}
 
-	l.warnLog.Println(l.formatMessage(WARN, message, fieldMap))
+// Debug логирует сообщение уровня DEBUG
+func (l *Logger) Debug(message string, fields ...map[string]interface{}) {
+	l.log(nil, 1, DEBUG, message, firstFields(fields))
 }
 
-// Error логирует сообщение уровня ERROR
-func (l *Logger) Error(message string, fields ...map[string]interface{}) {
-	if !l.shouldLog(ERROR) {
-		return
-	}
+// Info логирует сообщение уровня INFO
+func (l *Logger) Info(message string, fields ...map[string]interface{}) {
+	l.log(nil, 1, INFO, message, firstFields(fields))
+}
 
-	var fieldMap map[string]interface{}
-	if len(fields) > 0 {
-		fieldMap = fields[0]
-	}
+// Warn логирует сообщение уровня WARN
+func (l *Logger) Warn(message string, fields ...map[string]interface{}) {
+	l.log(nil, 1, WARN, message, firstFields(fields))
+}
 
-	l.errorLog.Println(l.formatMessage(ERROR, message, fieldMap))
+// Error логирует сообщение уровня ERROR
+func (l *Logger) Error(message string, fields ...map[string]interface{}) {
+	l.log(nil, 1, ERROR, message, firstFields(fields))
 }
 
 // Fatal логирует сообщение уровня FATAL и завершает программу
 func (l *Logger) Fatal(message string, fields ...map[string]interface{}) {
-	var fieldMap map[string]interface{}
-	if len(fields) > 0 {
-		fieldMap = fields[0]
-	}
-
-	l.fatalLog.Println(l.formatMessage(FATAL, message, fieldMap))
+	l.log(nil, 1, FATAL, message, firstFields(fields))
 	os.Exit(1)
 }
 
+// DebugContext логирует DEBUG сообщение с полями из контекста (request_id, operation_id)
+func (l *Logger) DebugContext(ctx context.Context, message string, fields ...map[string]interface{}) {
+	l.log(ctx, 1, DEBUG, message, firstFields(fields))
+}
+
+// InfoContext логирует INFO сообщение с полями из контекста
+func (l *Logger) InfoContext(ctx context.Context, message string, fields ...map[string]interface{}) {
+	l.log(ctx, 1, INFO, message, firstFields(fields))
+}
+
+// WarnContext логирует WARN сообщение с полями из контекста
+func (l *Logger) WarnContext(ctx context.Context, message string, fields ...map[string]interface{}) {
+	l.log(ctx, 1, WARN, message, firstFields(fields))
+}
+
+// ErrorContext логирует ERROR сообщение с полями из контекста
+func (l *Logger) ErrorContext(ctx context.Context, message string, fields ...map[string]interface{}) {
+	l.log(ctx, 1, ERROR, message, firstFields(fields))
+}
+
 // LogError логирует ошибку с дополнительным контекстом
 func (l *Logger) LogError(err error, context string, fields ...map[string]interface{}) {
 	var fieldMap map[string]interface{}
@@ -245,16 +360,33 @@ func (l *Logger) LogError(err error, context string, fields ...map[string]interf
 
 // LogDBQuery логирует запрос к базе данных
 func (l *Logger) LogDBQuery(query string, duration time.Duration, err error) {
+	l.logDBQuery(nil, 1, query, duration, -1, err, false)
+}
+
+// LogDBQueryContext логирует запрос к базе данных с полями контекста: ошибки - ERROR,
+// медленные запросы - WARN, остальные - DEBUG. rows < 0 - количество строк неизвестно
+func (l *Logger) LogDBQueryContext(ctx context.Context, query string, duration time.Duration, rows int64, err error, slow bool) {
+	l.logDBQuery(ctx, 1, query, duration, rows, err, slow)
+}
+
+// logDBQuery формирует запись о запросе к БД
+func (l *Logger) logDBQuery(ctx context.Context, skip int, query string, duration time.Duration, rows int64, err error, slow bool) {
 	fields := map[string]interface{}{
 		"query":    query,
 		"duration": duration.String(),
 	}
+	if rows >= 0 {
+		fields["rows"] = rows
+	}
 
-	if err != nil {
+	switch {
+	case err != nil:
 		fields["error"] = err.Error()
-		l.Error("Database query failed", fields)
-	} else {
-		l.Debug("Database query executed", fields)
+		l.log(ctx, skip+1, ERROR, "Database query failed", fields)
+	case slow:
+		l.log(ctx, skip+1, WARN, "Slow database query", fields)
+	default:
+		l.log(ctx, skip+1, DEBUG, "Database query executed", fields)
 	}
 }
 
@@ -329,6 +461,14 @@ func (l *Logger) WithFields(fields map[string]interface{}) *ContextLogger {
 	}
 }
 
+// WithContext создает временный логгер с полями из контекста (request_id, operation_id)
+func (l *Logger) WithContext(ctx context.Context) *ContextLogger {
+	return &ContextLogger{
+		logger: l,
+		fields: FieldsFromContext(ctx),
+	}
+}
+
 // ContextLogger предоставляет логгер с предустановленным контекстом
 type ContextLogger struct {
 	logger *Logger
@@ -337,22 +477,22 @@ type ContextLogger struct {
 
 // Debug логирует DEBUG сообщение с контекстом
 func (cl *ContextLogger) Debug(message string) {
-	cl.logger.Debug(message, cl.fields)
+	cl.logger.log(nil, 1, DEBUG, message, cl.fields)
 }
 
 // Info логирует INFO сообщение с контекстом
 func (cl *ContextLogger) Info(message string) {
-	cl.logger.Info(message, cl.fields)
+	cl.logger.log(nil, 1, INFO, message, cl.fields)
 }
 
 // Warn логирует WARN сообщение с контекстом
 func (cl *ContextLogger) Warn(message string) {
-	cl.logger.Warn(message, cl.fields)
+	cl.logger.log(nil, 1, WARN, message, cl.fields)
 }
 
 // Error логирует ERROR сообщение с контекстом
 func (cl *ContextLogger) Error(message string) {
-	cl.logger.Error(message, cl.fields)
+	cl.logger.log(nil, 1, ERROR, message, cl.fields)
 }
 
 // Глобальный логгер
@@ -365,19 +505,36 @@ func SetDefaultLogger(logger *Logger) {
 
 // Глобальные функции для удобства использования
 func Debug(message string, fields ...map[string]interface{}) {
-	defaultLogger.Debug(message, fields...)
+	defaultLogger.log(nil, 1, DEBUG, message, firstFields(fields))
 }
 
 func Info(message string, fields ...map[string]interface{}) {
-	defaultLogger.Info(message, fields...)
+	defaultLogger.log(nil, 1, INFO, message, firstFields(fields))
 }
 
 func Warn(message string, fields ...map[string]interface{}) {
-	defaultLogger.Warn(message, fields...)
+	defaultLogger.log(nil, 1, WARN, message, firstFields(fields))
 }
 
 func Error(message string, fields ...map[string]interface{}) {
-	defaultLogger.Error(message, fields...)
+	defaultLogger.log(nil, 1, ERROR, message, firstFields(fields))
+}
+
+// Функции с контекстом пишут в логгер из контекста (WithLogger) или в глобальный
+func DebugContext(ctx context.Context, message string, fields ...map[string]interface{}) {
+	LoggerFromContext(ctx).log(ctx, 1, DEBUG, message, firstFields(fields))
+}
+
+func InfoContext(ctx context.Context, message string, fields ...map[string]interface{}) {
+	LoggerFromContext(ctx).log(ctx, 1, INFO, message, firstFields(fields))
+}
+
+func WarnContext(ctx context.Context, message string, fields ...map[string]interface{}) {
+	LoggerFromContext(ctx).log(ctx, 1, WARN, message, firstFields(fields))
+}
+
+func ErrorContext(ctx context.Context, message string, fields ...map[string]interface{}) {
+	LoggerFromContext(ctx).log(ctx, 1, ERROR, message, firstFields(fields))
 }
 
 func Fatal(message string, fields ...map[string]interface{}) {
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// privatePrefixes содержит диапазоны, недоступные для исходящих запросов
+// к внешним адресам: сам хост, локальные и служебные сети
+var privatePrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "эта" сеть
+	netip.MustParsePrefix("10.0.0.0/8"),     // RFC 1918
+	netip.MustParsePrefix("100.64.0.0/10"),  // carrier-grade NAT, RFC 6598
+	netip.MustParsePrefix("127.0.0.0/8"),    // loopback
+	netip.MustParsePrefix("169.254.0.0/16"), // link-local
+	netip.MustParsePrefix("172.16.0.0/12"),  // RFC 1918
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("192.168.0.0/16"), // RFC 1918
+	netip.MustParsePrefix("198.18.0.0/15"),  // тестирование производительности
+	netip.MustParsePrefix("224.0.0.0/4"),    // multicast
+	netip.MustParsePrefix("240.0.0.0/4"),    // зарезервировано, включая broadcast
+	netip.MustParsePrefix("::/128"),         // неуказанный адрес
+	netip.MustParsePrefix("::1/128"),        // loopback
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64, RFC 6052
+	netip.MustParsePrefix("64:ff9b:1::/48"), // локальный NAT64, RFC 8215
+	netip.MustParsePrefix("fc00::/7"),       // unique local, RFC 4193
+	netip.MustParsePrefix("fe80::/10"),      // link-local
+	netip.MustParsePrefix("ff00::/8"),       // multicast
+}
+
+// IsPrivateIP сообщает, относится ли адрес к самому хосту, локальной или
+// служебной сети. IPv4-mapped адреса (::ffff:10.0.0.1) проверяются как IPv4
+func IsPrivateIP(ip net.IP) bool {
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return true
+	}
+	addr = addr.Unmap()
+
+	for _, prefix := range privatePrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPrivateHost сообщает, указывает ли имя хоста (без порта) на локальный адрес.
+// Проверяются только literal IP и имя localhost; имена, которые резолвятся
+// в локальные адреса, нужно проверять при подключении
+func IsPrivateHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	// Зона IPv6 (fe80::1%eth0) не входит в адрес
+	host, _, _ = strings.Cut(strings.Trim(host, "[]"), "%")
+	if ip := net.ParseIP(host); ip != nil {
+		return IsPrivateIP(ip)
+	}
+	return false
+}
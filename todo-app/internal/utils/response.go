@@ -3,25 +3,30 @@ package utils
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 )
 
 // StandardResponse представляет стандартную структуру ответа
 type StandardResponse struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
-	Message string      `json:"message,omitempty"`
-	Code    int         `json:"code,omitempty"`
+	Success bool         `json:"success"`
+	Data    interface{}  `json:"data,omitempty"`
+	Error   string       `json:"error,omitempty"`
+	Message string       `json:"message,omitempty"`
+	Code    int          `json:"code,omitempty"`
+	Type    ErrorType    `json:"type,omitempty"`
+	Fields  []FieldError `json:"fields,omitempty"`
 }
 
-// PaginationMeta содержит метаданные для пагинации
+// PaginationMeta содержит метаданные для пагинации.
+// При пагинации по курсору TotalItems и TotalPages не вычисляются (COUNT(*) не выполняется)
 type PaginationMeta struct {
-	CurrentPage int  `json:"current_page"`
-	PageSize    int  `json:"page_size"`
-	TotalItems  int  `json:"total_items"`
-	TotalPages  int  `json:"total_pages"`
-	HasNext     bool `json:"has_next"`
-	HasPrev     bool `json:"has_previous"`
+	CurrentPage int    `json:"current_page"`
+	PageSize    int    `json:"page_size"`
+	TotalItems  int    `json:"total_items"`
+	TotalPages  int    `json:"total_pages"`
+	HasNext     bool   `json:"has_next"`
+	HasPrev     bool   `json:"has_previous"`
+	NextCursor  string `json:"next_cursor,omitempty"`
 }
 
 // PaginatedResponse представляет ответ с пагинацией
@@ -49,11 +54,17 @@ func SuccessResponseWithMessage(data interface{}, message string) StandardRespon
 	}
 }
 
-// ErrorResponse создает ответ с ошибкой
+// ErrorResponse создает ответ с ошибкой: сообщение, HTTP-код и тип AppError
+// из цепочки err (см. AsAppError) и ошибки полей, чтобы фронтенд мог подсветить
+// все поля формы. Тип - стабильный машиночитаемый код для ветвления на клиенте
 func ErrorResponse(err error) StandardResponse {
+	appErr := AsAppError(err)
 	return StandardResponse{
 		Success: false,
-		Error:   err.Error(),
+		Error:   appErr.Message,
+		Code:    GetErrorCode(appErr),
+		Type:    appErr.Type,
+		Fields:  appErr.Fields,
 	}
 }
 
@@ -158,6 +169,27 @@ func CalculatePaginationMeta(currentPage, pageSize, totalItems int) PaginationMe
 	}
 }
 
+// CalculateCursorPaginationMeta формирует метаданные пагинации по курсору
+func CalculateCursorPaginationMeta(pageSize int, hasNext, hasPrev bool, nextCursor string) PaginationMeta {
+	return PaginationMeta{
+		PageSize:   pageSize,
+		HasNext:    hasNext,
+		HasPrev:    hasPrev,
+		NextCursor: nextCursor,
+	}
+}
+
+// WriteErrorJSON отправляет HTTP ответ с ошибкой в формате StandardResponse
+// и статусом по коду AppError
+func WriteErrorJSON(w http.ResponseWriter, err error) {
+	response := ErrorResponse(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(response.Code)
+	json.NewEncoder(w).Encode(response)
+}
+
 // ToJSON конвертирует ответ в JSON строку
 func (r StandardResponse) ToJSON() (string, error) {
 	jsonBytes, err := json.Marshal(r)
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// RetryPolicy описывает повтор операций с экспоненциальной задержкой и jitter
+type RetryPolicy struct {
+	MaxAttempts    int           // всего попыток, включая первую; <= 1 - без повторов
+	InitialBackoff time.Duration // задержка перед второй попыткой
+	MaxBackoff     time.Duration // верхняя граница задержки
+}
+
+// Backoff возвращает задержку перед попыткой attempt (начиная с 1 для первого повтора):
+// случайное значение в [d/2, d], где d = InitialBackoff * 2^(attempt-1), но не больше MaxBackoff.
+// Разброс не дает многим клиентам переподключаться к БД одновременно
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	if p.InitialBackoff <= 0 {
+		return 0
+	}
+
+	delay := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+}
+
+// Retry выполняет fn, повторяя ее при временных ошибках БД (IsTransientDBError).
+// onRetry (может быть nil) вызывается перед каждым повтором. Возвращает
+// последнюю ошибку fn или ошибку контекста, если он отменен во время ожидания
+func Retry(ctx context.Context, policy RetryPolicy, fn func() error, onRetry func(attempt int, delay time.Duration, err error)) error {
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil {
+			return nil
+		}
+		if !IsTransientDBError(err) {
+			return err
+		}
+
+		recordTransientDBError(err)
+
+		if attempt >= policy.MaxAttempts {
+			return err
+		}
+
+		delay := policy.Backoff(attempt)
+		if onRetry != nil {
+			onRetry(attempt, delay, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// transientSQLStates коды ошибок PostgreSQL, после которых повтор имеет смысл
+var transientSQLStates = map[pq.ErrorCode]bool{
+	"40001": true, // serialization_failure
+	"40P01": true, // deadlock_detected
+	"53300": true, // too_many_connections
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now (БД запускается)
+}
+
+// IsTransientDBError определяет, что ошибка вызвана временной недоступностью БД
+// (сеть, перезапуск сервера, нехватка соединений), а не запросом или данными.
+// Ошибки аутентификации, синтаксиса и ограничений временными не считаются
+func IsTransientDBError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// Отмена операции вызывающим кодом - не повод повторять
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		// Класс 08 - connection exception
+		return pqErr.Code.Class() == "08" || transientSQLStates[pqErr.Code]
+	}
+
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr)
+}
+
+// transientDBErrors хранит время последней временной ошибки БД
+// для определения деградированного режима
+var transientDBErrors struct {
+	sync.Mutex
+	last    time.Time
+	lastErr string
+	count   int64
+}
+
+// recordTransientDBError запоминает временную ошибку БД
+func recordTransientDBError(err error) {
+	transientDBErrors.Lock()
+	defer transientDBErrors.Unlock()
+
+	transientDBErrors.last = time.Now()
+	transientDBErrors.lastErr = err.Error()
+	transientDBErrors.count++
+}
+
+// LastTransientDBError возвращает время и текст последней временной ошибки БД
+// и общее количество таких ошибок с запуска
+func LastTransientDBError() (time.Time, string, int64) {
+	transientDBErrors.Lock()
+	defer transientDBErrors.Unlock()
+
+	return transientDBErrors.last, transientDBErrors.lastErr, transientDBErrors.count
+}
@@ -0,0 +1,373 @@
+package utils
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat формат времени ротации в имени архивного файла (UTC)
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// RotationConfig содержит настройки ротации файла логов.
+// Нулевые значения отключают соответствующее ограничение
+type RotationConfig struct {
+	MaxSize    int64         // размер в байтах, после которого файл ротируется
+	Interval   time.Duration // ротация по времени: файл закрывается на границе интервала (UTC), например 24h - в полночь
+	MaxAge     time.Duration // архивы старше удаляются
+	MaxBackups int           // сколько архивов хранить
+	Compress   bool          // сжимать архивы gzip
+}
+
+// RotatingFile файл логов с ротацией по размеру и времени.
+// При ротации текущий файл переименовывается в <имя>-<время><расширение>,
+// а запись продолжается в новый файл с исходным именем. Сжатие и удаление
+// старых архивов выполняются в фоне и не задерживают запись
+type RotatingFile struct {
+	path   string
+	config RotationConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	millMu sync.Mutex // сжатие и очистка архивов выполняются по одной
+	millWG sync.WaitGroup
+}
+
+// OpenRotatingFile открывает (или создает) файл логов с ротацией
+func OpenRotatingFile(path string, config RotationConfig) (*RotatingFile, error) {
+	return openRotatingFile(path, config, time.Now)
+}
+
+// openRotatingFile открывает файл с заданными часами (для тестов)
+func openRotatingFile(path string, config RotationConfig, now func() time.Time) (*RotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	f := &RotatingFile{
+		path:   path,
+		config: config,
+		now:    now,
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.openExisting(); err != nil {
+		return nil, err
+	}
+
+	// Архивы могли остаться от предыдущих запусков с другими настройками
+	f.mill()
+	return f, nil
+}
+
+// Path возвращает путь к текущему файлу логов
+func (f *RotatingFile) Path() string {
+	return f.path
+}
+
+// Write записывает данные, предварительно ротируя файл при необходимости.
+// Ошибка ротации не теряет запись: данные пишутся в прежний файл
+func (f *RotatingFile) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		if err := f.openExisting(); err != nil {
+			return 0, err
+		}
+	}
+
+	if f.shouldRotate(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "log rotation failed: %v\n", err)
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Rotate принудительно ротирует файл
+func (f *RotatingFile) Rotate() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.rotate()
+}
+
+// Close закрывает файл и дожидается фонового сжатия архивов
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+	f.mu.Unlock()
+
+	f.millWG.Wait()
+	return err
+}
+
+// openExisting открывает файл на дозапись. Время открытия берется из времени
+// изменения файла, чтобы ротация по времени сработала и после перезапуска
+func (f *RotatingFile) openExisting() error {
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	f.file = file
+	f.size = info.Size()
+	f.openedAt = f.now()
+	if info.Size() > 0 {
+		f.openedAt = info.ModTime()
+	}
+	return nil
+}
+
+// shouldRotate проверяет, нужно ли ротировать файл перед записью n байт.
+// Пустой файл не ротируется, поэтому одна длинная запись не создает пустых архивов
+func (f *RotatingFile) shouldRotate(n int64) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.config.MaxSize > 0 && f.size+n > f.config.MaxSize {
+		return true
+	}
+	if f.config.Interval > 0 {
+		now := f.now().UTC()
+		return !now.Truncate(f.config.Interval).Equal(f.openedAt.UTC().Truncate(f.config.Interval))
+	}
+	return false
+}
+
+// rotate переименовывает текущий файл в архив и открывает новый.
+// Если переименовать не удалось, прежний файл открывается заново,
+// чтобы запись логов не прерывалась
+func (f *RotatingFile) rotate() error {
+	if f.file != nil {
+		if err := f.file.Close(); err != nil {
+			return fmt.Errorf("failed to close log file: %w", err)
+		}
+		f.file = nil
+	}
+
+	backup := f.backupName(f.now())
+	renameErr := os.Rename(f.path, backup)
+	if renameErr != nil && os.IsNotExist(renameErr) {
+		renameErr = nil
+	}
+
+	if err := f.openExisting(); err != nil {
+		return err
+	}
+
+	// После неудачи следующая попытка будет через MaxSize байт или Interval, а не на каждой записи
+	f.size = 0
+	f.openedAt = f.now()
+	if renameErr != nil {
+		return fmt.Errorf("failed to rename log file: %w", renameErr)
+	}
+
+	f.mill()
+	return nil
+}
+
+// backupName возвращает свободное имя архива для времени ротации
+func (f *RotatingFile) backupName(t time.Time) string {
+	dir, prefix, ext := f.nameParts()
+	name := filepath.Join(dir, prefix+t.UTC().Format(backupTimeFormat)+ext)
+
+	// Несколько ротаций в одну миллисекунду (принудительные) не должны перезаписать архив
+	for i := 1; fileExists(name) || fileExists(name+".gz"); i++ {
+		name = filepath.Join(dir, fmt.Sprintf("%s%s-%d%s", prefix, t.UTC().Format(backupTimeFormat), i, ext))
+	}
+	return name
+}
+
+// nameParts возвращает директорию, префикс архивов и расширение файла логов
+func (f *RotatingFile) nameParts() (string, string, string) {
+	base := filepath.Base(f.path)
+	ext := filepath.Ext(base)
+	return filepath.Dir(f.path), strings.TrimSuffix(base, ext) + "-", ext
+}
+
+// logBackup архивный файл логов
+type logBackup struct {
+	path       string
+	rotatedAt  time.Time
+	compressed bool
+}
+
+// backups возвращает архивы файла логов, новые первыми
+func (f *RotatingFile) backups() ([]logBackup, error) {
+	dir, prefix, ext := f.nameParts()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log directory: %w", err)
+	}
+
+	var backups []logBackup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || strings.HasSuffix(name, ".tmp") {
+			continue
+		}
+
+		rest := strings.TrimPrefix(name, prefix)
+		compressed := strings.HasSuffix(rest, ext+".gz")
+		if compressed {
+			rest = strings.TrimSuffix(rest, ext+".gz")
+		} else if ext != "" && strings.HasSuffix(rest, ext) {
+			rest = strings.TrimSuffix(rest, ext)
+		} else if ext != "" {
+			continue
+		}
+
+		if len(rest) < len(backupTimeFormat) {
+			continue
+		}
+		rotatedAt, err := time.Parse(backupTimeFormat, rest[:len(backupTimeFormat)])
+		if err != nil {
+			continue
+		}
+
+		backups = append(backups, logBackup{
+			path:       filepath.Join(dir, name),
+			rotatedAt:  rotatedAt,
+			compressed: compressed,
+		})
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].rotatedAt.Equal(backups[j].rotatedAt) {
+			return backups[i].path > backups[j].path
+		}
+		return backups[i].rotatedAt.After(backups[j].rotatedAt)
+	})
+	return backups, nil
+}
+
+// mill запускает в фоне удаление лишних архивов и сжатие оставшихся
+func (f *RotatingFile) mill() {
+	if !f.config.Compress && f.config.MaxBackups <= 0 && f.config.MaxAge <= 0 {
+		return
+	}
+
+	f.millWG.Add(1)
+	go func() {
+		defer f.millWG.Done()
+
+		f.millMu.Lock()
+		defer f.millMu.Unlock()
+
+		if err := f.millOnce(); err != nil {
+			fmt.Fprintf(os.Stderr, "log retention failed: %v\n", err)
+		}
+	}()
+}
+
+// millOnce применяет ограничения MaxBackups и MaxAge и сжимает архивы
+func (f *RotatingFile) millOnce() error {
+	backups, err := f.backups()
+	if err != nil {
+		return err
+	}
+
+	var cutoff time.Time
+	if f.config.MaxAge > 0 {
+		cutoff = f.now().Add(-f.config.MaxAge)
+	}
+
+	var errs []string
+	kept := 0
+	for _, backup := range backups {
+		expired := !cutoff.IsZero() && backup.rotatedAt.Before(cutoff)
+		excess := f.config.MaxBackups > 0 && kept >= f.config.MaxBackups
+		if expired || excess {
+			if err := os.Remove(backup.path); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, err.Error())
+			}
+			continue
+		}
+		kept++
+
+		if f.config.Compress && !backup.compressed {
+			if err := compressLogFile(backup.path); err != nil {
+				errs = append(errs, err.Error())
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// compressLogFile сжимает файл в <path>.gz и удаляет исходный.
+// Архив пишется во временный файл, поэтому прерванное сжатие не оставляет битый .gz
+func compressLogFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer src.Close()
+
+	tmp := path + ".gz.tmp"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", tmp, err)
+	}
+
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to compress %s: %w", path, err)
+	}
+
+	if err := os.Rename(tmp, path+".gz"); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to rename %s: %w", tmp, err)
+	}
+
+	src.Close()
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return nil
+}
+
+// fileExists проверяет существование файла
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock управляемые часы для тестов ротации
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func listDir(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read dir: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
+
+func TestRotatingFile_SizeRotationAndCompression(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	clock := &fakeClock{now: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)}
+
+	file, err := openRotatingFile(path, RotationConfig{MaxSize: 10, Compress: true}, clock.Now)
+	if err != nil {
+		t.Fatalf("Failed to open: %v", err)
+	}
+
+	for _, line := range []string{"first-1\n", "second\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+		clock.Advance(time.Second)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	current, _ := os.ReadFile(path)
+	if string(current) != "second\n" {
+		t.Errorf("Expected current file to contain only the last line, got %q", current)
+	}
+
+	archive := filepath.Join(dir, "app-2026-01-02T10-00-01.000.log.gz")
+	gzFile, err := os.Open(archive)
+	if err != nil {
+		t.Fatalf("Expected compressed archive, dir: %v", listDir(t, dir))
+	}
+	defer gzFile.Close()
+	reader, err := gzip.NewReader(gzFile)
+	if err != nil {
+		t.Fatalf("Invalid gzip: %v", err)
+	}
+	content, _ := io.ReadAll(reader)
+	if string(content) != "first-1\n" {
+		t.Errorf("Unexpected archive content %q", content)
+	}
+
+	if names := listDir(t, dir); len(names) != 2 {
+		t.Errorf("Expected log and one archive, got %v", names)
+	}
+}
+
+func TestRotatingFile_IntervalRotationAndRetention(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)}
+
+	// Архив старше MaxAge от прошлого запуска
+	old := filepath.Join(dir, "app-2025-11-01T00-00-00.000.log")
+	if err := os.WriteFile(old, []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Посторонний файл не трогаем
+	other := filepath.Join(dir, "other.log")
+	if err := os.WriteFile(other, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := openRotatingFile(path, RotationConfig{Interval: 24 * time.Hour, MaxBackups: 2, MaxAge: 30 * 24 * time.Hour}, clock.Now)
+	if err != nil {
+		t.Fatalf("Failed to open: %v", err)
+	}
+
+	// Четыре дня: в течение дня ротации нет, на границе суток - есть
+	for day := 0; day < 4; day++ {
+		file.Write([]byte("morning\n"))
+		clock.Advance(6 * time.Hour)
+		file.Write([]byte("evening\n"))
+		clock.Advance(18 * time.Hour)
+	}
+	file.Write([]byte("today\n"))
+	file.Close()
+
+	var archives []string
+	for _, name := range listDir(t, dir) {
+		if strings.HasPrefix(name, "app-") {
+			archives = append(archives, name)
+		}
+	}
+	if len(archives) != 2 {
+		t.Fatalf("Expected 2 archives after retention, got %v", archives)
+	}
+	if archives[0] != "app-2026-01-04T12-00-00.000.log" || archives[1] != "app-2026-01-05T12-00-00.000.log" {
+		t.Errorf("Expected the newest archives to be kept, got %v", archives)
+	}
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Error("Expected expired archive to be removed")
+	}
+	if _, err := os.Stat(other); err != nil {
+		t.Error("Unrelated file must not be removed")
+	}
+
+	archived, _ := os.ReadFile(filepath.Join(dir, archives[1]))
+	if string(archived) != "morning\nevening\n" {
+		t.Errorf("Expected one day per archive, got %q", archived)
+	}
+}
+
+func TestNewLogger_FileWithoutOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+
+	logger, err := NewLogger(LoggerConfig{Level: INFO, LogFile: path})
+	if err != nil {
+		t.Fatalf("NewLogger failed: %v", err)
+	}
+	logger.Info("hello")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(content), "[INFO] hello") {
+		t.Errorf("Expected message in log file, got %q (%v)", content, err)
+	}
+}
@@ -0,0 +1,62 @@
+package utils
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// SamplingConfig ограничивает поток одинаковых DEBUG сообщений: в каждом интервале Tick
+// пишутся первые Initial записей с одним текстом, затем каждая Thereafter-я.
+// Initial <= 0 или Tick <= 0 отключают семплирование
+type SamplingConfig struct {
+	Initial    int
+	Thereafter int // 0 - после Initial записи до конца интервала отбрасываются
+	Tick       time.Duration
+}
+
+// Enabled проверяет, включено ли семплирование
+func (c SamplingConfig) Enabled() bool {
+	return c.Initial > 0 && c.Tick > 0
+}
+
+// sampler считает повторы сообщений в текущем интервале
+type sampler struct {
+	config SamplingConfig
+
+	mu      sync.Mutex
+	counts  map[string]int
+	resetAt time.Time
+
+	dropped atomic.Int64
+}
+
+func newSampler(config SamplingConfig) *sampler {
+	return &sampler{
+		config: config,
+		counts: make(map[string]int),
+	}
+}
+
+// allow решает, писать ли сообщение
+func (s *sampler) allow(message string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !now.Before(s.resetAt) {
+		clear(s.counts)
+		s.resetAt = now.Add(s.config.Tick)
+	}
+
+	s.counts[message]++
+	n := s.counts[message]
+	if n <= s.config.Initial {
+		return true
+	}
+	if s.config.Thereafter > 0 && (n-s.config.Initial)%s.config.Thereafter == 0 {
+		return true
+	}
+
+	s.dropped.Add(1)
+	return false
+}
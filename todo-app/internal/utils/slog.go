@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"runtime"
+	"sort"
+	"time"
+)
+
+// LevelFatal уровень slog для FATAL
+const LevelFatal = slog.LevelError + 4
+
+// SlogLevel переводит уровень Logger в уровень slog
+func (l LogLevel) SlogLevel() slog.Level {
+	switch l {
+	case DEBUG:
+		return slog.LevelDebug
+	case INFO:
+		return slog.LevelInfo
+	case WARN:
+		return slog.LevelWarn
+	case ERROR:
+		return slog.LevelError
+	default:
+		return LevelFatal
+	}
+}
+
+// logLevelFromSlog переводит уровень slog в уровень Logger
+func logLevelFromSlog(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelInfo:
+		return DEBUG
+	case level < slog.LevelWarn:
+		return INFO
+	case level < slog.LevelError:
+		return WARN
+	case level < LevelFatal:
+		return ERROR
+	default:
+		return FATAL
+	}
+}
+
+// handlerRef хранит обработчик slog для атомарной замены (конкретные типы обработчиков различаются)
+type handlerRef struct {
+	slog.Handler
+}
+
+// DefaultSlogHandler создает стандартный обработчик slog: JSON или текстовый,
+// с источником записи. Уровень фильтрует Logger, поэтому обработчик пропускает все
+func DefaultSlogHandler(w io.Writer, json bool) slog.Handler {
+	options := &slog.HandlerOptions{
+		AddSource: true,
+		Level:     slog.LevelDebug,
+	}
+	if json {
+		return slog.NewJSONHandler(w, options)
+	}
+	return slog.NewTextHandler(w, options)
+}
+
+// logSlog передает запись обработчику slog (LoggerConfig.NewHandler)
+func (l *Logger) logSlog(ctx context.Context, handler slog.Handler, skip int, level LogLevel, message string, fields map[string]interface{}) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !handler.Enabled(ctx, level.SlogLevel()) {
+		return
+	}
+
+	var pcs [1]uintptr
+	runtime.Callers(skip+2, pcs[:])
+
+	record := slog.NewRecord(time.Now(), level.SlogLevel(), message, pcs[0])
+
+	// Порядок полей в map случаен, сортируем для стабильного вывода
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		record.AddAttrs(slog.Any(key, fields[key]))
+	}
+
+	_ = handler.Handle(ctx, record)
+}
+
+// Slog возвращает *slog.Logger, пишущий через этот Logger: уровень, формат,
+// файл с ротацией, семплирование и поля контекста остаются общими.
+// Новый код может использовать slog, не меняя настройки логирования
+func (l *Logger) Slog() *slog.Logger {
+	return slog.New(&loggerHandler{logger: l})
+}
+
+// loggerHandler реализует slog.Handler поверх Logger
+type loggerHandler struct {
+	logger *Logger
+	attrs  []slog.Attr
+	group  string
+}
+
+// Enabled проверяет уровень Logger
+func (h *loggerHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.shouldLog(logLevelFromSlog(level))
+}
+
+// Handle записывает запись slog через Logger
+func (h *loggerHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := make(map[string]interface{}, len(h.attrs)+record.NumAttrs())
+	for _, attr := range h.attrs {
+		addAttr(fields, "", attr)
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		addAttr(fields, h.group, attr)
+		return true
+	})
+
+	// Кадры: Handle <- slog.Logger.log <- slog.Logger.Info <- вызывающий код
+	h.logger.log(ctx, 3, logLevelFromSlog(record.Level), record.Message, fields)
+	return nil
+}
+
+// WithAttrs возвращает обработчик с дополнительными атрибутами
+func (h *loggerHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append([]slog.Attr{}, h.attrs...)
+	for _, attr := range attrs {
+		if h.group != "" {
+			attr.Key = h.group + "." + attr.Key
+		}
+		clone.attrs = append(clone.attrs, attr)
+	}
+	return &clone
+}
+
+// WithGroup возвращает обработчик, добавляющий префикс группы к ключам
+func (h *loggerHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	if h.group != "" {
+		clone.group = h.group + "." + name
+	} else {
+		clone.group = name
+	}
+	return &clone
+}
+
+// addAttr добавляет атрибут в поля Logger, раскрывая группы в ключи через точку
+func addAttr(fields map[string]interface{}, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	key := attr.Key
+	if prefix != "" && key != "" {
+		key = prefix + "." + key
+	} else if key == "" {
+		key = prefix
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		for _, nested := range attr.Value.Group() {
+			addAttr(fields, key, nested)
+		}
+		return
+	}
+
+	fields[key] = attr.Value.Any()
+}
@@ -0,0 +1,329 @@
+package utils
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"time"
+)
+
+// InstrumentConnector оборачивает коннектор драйвера так, что каждый запрос,
+// выполненный через полученный пул (sql.OpenDB), учитывается в recorder:
+// длительность (для SELECT - до закрытия rows), количество строк и ошибки
+func InstrumentConnector(connector driver.Connector, recorder *QueryRecorder) driver.Connector {
+	return &instrumentedConnector{connector: connector, recorder: recorder}
+}
+
+type instrumentedConnector struct {
+	connector driver.Connector
+	recorder  *QueryRecorder
+}
+
+func (c *instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{conn: conn, recorder: c.recorder}, nil
+}
+
+func (c *instrumentedConnector) Driver() driver.Driver {
+	return c.connector.Driver()
+}
+
+// instrumentedConn соединение, учитывающее запросы. Драйвер должен поддерживать
+// контекстные интерфейсы (QueryerContext, ExecerContext, ConnBeginTx), как lib/pq
+type instrumentedConn struct {
+	conn     driver.Conn
+	recorder *QueryRecorder
+}
+
+func (c *instrumentedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	var (
+		stmt driver.Stmt
+		err  error
+	)
+	if preparer, ok := c.conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.conn.Prepare(query)
+	}
+	if err != nil {
+		c.recorder.Record(ctx, query, 0, -1, err)
+		return nil, err
+	}
+	return &instrumentedStmt{stmt: stmt, query: query, recorder: c.recorder}, nil
+}
+
+func (c *instrumentedConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *instrumentedConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	start := time.Now()
+
+	var (
+		tx  driver.Tx
+		err error
+	)
+	if beginner, ok := c.conn.(driver.ConnBeginTx); ok {
+		tx, err = beginner.BeginTx(ctx, opts)
+	} else {
+		tx, err = c.conn.Begin() // драйвер без BeginTx
+	}
+
+	c.recorder.Record(ctx, "BEGIN", time.Since(start), -1, err)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedTx{tx: tx, ctx: ctx, recorder: c.recorder}, nil
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		if !errors.Is(err, driver.ErrSkip) {
+			c.recorder.Record(ctx, query, time.Since(start), -1, err)
+		}
+		return nil, err
+	}
+	return newInstrumentedRows(ctx, rows, query, start, c.recorder), nil
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	if errors.Is(err, driver.ErrSkip) {
+		return nil, err
+	}
+	c.recorder.Record(ctx, query, time.Since(start), rowsAffected(result, err), err)
+	return result, err
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) IsValid() bool {
+	if validator, ok := c.conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+// instrumentedTx учитывает COMMIT и ROLLBACK
+type instrumentedTx struct {
+	tx       driver.Tx
+	ctx      context.Context
+	recorder *QueryRecorder
+}
+
+func (t *instrumentedTx) Commit() error {
+	start := time.Now()
+	err := t.tx.Commit()
+	t.recorder.Record(t.ctx, "COMMIT", time.Since(start), -1, err)
+	return err
+}
+
+func (t *instrumentedTx) Rollback() error {
+	start := time.Now()
+	err := t.tx.Rollback()
+	t.recorder.Record(t.ctx, "ROLLBACK", time.Since(start), -1, err)
+	return err
+}
+
+// instrumentedStmt подготовленный запрос
+type instrumentedStmt struct {
+	stmt     driver.Stmt
+	query    string
+	recorder *QueryRecorder
+}
+
+func (s *instrumentedStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s *instrumentedStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+func (s *instrumentedStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *instrumentedStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *instrumentedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+
+	var (
+		result driver.Result
+		err    error
+	)
+	if execer, ok := s.stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		result, err = s.stmt.Exec(plainValues(args)) // драйвер без StmtExecContext
+	}
+
+	s.recorder.Record(ctx, s.query, time.Since(start), rowsAffected(result, err), err)
+	return result, err
+}
+
+func (s *instrumentedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+
+	var (
+		rows driver.Rows
+		err  error
+	)
+	if queryer, ok := s.stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		rows, err = s.stmt.Query(plainValues(args)) // драйвер без StmtQueryContext
+	}
+
+	if err != nil {
+		s.recorder.Record(ctx, s.query, time.Since(start), -1, err)
+		return nil, err
+	}
+	return newInstrumentedRows(ctx, rows, s.query, start, s.recorder), nil
+}
+
+// instrumentedRows считает прочитанные строки и учитывает запрос при закрытии
+type instrumentedRows struct {
+	rows     driver.Rows
+	ctx      context.Context
+	query    string
+	start    time.Time
+	recorder *QueryRecorder
+
+	count  int64
+	err    error
+	closed bool
+}
+
+func newInstrumentedRows(ctx context.Context, rows driver.Rows, query string, start time.Time, recorder *QueryRecorder) *instrumentedRows {
+	return &instrumentedRows{
+		rows:     rows,
+		ctx:      ctx,
+		query:    query,
+		start:    start,
+		recorder: recorder,
+	}
+}
+
+func (r *instrumentedRows) Columns() []string {
+	return r.rows.Columns()
+}
+
+func (r *instrumentedRows) Next(dest []driver.Value) error {
+	err := r.rows.Next(dest)
+	switch {
+	case err == nil:
+		r.count++
+	case err != io.EOF:
+		r.err = err
+	}
+	return err
+}
+
+func (r *instrumentedRows) Close() error {
+	err := r.rows.Close()
+	if !r.closed {
+		r.closed = true
+		r.recorder.Record(r.ctx, r.query, time.Since(r.start), r.count, r.err)
+	}
+	return err
+}
+
+func (r *instrumentedRows) HasNextResultSet() bool {
+	if multi, ok := r.rows.(driver.RowsNextResultSet); ok {
+		return multi.HasNextResultSet()
+	}
+	return false
+}
+
+func (r *instrumentedRows) NextResultSet() error {
+	if multi, ok := r.rows.(driver.RowsNextResultSet); ok {
+		return multi.NextResultSet()
+	}
+	return io.EOF
+}
+
+func (r *instrumentedRows) ColumnTypeScanType(index int) reflect.Type {
+	if typed, ok := r.rows.(driver.RowsColumnTypeScanType); ok {
+		return typed.ColumnTypeScanType(index)
+	}
+	return reflect.TypeOf(new(any)).Elem()
+}
+
+func (r *instrumentedRows) ColumnTypeDatabaseTypeName(index int) string {
+	if typed, ok := r.rows.(driver.RowsColumnTypeDatabaseTypeName); ok {
+		return typed.ColumnTypeDatabaseTypeName(index)
+	}
+	return ""
+}
+
+// rowsAffected возвращает количество измененных строк или -1
+func rowsAffected(result driver.Result, err error) int64 {
+	if err != nil || result == nil {
+		return -1
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// namedValues преобразует позиционные аргументы в именованные
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, value := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: value}
+	}
+	return named
+}
+
+// plainValues преобразует именованные аргументы в позиционные
+func plainValues(args []driver.NamedValue) []driver.Value {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg.Value
+	}
+	return values
+}
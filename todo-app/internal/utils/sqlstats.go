@@ -0,0 +1,242 @@
+package utils
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultSlowQueryThreshold порог медленного запроса по умолчанию
+const DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+// maxTrackedQueries ограничивает число различных запросов в статистике:
+// динамически собранный SQL не должен расходовать память без предела
+const maxTrackedQueries = 500
+
+// otherQueries ключ статистики для запросов сверх maxTrackedQueries
+const otherQueries = "(other)"
+
+// maxQueryLength длина нормализованного запроса в статистике и логах
+const maxQueryLength = 1000
+
+// QueryStat агрегированная статистика одного нормализованного запроса
+type QueryStat struct {
+	Query     string
+	Calls     int64
+	Errors    int64
+	SlowCalls int64
+	Rows      int64
+	Total     time.Duration
+	Max       time.Duration
+	LastError string
+	LastSeen  time.Time
+}
+
+// Avg возвращает среднюю длительность запроса
+func (s QueryStat) Avg() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Calls)
+}
+
+// QueryRecorder собирает статистику SQL запросов и пишет медленные и ошибочные
+// запросы в лог (LogDBQueryContext). Текст запроса нормализуется: аргументы
+// и литералы в статистику и логи не попадают
+type QueryRecorder struct {
+	slowThreshold atomic.Int64 // time.Duration; <= 0 - медленные запросы не выделяются
+	logQueries    atomic.Bool  // писать каждый запрос на уровне DEBUG
+
+	mu    sync.Mutex
+	stats map[string]*QueryStat
+	since time.Time
+}
+
+// NewQueryRecorder создает сборщик статистики с порогом медленного запроса
+func NewQueryRecorder(slowThreshold time.Duration) *QueryRecorder {
+	r := &QueryRecorder{
+		stats: make(map[string]*QueryStat),
+		since: time.Now(),
+	}
+	r.slowThreshold.Store(int64(slowThreshold))
+	return r
+}
+
+// SetSlowThreshold меняет порог медленного запроса
+func (r *QueryRecorder) SetSlowThreshold(threshold time.Duration) {
+	r.slowThreshold.Store(int64(threshold))
+}
+
+// SlowThreshold возвращает порог медленного запроса
+func (r *QueryRecorder) SlowThreshold() time.Duration {
+	return time.Duration(r.slowThreshold.Load())
+}
+
+// SetLogQueries включает запись каждого запроса в лог на уровне DEBUG
+func (r *QueryRecorder) SetLogQueries(enabled bool) {
+	r.logQueries.Store(enabled)
+}
+
+// Record учитывает выполненный запрос. rows - прочитанные или измененные строки (-1 если неизвестно)
+func (r *QueryRecorder) Record(ctx context.Context, query string, duration time.Duration, rows int64, err error) {
+	normalized := NormalizeQuery(query)
+	threshold := r.SlowThreshold()
+	slow := threshold > 0 && duration >= threshold
+
+	r.mu.Lock()
+	stat, ok := r.stats[normalized]
+	if !ok {
+		key := normalized
+		if len(r.stats) >= maxTrackedQueries {
+			key = otherQueries
+		}
+		if stat, ok = r.stats[key]; !ok {
+			stat = &QueryStat{Query: key}
+			r.stats[key] = stat
+		}
+	}
+
+	stat.Calls++
+	stat.Total += duration
+	if duration > stat.Max {
+		stat.Max = duration
+	}
+	if rows > 0 {
+		stat.Rows += rows
+	}
+	if slow {
+		stat.SlowCalls++
+	}
+	if err != nil {
+		stat.Errors++
+		stat.LastError = err.Error()
+	}
+	stat.LastSeen = time.Now()
+	r.mu.Unlock()
+
+	if err != nil || slow || r.logQueries.Load() {
+		LoggerFromContext(ctx).logDBQuery(ctx, 2, normalized, duration, rows, err, slow)
+	}
+}
+
+// Snapshot возвращает статистику запросов, отсортированную по суммарному времени
+func (r *QueryRecorder) Snapshot() []QueryStat {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make([]QueryStat, 0, len(r.stats))
+	for _, stat := range r.stats {
+		stats = append(stats, *stat)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Total == stats[j].Total {
+			return stats[i].Query < stats[j].Query
+		}
+		return stats[i].Total > stats[j].Total
+	})
+	return stats
+}
+
+// Since возвращает время начала сбора статистики
+func (r *QueryRecorder) Since() time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.since
+}
+
+// Reset очищает статистику
+func (r *QueryRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stats = make(map[string]*QueryStat)
+	r.since = time.Now()
+}
+
+// NormalizeQuery приводит SQL к виду для группировки: убирает комментарии,
+// схлопывает пробелы и заменяет строковые и числовые литералы на "?".
+// Параметры ($1, $2, ...) сохраняются
+func NormalizeQuery(query string) string {
+	var b strings.Builder
+	b.Grow(len(query))
+
+	space := false
+	writeSpace := func() {
+		if space && b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		space = false
+	}
+
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = true
+
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+			space = true
+
+		case c == '/' && i+1 < len(query) && query[i+1] == '*':
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end + 3
+			}
+			space = true
+
+		case c == '\'':
+			// Строковый литерал; '' внутри - экранированная кавычка
+			for i++; i < len(query); i++ {
+				if query[i] == '\'' {
+					if i+1 < len(query) && query[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			writeSpace()
+			b.WriteByte('?')
+
+		case c >= '0' && c <= '9' && (space || !identChar(previousByte(&b))):
+			for i+1 < len(query) && (query[i+1] >= '0' && query[i+1] <= '9' || query[i+1] == '.') {
+				i++
+			}
+			writeSpace()
+			b.WriteByte('?')
+
+		default:
+			writeSpace()
+			b.WriteByte(c)
+		}
+	}
+
+	normalized := b.String()
+	if len(normalized) > maxQueryLength {
+		normalized = normalized[:maxQueryLength] + "..."
+	}
+	return normalized
+}
+
+// previousByte возвращает последний записанный символ
+func previousByte(b *strings.Builder) byte {
+	s := b.String()
+	if s == "" {
+		return 0
+	}
+	return s[len(s)-1]
+}
+
+// identChar проверяет, может ли символ быть частью идентификатора или параметра ($1)
+func identChar(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
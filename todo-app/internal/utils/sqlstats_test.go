@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestNormalizeQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{
+			query: "SELECT id,\n\t title FROM tasks\n WHERE status = 'active' AND priority = 'it''s' LIMIT 10",
+			want:  "SELECT id, title FROM tasks WHERE status = ? AND priority = ? LIMIT ?",
+		},
+		{
+			query: "UPDATE tasks SET title = $1 -- comment\nWHERE id = $2 /* block */ AND v2 > 1.5",
+			want:  "UPDATE tasks SET title = $1 WHERE id = $2 AND v2 > ?",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeQuery(tt.query); got != tt.want {
+			t.Errorf("NormalizeQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+// dsnConnector коннектор поверх драйвера sqlmock
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) { return c.driver.Open(c.dsn) }
+func (c dsnConnector) Driver() driver.Driver                        { return c.driver }
+
+func TestInstrumentConnector(t *testing.T) {
+	mockDB, mock, err := sqlmock.NewWithDSN("instrumented")
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer mockDB.Close()
+
+	var buf bytes.Buffer
+	logger, _ := NewLogger(LoggerConfig{Level: INFO, Output: &buf})
+
+	recorder := NewQueryRecorder(time.Nanosecond)
+	db := sql.OpenDB(InstrumentConnector(dsnConnector{dsn: "instrumented", driver: mockDB.Driver()}, recorder))
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT id FROM tasks`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectQuery(`SELECT id FROM tasks`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectExec(`UPDATE tasks`).WillReturnResult(sqlmock.NewResult(0, 4))
+	mock.ExpectExec(`DELETE FROM tasks`).WillReturnError(errors.New("boom"))
+
+	ctx := WithLogger(WithOperation(context.Background(), "Test"), logger)
+	for _, limit := range []string{"5", "7"} {
+		rows, err := db.QueryContext(ctx, "SELECT id FROM tasks LIMIT "+limit)
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		for rows.Next() {
+		}
+		rows.Close()
+	}
+	if _, err := db.ExecContext(ctx, "UPDATE tasks SET archived = $1", true); err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+	if _, err := db.ExecContext(ctx, "DELETE FROM tasks WHERE id = $1", 1); err == nil {
+		t.Fatal("Expected exec error")
+	}
+
+	stats := make(map[string]QueryStat)
+	for _, stat := range recorder.Snapshot() {
+		stats[stat.Query] = stat
+	}
+
+	selectStat := stats["SELECT id FROM tasks LIMIT ?"]
+	if selectStat.Calls != 2 || selectStat.Rows != 3 || selectStat.SlowCalls != 2 {
+		t.Errorf("Unexpected SELECT stats: %+v (all: %v)", selectStat, stats)
+	}
+	if update := stats["UPDATE tasks SET archived = $1"]; update.Calls != 1 || update.Rows != 4 {
+		t.Errorf("Unexpected UPDATE stats: %+v", update)
+	}
+	if del := stats["DELETE FROM tasks WHERE id = $1"]; del.Errors != 1 || del.LastError != "boom" {
+		t.Errorf("Unexpected DELETE stats: %+v", del)
+	}
+
+	logs := buf.String()
+	if !strings.Contains(logs, "[WARN] Slow database query") || !strings.Contains(logs, "[ERROR] Database query failed") {
+		t.Errorf("Expected slow and failed queries in log, got %q", logs)
+	}
+	if !strings.Contains(logs, "operation=Test") {
+		t.Errorf("Expected context fields in query log, got %q", logs)
+	}
+	if strings.Contains(logs, "LIMIT 5") {
+		t.Errorf("Literals must not be logged: %q", logs)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package validation
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"todo-app/app/models"
+	"todo-app/internal/i18n"
+	"todo-app/internal/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// AttachmentValidator представляет валидатор вложений задач
+type AttachmentValidator struct {
+	validator *validator.Validate
+}
+
+// NewAttachmentValidator создает новый валидатор вложений
+func NewAttachmentValidator() *AttachmentValidator {
+	return &AttachmentValidator{
+		validator: newValidator(),
+	}
+}
+
+// ValidateCreateAttachmentRequest валидирует запрос на прикрепление файла.
+// Имя должно быть именем файла, а не путем
+func (av *AttachmentValidator) ValidateCreateAttachmentRequest(req models.CreateAttachmentRequest) error {
+	fields := structFieldErrors(av.validator.Struct(req))
+
+	name := strings.TrimSpace(req.FileName)
+	switch {
+	case name == "":
+		fields = append(fields, requiredError("file_name"))
+	case name == "." || name == ".." || strings.ContainsAny(name, `/\`) || filepath.Base(name) != name:
+		fields = append(fields, invalidValueError("file_name", name))
+	}
+
+	return fieldsError(fields)
+}
+
+// FileTooLarge возвращает ошибку валидации файла больше maxSize байт
+func FileTooLarge(maxSize int64) error {
+	limit := formatSize(maxSize)
+	return fieldsError([]utils.FieldError{
+		utils.NewFieldError("file", "max_size", limit, "validation.file_too_large", i18n.Params{"field": "file", "max": limit}),
+	})
+}
+
+// FileTypeNotAllowed возвращает ошибку валидации неразрешенного MIME типа
+func FileTypeNotAllowed(contentType string) error {
+	return fieldsError([]utils.FieldError{
+		utils.NewFieldError("file", "file_type", contentType, "validation.file_type", i18n.Params{"field": "file", "type": contentType}),
+	})
+}
+
+// formatSize форматирует размер в байтах для сообщения: 25 MB, 512 KB, 100 B
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<20 && size%(1<<20) == 0:
+		return fmt.Sprintf("%d MB", size>>20)
+	case size >= 1<<10 && size%(1<<10) == 0:
+		return fmt.Sprintf("%d KB", size>>10)
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
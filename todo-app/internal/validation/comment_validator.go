@@ -0,0 +1,40 @@
+package validation
+
+import (
+	"strings"
+
+	"todo-app/app/models"
+	"todo-app/internal/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// CommentValidator представляет валидатор комментариев задач
+type CommentValidator struct {
+	validator *validator.Validate
+}
+
+// NewCommentValidator создает новый валидатор комментариев
+func NewCommentValidator() *CommentValidator {
+	return &CommentValidator{
+		validator: newValidator(),
+	}
+}
+
+// ValidateCreateCommentRequest валидирует запрос на добавление комментария
+func (cv *CommentValidator) ValidateCreateCommentRequest(req models.CreateCommentRequest) error {
+	return fieldsError(cv.bodyErrors(structFieldErrors(cv.validator.Struct(req)), req.Body))
+}
+
+// ValidateUpdateCommentRequest валидирует запрос на изменение комментария
+func (cv *CommentValidator) ValidateUpdateCommentRequest(req models.UpdateCommentRequest) error {
+	return fieldsError(cv.bodyErrors(structFieldErrors(cv.validator.Struct(req)), req.Body))
+}
+
+// bodyErrors добавляет ошибку пустого тела: тело из одних пробелов тоже пустое
+func (cv *CommentValidator) bodyErrors(fields []utils.FieldError, body string) []utils.FieldError {
+	if strings.TrimSpace(body) == "" {
+		fields = append(fields, requiredError("body"))
+	}
+	return fields
+}
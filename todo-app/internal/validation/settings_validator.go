@@ -0,0 +1,24 @@
+package validation
+
+import (
+	"todo-app/app/models"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// SettingsValidator представляет валидатор настроек приложения
+type SettingsValidator struct {
+	validator *validator.Validate
+}
+
+// NewSettingsValidator создает новый валидатор настроек
+func NewSettingsValidator() *SettingsValidator {
+	return &SettingsValidator{
+		validator: newValidator(),
+	}
+}
+
+// ValidateSettings валидирует настройки по тегам AppSettings
+func (sv *SettingsValidator) ValidateSettings(settings models.AppSettings) error {
+	return fieldsError(structFieldErrors(sv.validator.Struct(settings)))
+}
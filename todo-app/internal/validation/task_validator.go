@@ -1,10 +1,15 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 
 	"todo-app/app/models"
+	"todo-app/internal/i18n"
+	"todo-app/internal/utils"
 
 	"github.com/go-playground/validator/v10"
 )
@@ -16,7 +21,7 @@ type TaskValidator struct {
 
 // NewTaskValidator создает новый валидатор задач
 func NewTaskValidator() *TaskValidator {
-	v := validator.New()
+	v := newValidator()
 
 	// Регистрация кастомных валидаторов
 	v.RegisterValidation("priority", validatePriority)
@@ -28,79 +33,179 @@ func NewTaskValidator() *TaskValidator {
 	}
 }
 
+// newValidator создает validator, называющий поля в ошибках по json-тегам
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(jsonFieldName)
+	return v
+}
+
 // ValidateCreateTaskRequest валидирует запрос создания задачи
 func (tv *TaskValidator) ValidateCreateTaskRequest(req models.CreateTaskRequest) error {
-	if err := tv.validator.Struct(req); err != nil {
-		return formatValidationError(err)
-	}
+	fields := structFieldErrors(tv.validator.Struct(req))
 
 	// Дополнительные проверки
-	if req.DueDate != nil && req.DueDate.Before(time.Now()) {
-		return fmt.Errorf("дата выполнения не может быть в прошлом")
+	if isDueDatePast(req.DueDate, req.DueAllDay) {
+		fields = append(fields, dueDatePastError())
 	}
 
-	return nil
+	return fieldsError(fields)
 }
 
 // ValidateUpdateTaskRequest валидирует запрос обновления задачи
 func (tv *TaskValidator) ValidateUpdateTaskRequest(req models.UpdateTaskRequest) error {
-	if err := tv.validator.Struct(req); err != nil {
-		return formatValidationError(err)
-	}
+	fields := structFieldErrors(tv.validator.Struct(req))
 
 	// Дополнительные проверки
-	if req.DueDate != nil && req.DueDate.Before(time.Now()) {
-		return fmt.Errorf("дата выполнения не может быть в прошлом")
+	if isDueDatePast(req.DueDate, req.DueAllDay) {
+		fields = append(fields, dueDatePastError())
 	}
 
-	return nil
+	return fieldsError(fields)
+}
+
+// ValidateSetEstimateRequest валидирует запрос изменения оценки задачи
+func (tv *TaskValidator) ValidateSetEstimateRequest(req models.SetEstimateRequest) error {
+	return fieldsError(structFieldErrors(tv.validator.Struct(req)))
+}
+
+// isDueDatePast проверяет, что срок уже прошел; срок на весь день - с концом даты
+func isDueDatePast(due *time.Time, allDay bool) bool {
+	return due != nil && models.NewDueWindow(time.Now(), "").IsPast(*models.NormalizeDueDate(due, allDay), allDay)
 }
 
 // ValidateTaskFilter валидирует фильтр задач
 func (tv *TaskValidator) ValidateTaskFilter(filter models.TaskFilter) error {
+	return fieldsError(filterFieldErrors(filter))
+}
+
+// filterFieldErrors возвращает ошибки полей фильтра задач
+func filterFieldErrors(filter models.TaskFilter) []utils.FieldError {
+	var fields []utils.FieldError
+
 	if filter.Status != "" && !models.IsValidStatus(string(filter.Status)) {
-		return fmt.Errorf("некорректный статус: %s", filter.Status)
+		fields = append(fields, invalidValueError("status", string(filter.Status)))
 	}
 
 	if filter.Priority != "" && !models.IsValidPriority(string(filter.Priority)) {
-		return fmt.Errorf("некорректный приоритет: %s", filter.Priority)
+		fields = append(fields, invalidValueError("priority", string(filter.Priority)))
 	}
 
 	if !models.IsValidDateFilter(string(filter.DateType)) {
-		return fmt.Errorf("некорректный фильтр по дате: %s", filter.DateType)
+		fields = append(fields, invalidValueError("date_type", string(filter.DateType)))
+	}
+
+	if filter.Timezone != "" {
+		if _, err := time.LoadLocation(filter.Timezone); err != nil {
+			fields = append(fields, invalidValueError("timezone", filter.Timezone))
+		}
 	}
 
 	// Проверка диапазона дат
 	if filter.DueFrom != nil && filter.DueTo != nil {
 		if filter.DueFrom.After(*filter.DueTo) {
-			return fmt.Errorf("дата 'с' не может быть позже даты 'по'")
+			fields = append(fields, dateRangeError("due_from", "due_to"))
 		}
 	}
 
-	return nil
+	return fields
 }
 
 // ValidateTaskSort валидирует параметры сортировки
 func (tv *TaskValidator) ValidateTaskSort(sort models.TaskSort) error {
+	var fields []utils.FieldError
+
 	if !models.IsValidSortField(string(sort.Field)) {
-		return fmt.Errorf("некорректное поле сортировки: %s", sort.Field)
+		fields = append(fields, invalidValueError("sort_field", string(sort.Field)))
 	}
 
 	if !models.IsValidSortOrder(string(sort.Order)) {
-		return fmt.Errorf("некорректный порядок сортировки: %s", sort.Order)
+		fields = append(fields, invalidValueError("sort_order", string(sort.Order)))
 	}
 
+	return fieldsError(fields)
+}
+
+// ValidateCursor декодирует курсор страницы и проверяет, что он выдан для той же сортировки
+func (tv *TaskValidator) ValidateCursor(cursor string, sort models.TaskSort) (*models.TaskCursor, error) {
+	decoded, err := models.DecodeTaskCursor(cursor)
+	if err == nil && !decoded.MatchesSort(sort) {
+		err = fmt.Errorf("cursor does not match sort %s %s", sort.Field, sort.Order)
+	}
+	if err != nil {
+		appErr := utils.NewFieldValidationError([]utils.FieldError{
+			utils.NewFieldError("cursor", "cursor", "", "validation.invalid_cursor", i18n.Params{"field": "cursor"}),
+		})
+		appErr.OriginalErr = err
+		return nil, appErr
+	}
+	return decoded, nil
+}
+
+// ValidateStatusTransition валидирует смену статуса задачи по правилам workflow
+func (tv *TaskValidator) ValidateStatusTransition(workflow *models.Workflow, from, to models.TaskStatus) error {
+	if !workflow.Has(to) {
+		return InvalidValue("status", string(to))
+	}
+	if !workflow.CanTransition(from, to) {
+		return fieldsError([]utils.FieldError{
+			utils.NewFieldError("status", "status_transition", string(from), "validation.status_transition",
+				i18n.Params{"field": "status", "from": string(from), "to": string(to)}),
+		})
+	}
 	return nil
 }
 
+// ValidateMove валидирует соседей переносимой задачи: соседи должны быть другими
+// задачами той же области (колонки доски или общего списка) и идти в ней по порядку
+func (tv *TaskValidator) ValidateMove(task, before, after *models.Task, column models.TaskStatus) error {
+	var fields []utils.FieldError
+
+	neighbor := func(field string, other *models.Task) bool {
+		if other == nil {
+			return true
+		}
+		if other.ID == task.ID || (column != "" && other.Status != column) {
+			fields = append(fields, moveNeighborError(field))
+			return false
+		}
+		return true
+	}
+
+	if neighbor("before_id", before) && neighbor("after_id", after) &&
+		before != nil && after != nil && before.PositionIn(column) >= after.PositionIn(column) {
+		fields = append(fields, moveNeighborError("after_id"))
+	}
+
+	return fieldsError(fields)
+}
+
 // ValidateID валидирует ID задачи
 func (tv *TaskValidator) ValidateID(id int) error {
+	return PositiveID("id", int64(id))
+}
+
+// PositiveID проверяет, что идентификатор в поле field положительный
+func PositiveID(field string, id int64) error {
 	if id <= 0 {
-		return fmt.Errorf("ID должен быть положительным числом")
+		return fieldsError([]utils.FieldError{
+			utils.NewFieldError(field, "gt", "0", "validation.positive_id", i18n.Params{"field": field}),
+		})
 	}
 	return nil
 }
 
+// InvalidValue возвращает ошибку валидации недопустимого значения поля
+// для проверок вне валидатора (например, по справочнику типов событий)
+func InvalidValue(field, value string) error {
+	return fieldsError([]utils.FieldError{invalidValueError(field, value)})
+}
+
+// DueDateInPast возвращает ошибку валидации срока в прошлом
+func DueDateInPast() error {
+	return fieldsError([]utils.FieldError{dueDatePastError()})
+}
+
 // Кастомные валидаторы
 func validatePriority(fl validator.FieldLevel) bool {
 	priority := fl.Field().String()
@@ -125,32 +230,102 @@ func validateFutureDate(fl validator.FieldLevel) bool {
 	return date.After(time.Now())
 }
 
-// formatValidationError форматирует ошибки валидации
+// formatValidationError собирает ошибки всех полей в ошибку валидации
 func formatValidationError(err error) error {
-	validationErrors := err.(validator.ValidationErrors)
+	return fieldsError(structFieldErrors(err))
+}
+
+// fieldsError возвращает ошибку валидации с ошибками полей или nil
+func fieldsError(fields []utils.FieldError) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return utils.NewFieldValidationError(fields)
+}
 
+// structFieldErrors преобразует ошибки validator в ошибки полей.
+// Сообщение выбирается по тегу, для min/max - еще и по виду поля
+// (строка, коллекция, число), чтобы склонять "символов" и "элементов"
+func structFieldErrors(err error) []utils.FieldError {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		if err != nil {
+			return []utils.FieldError{utils.NewFieldError("", "invalid", "", "validation.default", i18n.Params{"field": ""})}
+		}
+		return nil
+	}
+
+	fields := make([]utils.FieldError, 0, len(validationErrors))
 	for _, fieldError := range validationErrors {
+		field := fieldPath(fieldError)
+		params := i18n.Params{"field": field, "param": fieldError.Param()}
+
+		key := "validation." + fieldError.Tag()
 		switch fieldError.Tag() {
-		case "required":
-			return fmt.Errorf("поле '%s' обязательно для заполнения", fieldError.Field())
-		case "min":
-			return fmt.Errorf("поле '%s' должно содержать минимум %s символов", fieldError.Field(), fieldError.Param())
-		case "max":
-			return fmt.Errorf("поле '%s' должно содержать максимум %s символов", fieldError.Field(), fieldError.Param())
-		case "gt":
-			return fmt.Errorf("поле '%s' должно быть больше %s", fieldError.Field(), fieldError.Param())
-		case "oneof":
-			return fmt.Errorf("поле '%s' должно быть одним из: %s", fieldError.Field(), fieldError.Param())
-		case "priority":
-			return fmt.Errorf("некорректный приоритет в поле '%s'", fieldError.Field())
-		case "status":
-			return fmt.Errorf("некорректный статус в поле '%s'", fieldError.Field())
-		case "future_date":
-			return fmt.Errorf("дата в поле '%s' должна быть в будущем", fieldError.Field())
+		case "required", "gt", "gte", "lt", "lte", "oneof", "priority", "status", "url", "future_date", "timezone":
+		case "min", "max":
+			switch fieldError.Kind() {
+			case reflect.String:
+				key += ".string"
+				params["count"] = fieldError.Param()
+			case reflect.Slice, reflect.Array, reflect.Map:
+				key += ".items"
+				params["count"] = fieldError.Param()
+			default:
+				key += ".number"
+			}
 		default:
-			return fmt.Errorf("ошибка валидации поля '%s': %s", fieldError.Field(), fieldError.Tag())
+			key = "validation.default"
 		}
+
+		fields = append(fields, utils.NewFieldError(field, fieldError.Tag(), fieldError.Param(), key, params))
 	}
+	return fields
+}
+
+// fieldPath возвращает путь поля по json-именам без имени корневой структуры:
+// "CreateWebhookRequest.event_types[0]" -> "event_types[0]"
+func fieldPath(fieldError validator.FieldError) string {
+	namespace := fieldError.Namespace()
+	if i := strings.IndexByte(namespace, '.'); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fieldError.Field()
+}
+
+// jsonFieldName возвращает имя поля из json-тега для сообщений об ошибках
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// dueDatePastError ошибка срока в прошлом
+func dueDatePastError() utils.FieldError {
+	return utils.NewFieldError("due_date", "due_date_past", "", "validation.due_date_past", i18n.Params{"field": "due_date"})
+}
+
+// requiredError ошибка незаполненного поля для проверок вне тегов
+func requiredError(field string) utils.FieldError {
+	return utils.NewFieldError(field, "required", "", "validation.required", i18n.Params{"field": field})
+}
+
+// dateRangeError ошибка диапазона, начало которого позже окончания
+func dateRangeError(field, other string) utils.FieldError {
+	return utils.NewFieldError(field, "date_range", other, "validation.date_range", i18n.Params{"field": field, "other": other})
+}
+
+// moveNeighborError ошибка соседа, не подходящего для переноса задачи
+func moveNeighborError(field string) utils.FieldError {
+	return utils.NewFieldError(field, "move_neighbor", "", "validation.move_neighbor", i18n.Params{"field": field})
+}
 
-	return fmt.Errorf("ошибка валидации")
+// invalidValueError ошибка недопустимого значения перечисления
+func invalidValueError(field, value string) utils.FieldError {
+	return utils.NewFieldError(field, "invalid_value", value, "validation.invalid_value", i18n.Params{"field": field, "value": value})
 }
@@ -0,0 +1,168 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"todo-app/app/models"
+	"todo-app/internal/i18n"
+	"todo-app/internal/utils"
+)
+
+func TestValidateCreateTaskRequest_ReturnsAllFieldErrors(t *testing.T) {
+	past := time.Now().Add(-48 * time.Hour)
+	req := models.CreateTaskRequest{
+		Title:       "",
+		Description: strings.Repeat("x", 1001),
+		Priority:    "urgent",
+		DueDate:     &past,
+	}
+
+	err := NewTaskValidator().ValidateCreateTaskRequest(req)
+
+	// Ошибка остается доступной после обертки в use case
+	var appErr *utils.AppError
+	if !errors.As(fmt.Errorf("validation failed: %w", err), &appErr) {
+		t.Fatalf("Expected *utils.AppError, got %T: %v", err, err)
+	}
+	if appErr.Type != utils.ErrorTypeValidation || appErr.Code != 400 {
+		t.Errorf("Unexpected error: %+v", appErr)
+	}
+
+	got := make([]string, 0, len(appErr.Fields))
+	for _, field := range appErr.Fields {
+		got = append(got, field.Field+":"+field.Tag)
+	}
+	want := "title:required description:max priority:oneof due_date:due_date_past"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("Expected fields %q, got %q", want, strings.Join(got, " "))
+	}
+	if appErr.Fields[1].Message != "Description must be at most 1000 characters long" {
+		t.Errorf("Unexpected default message: %q", appErr.Fields[1].Message)
+	}
+
+	localized := utils.LocalizeError(err, i18n.RU).(*utils.AppError)
+	if msg := localized.Fields[1].Message; msg != "Поле «Описание» должно содержать максимум 1000 символов" {
+		t.Errorf("Unexpected ru message: %q", msg)
+	}
+	if msg := utils.GetLocalizedMessage(localized, i18n.RU); msg != "Исправьте 4 поля" {
+		t.Errorf("Unexpected ru summary: %q", msg)
+	}
+	if appErr.Fields[0].Message != "Title is required" {
+		t.Error("LocalizeError must not modify the original error")
+	}
+}
+
+func TestValidateCreateWebhookRequest_FieldErrors(t *testing.T) {
+	err := NewWebhookValidator().ValidateCreateWebhookRequest(models.CreateWebhookRequest{
+		URL:        "ftp://example.com/hook",
+		EventTypes: []string{"task.created", ""},
+		Secret:     "short",
+		Filter:     &models.TaskFilter{Status: "unknown"},
+	})
+
+	appErr, ok := utils.LocalizeError(err, i18n.RU).(*utils.AppError)
+	if !ok {
+		t.Fatalf("Expected *utils.AppError, got %T", err)
+	}
+
+	messages := make(map[string]string, len(appErr.Fields))
+	for _, field := range appErr.Fields {
+		messages[field.Field] = field.Message
+	}
+
+	want := map[string]string{
+		"event_types[1]": "Поле «Типы событий» обязательно для заполнения",
+		"secret":         "Поле «Секрет» должно содержать минимум 16 символов",
+		"url":            "Поле «Адрес» должно быть абсолютным http(s) URL",
+		"status":         "Недопустимое значение «unknown» в поле «Статус»",
+	}
+	if len(messages) != len(want) {
+		t.Fatalf("Expected %d field errors, got %v", len(want), messages)
+	}
+	for field, message := range want {
+		if messages[field] != message {
+			t.Errorf("%s: expected %q, got %q", field, message, messages[field])
+		}
+	}
+}
+
+func TestValidateCreateWebhookRequest_PrivateTargets(t *testing.T) {
+	tests := []struct {
+		url     string
+		private bool
+	}{
+		{"http://localhost:8080/hook", true},
+		{"http://127.0.0.1/hook", true},
+		{"http://[::1]:9000/hook", true},
+		{"http://169.254.169.254/latest/meta-data", true},
+		{"https://10.1.2.3/hook", true},
+		{"https://192.168.0.10/hook", true},
+		{"http://0.0.0.0/hook", true},
+		{"http://100.64.0.1/hook", true},
+		{"http://192.0.0.8/hook", true},
+		{"http://198.18.0.1/hook", true},
+		{"http://[::ffff:10.0.0.1]/hook", true},
+		{"http://[64:ff9b::a00:1]/hook", true},
+		{"http://[fd00::1]/hook", true},
+		{"https://hooks.example.com/hook", false},
+		{"https://8.8.8.8/hook", false},
+	}
+
+	for _, tt := range tests {
+		req := models.CreateWebhookRequest{URL: tt.url, EventTypes: []string{"task.created"}}
+
+		err := NewWebhookValidator().ValidateCreateWebhookRequest(req)
+		if got := err != nil; got != tt.private {
+			t.Errorf("%s: expected rejected=%v, got %v", tt.url, tt.private, err)
+		}
+		if err := NewWebhookValidator().AllowPrivateTargets(true).ValidateCreateWebhookRequest(req); err != nil {
+			t.Errorf("%s: expected allowed with AllowPrivateTargets, got %v", tt.url, err)
+		}
+	}
+}
+
+func TestLocalizeError_PassesThroughPlainErrors(t *testing.T) {
+	plain := errors.New("connection refused")
+	if got := utils.LocalizeError(plain, i18n.RU); got != plain {
+		t.Errorf("Expected plain error unchanged, got %v", got)
+	}
+
+	notFound := utils.LocalizeError(utils.NewNotFoundError("task"), i18n.RU).(*utils.AppError)
+	if notFound.Message != "Запрошенные данные не найдены" || notFound.Details != "task not found" {
+		t.Errorf("Unexpected localized error: %+v", notFound)
+	}
+}
+
+func TestValidateSettings_FieldErrors(t *testing.T) {
+	validator := NewSettingsValidator()
+
+	if err := validator.ValidateSettings(*models.DefaultAppSettings()); err != nil {
+		t.Fatalf("Default settings should be valid, got %v", err)
+	}
+
+	settings := models.DefaultAppSettings()
+	settings.WeekStart = "friday"
+	settings.Timezone = "Mars/Olympus"
+
+	var appErr *utils.AppError
+	if !errors.As(validator.ValidateSettings(*settings), &appErr) {
+		t.Fatal("Expected *utils.AppError")
+	}
+
+	got := make([]string, 0, len(appErr.Fields))
+	for _, field := range appErr.Fields {
+		got = append(got, field.Field+":"+field.Tag)
+	}
+	if want := "week_start:oneof timezone:timezone"; strings.Join(got, " ") != want {
+		t.Fatalf("Expected fields %q, got %q", want, strings.Join(got, " "))
+	}
+
+	localized := utils.LocalizeError(appErr, i18n.RU).(*utils.AppError)
+	if msg := localized.Fields[1].Message; msg != "Поле «Часовой пояс» должно быть часовым поясом IANA, например Europe/Moscow" {
+		t.Errorf("Unexpected ru message: %q", msg)
+	}
+}
@@ -0,0 +1,67 @@
+package validation
+
+import (
+	"time"
+
+	"todo-app/app/models"
+	"todo-app/internal/i18n"
+	"todo-app/internal/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// TimeEntryValidator представляет валидатор учета времени
+type TimeEntryValidator struct {
+	validator *validator.Validate
+}
+
+// NewTimeEntryValidator создает новый валидатор учета времени
+func NewTimeEntryValidator() *TimeEntryValidator {
+	return &TimeEntryValidator{
+		validator: newValidator(),
+	}
+}
+
+// ValidateStartTimer валидирует запрос запуска таймера
+func (tv *TimeEntryValidator) ValidateStartTimer(req models.StartTimerRequest) error {
+	return fieldsError(structFieldErrors(tv.validator.Struct(req)))
+}
+
+// ValidateCreateTimeEntry валидирует ручную запись времени: отрезок задан полностью,
+// не перевернут и уже закончился - текущее время учитывается таймером
+func (tv *TimeEntryValidator) ValidateCreateTimeEntry(req models.CreateTimeEntryRequest, now time.Time) error {
+	fields := structFieldErrors(tv.validator.Struct(req))
+
+	if req.StartedAt.IsZero() {
+		fields = append(fields, requiredError("started_at"))
+	}
+	if req.EndedAt.IsZero() {
+		fields = append(fields, requiredError("ended_at"))
+	}
+
+	if !req.StartedAt.IsZero() && !req.EndedAt.IsZero() {
+		if req.StartedAt.After(req.EndedAt) {
+			fields = append(fields, dateRangeError("started_at", "ended_at"))
+		}
+		if req.EndedAt.After(now) {
+			fields = append(fields, utils.NewFieldError("ended_at", "time_in_future", "", "validation.time_in_future", i18n.Params{"field": "ended_at"}))
+		}
+	}
+
+	return fieldsError(fields)
+}
+
+// ValidateTimeReport валидирует группировку и период итогов времени
+func (tv *TimeEntryValidator) ValidateTimeReport(req models.TimeReportRequest) error {
+	var fields []utils.FieldError
+
+	if !models.IsValidTimeGroup(string(req.GroupBy)) {
+		fields = append(fields, invalidValueError("group_by", string(req.GroupBy)))
+	}
+
+	if req.From != nil && req.To != nil && req.From.After(*req.To) {
+		fields = append(fields, dateRangeError("from", "to"))
+	}
+
+	return fieldsError(fields)
+}
@@ -0,0 +1,68 @@
+package validation
+
+import (
+	"net/url"
+
+	"todo-app/app/models"
+	"todo-app/internal/i18n"
+	"todo-app/internal/utils"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// WebhookValidator представляет валидатор для подписок webhooks
+type WebhookValidator struct {
+	validator     *validator.Validate
+	taskValidator *TaskValidator
+	allowPrivate  bool
+}
+
+// NewWebhookValidator создает новый валидатор webhooks. По умолчанию адреса
+// локальной сети и самого хоста запрещены, см. AllowPrivateTargets
+func NewWebhookValidator() *WebhookValidator {
+	return &WebhookValidator{
+		validator:     newValidator(),
+		taskValidator: NewTaskValidator(),
+	}
+}
+
+// AllowPrivateTargets разрешает адреса loopback, link-local и частных сетей
+// (например, для приемника webhooks на той же машине)
+func (wv *WebhookValidator) AllowPrivateTargets(allow bool) *WebhookValidator {
+	wv.allowPrivate = allow
+	return wv
+}
+
+// ValidateCreateWebhookRequest валидирует запрос создания подписки
+func (wv *WebhookValidator) ValidateCreateWebhookRequest(req models.CreateWebhookRequest) error {
+	fields := structFieldErrors(wv.validator.Struct(req))
+
+	// Доставка возможна только по HTTP(S); ошибка тега url уже сообщена
+	parsed, err := url.Parse(req.URL)
+	if req.URL != "" && !hasFieldError(fields, "url") &&
+		(err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "") {
+		fields = append(fields, utils.NewFieldError("url", "webhook_url", "", "validation.webhook_url", i18n.Params{"field": "url"}))
+	} else if err == nil && !wv.allowPrivate && !hasFieldError(fields, "url") && utils.IsPrivateHost(parsed.Hostname()) {
+		fields = append(fields, utils.NewFieldError("url", "webhook_private", parsed.Hostname(), "validation.webhook_private", i18n.Params{"field": "url"}))
+	}
+
+	if req.Filter != nil {
+		filter := *req.Filter
+		if filter.DateType == "" {
+			filter.DateType = models.DateFilterAll
+		}
+		fields = append(fields, filterFieldErrors(filter)...)
+	}
+
+	return fieldsError(fields)
+}
+
+// hasFieldError проверяет, есть ли уже ошибка поля
+func hasFieldError(fields []utils.FieldError, field string) bool {
+	for _, fe := range fields {
+		if fe.Field == field {
+			return true
+		}
+	}
+	return false
+}
@@ -14,14 +14,28 @@ import (
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+
+	// База часовых поясов для настройки timezone на системах без zoneinfo (Windows)
+	_ "time/tzdata"
 )
 
 //go:embed all:frontend/dist
 var assets embed.FS
 
 func main() {
+	flags, args, err := config.ParseFlags(os.Args[1:])
+	if err != nil {
+		log.Fatalf("Invalid arguments: %v", err)
+	}
+
+	// Подкоманды выполняются без запуска приложения
+	if len(args) > 0 {
+		os.Exit(runCommand(flags, args))
+	}
+
 	// Загружаем конфигурацию
-	cfg, err := loadConfiguration()
+	loader := config.NewLoader(flags)
+	cfg, err := loadConfiguration(loader)
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
@@ -44,6 +58,13 @@ func main() {
 		}
 	}()
 
+	// Применяем изменения конфигурации без перезапуска
+	if err := container.WatchConfig(loader); err != nil {
+		container.Logger.Warn("Config hot reload is not available", map[string]interface{}{
+			"error": err.Error(),
+		})
+	}
+
 	// Выполняем миграции если необходимо
 	if err := runMigrations(container); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
@@ -61,6 +82,16 @@ func main() {
 	wailsApp.TaskUseCase = container.TaskUseCase
 	wailsApp.AnalyticsUseCase = container.AnalyticsUseCase
 	wailsApp.ExportUseCase = container.ExportUseCase
+	wailsApp.WebhookUseCase = container.WebhookUseCase
+	wailsApp.SettingsUseCase = container.SettingsUseCase
+	wailsApp.TimeUseCase = container.TimeUseCase
+	wailsApp.CommentUseCase = container.CommentUseCase
+	wailsApp.AttachmentUseCase = container.AttachmentUseCase
+	wailsApp.Events = container.EventBus
+	wailsApp.Realtime = container.RealtimeHub
+	wailsApp.Reminders = container.Reminders
+	wailsApp.Diagnostics = container.Diagnostics
+	wailsApp.Locales = container.Locales
 
 	// Настраиваем Wails опции
 	wailsOptions := buildWailsOptions(cfg, wailsApp)
@@ -71,12 +102,12 @@ func main() {
 	}
 }
 
-// loadConfiguration загружает конфигурацию приложения
-func loadConfiguration() (*config.Config, error) {
-	// Пробуем загрузить из переменных окружения
-	cfg, err := config.LoadFromEnv()
+// loadConfiguration загружает конфигурацию приложения: значения по умолчанию,
+// файл из каталога конфигурации пользователя, переменные окружения и флаги
+func loadConfiguration(loader *config.Loader) (*config.Config, error) {
+	cfg, err := loader.Load()
 	if err != nil {
-		return nil, fmt.Errorf("failed to load config from environment: %w", err)
+		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
 	// Валидируем конфигурацию
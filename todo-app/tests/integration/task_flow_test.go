@@ -33,7 +33,7 @@ func TestTaskFlow_CreateUpdateDeleteFlow(t *testing.T) {
 	testutils.AssertEqual(t, createReq.Title, createdTask.Title, "Title should match")
 	testutils.AssertEqual(t, createReq.Description, createdTask.Description, "Description should match")
 	testutils.AssertEqual(t, createReq.Priority, createdTask.Priority, "Priority should match")
-	testutils.AssertEqual(t, models.TaskStatusActive, createdTask.Status, "Status should be active")
+	testutils.AssertEqual(t, models.TaskStatusTodo, createdTask.Status, "Status should be todo")
 	testutils.AssertNotEqual(t, 0, createdTask.ID, "ID should be set")
 
 	// === Тест 2: Получение задачи по ID ===
@@ -62,15 +62,15 @@ func TestTaskFlow_CreateUpdateDeleteFlow(t *testing.T) {
 	toggledTask, err := app.TaskUseCase.ToggleTaskStatus(ctx, createdTask.ID)
 	testutils.AssertNoError(t, err, "Toggle task status should not return error")
 	testutils.AssertNotNil(t, toggledTask, "Toggled task should not be nil")
-	testutils.AssertEqual(t, models.TaskStatusCompleted, toggledTask.Status, "Status should be completed")
+	testutils.AssertEqual(t, models.TaskStatusDone, toggledTask.Status, "Status should be done")
 	testutils.AssertNotNil(t, toggledTask.CompletedAt, "CompletedAt should be set")
 
 	// Переключаем обратно
 	toggledTask, err = app.TaskUseCase.ToggleTaskStatus(ctx, createdTask.ID)
 	testutils.AssertNoError(t, err, "Toggle task status back should not return error")
-	testutils.AssertEqual(t, models.TaskStatusActive, toggledTask.Status, "Status should be active again")
+	testutils.AssertEqual(t, models.TaskStatusTodo, toggledTask.Status, "Status should be todo again")
 	if toggledTask.CompletedAt != nil {
-		t.Errorf("CompletedAt should be nil for active task")
+		t.Errorf("CompletedAt should be nil for reopened task")
 	}
 
 	// === Тест 5: Удаление задачи ===
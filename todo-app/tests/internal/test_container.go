@@ -42,7 +42,8 @@ func SetupTestContainer(t *testing.T) *TestContainer {
 	}
 
 	// Подключаемся к тестовой БД
-	dsn := cfg.GetDatabaseDSN()
+	dsn, err := cfg.GetDatabaseDSN()
+	testutils.AssertNoError(t, err, "Failed to build test database DSN")
 	db, err := sql.Open("postgres", dsn)
 	testutils.AssertNoError(t, err, "Failed to connect to test database")
 
@@ -94,11 +95,11 @@ func (tc *TestContainer) ClearTestData(t *testing.T) {
 func (tc *TestContainer) LoadTestData(t *testing.T) {
 	testDataSQL := `
 		INSERT INTO tasks (title, description, status, priority, created_at, updated_at, due_date) VALUES
-		('Test Task 1', 'First test task', 'active', 'high', NOW(), NOW(), NULL),
-		('Test Task 2', 'Second test task', 'completed', 'medium', NOW(), NOW(), '2024-12-25 10:00:00'),
-		('Test Task 3', 'Third test task', 'active', 'low', NOW(), NOW(), '2024-12-30 15:00:00'),
-		('Test Task 4', 'Fourth test task', 'active', 'high', NOW(), NOW(), NULL),
-		('Test Task 5', 'Fifth test task', 'completed', 'medium', NOW(), NOW(), '2024-12-20 09:00:00');
+		('Test Task 1', 'First test task', 'todo', 'high', NOW(), NOW(), NULL),
+		('Test Task 2', 'Second test task', 'done', 'medium', NOW(), NOW(), '2024-12-25 10:00:00'),
+		('Test Task 3', 'Third test task', 'todo', 'low', NOW(), NOW(), '2024-12-30 15:00:00'),
+		('Test Task 4', 'Fourth test task', 'todo', 'high', NOW(), NOW(), NULL),
+		('Test Task 5', 'Fifth test task', 'done', 'medium', NOW(), NOW(), '2024-12-20 09:00:00');
 	`
 
 	_, err := tc.TestDB.Exec(testDataSQL)